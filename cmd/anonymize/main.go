@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"subtracker/internal/anonymizer"
+	"subtracker/internal/config"
+	"subtracker/internal/repository"
+	"subtracker/pkg/loadenv"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// anonymize copies a representative, anonymized sample of production data into
+// a staging schema of the same database.
+func main() {
+	schema := flag.String("schema", "staging", "target schema, dropped and recreated on every run")
+	salt := flag.String("salt", os.Getenv("ANONYMIZE_SALT"), "salt for user ID rehashing (random when empty)")
+	sample := flag.Float64("sample", 100, "percentage of subscriptions to copy")
+	jitter := flag.Float64("jitter", 0.1, "maximum relative price jitter")
+	flag.Parse()
+
+	loadenv.LoadEnvFile(".env")
+	logger := logger.New(os.Getenv("APP_ENV"))
+	defer logger.Sync()
+
+	if *salt == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			logger.Fatal("Failed to generate salt", zap.Error(err))
+		}
+		*salt = hex.EncodeToString(buf)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	cfg := config.LoadConfig()
+	db, err := repository.ConnectDB(ctx, cfg.Postgres, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to the database", zap.Error(err))
+	}
+	defer db.Close()
+
+	opts := anonymizer.Options{
+		Schema:        *schema,
+		Salt:          *salt,
+		SamplePercent: *sample,
+		PriceJitter:   *jitter,
+	}
+	if err := anonymizer.Run(ctx, db, opts, logger); err != nil {
+		logger.Fatal("Anonymized copy failed", zap.Error(err))
+	}
+	fmt.Printf("Anonymized sample copied into schema %q\n", *schema)
+}
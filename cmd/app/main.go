@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,10 +11,17 @@ import (
 	"os/signal"
 	"subtracker/internal/config"
 	"subtracker/internal/handler"
+	"subtracker/internal/mailer"
+	"subtracker/internal/metrics"
 	"subtracker/internal/repository"
 	"subtracker/internal/service"
+	"subtracker/internal/telegram"
+	"subtracker/internal/webhook"
+	"subtracker/pkg/clock"
 	"subtracker/pkg/loadenv"
 	"subtracker/pkg/logger"
+	"sync"
+	"syscall"
 	"time"
 
 	"go.uber.org/zap"
@@ -31,9 +40,27 @@ import (
 // @host      localhost:8080
 // @BasePath  /
 // @schemes   http
+
+// @securityDefinitions.apikey BearerAuth
+// @in                         header
+// @name                       Authorization
+// @description                "Bearer <token>" from /auth/login. Required on /subscriptions routes when AUTH_JWT_SECRET is set.
 func main() {
+	checkConfig := flag.Bool("check-config", false, "validate configuration and exit")
+	flag.Parse()
+
 	ctx := context.Background()
 	loadenv.LoadEnvFile(".env")
+
+	if *checkConfig {
+		if err := config.LoadConfig().Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Configuration OK")
+		return
+	}
+
 	logger := logger.New(os.Getenv("APP_ENV"))
 	defer func() {
 		if err := logger.Sync(); err != nil {
@@ -43,24 +70,61 @@ func main() {
 	logger.Info("Starting Subtracker application", zap.String("environment", os.Getenv("APP_ENV")))
 	// Initialize configuration
 	cfg := config.LoadConfig()
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal("Invalid configuration", zap.Error(err))
+	}
 	logger.Info("Configuration loaded", zap.Any("config", cfg))
 	// Connect to the database
-	db, err := repository.ConnectDB(ctx, cfg.Postgres, logger)
-	if err != nil {
-		logger.Fatal("Failed to connect to the database", zap.Error(err))
+	var middlewares []func(http.Handler) http.Handler
+	var db *sql.DB
+	var err error
+	if cfg.Postgres.ConnectInBackground {
+		var availability *repository.Availability
+		db, availability, err = repository.ConnectDBInBackground(ctx, cfg.Postgres, logger)
+		if err != nil {
+			logger.Fatal("Failed to open the database", zap.Error(err))
+		}
+		middlewares = append(middlewares, handler.RequireDatabase(availability.Ready))
+		logger.Info("Connecting to the database in the background")
+	} else {
+		db, err = repository.ConnectDB(ctx, cfg.Postgres, logger)
+		if err != nil {
+			logger.Fatal("Failed to connect to the database", zap.Error(err))
+		}
+		logger.Info("Connected to the database successfully", zap.String("dsn", cfg.Postgres.PostgresDSN))
 	}
 	defer db.Close()
-	logger.Info("Connected to the database successfully", zap.String("dsn", cfg.Postgres.PostgresDSN))
+	if cfg.App.ReadOnly {
+		logger.Warn("Starting in read-only mode, mutating requests will be refused")
+	}
+
+	reportingDB, err := repository.OpenReportingDB(cfg.Postgres)
+	if err != nil {
+		logger.Fatal("Failed to open the reporting database pool", zap.Error(err))
+	}
+	defer reportingDB.Close()
 
 	// Initialize the all components
-	repo := repository.NewRepository(db, logger)
-	service := service.NewService(repo, logger)
-	handlers := handler.NewHandlers(service, logger)
+	repo := repository.NewRepository(db, reportingDB, logger)
+	var mail mailer.Mailer
+	if cfg.SMTP.Enabled() {
+		mail = mailer.NewSMTPMailer(cfg.SMTP)
+	}
+	var bot *telegram.Client
+	var botSender telegram.Sender
+	if cfg.Telegram.Enabled() {
+		bot = telegram.NewClient(cfg.Telegram, logger)
+		botSender = bot
+	}
+	hooks := webhook.NewHTTPSender(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateTargets)
+	systemClock := clock.System()
+	service := service.NewService(repo, mail, botSender, hooks, cfg.App, cfg.Telegram, cfg.Webhooks, systemClock, logger)
+	handlers := handler.NewHandlers(service, cfg.App, logger)
 	logger.Info("All components initialized successfully")
 
-	mux := handler.Router(*handlers)
+	mux := handler.Router(*handlers, middlewares...)
 	httpServer := &http.Server{
-		Addr:    ":8080",
+		Addr:    ":" + cfg.App.AppPort,
 		Handler: mux,
 	}
 	go func() {
@@ -69,20 +133,108 @@ func main() {
 			logger.Fatal("ListenAndServe error", zap.Error(err))
 		}
 	}()
+	var adminServer *http.Server
+	if cfg.App.AdminListenerEnabled() {
+		adminServer = &http.Server{
+			Addr:    ":" + cfg.App.AdminPort,
+			Handler: handler.AdminRouter(*handlers, mux, string(cfg.App.AdminToken)),
+		}
+		go func() {
+			logger.Info("Admin listener is running", zap.String("addr", adminServer.Addr))
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Admin ListenAndServe error", zap.Error(err))
+			}
+		}()
+	}
 	// Graceful shutdown
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Background workers are stopped only once the API has drained, and are
+	// waited for before the database pool closes.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	runWorker := func(run func()) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run()
+		}()
+	}
+
+	runWorker(func() { metrics.RefreshKPIs(workerCtx, repo.ReportingRepository, time.Minute, systemClock, logger) })
+	runWorker(func() { service.IntegrityService.Run(workerCtx, cfg.App.IntegrityCheckInterval) })
+	switch {
+	case mail == nil && bot == nil:
+		logger.Info("Neither SMTP_ADDR nor TELEGRAM_BOT_TOKEN is set, renewal reminders are disabled")
+	case cfg.App.ReadOnly:
+		logger.Info("Renewal reminders are paused in read-only mode")
+	default:
+		runWorker(func() { service.ReminderService.Run(workerCtx, cfg.App.ReminderInterval) })
+	}
+	if cfg.App.ReadOnly {
+		logger.Info("Scheduled price changes are paused in read-only mode")
+	} else {
+		runWorker(func() { service.PriceChangeService.Run(workerCtx, cfg.App.PriceChangeInterval) })
+	}
+	if cfg.App.ReadOnly {
+		logger.Info("Trial conversions are paused in read-only mode")
+	} else {
+		runWorker(func() { service.TrialService.Run(workerCtx, cfg.App.TrialConversionInterval) })
+	}
+	if cfg.App.ReadOnly {
+		logger.Info("Webhook deliveries are paused in read-only mode")
+	} else {
+		runWorker(func() { service.WebhookService.Run(workerCtx, cfg.Webhooks.DispatchInterval) })
+	}
+	if bot != nil {
+		if cfg.App.ReadOnly {
+			logger.Info("The Telegram bot is paused in read-only mode")
+		} else {
+			runWorker(func() { bot.Poll(workerCtx, service.TelegramService.HandleMessage) })
+		}
+	}
+
 	<-ctx.Done()
-	logger.Info("Shutdown signal received")
+	// A second signal now terminates the process instead of waiting for the drain.
+	stop()
+	logger.Info("Shutdown signal received, draining", zap.Duration("timeout", cfg.App.DrainTimeout))
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	drainCtx, cancel := context.WithTimeout(context.Background(), cfg.App.DrainTimeout)
 	defer cancel()
 
-	if err := httpServer.Shutdown(shutdownCtx); err != nil {
-		logger.Fatal("HTTP server shutdown error", zap.Error(err))
+	// Stop accepting connections and let in-flight requests, such as long exports, finish.
+	if err := httpServer.Shutdown(drainCtx); err != nil {
+		logger.Error("HTTP server did not drain in time, closing remaining connections", zap.Error(err))
+		httpServer.Close()
+	}
+	stopWorkers()
+	if err := waitWorkers(drainCtx, &workers); err != nil {
+		logger.Error("Background workers did not stop in time", zap.Error(err))
+	}
+	// The admin listener goes last so metrics stay reachable during the drain.
+	if adminServer != nil {
+		if err := adminServer.Shutdown(drainCtx); err != nil {
+			logger.Error("Admin server did not drain in time, closing remaining connections", zap.Error(err))
+			adminServer.Close()
+		}
 	}
 
 	logger.Info("Server stopped gracefully")
+}
 
+// waitWorkers waits for workers to return, giving up once ctx is done.
+func waitWorkers(ctx context.Context, workers *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
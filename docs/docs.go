@@ -22,88 +22,26 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/subscriptions": {
+        "/admin/flags": {
             "get": {
-                "description": "Gets a list of subscriptions with filtering and pagination.",
+                "description": "Lists every feature flag by name. Only available when DEBUG_ENDPOINTS is enabled.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscriptions"
-                ],
-                "summary": "List Subscriptions",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by User ID (UUID)",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by Service Name",
-                        "name": "service_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by minimum price",
-                        "name": "min_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by maximum price",
-                        "name": "max_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by start date (format: MM-YYYY)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by end date (format: MM-YYYY)",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by presence of an end date",
-                        "name": "has_end_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Pagination limit (default 10, max 100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Pagination offset (default 0)",
-                        "name": "offset",
-                        "in": "query"
-                    }
+                    "Admin"
                 ],
+                "summary": "List Feature Flags",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.SubscriptionResponse"
+                                "$ref": "#/definitions/dto.FeatureFlagResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid filter parameters",
-                        "schema": {
-                            "$ref": "#/definitions/apperrors.AppError"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -111,9 +49,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Adds a new subscription to the system based on the provided data.",
+            }
+        },
+        "/admin/flags/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or overwrites a feature flag. A flag is on only while enabled, in one of its environments\n(none means every APP_ENV), and for rollout_percent of users, each of whom stays in or out of the\nrollout as it grows. Other instances pick the change up within FEATURE_FLAG_REFRESH_INTERVAL.\nOnly available when DEBUG_ENDPOINTS is enabled. Without ADMIN_PORT it requires an admin's bearer token.",
                 "consumes": [
                     "application/json"
                 ],
@@ -121,35 +66,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Subscriptions"
+                    "Admin"
                 ],
-                "summary": "Create Subscription",
+                "summary": "Set Feature Flag",
                 "parameters": [
                     {
-                        "description": "Subscription Information",
-                        "name": "subscription",
+                        "type": "string",
+                        "description": "Flag name: lowercase letters, digits and underscores",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Flag settings",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                            "$ref": "#/definitions/dto.SetFeatureFlagRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "$ref": "#/definitions/dto.FeatureFlagResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or fields",
+                        "description": "Invalid flag name, request body or fields",
                         "schema": {
                             "$ref": "#/definitions/apperrors.AppError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict if subscription with this ID already exists",
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not an admin",
                         "schema": {
                             "$ref": "#/definitions/apperrors.AppError"
                         }
@@ -161,58 +119,76 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/subscriptions/cost": {
-            "get": {
-                "description": "Calculates the total cost of subscriptions for a user over a specified period.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a feature flag, which turns it off everywhere. Only available when DEBUG_ENDPOINTS is enabled.\nWithout ADMIN_PORT it requires an admin's bearer token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscriptions"
+                    "Admin"
                 ],
-                "summary": "Calculate Total Cost",
+                "summary": "Delete Feature Flag",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID format) for whom to calculate the cost",
-                        "name": "user_id",
-                        "in": "query",
+                        "description": "Flag name",
+                        "name": "name",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
-                    {
-                        "type": "string",
-                        "description": "Start of the calculation period (format: MM-YYYY)",
-                        "name": "period_start",
-                        "in": "query",
-                        "required": true
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "End of the calculation period (format: MM-YYYY)",
-                        "name": "period_end",
-                        "in": "query",
-                        "required": true
+                    "403": {
+                        "description": "Caller is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Optional: filter by a specific service name",
-                        "name": "service_name",
-                        "in": "query"
+                    "404": {
+                        "description": "Flag not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
                     }
+                }
+            }
+        },
+        "/admin/integrity": {
+            "get": {
+                "description": "Counts rows that break data invariants (negative prices, end date before start date).\nOnly available when DEBUG_ENDPOINTS is enabled.",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Check Data Integrity",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CostResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid or missing parameters",
-                        "schema": {
-                            "$ref": "#/definitions/apperrors.AppError"
+                            "$ref": "#/definitions/dto.IntegrityReportResponse"
                         }
                     },
                     "500": {
@@ -224,43 +200,74 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/{id}": {
+        "/admin/queries": {
             "get": {
-                "description": "Retrieves a single subscription by its unique ID.",
+                "description": "Lists the queries with the highest mean execution time since statistics were last reset, read from\npg_stat_statements, to guide tuning of the repository layer. The extension must be in the database's\nshared_preload_libraries and created with CREATE EXTENSION pg_stat_statements.\nOnly available when DEBUG_ENDPOINTS is enabled.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscriptions"
+                    "Admin"
                 ],
-                "summary": "Get Subscription by ID",
+                "summary": "Top Queries",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Subscription ID (UUID format)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Number of queries (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SubscriptionResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.QueryStatResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format",
+                        "description": "Invalid limit",
                         "schema": {
                             "$ref": "#/definitions/apperrors.AppError"
                         }
                     },
-                    "404": {
-                        "description": "Subscription not found",
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "503": {
+                        "description": "pg_stat_statements is not enabled",
                         "schema": {
                             "$ref": "#/definitions/apperrors.AppError"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/routes": {
+            "get": {
+                "description": "Lists every route of the public API with its method and middleware chain. Only available when DEBUG_ENDPOINTS is enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List Routes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RouteResponse"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -269,9 +276,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing subscription's details by its ID. UserID cannot be changed.",
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Exchanges an account's email and password for a bearer token. Send it as\n` + "`" + `Authorization: Bearer \u003ctoken\u003e` + "`" + `; protected routes then act on that user only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,24 +288,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Subscriptions"
+                    "Auth"
                 ],
-                "summary": "Update Subscription",
+                "summary": "Login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Subscription ID (UUID format)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Fields to update",
-                        "name": "subscription",
+                        "description": "Email and password",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateSubscriptionRequest"
+                            "$ref": "#/definitions/dto.LoginRequest"
                         }
                     }
                 ],
@@ -304,42 +306,376 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "$ref": "#/definitions/dto.TokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format or request body",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/apperrors.AppError"
                         }
                     },
-                    "404": {
-                        "description": "Subscription not found",
+                    "401": {
+                        "description": "Invalid credentials",
                         "schema": {
                             "$ref": "#/definitions/apperrors.AppError"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apperrors.AppError"
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a subscription by its unique ID.",
+            }
+        },
+        "/integrations/ack": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records that a consumer handled a message, typically a webhook delivery identified by its\nX-Subtracker-Delivery header. Deliveries are retried until answered with 2xx, so the same one\ncan arrive twice; a consumer that acks before acting and skips messages reported as duplicate\nhandles each exactly once. The first ack answers 201, any later one 200 with duplicate set.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscriptions"
+                    "Integrations"
                 ],
-                "summary": "Delete Subscription",
+                "summary": "Acknowledge Message",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Subscription ID (UUID format)",
+                        "description": "Consumer and message",
+                        "name": "ack",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AckMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message was acked before",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AckMessageResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "First ack",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AckMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Reports ready only once the database is reachable and all migrations are applied.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Not ready, message lists failing checks",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/savings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Summarizes the money a user saves by having cancelled subscriptions: every subscription whose\nend date falls in the period counts, with its monthly price as the monthly saving and twelve\ntimes that as the projected annual saving.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Savings Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "A month (MM-YYYY) or a whole year (YYYY)",
+                        "name": "period",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SavingsReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the rules that apply to a user, in the order they are tried. Without user_id the\nauthenticated user's rules are listed, or only the global rules for an admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "List Category Rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RuleResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a rule that sets the category of subscriptions whose service name contains the pattern,\nignoring case. New subscriptions created without a category get the category of the first\nmatching rule: the user's own rules before global ones, then higher priority first.\nOmit user_id to create a global rule; only admins may.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Create Category Rule",
+                "parameters": [
+                    {
+                        "description": "Category rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Global rule without admin role, or user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/apply": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Categorizes a user's existing subscriptions with the current rules. Subscriptions that already\nhave a category are left alone unless overwrite is set; no subscription loses its category\nbecause no rule matches it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Apply Category Rules",
+                "parameters": [
+                    {
+                        "description": "User to categorize",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApplyRulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApplyRulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a rule. Subscriptions it already categorized keep their category.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Delete Category Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -355,8 +691,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperrors.AppError"
                         }
                     },
+                    "403": {
+                        "description": "Global rule without admin role",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
                     "404": {
-                        "description": "Subscription not found",
+                        "description": "Rule not found",
                         "schema": {
                             "$ref": "#/definitions/apperrors.AppError"
                         }
@@ -369,66 +711,5021 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "apperrors.AppError": {
+        },
+        "/saved-filters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the saved filters of a user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Filters"
+                ],
+                "summary": "List Saved Filters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SavedFilterResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stores a named set of subscription list filters for a user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Filters"
+                ],
+                "summary": "Create Saved Filter",
+                "parameters": [
+                    {
+                        "description": "Saved filter",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSavedFilterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SavedFilterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Saved filter with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-filters/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Filters"
+                ],
+                "summary": "Get Saved Filter by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved filter ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SavedFilterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Saved filter not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renames a saved filter or replaces its params. The owner cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Filters"
+                ],
+                "summary": "Update Saved Filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved filter ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSavedFilterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Saved filter not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Saved filter with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Filters"
+                ],
+                "summary": "Delete Saved Filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved filter ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Saved filter not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schemas/{name}": {
+            "get": {
+                "description": "Returns a JSON Schema (draft 2020-12) generated from the request DTO and its validation rules, for validating payloads client-side. Cross-field rules, such as max_price not being below min_price, are only enforced by the server. The subscription-filter schema describes the list query parameters.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schemas"
+                ],
+                "summary": "Get a request JSON Schema",
+                "parameters": [
+                    {
+                        "enum": [
+                            "create-subscription",
+                            "update-subscription",
+                            "subscription-filter"
+                        ],
+                        "type": "string",
+                        "description": "Schema name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jsonschema.Schema"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown schema",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "Reports the version and commit this instance runs, how long it has been up and the health of the\ndependencies readiness checks. It answers 200 even when a dependency fails; ` + "`" + `status` + "`" + ` is then degraded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Instance status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets a list of subscriptions with filtering and pagination. ` + "`" + `pagination.total` + "`" + ` counts every\nsubscription matching the filters and ` + "`" + `pagination.has_more` + "`" + ` tells whether pages follow this one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "List Subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by User ID (UUID); defaults to the authenticated user unless they are an admin, for whom omitting it lists every user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Service Name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start date (format: MM-YYYY)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by end date (format: MM-YYYY)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by presence of an end date",
+                        "name": "has_end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by cost center",
+                        "name": "cost_center",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by whether a failed charge is still unpaid",
+                        "name": "past_due",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "subscription",
+                            "rent",
+                            "utilities",
+                            "insurance",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Filter by expense type",
+                        "name": "expense_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination limit (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields (start_date, end_date, price, service_name); prefix with - for descending (default -start_date)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Apply a saved filter by ID; explicit query params override its values",
+                        "name": "saved_filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new subscription to the system based on the provided data.\nNon-fatal issues (unusual price, far-future start, probable duplicate, quota nearly reached) are returned in ` + "`" + `warnings` + "`" + `.\nRent, utilities and insurance must have a price above zero; rent is billed monthly or weekly, utilities\nmonthly, and insurance monthly or yearly.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Create Subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription Information",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Subscriptions the user may still create; only sent when a quota is configured"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Subscription quota reached",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict if subscription with this ID already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates up to 100 subscriptions in one request and one transaction. Each item is validated on its\nown: an invalid item, or one over its user's quota, is reported in ` + "`" + `results` + "`" + ` and the rest are still\ncreated. If the write itself fails, nothing is created and the error is returned for the whole request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Create Subscriptions in Batch",
+                "parameters": [
+                    {
+                        "description": "Subscriptions to create",
+                        "name": "subscriptions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, batch size or user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "A subscription with one of the IDs already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/cost": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Calculates the total cost of subscriptions for a user over a specified period.\nYearly and weekly prices are normalized to months (a year of 52 weeks) before summing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Calculate Total Cost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format) for whom to calculate the cost",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the calculation period (format: MM-YYYY)",
+                        "name": "period_start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the calculation period (format: MM-YYYY)",
+                        "name": "period_end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional: filter by a specific service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out months whose charge failed and is still unpaid",
+                        "name": "exclude_unpaid",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/cost/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Calculates total costs for several users/periods in one call. Items are computed concurrently;\na failing item reports its error without failing the whole batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Calculate Total Cost in Batch",
+                "parameters": [
+                    {
+                        "description": "Up to 100 cost requests",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CostBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CostBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or items",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/cost/by-cost-center": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Splits the total cost of a user's subscriptions over a period by cost center, for recharging\nspend to departments. Subscriptions without a cost center are grouped under an empty name.\nWith format=csv the report is returned as a CSV attachment with ISO dates, one row per cost center.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Cost by Cost Center",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format) for whom to calculate the cost",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the calculation period (format: MM-YYYY)",
+                        "name": "period_start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the calculation period (format: MM-YYYY)",
+                        "name": "period_end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional: filter by a specific service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out months whose charge failed and is still unpaid",
+                        "name": "exclude_unpaid",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CostCenterReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Downloads the subscriptions matching the filter as a CSV or Excel file. Takes the same filters as List Subscriptions,\nbut no limit applies unless one is given; rows are streamed, so large exports are fine.\nRows are sent in batches of EXPORT_BATCH_ROWS; a client that takes longer than EXPORT_FLUSH_TIMEOUT to\nreceive a batch is disconnected.\nDates are formatted MM-YYYY and prices are in the smallest currency unit.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Export Subscriptions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by User ID (UUID); defaults to the authenticated user unless they are an admin, for whom omitting it exports every user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Service Name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start date (format: MM-YYYY)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by end date (format: MM-YYYY)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by presence of an end date",
+                        "name": "has_end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by cost center",
+                        "name": "cost_center",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by whether a failed charge is still unpaid",
+                        "name": "past_due",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "subscription",
+                            "rent",
+                            "utilities",
+                            "insurance",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Filter by expense type",
+                        "name": "expense_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows (default unlimited)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields (start_date, end_date, price, service_name); prefix with - for descending (default -start_date)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Apply a saved filter by ID; explicit query params override its values",
+                        "name": "saved_filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or filter parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/lookup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resolves up to 100 subscription IDs in one request. IDs that do not exist or belong to another user\nare listed in ` + "`" + `not_found` + "`" + ` instead of failing the request. Found subscriptions keep the requested order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Look Up Subscriptions",
+                "parameters": [
+                    {
+                        "description": "Subscription IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LookupSubscriptionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LookupSubscriptionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists deleted subscriptions that can still be restored, most recently deleted first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "List Trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID); defaults to the authenticated user unless they are an admin, for whom omitting it lists every user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination limit (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrashListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/trash/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores deleted subscriptions by ID, all of them or none. IDs that are not in the user's trash are\nlisted in ` + "`" + `not_found` + "`" + ` instead of failing the request. Restored subscriptions count against the quota.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Restore From Trash",
+                "parameters": [
+                    {
+                        "description": "Subscriptions to restore",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreSubscriptionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreSubscriptionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Quota exceeded or user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "A subscription with the same ID exists again",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single subscription by its unique ID.\nWith benchmark=true the average monthly price other users currently pay for the same service is included,\nunless too few users hold it (BENCHMARK_MIN_USERS) to keep their prices private.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Get Subscription by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the service's average price",
+                        "name": "benchmark",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionDetailResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the subscription, to send in If-Match when updating it"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing subscription's details by its ID. UserID cannot be changed.\nIf-Match must carry the ETag the subscription was read with; a write based on an older version\nis rejected with 412. Non-fatal issues are returned in ` + "`" + `warnings` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Update Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET /subscriptions/{id}, or * for any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "412": {
+                        "description": "Subscription was changed since the ETag was read",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a subscription to the trash. It stops counting everywhere and can be restored with\nPOST /subscriptions/trash/restore, or with POST /undo/{token} using the token in X-Undo-Token\nuntil X-Undo-Expires-At.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Delete Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "X-Undo-Expires-At": {
+                                "type": "string",
+                                "description": "When the undo token expires (RFC 3339)"
+                            },
+                            "X-Undo-Token": {
+                                "type": "string",
+                                "description": "Token that reverses this delete"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes only the fields sent, e.g. just the price or just the end date; the rest keep their\nstored values. An empty end_date, cost_center or category clears it. If-Match must carry the\nETag the subscription was read with; a write based on an older version is rejected with 412.\nNon-fatal issues are returned in ` + "`" + `warnings` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Patch Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET /subscriptions/{id}, or * for any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "412": {
+                        "description": "Subscription was changed since the ETag was read",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the recorded changes of a subscription, newest first: who made each change, when, and every\nchanged field's value before and after. The history stays readable after the subscription is deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Subscription History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AuditEntryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns whether the subscription is past due, i.e. has a failed charge that no retry has paid yet,\nwith its failed charges by month.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get Payment Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/payments/failed": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records that the charge for a month failed, which makes the subscription past due until a retry\nsucceeds. The month may not be a future one and must fall within the subscription's term.\nMarking a month again keeps its retries; marking a paid month reopens it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Mark Payment Failed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Month whose charge failed",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentFailedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentFailureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or fields, or the month is in the future or outside the term",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/payments/retried": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records a retry of a month's unpaid charge. A successful retry pays it, and the subscription is no\nlonger past due once no unpaid charge is left.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Mark Payment Retried",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Month retried and whether the retry went through",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentRetriedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentFailureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found, or no unpaid charge for the month",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/price-changes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the subscription's scheduled price changes, pending and applied, by effective month.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price Changes"
+                ],
+                "summary": "List Price Changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PriceChangeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedules the subscription's price to change from the first day of a future month within its\nterm, e.g. for an announced price hike. Cost forecasts use the new price right away; the stored\nprice is updated once the month begins. Scheduling a month again replaces its price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price Changes"
+                ],
+                "summary": "Schedule Price Change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New price and the month it takes effect",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SchedulePriceChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PriceChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or fields, or the month is not a future one within the term",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/reminder": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns when the owner is emailed ahead of the subscription's next renewal. Subscriptions that\nnever changed the setting are reminded, at the server's default offset.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "Get Renewal Reminder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReminderSettingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turns the renewal reminder email on or off and sets how many days ahead of a renewal it is\nsent. Omit days_before to follow the server default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reminders"
+                ],
+                "summary": "Set Renewal Reminder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reminder setting",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetReminderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReminderSettingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/trial": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the subscription's trial: when it ends, what it converts to and whether it has\nconverted yet. A trial lapses when the subscription ends or is deleted before the trial does.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trials"
+                ],
+                "summary": "Get Trial",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrialResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription or trial not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks the subscription as on trial until ends_on; its current price is the trial price. The\nday after ends_on it switches to regular_price and regular_billing_period, a\nsubscription.trial_converted webhook is sent and the owner is notified. Setting a trial again\nreplaces it and starts it over.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trials"
+                ],
+                "summary": "Set Trial",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trial end and regular price",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetTrialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrialResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or fields, or the trial end has passed",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists a user's suggestions, oldest first. Without status only the pending review queue is listed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "List Suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID); defaults to the authenticated user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "rejected",
+                            "merged"
+                        ],
+                        "type": "string",
+                        "description": "Suggestion status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SuggestionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues an automatically detected subscription for review. Suggestions never appear among the\nuser's subscriptions until they are accepted or merged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Submit Suggestion",
+                "parameters": [
+                    {
+                        "description": "Detected subscription",
+                        "name": "suggestion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSuggestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuggestionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates the suggested subscription. The new subscription has the suggestion's ID and is checked\nlike any other new subscription; non-fatal issues are returned in ` + "`" + `warnings` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Accept Suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suggestion ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuggestionResolutionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or subscription fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Subscription quota reached",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Suggestion not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Suggestion already resolved",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Folds a suggestion into a subscription the user already tracks. The subscription takes the\nsuggested price, and the suggested end date when there is one; everything else is kept.\nNon-fatal issues with the updated subscription are returned in ` + "`" + `warnings` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Merge Suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suggestion ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription to merge into",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergeSuggestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuggestionResolutionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body, or subscription of another user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Suggestion or subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Suggestion already resolved",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dismisses a suggestion without touching the user's subscriptions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Reject Suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suggestion ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuggestionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Suggestion not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Suggestion already resolved",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the latest state of every subscription of a user changed since the cursor:\nupserts carry the subscription, deletes are tombstones. Start with since=0 and pass\nthe returned cursor back until has_more is false.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "Pull Changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor returned by the previous call (default 0)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of changes (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies changes made offline, in order. Each change names the version it was based on;\nif the server changed the subscription since, it is a conflict. With strategy=manual\n(default) conflicts are returned with the server state for the client to resolve; with\nstrategy=last_writer_wins the change is applied when client_updated_at is newer than the\nserver's write. A failing change reports its error without failing the whole batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "Push Changes",
+                "parameters": [
+                    {
+                        "description": "Up to 100 client changes",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncPushRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncPushResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or changes",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/undo/{token}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reverses a delete while its undo token is valid and returns the restored subscription. A token\nworks once; unknown, expired and used tokens are all reported as not found. The restored\nsubscription counts against the quota.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscriptions"
+                ],
+                "summary": "Undo Delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Undo token from the X-Undo-Token header of the delete response",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Subscription quota reached",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Undo token not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "A subscription with the same ID exists again",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Creates an account. Emails are case-insensitive. The returned ID is the user_id that\nsubscriptions, saved filters and category rules refer to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register User",
+                "parameters": [
+                    {
+                        "description": "Email and password",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "User with this email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Another user's profile",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the password after checking the current one. Tokens issued earlier stay valid\nuntil they expire.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change Password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Another user's account or wrong current password",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/telegram": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Telegram"
+                ],
+                "summary": "Get Linked Telegram Chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TelegramLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Another user's account",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "No chat linked",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops the bot from acting for the user; renewal reminders go back to email.",
+                "tags": [
+                    "Telegram"
+                ],
+                "summary": "Unlink Telegram Chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Another user's account",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "No chat linked",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/telegram/link-code": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a one-time code for linking a Telegram chat. Sending the returned command to the bot\nlinks that chat: the bot then lists and adds subscriptions, and renewal reminders go there\ninstead of by email. A new code invalidates the previous one. Only available while the bot\nis enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Telegram"
+                ],
+                "summary": "Create Telegram Link Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TelegramLinkCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "Another user's account",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID); defaults to the authenticated user",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a URL that is POSTed a JSON payload whenever one of the user's subscriptions is\ncreated, updated or deleted. Each request carries X-Subtracker-Event, X-Subtracker-Delivery,\nX-Subtracker-Sequence, X-Subtracker-Timestamp and X-Subtracker-Signature, the hex HMAC-SHA256\nof \"\u003ctimestamp\u003e.\u003cbody\u003e\" under the webhook's secret prefixed with \"sha256=\". The sequence, also\nin the payload, counts the webhook's deliveries up from 1 in event order. The secret is returned\nonly in this response. Any answer other than 2xx is retried with exponential backoff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "description": "URL and events",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops calling the URL. Deliveries not sent yet are dropped along with the delivery history.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the webhook's URL or the events it is called for; fields left out are kept. Deliveries\nalready queued are still sent, to the new URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or fields",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the webhook's most recent deliveries, newest first, with their status: pending while\nattempts remain, delivered once the receiver answered 2xx, failed after the last attempt.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/secret": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the webhook's secret with a new one, returned only in this response. Every delivery sent\nfrom now on, including retries of earlier ones, is signed with the new secret.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Rotate Webhook Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Counts the webhook's deliveries by status, overall and for each event, with the time of the last\nsuccessful one. Events the webhook is registered for are listed even before their first delivery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Webhook Delivery Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.AppError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperrors.AppError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "err": {},
+                "errorCode": {
+                    "description": "ErrorCode is an optional machine-readable reason clients can branch on.",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AckMessageRequest": {
+            "type": "object",
+            "required": [
+                "consumer",
+                "message_id",
+                "user_id"
+            ],
+            "properties": {
+                "consumer": {
+                    "description": "Consumer names the service handling the message; each consumer keeps\nits own acks.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "billing-sync"
+                },
+                "message_id": {
+                    "description": "MessageID identifies the message, e.g. the X-Subtracker-Delivery header\nof a webhook delivery.",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "0b8a3c5e-2f0d-4c1e-9b7a-8d6e5f4c3b2a"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.AckMessageResponse": {
+            "type": "object",
+            "properties": {
+                "acked_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:02:31Z"
+                },
+                "consumer": {
+                    "type": "string",
+                    "example": "billing-sync"
+                },
+                "duplicate": {
+                    "description": "Duplicate means the message was acked before and must not be handled\nagain.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "message_id": {
+                    "type": "string",
+                    "example": "0b8a3c5e-2f0d-4c1e-9b7a-8d6e5f4c3b2a"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.ApplyRulesRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "overwrite": {
+                    "description": "Overwrite recategorizes subscriptions that already have a category.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.ApplyRulesResponse": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.AuditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "restore"
+                    ],
+                    "example": "update"
+                },
+                "actor_id": {
+                    "description": "ActorID is the user who made the change; omitted when none was authenticated.",
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "changed_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
+                "changes": {
+                    "description": "Changes maps each changed field to its old and new value.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.FieldChangeResponse"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "correct horse battery"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "staple battery horse"
+                }
+            }
+        },
+        "dto.CostBatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "database error on cost calculation"
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "01-2025"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "total_cost": {
+                    "type": "integer",
+                    "example": 2434
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.CostBatchRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.CostRequest"
+                    }
+                }
+            }
+        },
+        "dto.CostBatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CostBatchItemResponse"
+                    }
+                }
+            }
+        },
+        "dto.CostCenterCostResponse": {
+            "type": "object",
+            "properties": {
+                "cost_center": {
+                    "type": "string",
+                    "example": "Marketing"
+                },
+                "subscriptions": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "total_cost": {
+                    "type": "integer",
+                    "example": 11976
+                }
+            }
+        },
+        "dto.CostCenterReportResponse": {
+            "type": "object",
+            "properties": {
+                "cost_centers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CostCenterCostResponse"
+                    }
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "01-2025"
+                },
+                "total_cost": {
+                    "type": "integer",
+                    "example": 24352
+                }
+            }
+        },
+        "dto.CostRequest": {
+            "type": "object",
+            "required": [
+                "period_end",
+                "period_start",
+                "user_id"
+            ],
+            "properties": {
+                "exclude_unpaid": {
+                    "description": "ExcludeUnpaid leaves months whose charge failed and is still unpaid out\nof the cost.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "01-2025"
+                },
+                "service_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Yandex Plus"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.CostResponse": {
+            "type": "object",
+            "properties": {
+                "total_cost": {
+                    "type": "integer",
+                    "example": 2434
+                }
+            }
+        },
+        "dto.CountedPagination": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 27
+                }
+            }
+        },
+        "dto.CreateBatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "subscription quota of 10 reached"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "quota_exceeded"
+                },
+                "id": {
+                    "description": "ID is set for created subscriptions, Error for rejected ones.",
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "rejected"
+                    ],
+                    "example": "created"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Warning"
+                    }
+                }
+            }
+        },
+        "dto.CreateBatchResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "description": "Results are in the order of the request.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateBatchItemResponse"
+                    }
+                }
+            }
+        },
+        "dto.CreateRuleRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "pattern"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Entertainment"
+                },
+                "pattern": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "netflix"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "user_id": {
+                    "description": "UserID scopes the rule to one user; omit it for a global rule.",
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.CreateSavedFilterRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "params",
+                "user_id"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Work tools over 1000"
+                },
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "price",
+                "service_name",
+                "start_date",
+                "user_id"
+            ],
+            "properties": {
+                "billing_period": {
+                    "description": "BillingPeriod defaults to monthly.",
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "monthly"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Entertainment"
+                },
+                "cost_center": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Marketing"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "08-2026"
+                },
+                "expense_type": {
+                    "description": "ExpenseType defaults to subscription.",
+                    "type": "string",
+                    "enum": [
+                        "subscription",
+                        "rent",
+                        "utilities",
+                        "insurance",
+                        "other"
+                    ],
+                    "example": "subscription"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 299
+                },
+                "service_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Yandex Plus"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.CreateSuggestionRequest": {
+            "type": "object",
+            "required": [
+                "service_name",
+                "source",
+                "start_date",
+                "user_id"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "08-2026"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 999
+                },
+                "service_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Netflix"
+                },
+                "source": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "bank"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url",
+                "user_id"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "subscription.created",
+                            "subscription.updated",
+                            "subscription.deleted",
+                            "subscription.trial_converted"
+                        ]
+                    },
+                    "example": [
+                        "subscription.created"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/subtracker"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.CreateWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscription.created"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6f1c2c9e-8d1e-4a57-9a43-2f0f3c1c5a10"
+                },
+                "secret": {
+                    "description": "Secret signs every payload. It is returned only here.",
+                    "type": "string",
+                    "example": "Jx1cJ6Vw0m2Wm9yQn1Qe3A"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/subtracker"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.DependencyStatusResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "database"
+                },
+                "problem": {
+                    "type": "string",
+                    "example": "unreachable"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "failing"
+                    ],
+                    "example": "ok"
+                }
+            }
+        },
+        "dto.FeatureFlagResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Aggregate costs in SQL instead of in the service"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "sql_cost_aggregation"
+                },
+                "rollout_percent": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                }
+            }
+        },
+        "dto.FieldChangeResponse": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string",
+                    "example": "Netflix Premium"
+                },
+                "before": {
+                    "type": "string",
+                    "example": "Netflix"
+                }
+            }
+        },
+        "dto.IntegrityReportResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string",
+                    "example": "2025-07-01T12:00:00Z"
+                },
+                "healthy": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "violations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "correct horse battery"
+                }
+            }
+        },
+        "dto.LookupSubscriptionsRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                    ]
+                }
+            }
+        },
+        "dto.LookupSubscriptionsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SubscriptionResponse"
+                    }
+                },
+                "not_found": {
+                    "description": "NotFound lists the IDs that do not exist or belong to another user.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                    ]
+                }
+            }
+        },
+        "dto.MergeSuggestionRequest": {
+            "type": "object",
+            "required": [
+                "subscription_id"
+            ],
+            "properties": {
+                "subscription_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dto.PatchSubscriptionRequest": {
+            "description": "PatchSubscriptionRequest changes only the fields present. An empty\nend_date, cost_center or category clears it.",
+            "type": "object",
+            "properties": {
+                "billing_period": {
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "yearly"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Entertainment"
+                },
+                "cost_center": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Marketing"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "08-2027"
+                },
+                "expense_type": {
+                    "type": "string",
+                    "enum": [
+                        "subscription",
+                        "rent",
+                        "utilities",
+                        "insurance",
+                        "other"
+                    ],
+                    "example": "insurance"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 499
+                },
+                "service_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Yandex Plus Family"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                }
+            }
+        },
+        "dto.PaymentFailedRequest": {
+            "type": "object",
+            "required": [
+                "period"
+            ],
+            "properties": {
+                "period": {
+                    "description": "Period is the month whose charge failed; it may not be a future month.",
+                    "type": "string",
+                    "example": "01-2026"
+                }
+            }
+        },
+        "dto.PaymentFailureResponse": {
+            "type": "object",
+            "properties": {
+                "failed_at": {
+                    "type": "string",
+                    "example": "2026-01-03T08:00:00Z"
+                },
+                "last_retry_at": {
+                    "type": "string",
+                    "example": "2026-01-06T08:00:00Z"
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2026-01-06T08:00:00Z"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "01-2026"
+                },
+                "retries": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "unpaid",
+                        "paid"
+                    ],
+                    "example": "unpaid"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.PaymentHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PaymentFailureResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "current",
+                        "past_due"
+                    ],
+                    "example": "past_due"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.PaymentRetriedRequest": {
+            "type": "object",
+            "required": [
+                "period",
+                "succeeded"
+            ],
+            "properties": {
+                "period": {
+                    "type": "string",
+                    "example": "01-2026"
+                },
+                "succeeded": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.PriceChangeResponse": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:05:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-20T10:00:00Z"
+                },
+                "effective_date": {
+                    "type": "string",
+                    "example": "01-2026"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 399
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "applied"
+                    ],
+                    "example": "pending"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.QueryStatResponse": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer",
+                    "example": 1520
+                },
+                "mean_time_ms": {
+                    "type": "number",
+                    "example": 3.49
+                },
+                "query": {
+                    "type": "string",
+                    "example": "SELECT id, user_id, service_name FROM subscriptions WHERE user_id = $1"
+                },
+                "query_id": {
+                    "type": "integer",
+                    "example": -3713942186431201231
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 30400
+                },
+                "total_time_ms": {
+                    "type": "number",
+                    "example": 5312.4
+                }
+            }
+        },
+        "dto.RegisterUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "alice@example.com"
+                },
+                "password": {
+                    "description": "Password is capped at 72 bytes, the most bcrypt hashes.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "correct horse battery"
+                }
+            }
+        },
+        "dto.ReminderSettingResponse": {
+            "type": "object",
+            "properties": {
+                "days_before": {
+                    "description": "DaysBefore is null while the server default applies.",
+                    "type": "integer",
+                    "example": 7
+                },
+                "effective_days_before": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.RestoreSubscriptionsRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.RestoreSubscriptionsResponse": {
+            "type": "object",
+            "properties": {
+                "not_found": {
+                    "description": "NotFound lists the IDs that were not in the trash, or not in the\ncaller's trash.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                    ]
+                },
+                "restored": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                    ]
+                }
+            }
+        },
+        "dto.RouteResponse": {
+            "type": "object",
+            "properties": {
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "middlewares": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subtracker/internal/handler.observeLatency"
+                    ]
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "/subscriptions/{id}"
+                }
+            }
+        },
+        "dto.RuleResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "Entertainment"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "netflix"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.SavedFilterResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "5b0c7a2e-3f51-4c55-9d1e-0b7a2c3e4f51"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Work tools over 1000"
+                },
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.SavingsReportResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SubscriptionResponse"
+                    }
+                },
+                "cancelled_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "monthly_savings": {
+                    "type": "integer",
+                    "example": 798
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "01-2025"
+                },
+                "projected_annual_savings": {
+                    "type": "integer",
+                    "example": 9576
+                }
+            }
+        },
+        "dto.SchedulePriceChangeRequest": {
+            "type": "object",
+            "required": [
+                "effective_date",
+                "price"
+            ],
+            "properties": {
+                "effective_date": {
+                    "description": "EffectiveDate is the first month billed at the new price; it must be\na future month.",
+                    "type": "string",
+                    "example": "01-2026"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 399
+                }
+            }
+        },
+        "dto.ServiceBenchmarkResponse": {
+            "type": "object",
+            "properties": {
+                "average_price": {
+                    "type": "integer",
+                    "example": 520
+                },
+                "difference": {
+                    "description": "Difference is the subscription's price minus the average.",
+                    "type": "integer",
+                    "example": -21
+                }
+            }
+        },
+        "dto.SetFeatureFlagRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Aggregate costs in SQL instead of in the service"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "description": "Environments limits the flag to these APP_ENV values; empty means all.",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "rollout_percent": {
+                    "description": "RolloutPercent defaults to 100 when omitted.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "maximum": 100,
+                    "example": 25
+                }
+            }
+        },
+        "dto.SetReminderRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "days_before": {
+                    "description": "DaysBefore follows the server default when omitted or null.",
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 0,
+                    "example": 7
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.SetTrialRequest": {
+            "type": "object",
+            "required": [
+                "ends_on",
+                "regular_price"
+            ],
+            "properties": {
+                "ends_on": {
+                    "description": "EndsOn is the last day of the trial; the regular price applies from the\nday after.",
+                    "type": "string",
+                    "example": "2026-01-14"
+                },
+                "regular_billing_period": {
+                    "description": "RegularBillingPeriod defaults to the subscription's current period.",
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "monthly"
+                },
+                "regular_price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 999
+                }
+            }
+        },
+        "dto.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "commit": {
+                    "type": "string",
+                    "example": "9bcb46f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6"
+                },
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependencyStatusResponse"
+                    }
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-10-15T08:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "degraded"
+                    ],
+                    "example": "ok"
+                },
+                "uptime_seconds": {
+                    "type": "integer",
+                    "example": 86400
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v1.4.0"
+                }
+            }
+        },
+        "dto.SubscriptionDetailResponse": {
+            "type": "object",
+            "properties": {
+                "benchmark": {
+                    "$ref": "#/definitions/dto.ServiceBenchmarkResponse"
+                },
+                "billing_period": {
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "monthly"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Entertainment"
+                },
+                "cost_center": {
+                    "type": "string",
+                    "example": "Marketing"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "08-2026"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 299
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.SubscriptionListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SubscriptionResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.CountedPagination"
+                }
+            }
+        },
+        "dto.SubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "billing_period": {
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "monthly"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Entertainment"
+                },
+                "cost_center": {
+                    "type": "string",
+                    "example": "Marketing"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "08-2026"
+                },
+                "expense_type": {
+                    "type": "string",
+                    "enum": [
+                        "subscription",
+                        "rent",
+                        "utilities",
+                        "insurance",
+                        "other"
+                    ],
+                    "example": "subscription"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 299
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.SuggestionResolutionResponse": {
+            "type": "object",
+            "properties": {
+                "suggestion": {
+                    "$ref": "#/definitions/dto.SuggestionResponse"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Warning"
+                    }
+                }
+            }
+        },
+        "dto.SuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "08-2026"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2025-07-02T09:30:00Z"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "bank"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "accepted",
+                        "rejected",
+                        "merged"
+                    ],
+                    "example": "pending"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.SyncChangeResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "upsert",
+                        "delete"
+                    ],
+                    "example": "upsert"
+                },
+                "subscription": {
+                    "$ref": "#/definitions/dto.SubscriptionResponse"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1042
+                }
+            }
+        },
+        "dto.SyncPushChange": {
+            "type": "object",
+            "required": [
+                "client_updated_at",
+                "id",
+                "op"
+            ],
+            "properties": {
+                "base_version": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1042
+                },
+                "client_updated_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "upsert",
+                        "delete"
+                    ],
+                    "example": "upsert"
+                },
+                "subscription": {
+                    "$ref": "#/definitions/dto.UpdateSubscriptionRequest"
+                }
+            }
+        },
+        "dto.SyncPushRequest": {
+            "type": "object",
+            "required": [
+                "changes",
+                "user_id"
+            ],
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncPushChange"
+                    }
+                },
+                "strategy": {
+                    "type": "string",
+                    "enum": [
+                        "manual",
+                        "last_writer_wins"
+                    ],
+                    "example": "manual"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.SyncPushResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncPushResultResponse"
+                    }
+                }
+            }
+        },
+        "dto.SyncPushResultResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "end date cannot be before start date"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                },
+                "server": {
+                    "$ref": "#/definitions/dto.SyncChangeResponse"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "applied",
+                        "conflict",
+                        "rejected"
+                    ],
+                    "example": "applied"
+                }
+            }
+        },
+        "dto.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncChangeResponse"
+                    }
+                },
+                "cursor": {
+                    "type": "string",
+                    "example": "1042"
+                },
+                "has_more": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.TelegramLinkCodeResponse": {
             "type": "object",
             "properties": {
                 "code": {
-                    "type": "integer"
+                    "type": "string",
+                    "example": "q3X9aZ1bT0c2LmNpQrStUw"
                 },
-                "err": {},
-                "message": {
-                    "type": "string"
+                "command": {
+                    "description": "Command is what to send the bot to link a chat.",
+                    "type": "string",
+                    "example": "/start q3X9aZ1bT0c2LmNpQrStUw"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:15:00Z"
                 }
             }
         },
-        "dto.CostResponse": {
+        "dto.TelegramLinkResponse": {
             "type": "object",
             "properties": {
-                "total_cost": {
+                "chat_id": {
                     "type": "integer",
-                    "example": 2434
+                    "example": 123456789
+                },
+                "linked_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:05:00Z"
                 }
             }
         },
-        "dto.CreateSubscriptionRequest": {
+        "dto.TokenResponse": {
             "type": "object",
-            "required": [
-                "price",
-                "service_name",
-                "start_date",
-                "user_id"
-            ],
             "properties": {
-                "end_date": {
+                "access_token": {
                     "type": "string",
-                    "example": "08-2026"
-                },
-                "price": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 299
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
-                "service_name": {
+                "expires_at": {
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "Yandex Plus"
+                    "example": "2025-07-02T12:00:00Z"
                 },
-                "start_date": {
+                "token_type": {
                     "type": "string",
-                    "example": "07-2025"
+                    "example": "Bearer"
+                }
+            }
+        },
+        "dto.TrashListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrashedSubscriptionResponse"
+                    }
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
                 }
             }
         },
-        "dto.SubscriptionResponse": {
+        "dto.TrashedSubscriptionResponse": {
             "type": "object",
             "properties": {
+                "billing_period": {
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "monthly"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Entertainment"
+                },
+                "cost_center": {
+                    "type": "string",
+                    "example": "Marketing"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
                 "end_date": {
                     "type": "string",
                     "example": "08-2026"
@@ -455,6 +5752,65 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TrialResponse": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "type": "string",
+                    "example": "2026-01-15T00:05:00Z"
+                },
+                "ends_on": {
+                    "type": "string",
+                    "example": "2026-01-14"
+                },
+                "regular_billing_period": {
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "monthly"
+                },
+                "regular_price": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "converted",
+                        "lapsed"
+                    ],
+                    "example": "active"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "d290f1ee-6c54-4b01-90e6-d701748f0851"
+                }
+            }
+        },
+        "dto.UpdateSavedFilterRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "params"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Work tools over 1500"
+                },
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "dto.UpdateSubscriptionRequest": {
             "type": "object",
             "required": [
@@ -463,10 +5819,42 @@ const docTemplate = `{
                 "start_date"
             ],
             "properties": {
+                "billing_period": {
+                    "description": "BillingPeriod keeps the current period when omitted.",
+                    "type": "string",
+                    "enum": [
+                        "monthly",
+                        "yearly",
+                        "weekly"
+                    ],
+                    "example": "yearly"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Entertainment"
+                },
+                "cost_center": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Marketing"
+                },
                 "end_date": {
                     "type": "string",
                     "example": "08-2027"
                 },
+                "expense_type": {
+                    "description": "ExpenseType keeps the current type when omitted.",
+                    "type": "string",
+                    "enum": [
+                        "subscription",
+                        "rent",
+                        "utilities",
+                        "insurance",
+                        "other"
+                    ],
+                    "example": "subscription"
+                },
                 "price": {
                     "type": "integer",
                     "minimum": 0,
@@ -483,6 +5871,279 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpdateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "subscription.created",
+                            "subscription.updated",
+                            "subscription.deleted",
+                            "subscription.trial_converted"
+                        ]
+                    },
+                    "example": [
+                        "subscription.deleted"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/subtracker"
+                }
+            }
+        },
+        "dto.UserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ],
+                    "example": "user"
+                }
+            }
+        },
+        "dto.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:02:31Z"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "subscription.updated"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b8a3c5e-2f0d-4c1e-9b7a-8d6e5f4c3b2a"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "receiver answered 503 Service Unavailable"
+                },
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is only set while the delivery is pending.",
+                    "type": "string",
+                    "example": "2025-07-01T10:02:30Z"
+                },
+                "response_status": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "sequence": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ],
+                    "example": "pending"
+                }
+            }
+        },
+        "dto.WebhookEventStatsResponse": {
+            "type": "object",
+            "properties": {
+                "delivered": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "event": {
+                    "type": "string",
+                    "example": "subscription.updated"
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:00:00Z"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscription.created"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6f1c2c9e-8d1e-4a57-9a43-2f0f3c1c5a10"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/subtracker"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+                }
+            }
+        },
+        "dto.WebhookStatsResponse": {
+            "type": "object",
+            "properties": {
+                "delivered": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookEventStatsResponse"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "last_delivered_at": {
+                    "type": "string",
+                    "example": "2025-07-01T10:02:31Z"
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "webhook_id": {
+                    "type": "string",
+                    "example": "6f1c2c9e-8d1e-4a57-9a43-2f0f3c1c5a10"
+                }
+            }
+        },
+        "jsonschema.Schema": {
+            "type": "object",
+            "properties": {
+                "$schema": {
+                    "type": "string"
+                },
+                "const": {},
+                "enum": {
+                    "type": "array",
+                    "items": {}
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {}
+                },
+                "exclusiveMaximum": {
+                    "type": "number"
+                },
+                "exclusiveMinimum": {
+                    "type": "number"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "items": {
+                    "$ref": "#/definitions/jsonschema.Schema"
+                },
+                "maxItems": {
+                    "type": "integer"
+                },
+                "maxLength": {
+                    "type": "integer"
+                },
+                "maximum": {
+                    "type": "number"
+                },
+                "minItems": {
+                    "type": "integer"
+                },
+                "minLength": {
+                    "type": "integer"
+                },
+                "minimum": {
+                    "type": "number"
+                },
+                "not": {
+                    "$ref": "#/definitions/jsonschema.Schema"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/jsonschema.Schema"
+                    }
+                },
+                "required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error_code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
         "response.APIResponse": {
             "type": "object",
             "properties": {
@@ -491,9 +6152,36 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Warning"
+                    }
+                }
+            }
+        },
+        "response.Warning": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "unusual_price"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "price is zero"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "\"Bearer \u003ctoken\u003e\" from /auth/login. Required on /subscriptions routes when AUTH_JWT_SECRET is set.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,99 @@
+package anonymizer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+var identifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// Options controls how production rows are copied into the staging schema.
+type Options struct {
+	// Schema is the target schema; it is recreated on every run.
+	Schema string
+	// Salt is mixed into user ID hashes so staging IDs cannot be reversed by
+	// hashing known production IDs.
+	Salt string
+	// SamplePercent is the share of subscriptions copied (0 < p <= 100).
+	SamplePercent float64
+	// PriceJitter is the maximum relative price change, e.g. 0.1 for ±10%.
+	PriceJitter float64
+}
+
+func (o Options) validate() error {
+	if !identifierPattern.MatchString(o.Schema) || o.Schema == "public" {
+		return fmt.Errorf("invalid staging schema name %q", o.Schema)
+	}
+	if o.Salt == "" {
+		return fmt.Errorf("salt must not be empty")
+	}
+	if o.SamplePercent <= 0 || o.SamplePercent > 100 {
+		return fmt.Errorf("sample percent must be in (0, 100], got %v", o.SamplePercent)
+	}
+	if o.PriceJitter < 0 || o.PriceJitter >= 1 {
+		return fmt.Errorf("price jitter must be in [0, 1), got %v", o.PriceJitter)
+	}
+	return nil
+}
+
+// Run copies a sample of production subscriptions into opts.Schema with user IDs
+// rehashed, service names preserved and prices jittered, all in one transaction.
+func Run(ctx context.Context, db *sql.DB, opts Options, logger logger.Logger) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Rehashing is deterministic per salt, so every subscription of a user maps
+	// to the same staging user and per-user query shapes stay realistic.
+	rehash := `md5($1 || user_id::text)::uuid`
+	statements := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{"drop schema", fmt.Sprintf(`DROP SCHEMA IF EXISTS %s CASCADE`, opts.Schema), nil},
+		{"create schema", fmt.Sprintf(`CREATE SCHEMA %s`, opts.Schema), nil},
+		{"create subscriptions", fmt.Sprintf(`CREATE TABLE %s.subscriptions (LIKE public.subscriptions INCLUDING ALL)`, opts.Schema), nil},
+		{"copy subscriptions", fmt.Sprintf(
+			`INSERT INTO %s.subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type)
+			SELECT id, %s, service_name,
+				GREATEST(0, round(price * (1 + (random() * 2 - 1) * $2)))::int,
+				start_date, end_date, cost_center, category, billing_period, expense_type
+			FROM public.subscriptions TABLESAMPLE BERNOULLI ($3)`, opts.Schema, rehash),
+			[]any{opts.Salt, opts.PriceJitter, opts.SamplePercent}},
+		{"create saved filters", fmt.Sprintf(`CREATE TABLE %s.saved_filters (LIKE public.saved_filters INCLUDING ALL)`, opts.Schema), nil},
+		{"copy saved filters", fmt.Sprintf(
+			`INSERT INTO %s.saved_filters (id, user_id, name, params)
+			SELECT id, %s, name, params FROM public.saved_filters
+			WHERE %s IN (SELECT user_id FROM %s.subscriptions)`, opts.Schema, rehash, rehash, opts.Schema),
+			[]any{opts.Salt}},
+	}
+
+	for _, stmt := range statements {
+		logger.Debug("Executing anonymizer statement", zap.String("step", stmt.name))
+		result, err := tx.ExecContext(ctx, stmt.query, stmt.args...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", stmt.name, err)
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows > 0 {
+			logger.Info("Anonymizer step completed", zap.String("step", stmt.name), zap.Int64("rows", rows))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit staging copy: %w", err)
+	}
+	return nil
+}
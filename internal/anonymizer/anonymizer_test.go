@@ -0,0 +1,66 @@
+package anonymizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	opts := Options{Schema: "staging", Salt: "pepper", SamplePercent: 10, PriceJitter: 0.1}
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DROP SCHEMA IF EXISTS staging CASCADE`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`CREATE SCHEMA staging`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`CREATE TABLE staging.subscriptions`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO staging.subscriptions`).
+			WithArgs("pepper", 0.1, 10.0).
+			WillReturnResult(sqlmock.NewResult(0, 42))
+		mock.ExpectExec(`CREATE TABLE staging.saved_filters`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO staging.saved_filters`).
+			WithArgs("pepper").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		err = Run(context.Background(), db, opts, logger.NewNopLogger())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls Back on Failure", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DROP SCHEMA`).WillReturnError(errors.New("permission denied"))
+		mock.ExpectRollback()
+
+		err = Run(context.Background(), db, opts, logger.NewNopLogger())
+		assert.ErrorContains(t, err, "drop schema")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rejects Unsafe Options", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		for _, bad := range []Options{
+			{Schema: "public", Salt: "s", SamplePercent: 10},
+			{Schema: "staging; DROP TABLE x", Salt: "s", SamplePercent: 10},
+			{Schema: "staging", Salt: "", SamplePercent: 10},
+			{Schema: "staging", Salt: "s", SamplePercent: 0},
+			{Schema: "staging", Salt: "s", SamplePercent: 10, PriceJitter: 1},
+		} {
+			assert.Error(t, Run(context.Background(), db, bad, logger.NewNopLogger()))
+		}
+	})
+}
@@ -0,0 +1,31 @@
+// Package buildinfo identifies the running build. Version and Commit are set
+// at link time, e.g.
+//
+//	go build -ldflags "-X subtracker/internal/buildinfo.Version=v1.4.0 -X subtracker/internal/buildinfo.Commit=$(git rev-parse HEAD)"
+package buildinfo
+
+import "runtime/debug"
+
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+// Revision returns Commit, falling back to the VCS revision the Go toolchain
+// stamps into binaries built inside a checkout. It is empty when neither is
+// known.
+func Revision() string {
+	if Commit != "" {
+		return Commit
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
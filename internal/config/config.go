@@ -1,10 +1,121 @@
 package config
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type AppConfig struct {
-	AppPort  string
-	LogLevel string
+	AppPort string
+	// AdminPort moves metrics, admin routes and pprof to their own listener so
+	// they are never reachable on AppPort. Empty keeps them on AppPort.
+	AdminPort string
+	// AdminToken, when set, is required as a bearer token on the admin listener.
+	AdminToken Secret
+	// DrainTimeout bounds a graceful shutdown: in-flight requests, such as long
+	// exports, and background workers get this long to finish.
+	DrainTimeout time.Duration
+	LogLevel     string
+	// Environment names the deployment, e.g. production or staging; feature
+	// flags can be limited to some environments.
+	Environment string
+	// FeatureFlagRefresh is how long feature flags are served from memory
+	// before they are read again, so changes made through another instance
+	// take at most this long to apply.
+	FeatureFlagRefresh time.Duration
+	// ListDefaultLimit applies when a list request does not set limit;
+	// ListMaxLimit is the largest limit a client may ask for.
+	ListDefaultLimit int
+	ListMaxLimit     int
+	// ExportBatchRows is how many rows an export writes between flushes, and
+	// ExportFlushTimeout how long the client gets to take in each batch. A
+	// slower client is cut off, releasing the export's database cursor.
+	ExportBatchRows    int
+	ExportFlushTimeout time.Duration
+	// StartDateMaxYearsPast and StartDateMaxYearsFuture bound how far a
+	// subscription's start date may lie from today. Zero disables the bound.
+	StartDateMaxYearsPast   int
+	StartDateMaxYearsFuture int
+	// DebugEndpoints exposes introspection routes under /admin, plus pprof under
+	// /debug on the admin listener. Keep it off in production.
+	DebugEndpoints bool
+	// IntegrityCheckInterval is how often data invariants are re-checked.
+	IntegrityCheckInterval time.Duration
+	// SubscriptionQuota caps how many subscriptions one user may hold. Zero disables it.
+	SubscriptionQuota int
+	// BenchmarkMinUsers is how many distinct users must hold a service before
+	// its average price is shown, so no single user's price can be inferred.
+	BenchmarkMinUsers int
+	// JWTSecret signs access tokens. Authentication is off while it is empty.
+	JWTSecret Secret
+	// TokenTTL is how long an issued access token stays valid.
+	TokenTTL time.Duration
+	// UndoWindow is how long a delete can be reversed with its undo token.
+	UndoWindow time.Duration
+	// ReadOnly rejects every mutating request with 503 while reads keep
+	// working, e.g. during a primary failover or a long migration.
+	ReadOnly bool
+	// ReminderInterval is how often renewal reminders are looked for.
+	ReminderInterval time.Duration
+	// ReminderDaysBefore is how many days ahead of a renewal reminders go out
+	// for subscriptions that do not set their own offset.
+	ReminderDaysBefore int
+	// ReminderDigestDaysEmail and ReminderDigestDaysTelegram are how many days
+	// early, per channel, a reminder may go out to join the digest of another
+	// one due for the same owner. Reminders due together always share one.
+	ReminderDigestDaysEmail    int
+	ReminderDigestDaysTelegram int
+	// PriceChangeInterval is how often due price changes are applied.
+	PriceChangeInterval time.Duration
+	// TrialConversionInterval is how often ended trials are converted to
+	// their regular price.
+	TrialConversionInterval time.Duration
+	// CORS holds the cross-origin policy of each route group.
+	CORS CORSConfig
+}
+
+// CORSPolicy is which browser origins may call a group of routes. No origins
+// means no cross-origin access at all.
+type CORSPolicy struct {
+	// AllowedOrigins are origins such as https://app.example.com, or "*" for any.
+	AllowedOrigins []string
+	// AllowCredentials lets browsers send cookies and HTTP auth along.
+	AllowCredentials bool
+}
+
+// CORSConfig assigns a policy to each route group.
+type CORSConfig struct {
+	// API covers every route not in another group.
+	API CORSPolicy
+	// Public covers the API description routes, /swagger.json and /schemas,
+	// which third-party tools fetch from anywhere.
+	Public CORSPolicy
+	// Admin covers /admin, /metrics and /debug, on whichever listener serves them.
+	Admin CORSPolicy
+}
+
+// AdminListenerEnabled reports whether admin endpoints have their own listener.
+func (c AppConfig) AdminListenerEnabled() bool {
+	return c.AdminPort != ""
+}
+
+// AuthEnabled reports whether requests must carry a valid access token.
+func (c AppConfig) AuthEnabled() bool {
+	return c.JWTSecret != ""
+}
+
+// Secret is a config value that must never be logged.
+type Secret string
+
+func (Secret) String() string {
+	return "[redacted]"
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
 }
 
 type PostgresConfig struct {
@@ -14,18 +125,128 @@ type PostgresConfig struct {
 	DBUser      string
 	DBPassword  string
 	PostgresDSN string
+	// ConnectTimeout bounds the blocking startup connection attempts.
+	ConnectTimeout time.Duration
+	// ConnectInBackground starts serving immediately and keeps retrying the
+	// database in the background, answering 503 until it is reachable.
+	ConnectInBackground bool
+	// ReportingMaxConns caps the separate pool used by aggregate/report queries.
+	ReportingMaxConns int
+	// QueryExecMode is pgx's default_query_exec_mode. cache_statement
+	// prepares each distinct query once per connection and cache_describe
+	// caches only its parameter and result types; exec and simple_protocol
+	// cache nothing, as transaction-pooling proxies such as PgBouncer need.
+	QueryExecMode string
+	// StatementCacheCapacity and DescriptionCacheCapacity bound those caches
+	// per connection.
+	StatementCacheCapacity   int
+	DescriptionCacheCapacity int
+}
+
+// SMTPConfig is the relay outgoing email is sent through. Email, and with it
+// renewal reminders, is off while Addr is empty.
+type SMTPConfig struct {
+	Addr     string
+	Username string
+	Password Secret
+	From     string
+}
+
+// Enabled reports whether email can be sent.
+func (c SMTPConfig) Enabled() bool {
+	return c.Addr != ""
+}
+
+// TelegramConfig is the bot users link their chats to. The bot, and reminders
+// through Telegram, are off while BotToken is empty.
+type TelegramConfig struct {
+	BotToken Secret
+	// APIURL is the Bot API server, overridable for a self-hosted one.
+	APIURL string
+	// LinkCodeTTL is how long a code for linking a chat stays valid.
+	LinkCodeTTL time.Duration
+}
+
+// Enabled reports whether the bot runs.
+func (c TelegramConfig) Enabled() bool {
+	return c.BotToken != ""
+}
+
+// WebhookConfig controls how queued webhook deliveries are sent.
+type WebhookConfig struct {
+	// DispatchInterval is how often due deliveries are looked for.
+	DispatchInterval time.Duration
+	// Timeout bounds a single delivery attempt.
+	Timeout time.Duration
+	// MaxAttempts is how often a delivery is tried before it is marked failed.
+	MaxAttempts int
+	// AllowPrivateTargets lets webhooks call loopback and private addresses,
+	// e.g. for local development. Keep it off in production.
+	AllowPrivateTargets bool
 }
 
 type Config struct {
 	App      AppConfig
 	Postgres PostgresConfig
+	SMTP     SMTPConfig
+	Telegram TelegramConfig
+	Webhooks WebhookConfig
+
+	// parseErrs lists the variables LoadConfig found set but could not parse;
+	// their defaults were used and Validate reports them.
+	parseErrs []error
 }
 
 func LoadConfig() *Config {
+	var parseErrs []error
 	cfg := &Config{
 		App: AppConfig{
-			AppPort:  getEnv("APP_PORT", "8080"),
-			LogLevel: getEnv("LOG_LEVEL", "DEBUG"),
+			AppPort:      getEnv("APP_PORT", "8080"),
+			AdminPort:    getEnv("ADMIN_PORT", ""),
+			AdminToken:   Secret(getEnv("ADMIN_TOKEN", "")),
+			DrainTimeout: getEnvDuration("SHUTDOWN_DRAIN_TIMEOUT", time.Minute, &parseErrs),
+			LogLevel:     getEnv("LOG_LEVEL", "DEBUG"),
+
+			Environment:        getEnv("APP_ENV", "development"),
+			FeatureFlagRefresh: getEnvDuration("FEATURE_FLAG_REFRESH_INTERVAL", 30*time.Second, &parseErrs),
+
+			ListDefaultLimit: getEnvInt("LIST_DEFAULT_LIMIT", 10, &parseErrs),
+			ListMaxLimit:     getEnvInt("LIST_MAX_LIMIT", 100, &parseErrs),
+
+			ExportBatchRows:    getEnvInt("EXPORT_BATCH_ROWS", 500, &parseErrs),
+			ExportFlushTimeout: getEnvDuration("EXPORT_FLUSH_TIMEOUT", 30*time.Second, &parseErrs),
+
+			StartDateMaxYearsPast:   getEnvInt("START_DATE_MAX_YEARS_PAST", 30, &parseErrs),
+			StartDateMaxYearsFuture: getEnvInt("START_DATE_MAX_YEARS_FUTURE", 5, &parseErrs),
+
+			DebugEndpoints:         getEnvBool("DEBUG_ENDPOINTS", false, &parseErrs),
+			IntegrityCheckInterval: getEnvDuration("INTEGRITY_CHECK_INTERVAL", time.Hour, &parseErrs),
+			SubscriptionQuota:      getEnvInt("SUBSCRIPTION_QUOTA", 0, &parseErrs),
+			BenchmarkMinUsers:      getEnvInt("BENCHMARK_MIN_USERS", 10, &parseErrs),
+
+			JWTSecret: Secret(getEnv("AUTH_JWT_SECRET", "")),
+			TokenTTL:  getEnvDuration("AUTH_TOKEN_TTL", 24*time.Hour, &parseErrs),
+
+			UndoWindow: getEnvDuration("UNDO_WINDOW", 5*time.Minute, &parseErrs),
+			ReadOnly:   getEnvBool("READ_ONLY", false, &parseErrs),
+
+			ReminderInterval:   getEnvDuration("REMINDER_INTERVAL", time.Hour, &parseErrs),
+			ReminderDaysBefore: getEnvInt("REMINDER_DAYS_BEFORE", 3, &parseErrs),
+
+			ReminderDigestDaysEmail:    getEnvInt("REMINDER_DIGEST_DAYS_EMAIL", 0, &parseErrs),
+			ReminderDigestDaysTelegram: getEnvInt("REMINDER_DIGEST_DAYS_TELEGRAM", 0, &parseErrs),
+
+			PriceChangeInterval:     getEnvDuration("PRICE_CHANGE_INTERVAL", time.Hour, &parseErrs),
+			TrialConversionInterval: getEnvDuration("TRIAL_CONVERSION_INTERVAL", time.Hour, &parseErrs),
+
+			CORS: CORSConfig{
+				API: CORSPolicy{
+					AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS", []string{"*"}),
+					AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false, &parseErrs),
+				},
+				Public: CORSPolicy{AllowedOrigins: getEnvList("CORS_PUBLIC_ALLOWED_ORIGINS", []string{"*"})},
+				Admin:  CORSPolicy{AllowedOrigins: getEnvList("CORS_ADMIN_ALLOWED_ORIGINS", nil)},
+			},
 		},
 		Postgres: PostgresConfig{
 			DBHost:      getEnv("DB_HOST", "db"),
@@ -34,8 +255,34 @@ func LoadConfig() *Config {
 			DBUser:      getEnv("DB_USER", "postgres"),
 			DBPassword:  getEnv("DB_PASSWORD", "supersecret"),
 			PostgresDSN: getEnv("POSTGRES_DSN", "postgres://postgres:supersecret@db:5432/subtracker?sslmode=disable"),
+
+			ConnectTimeout:      getEnvDuration("DB_CONNECT_TIMEOUT", 30*time.Second, &parseErrs),
+			ConnectInBackground: getEnvBool("DB_CONNECT_BACKGROUND", false, &parseErrs),
+			ReportingMaxConns:   getEnvInt("DB_REPORTING_MAX_CONNS", 4, &parseErrs),
+
+			QueryExecMode:            getEnv("DB_QUERY_EXEC_MODE", "cache_statement"),
+			StatementCacheCapacity:   getEnvInt("DB_STATEMENT_CACHE_CAPACITY", 512, &parseErrs),
+			DescriptionCacheCapacity: getEnvInt("DB_DESCRIPTION_CACHE_CAPACITY", 512, &parseErrs),
+		},
+		SMTP: SMTPConfig{
+			Addr:     getEnv("SMTP_ADDR", ""),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: Secret(getEnv("SMTP_PASSWORD", "")),
+			From:     getEnv("SMTP_FROM", ""),
+		},
+		Telegram: TelegramConfig{
+			BotToken:    Secret(getEnv("TELEGRAM_BOT_TOKEN", "")),
+			APIURL:      getEnv("TELEGRAM_API_URL", "https://api.telegram.org"),
+			LinkCodeTTL: getEnvDuration("TELEGRAM_LINK_CODE_TTL", 15*time.Minute, &parseErrs),
+		},
+		Webhooks: WebhookConfig{
+			DispatchInterval:    getEnvDuration("WEBHOOK_DISPATCH_INTERVAL", 10*time.Second, &parseErrs),
+			Timeout:             getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second, &parseErrs),
+			MaxAttempts:         getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8, &parseErrs),
+			AllowPrivateTargets: getEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false, &parseErrs),
 		},
 	}
+	cfg.parseErrs = parseErrs
 	return cfg
 }
 
@@ -45,3 +292,55 @@ func getEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+// getEnvList splits a comma-separated value, dropping empty items; set but
+// empty yields an empty list.
+func getEnvList(key string, defaultVal []string) []string {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultVal
+	}
+	var list []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvDuration, getEnvInt and getEnvBool return defaultVal when key is
+// unset. A value that does not parse also yields defaultVal, and is recorded
+// in errs so the misconfiguration is not silently ignored.
+func getEnvDuration(key string, defaultVal time.Duration, errs *[]error) time.Duration {
+	if val, ok := os.LookupEnv(key); ok {
+		d, err := time.ParseDuration(val)
+		if err == nil {
+			return d
+		}
+		*errs = append(*errs, fmt.Errorf("%s: must be a duration such as 30s or 5m, got %q", key, val))
+	}
+	return defaultVal
+}
+
+func getEnvInt(key string, defaultVal int, errs *[]error) int {
+	if val, ok := os.LookupEnv(key); ok {
+		n, err := strconv.Atoi(val)
+		if err == nil {
+			return n
+		}
+		*errs = append(*errs, fmt.Errorf("%s: must be an integer, got %q", key, val))
+	}
+	return defaultVal
+}
+
+func getEnvBool(key string, defaultVal bool, errs *[]error) bool {
+	if val, ok := os.LookupEnv(key); ok {
+		b, err := strconv.ParseBool(val)
+		if err == nil {
+			return b
+		}
+		*errs = append(*errs, fmt.Errorf("%s: must be true or false, got %q", key, val))
+	}
+	return defaultVal
+}
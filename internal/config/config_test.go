@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretIsRedacted(t *testing.T) {
+	cfg := AppConfig{JWTSecret: "0123456789abcdef0123456789abcdef"}
+
+	out, err := json.Marshal(cfg)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(out), "0123456789abcdef")
+	assert.Contains(t, string(out), "[redacted]")
+}
+
+func TestGetEnvList(t *testing.T) {
+	assert.Equal(t, []string{"*"}, getEnvList("SUBTRACKER_TEST_LIST", []string{"*"}), "unset uses the default")
+
+	t.Setenv("SUBTRACKER_TEST_LIST", " https://a.example.com, ,https://b.example.com ")
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, getEnvList("SUBTRACKER_TEST_LIST", []string{"*"}))
+
+	t.Setenv("SUBTRACKER_TEST_LIST", "")
+	assert.Empty(t, getEnvList("SUBTRACKER_TEST_LIST", []string{"*"}), "set but empty disables the list")
+}
+
+func TestGetEnvDuration(t *testing.T) {
+	var errs []error
+	assert.Equal(t, time.Minute, getEnvDuration("SUBTRACKER_TEST_DURATION", time.Minute, &errs), "unset uses the default")
+
+	t.Setenv("SUBTRACKER_TEST_DURATION", "90s")
+	assert.Equal(t, 90*time.Second, getEnvDuration("SUBTRACKER_TEST_DURATION", time.Minute, &errs))
+	assert.Empty(t, errs)
+
+	t.Setenv("SUBTRACKER_TEST_DURATION", "90")
+	assert.Equal(t, time.Minute, getEnvDuration("SUBTRACKER_TEST_DURATION", time.Minute, &errs), "a bad value falls back to the default")
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], `SUBTRACKER_TEST_DURATION: must be a duration such as 30s or 5m, got "90"`)
+	}
+}
+
+func TestGetEnvInt(t *testing.T) {
+	var errs []error
+	assert.Equal(t, 25, getEnvInt("SUBTRACKER_TEST_INT", 25, &errs), "unset uses the default")
+
+	t.Setenv("SUBTRACKER_TEST_INT", "-3")
+	assert.Equal(t, -3, getEnvInt("SUBTRACKER_TEST_INT", 25, &errs))
+	assert.Empty(t, errs)
+
+	t.Setenv("SUBTRACKER_TEST_INT", "2.5")
+	assert.Equal(t, 25, getEnvInt("SUBTRACKER_TEST_INT", 25, &errs), "a bad value falls back to the default")
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], `SUBTRACKER_TEST_INT: must be an integer, got "2.5"`)
+	}
+}
+
+func TestGetEnvBool(t *testing.T) {
+	var errs []error
+	assert.True(t, getEnvBool("SUBTRACKER_TEST_BOOL", true, &errs), "unset uses the default")
+
+	t.Setenv("SUBTRACKER_TEST_BOOL", "false")
+	assert.False(t, getEnvBool("SUBTRACKER_TEST_BOOL", true, &errs))
+	assert.Empty(t, errs)
+
+	t.Setenv("SUBTRACKER_TEST_BOOL", "yes")
+	assert.True(t, getEnvBool("SUBTRACKER_TEST_BOOL", true, &errs), "a bad value falls back to the default")
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], `SUBTRACKER_TEST_BOOL: must be true or false, got "yes"`)
+	}
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var logLevels = map[string]struct{}{"DEBUG": {}, "INFO": {}, "WARN": {}, "ERROR": {}}
+
+var queryExecModes = map[string]struct{}{"cache_statement": {}, "cache_describe": {}, "describe_exec": {}, "exec": {}, "simple_protocol": {}}
+
+// minBenchmarkUsers keeps benchmark averages from exposing a single other
+// user's price: with two users, each could derive the other's.
+const minBenchmarkUsers = 3
+
+// MaxReminderDaysBefore matches the check on subscription_reminders.days_before.
+const MaxReminderDaysBefore = 60
+
+// minJWTSecretBytes matches the HS256 output size; shorter keys weaken the MAC.
+const minJWTSecretBytes = 32
+
+// Validate checks the whole configuration and reports every problem at once, so
+// a misconfigured deployment fails at boot instead of on first use.
+func (c *Config) Validate() error {
+	errs := append([]error(nil), c.parseErrs...)
+
+	if err := validatePort(c.App.AppPort); err != nil {
+		errs = append(errs, fmt.Errorf("APP_PORT: %w", err))
+	}
+	if c.App.AdminListenerEnabled() {
+		if err := validatePort(c.App.AdminPort); err != nil {
+			errs = append(errs, fmt.Errorf("ADMIN_PORT: %w", err))
+		} else if c.App.AdminPort == c.App.AppPort {
+			errs = append(errs, fmt.Errorf("ADMIN_PORT: must differ from APP_PORT, got %s", c.App.AdminPort))
+		}
+	} else if c.App.AdminToken != "" {
+		errs = append(errs, errors.New("ADMIN_TOKEN: requires ADMIN_PORT, the public listener never checks it"))
+	}
+	if _, ok := logLevels[strings.ToUpper(c.App.LogLevel)]; !ok {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL: must be one of DEBUG, INFO, WARN, ERROR, got %q", c.App.LogLevel))
+	}
+
+	if strings.TrimSpace(c.App.Environment) == "" {
+		errs = append(errs, errors.New("APP_ENV: must not be empty"))
+	}
+	if c.App.FeatureFlagRefresh <= 0 {
+		errs = append(errs, fmt.Errorf("FEATURE_FLAG_REFRESH_INTERVAL: must be positive, got %s", c.App.FeatureFlagRefresh))
+	}
+
+	if c.App.ListDefaultLimit < 1 {
+		errs = append(errs, fmt.Errorf("LIST_DEFAULT_LIMIT: must be at least 1, got %d", c.App.ListDefaultLimit))
+	}
+	if c.App.ListMaxLimit < c.App.ListDefaultLimit {
+		errs = append(errs, fmt.Errorf("LIST_MAX_LIMIT: must not be less than LIST_DEFAULT_LIMIT (%d), got %d", c.App.ListDefaultLimit, c.App.ListMaxLimit))
+	}
+	if c.App.DrainTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("SHUTDOWN_DRAIN_TIMEOUT: must be positive, got %s", c.App.DrainTimeout))
+	}
+	if c.App.ExportBatchRows < 1 {
+		errs = append(errs, fmt.Errorf("EXPORT_BATCH_ROWS: must be at least 1, got %d", c.App.ExportBatchRows))
+	}
+	if c.App.ExportFlushTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("EXPORT_FLUSH_TIMEOUT: must be positive, got %s", c.App.ExportFlushTimeout))
+	}
+	if c.App.StartDateMaxYearsPast < 0 {
+		errs = append(errs, fmt.Errorf("START_DATE_MAX_YEARS_PAST: must not be negative, got %d", c.App.StartDateMaxYearsPast))
+	}
+	if c.App.StartDateMaxYearsFuture < 0 {
+		errs = append(errs, fmt.Errorf("START_DATE_MAX_YEARS_FUTURE: must not be negative, got %d", c.App.StartDateMaxYearsFuture))
+	}
+	if c.App.IntegrityCheckInterval <= 0 {
+		errs = append(errs, fmt.Errorf("INTEGRITY_CHECK_INTERVAL: must be positive, got %s", c.App.IntegrityCheckInterval))
+	}
+	if c.App.PriceChangeInterval <= 0 {
+		errs = append(errs, fmt.Errorf("PRICE_CHANGE_INTERVAL: must be positive, got %s", c.App.PriceChangeInterval))
+	}
+	if c.App.TrialConversionInterval <= 0 {
+		errs = append(errs, fmt.Errorf("TRIAL_CONVERSION_INTERVAL: must be positive, got %s", c.App.TrialConversionInterval))
+	}
+	if c.App.SubscriptionQuota < 0 {
+		errs = append(errs, fmt.Errorf("SUBSCRIPTION_QUOTA: must not be negative, got %d", c.App.SubscriptionQuota))
+	}
+	if c.App.BenchmarkMinUsers < minBenchmarkUsers {
+		errs = append(errs, fmt.Errorf("BENCHMARK_MIN_USERS: must be at least %d, got %d", minBenchmarkUsers, c.App.BenchmarkMinUsers))
+	}
+	if c.App.UndoWindow <= 0 {
+		errs = append(errs, fmt.Errorf("UNDO_WINDOW: must be positive, got %s", c.App.UndoWindow))
+	}
+	if c.App.AuthEnabled() {
+		if len(c.App.JWTSecret) < minJWTSecretBytes {
+			errs = append(errs, fmt.Errorf("AUTH_JWT_SECRET: must be at least %d bytes, got %d", minJWTSecretBytes, len(c.App.JWTSecret)))
+		}
+		if c.App.TokenTTL <= 0 {
+			errs = append(errs, fmt.Errorf("AUTH_TOKEN_TTL: must be positive, got %s", c.App.TokenTTL))
+		}
+	}
+
+	policies := []struct {
+		name   string
+		policy CORSPolicy
+	}{
+		{"CORS_ALLOWED_ORIGINS", c.App.CORS.API},
+		{"CORS_PUBLIC_ALLOWED_ORIGINS", c.App.CORS.Public},
+		{"CORS_ADMIN_ALLOWED_ORIGINS", c.App.CORS.Admin},
+	}
+	for _, p := range policies {
+		if err := validateCORSPolicy(p.policy); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.name, err))
+		}
+	}
+
+	required := []struct{ name, value string }{
+		{"DB_HOST", c.Postgres.DBHost},
+		{"DB_NAME", c.Postgres.DBName},
+		{"DB_USER", c.Postgres.DBUser},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			errs = append(errs, fmt.Errorf("%s: must not be empty", r.name))
+		}
+	}
+	if err := validatePort(c.Postgres.DBPort); err != nil {
+		errs = append(errs, fmt.Errorf("DB_PORT: %w", err))
+	}
+	if c.Postgres.ConnectTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("DB_CONNECT_TIMEOUT: must be positive, got %s", c.Postgres.ConnectTimeout))
+	}
+	if c.Postgres.ReportingMaxConns < 1 {
+		errs = append(errs, fmt.Errorf("DB_REPORTING_MAX_CONNS: must be at least 1, got %d", c.Postgres.ReportingMaxConns))
+	}
+	if _, ok := queryExecModes[c.Postgres.QueryExecMode]; !ok {
+		errs = append(errs, fmt.Errorf("DB_QUERY_EXEC_MODE: must be one of cache_statement, cache_describe, describe_exec, exec, simple_protocol, got %q", c.Postgres.QueryExecMode))
+	}
+	// pgx refuses the caching modes outright when their cache is disabled.
+	if c.Postgres.StatementCacheCapacity < 0 || (c.Postgres.QueryExecMode == "cache_statement" && c.Postgres.StatementCacheCapacity == 0) {
+		errs = append(errs, fmt.Errorf("DB_STATEMENT_CACHE_CAPACITY: must be at least 1 with cache_statement and not negative otherwise, got %d", c.Postgres.StatementCacheCapacity))
+	}
+	if c.Postgres.DescriptionCacheCapacity < 0 || (c.Postgres.QueryExecMode == "cache_describe" && c.Postgres.DescriptionCacheCapacity == 0) {
+		errs = append(errs, fmt.Errorf("DB_DESCRIPTION_CACHE_CAPACITY: must be at least 1 with cache_describe and not negative otherwise, got %d", c.Postgres.DescriptionCacheCapacity))
+	}
+	if c.Postgres.PostgresDSN != "" {
+		if err := validateDSN(c.Postgres.PostgresDSN); err != nil {
+			errs = append(errs, fmt.Errorf("POSTGRES_DSN: %w", err))
+		}
+	}
+
+	if c.App.ReminderDaysBefore < 0 || c.App.ReminderDaysBefore > MaxReminderDaysBefore {
+		errs = append(errs, fmt.Errorf("REMINDER_DAYS_BEFORE: must be in range 0-%d, got %d", MaxReminderDaysBefore, c.App.ReminderDaysBefore))
+	}
+	if c.App.ReminderDigestDaysEmail < 0 || c.App.ReminderDigestDaysEmail > MaxReminderDaysBefore {
+		errs = append(errs, fmt.Errorf("REMINDER_DIGEST_DAYS_EMAIL: must be in range 0-%d, got %d", MaxReminderDaysBefore, c.App.ReminderDigestDaysEmail))
+	}
+	if c.App.ReminderDigestDaysTelegram < 0 || c.App.ReminderDigestDaysTelegram > MaxReminderDaysBefore {
+		errs = append(errs, fmt.Errorf("REMINDER_DIGEST_DAYS_TELEGRAM: must be in range 0-%d, got %d", MaxReminderDaysBefore, c.App.ReminderDigestDaysTelegram))
+	}
+	if c.SMTP.Enabled() {
+		if _, port, err := net.SplitHostPort(c.SMTP.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("SMTP_ADDR: must be host:port, got %q", c.SMTP.Addr))
+		} else if err := validatePort(port); err != nil {
+			errs = append(errs, fmt.Errorf("SMTP_ADDR: port %w", err))
+		}
+		if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+			errs = append(errs, fmt.Errorf("SMTP_FROM: must be an email address, got %q", c.SMTP.From))
+		}
+	}
+	if c.Telegram.Enabled() {
+		if u, err := url.Parse(c.Telegram.APIURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("TELEGRAM_API_URL: must be an http(s) URL, got %q", c.Telegram.APIURL))
+		}
+		if c.Telegram.LinkCodeTTL <= 0 {
+			errs = append(errs, fmt.Errorf("TELEGRAM_LINK_CODE_TTL: must be positive, got %s", c.Telegram.LinkCodeTTL))
+		}
+	}
+	if (c.SMTP.Enabled() || c.Telegram.Enabled()) && c.App.ReminderInterval <= 0 {
+		errs = append(errs, fmt.Errorf("REMINDER_INTERVAL: must be positive, got %s", c.App.ReminderInterval))
+	}
+	if c.Webhooks.DispatchInterval <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_DISPATCH_INTERVAL: must be positive, got %s", c.Webhooks.DispatchInterval))
+	}
+	if c.Webhooks.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_TIMEOUT: must be positive, got %s", c.Webhooks.Timeout))
+	}
+	if c.Webhooks.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_MAX_ATTEMPTS: must be at least 1, got %d", c.Webhooks.MaxAttempts))
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePort(value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("must be a number, got %q", value)
+	}
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("must be in range 1-65535, got %d", port)
+	}
+	return nil
+}
+
+func validateCORSPolicy(p CORSPolicy) error {
+	for _, origin := range p.AllowedOrigins {
+		if origin == "*" {
+			// Browsers refuse credentials with a wildcard, so the origin would
+			// have to be reflected, trusting every site with the user's session.
+			if p.AllowCredentials {
+				return errors.New(`"*" cannot be combined with CORS_ALLOW_CREDENTIALS, list the origins instead`)
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" {
+			return fmt.Errorf("origin must be \"*\" or scheme://host[:port], got %q", origin)
+		}
+	}
+	return nil
+}
+
+func validateDSN(dsn string) error {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return errors.New("is not a valid URL")
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return fmt.Errorf("scheme must be postgres or postgresql, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("must include a host")
+	}
+	return nil
+}
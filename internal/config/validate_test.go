@@ -0,0 +1,165 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validConfig() *Config {
+	return &Config{
+		App: AppConfig{AppPort: "8080", DrainTimeout: time.Minute, LogLevel: "DEBUG", Environment: "development", FeatureFlagRefresh: 30 * time.Second, ListDefaultLimit: 10, ListMaxLimit: 100, ExportBatchRows: 500, ExportFlushTimeout: 30 * time.Second, IntegrityCheckInterval: time.Hour, BenchmarkMinUsers: 10, UndoWindow: 5 * time.Minute, ReminderDaysBefore: 3, PriceChangeInterval: time.Hour, TrialConversionInterval: time.Hour},
+		Postgres: PostgresConfig{
+			DBHost:      "db",
+			DBPort:      "5432",
+			DBName:      "subtracker",
+			DBUser:      "postgres",
+			PostgresDSN: "postgres://postgres:secret@db:5432/subtracker?sslmode=disable",
+
+			ConnectTimeout:    30 * time.Second,
+			ReportingMaxConns: 4,
+
+			QueryExecMode:            "cache_statement",
+			StatementCacheCapacity:   512,
+			DescriptionCacheCapacity: 512,
+		},
+		Webhooks: WebhookConfig{DispatchInterval: 10 * time.Second, Timeout: 10 * time.Second, MaxAttempts: 8},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, validConfig().Validate())
+	})
+
+	t.Run("Unparsable Variables Reported", func(t *testing.T) {
+		t.Setenv("DB_CONNECT_TIMEOUT", "30")
+		t.Setenv("DB_REPORTING_MAX_CONNS", "many")
+		t.Setenv("READ_ONLY", "maybe")
+
+		err := LoadConfig().Validate()
+		assert.ErrorContains(t, err, "DB_CONNECT_TIMEOUT")
+		assert.ErrorContains(t, err, "DB_REPORTING_MAX_CONNS")
+		assert.ErrorContains(t, err, "READ_ONLY")
+	})
+
+	t.Run("Auth Settings Checked Once Enabled", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.App.JWTSecret = "too-short"
+
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "AUTH_JWT_SECRET")
+
+		cfg.App.JWTSecret = "0123456789abcdef0123456789abcdef"
+		cfg.App.TokenTTL = time.Hour
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("SMTP Settings Checked Once Enabled", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.SMTP.Addr = "smtp.example.com"
+
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "SMTP_ADDR")
+		assert.ErrorContains(t, err, "SMTP_FROM")
+		assert.ErrorContains(t, err, "REMINDER_INTERVAL")
+
+		cfg.SMTP.Addr = "smtp.example.com:587"
+		cfg.SMTP.From = "Subtracker <reminders@example.com>"
+		cfg.App.ReminderInterval = time.Hour
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("Telegram Settings Checked Once Enabled", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Telegram.BotToken = "123:abc"
+		cfg.Telegram.APIURL = "api.telegram.org"
+
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "TELEGRAM_API_URL")
+		assert.ErrorContains(t, err, "TELEGRAM_LINK_CODE_TTL")
+		assert.ErrorContains(t, err, "REMINDER_INTERVAL")
+
+		cfg.Telegram.APIURL = "https://api.telegram.org"
+		cfg.Telegram.LinkCodeTTL = 15 * time.Minute
+		cfg.App.ReminderInterval = time.Hour
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("CORS Policies", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.App.CORS.API = CORSPolicy{AllowedOrigins: []string{"*"}, AllowCredentials: true}
+		cfg.App.CORS.Admin = CORSPolicy{AllowedOrigins: []string{"admin.example.com"}}
+
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "CORS_ALLOWED_ORIGINS")
+		assert.ErrorContains(t, err, "CORS_ADMIN_ALLOWED_ORIGINS")
+
+		cfg.App.CORS.API.AllowedOrigins = []string{"https://app.example.com", "http://localhost:3000"}
+		cfg.App.CORS.Admin.AllowedOrigins = []string{"https://admin.example.com"}
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("Webhook Settings", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Webhooks = WebhookConfig{}
+
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "WEBHOOK_DISPATCH_INTERVAL")
+		assert.ErrorContains(t, err, "WEBHOOK_TIMEOUT")
+		assert.ErrorContains(t, err, "WEBHOOK_MAX_ATTEMPTS")
+	})
+
+	t.Run("Statement Cache", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Postgres.QueryExecMode = "prepare"
+		assert.ErrorContains(t, cfg.Validate(), "DB_QUERY_EXEC_MODE")
+
+		cfg.Postgres.QueryExecMode = "cache_statement"
+		cfg.Postgres.StatementCacheCapacity = 0
+		assert.ErrorContains(t, cfg.Validate(), "DB_STATEMENT_CACHE_CAPACITY")
+
+		// PgBouncer in transaction mode needs the cache off.
+		cfg.Postgres.QueryExecMode = "exec"
+		cfg.Postgres.DescriptionCacheCapacity = 0
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("Admin Listener", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.App.AdminToken = "admin-secret"
+		assert.ErrorContains(t, cfg.Validate(), "ADMIN_TOKEN")
+
+		cfg.App.AdminPort = cfg.App.AppPort
+		assert.ErrorContains(t, cfg.Validate(), "ADMIN_PORT")
+
+		cfg.App.AdminPort = "9090"
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("Reports Every Problem", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.App.AppPort = "70000"
+		cfg.App.LogLevel = "LOUD"
+		cfg.App.ListMaxLimit = 5
+		cfg.App.ReminderDigestDaysEmail = -1
+		cfg.App.DrainTimeout = 0
+		cfg.App.Environment = " "
+		cfg.App.FeatureFlagRefresh = 0
+		cfg.App.ExportFlushTimeout = 0
+		cfg.App.StartDateMaxYearsFuture = -1
+		cfg.App.BenchmarkMinUsers = 2
+		cfg.App.UndoWindow = 0
+		cfg.Postgres.DBHost = ""
+		cfg.Postgres.DBPort = "pg"
+		cfg.Postgres.PostgresDSN = "mysql://db/subtracker"
+		cfg.Postgres.ReportingMaxConns = 0
+
+		err := cfg.Validate()
+		assert.Error(t, err)
+		for _, want := range []string{"APP_PORT", "LOG_LEVEL", "LIST_MAX_LIMIT", "SHUTDOWN_DRAIN_TIMEOUT", "APP_ENV", "FEATURE_FLAG_REFRESH_INTERVAL", "EXPORT_FLUSH_TIMEOUT", "START_DATE_MAX_YEARS_FUTURE", "REMINDER_DIGEST_DAYS_EMAIL", "BENCHMARK_MIN_USERS", "UNDO_WINDOW", "DB_HOST", "DB_PORT", "DB_REPORTING_MAX_CONNS", "POSTGRES_DSN"} {
+			assert.ErrorContains(t, err, want)
+		}
+	})
+}
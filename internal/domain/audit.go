@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntitySubscription is the entity type of subscription audit entries.
+const AuditEntitySubscription = "subscription"
+
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	// AuditDelete moves the entity to the trash; AuditRestore brings it back.
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+)
+
+// FieldChange is a field's value before and after a change. A side is nil
+// when the entity did not exist then or the field was unset.
+type FieldChange struct {
+	Before any
+	After  any
+}
+
+// AuditEntry is one recorded change of an entity.
+type AuditEntry struct {
+	ID         int64
+	EntityType string
+	EntityID   uuid.UUID
+	// OwnerID is the user the entity belongs to.
+	OwnerID uuid.UUID
+	// ActorID is the user who made the change, nil when none was authenticated.
+	ActorID   *uuid.UUID
+	Action    AuditAction
+	Changes   map[string]FieldChange
+	ChangedAt time.Time
+}
+
+// DiffSubscriptions lists the fields that differ between before and after,
+// either of which may be nil for a subscription that does not exist on that
+// side. Values are in the API's representation, e.g. dates as MM-YYYY.
+func DiffSubscriptions(before, after *Subscription) map[string]FieldChange {
+	var from, to map[string]any
+	if before != nil {
+		from = before.auditFields()
+	}
+	if after != nil {
+		to = after.auditFields()
+	}
+	changes := make(map[string]FieldChange)
+	for _, field := range subscriptionAuditFields {
+		if from[field] != to[field] {
+			changes[field] = FieldChange{Before: from[field], After: to[field]}
+		}
+	}
+	return changes
+}
+
+var subscriptionAuditFields = []string{"user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}
+
+// auditFields holds only comparable values so DiffSubscriptions can use !=.
+// Unset optional fields are left out.
+func (s Subscription) auditFields() map[string]any {
+	fields := map[string]any{
+		"user_id":        s.UserID.String(),
+		"service_name":   s.ServiceName,
+		"price":          s.Price,
+		"start_date":     s.StartDate.Format("01-2006"),
+		"billing_period": string(s.BillingPeriod),
+		"expense_type":   string(s.ExpenseType),
+	}
+	if s.EndDate != nil {
+		fields["end_date"] = s.EndDate.Format("01-2006")
+	}
+	if s.CostCenter != "" {
+		fields["cost_center"] = s.CostCenter
+	}
+	if s.Category != "" {
+		fields["category"] = s.Category
+	}
+	return fields
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID uuid.UUID
+	Role   Role
+}
+
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// AccessToken is a signed bearer token issued on login.
+type AccessToken struct {
+	UserID    uuid.UUID
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// BillingPeriod is how often a subscription's price is charged.
+type BillingPeriod string
+
+const (
+	BillingMonthly BillingPeriod = "monthly"
+	BillingYearly  BillingPeriod = "yearly"
+	BillingWeekly  BillingPeriod = "weekly"
+)
+
+// monthlyRatio is the fraction of a period's price that falls in one month; a
+// year has 52 weeks.
+func (p BillingPeriod) monthlyRatio() (num, den int) {
+	switch p {
+	case BillingYearly:
+		return 1, 12
+	case BillingWeekly:
+		return 52, 12
+	default:
+		return 1, 1
+	}
+}
+
+// Cost is what price, charged every period, amounts to over the given number
+// of months, rounded to the nearest unit. Rounding happens once over the whole
+// span so short periods do not accumulate rounding errors month by month.
+func (p BillingPeriod) Cost(price, months int) int {
+	num, den := p.monthlyRatio()
+	return (price*months*num + den/2) / den
+}
+
+// Unit names one period, as in "per month".
+func (p BillingPeriod) Unit() string {
+	switch p {
+	case BillingYearly:
+		return "year"
+	case BillingWeekly:
+		return "week"
+	default:
+		return "month"
+	}
+}
+
+// MonthlyPrice is the price normalized to one month.
+func (p BillingPeriod) MonthlyPrice(price int) int {
+	return p.Cost(price, 1)
+}
+
+// NextRenewal is the first charge after the one on start that falls on or
+// after from. Charges repeat from start itself, so months never drift.
+func (p BillingPeriod) NextRenewal(start, from time.Time) time.Time {
+	charge := func(k int) time.Time {
+		switch p {
+		case BillingYearly:
+			return start.AddDate(k, 0, 0)
+		case BillingWeekly:
+			return start.AddDate(0, 0, 7*k)
+		default:
+			return start.AddDate(0, k, 0)
+		}
+	}
+	// Skip close to from first; the estimate never passes the answer.
+	k := 1
+	if from.After(start) {
+		switch p {
+		case BillingYearly:
+			k = max(k, from.Year()-start.Year()-1)
+		case BillingWeekly:
+			k = max(k, int(from.Sub(start).Hours()/24)/7-1)
+		default:
+			k = max(k, (from.Year()-start.Year())*12+int(from.Month())-int(start.Month())-1)
+		}
+	}
+	for charge(k).Before(from) {
+		k++
+	}
+	return charge(k)
+}
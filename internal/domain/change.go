@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionChange is the latest state of one subscription in a sync feed.
+// Version is the changelog sequence number of that state; deleted
+// subscriptions are tombstones with a nil Subscription.
+type SubscriptionChange struct {
+	Version        int64
+	SubscriptionID uuid.UUID
+	Deleted        bool
+	Subscription   *Subscription
+}
+
+// ChangeSet is one page of a sync feed. Cursor is passed back as `since` to
+// fetch the next page.
+type ChangeSet struct {
+	Changes []SubscriptionChange
+	Cursor  int64
+	HasMore bool
+}
+
+// Conflict strategies for client pushes.
+const (
+	// ConflictManual reports every conflict back to the client.
+	ConflictManual = "manual"
+	// ConflictLastWriterWins applies the client change when it is newer than
+	// the server's latest change to the same subscription.
+	ConflictLastWriterWins = "last_writer_wins"
+)
+
+// Outcomes of one pushed change.
+const (
+	PushApplied  = "applied"
+	PushConflict = "conflict"
+	PushRejected = "rejected"
+)
+
+// ClientChange is a write made offline. BaseVersion is the version the client
+// last saw for the subscription, zero for subscriptions it created itself.
+type ClientChange struct {
+	SubscriptionID  uuid.UUID
+	Deleted         bool
+	BaseVersion     int64
+	ClientUpdatedAt time.Time
+	Subscription    Subscription
+}
+
+// PushResult is the outcome of one ClientChange. Server holds the server's
+// current state when the change was not applied.
+type PushResult struct {
+	SubscriptionID uuid.UUID
+	Status         string
+	Err            error
+	Server         *SubscriptionChange
+}
@@ -0,0 +1,9 @@
+package domain
+
+// CostCenterCost is the spend of one cost center over a period. An empty
+// CostCenter groups the subscriptions that have none assigned.
+type CostCenterCost struct {
+	CostCenter    string
+	Subscriptions int
+	TotalCost     int
+}
@@ -0,0 +1,24 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditRow struct {
+	ID         int64      `db:"id"`
+	EntityType string     `db:"entity_type"`
+	EntityID   uuid.UUID  `db:"entity_id"`
+	OwnerID    uuid.UUID  `db:"owner_id"`
+	ActorID    *uuid.UUID `db:"actor_id"`
+	Action     string     `db:"action"`
+	// Changes is stored as JSONB keyed by field name.
+	Changes   map[string]AuditChange `db:"changes"`
+	ChangedAt time.Time              `db:"changed_at"`
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
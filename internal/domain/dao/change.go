@@ -0,0 +1,38 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Changelog operations, as stored in subscription_changes.op.
+const (
+	ChangeOpUpsert = "upsert"
+	ChangeOpDelete = "delete"
+)
+
+// SubscriptionChangeRow is a changelog entry joined with the subscription's
+// current row. The subscription columns are NULL for deletes.
+type SubscriptionChangeRow struct {
+	Seq            int64      `db:"seq"`
+	SubscriptionID uuid.UUID  `db:"subscription_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	Op             string     `db:"op"`
+	ServiceName    *string    `db:"service_name"`
+	Price          *int       `db:"price"`
+	StartDate      *time.Time `db:"start_date"`
+	EndDate        *time.Time `db:"end_date"`
+	CostCenter     *string    `db:"cost_center"`
+	Category       *string    `db:"category"`
+	BillingPeriod  *string    `db:"billing_period"`
+	ExpenseType    *string    `db:"expense_type"`
+}
+
+// ChangeVersion is the newest changelog entry of one subscription.
+type ChangeVersion struct {
+	Seq       int64     `db:"seq"`
+	UserID    uuid.UUID `db:"user_id"`
+	Op        string    `db:"op"`
+	ChangedAt time.Time `db:"changed_at"`
+}
@@ -0,0 +1,12 @@
+package dao
+
+import "time"
+
+type FeatureFlagRow struct {
+	Name           string    `db:"name"`
+	Description    string    `db:"description"`
+	Enabled        bool      `db:"enabled"`
+	Environments   []string  `db:"environments"`
+	RolloutPercent int       `db:"rollout_percent"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
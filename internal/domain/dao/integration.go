@@ -0,0 +1,14 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConsumerAckRow struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Consumer  string    `db:"consumer"`
+	MessageID string    `db:"message_id"`
+	AckedAt   time.Time `db:"acked_at"`
+}
@@ -0,0 +1,6 @@
+package dao
+
+type IntegrityCounts struct {
+	NegativePrice  int
+	EndBeforeStart int
+}
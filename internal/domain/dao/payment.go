@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentFailureRow struct {
+	SubscriptionID uuid.UUID  `db:"subscription_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	Period         time.Time  `db:"period"`
+	FailedAt       time.Time  `db:"failed_at"`
+	Retries        int        `db:"retries"`
+	LastRetryAt    *time.Time `db:"last_retry_at"`
+	PaidAt         *time.Time `db:"paid_at"`
+}
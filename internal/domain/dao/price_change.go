@@ -0,0 +1,24 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PriceChangeRow struct {
+	ID             uuid.UUID  `db:"id"`
+	SubscriptionID uuid.UUID  `db:"subscription_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	Price          int        `db:"price"`
+	EffectiveDate  time.Time  `db:"effective_date"`
+	CreatedAt      time.Time  `db:"created_at"`
+	AppliedAt      *time.Time `db:"applied_at"`
+}
+
+// AppliedPriceChangeRow is a subscription as it is after a scheduled price
+// change was written to it.
+type AppliedPriceChangeRow struct {
+	SubscriptionRow
+	PreviousPrice int `db:"previous_price"`
+}
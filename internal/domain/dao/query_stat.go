@@ -0,0 +1,11 @@
+package dao
+
+// QueryStatRow is a pg_stat_statements entry; times are in milliseconds.
+type QueryStatRow struct {
+	QueryID       int64   `db:"queryid"`
+	Query         string  `db:"query"`
+	Calls         int64   `db:"calls"`
+	Rows          int64   `db:"rows"`
+	TotalExecTime float64 `db:"total_exec_time"`
+	MeanExecTime  float64 `db:"mean_exec_time"`
+}
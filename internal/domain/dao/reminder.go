@@ -0,0 +1,18 @@
+package dao
+
+import "github.com/google/uuid"
+
+type ReminderSettingRow struct {
+	SubscriptionID uuid.UUID `db:"subscription_id"`
+	Enabled        bool      `db:"enabled"`
+	DaysBefore     *int      `db:"days_before"`
+}
+
+// ReminderCandidateRow is an active subscription joined with its owner's
+// contacts and effective reminder offset.
+type ReminderCandidateRow struct {
+	SubscriptionRow
+	Email          string `db:"email"`
+	TelegramChatID *int64 `db:"chat_id"`
+	DaysBefore     int    `db:"days_before"`
+}
@@ -0,0 +1,7 @@
+package dao
+
+// ServiceBenchmarkRow aggregates the active subscriptions to one service.
+type ServiceBenchmarkRow struct {
+	Users        int `db:"users"`
+	AveragePrice int `db:"average_price"`
+}
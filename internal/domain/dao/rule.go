@@ -0,0 +1,16 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CategoryRuleRow struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    *uuid.UUID `db:"user_id"`
+	Pattern   string     `db:"pattern"`
+	Category  string     `db:"category"`
+	Priority  int        `db:"priority"`
+	CreatedAt time.Time  `db:"created_at"`
+}
@@ -0,0 +1,10 @@
+package dao
+
+import "github.com/google/uuid"
+
+type SavedFilterRow struct {
+	ID     uuid.UUID `db:"id"`
+	UserID uuid.UUID `db:"user_id"`
+	Name   string    `db:"name"`
+	Params []byte    `db:"params"`
+}
@@ -13,4 +13,18 @@ type SubscriptionRow struct {
 	Price       int        `db:"price"`
 	StartDate   time.Time  `db:"start_date"`
 	EndDate     *time.Time `db:"end_date"`
+	CostCenter  string     `db:"cost_center"`
+	Category    string     `db:"category"`
+	// BillingPeriod is one of monthly, yearly or weekly.
+	BillingPeriod string `db:"billing_period"`
+	// ExpenseType is one of subscription, rent, utilities, insurance or other.
+	ExpenseType string `db:"expense_type"`
+	// Version changes on every write; zero when it was not read.
+	Version int64 `db:"version"`
+}
+
+// TrashedSubscriptionRow is a row of deleted_subscriptions.
+type TrashedSubscriptionRow struct {
+	SubscriptionRow
+	DeletedAt time.Time `db:"deleted_at"`
 }
@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuggestionRow struct {
+	ID             uuid.UUID  `db:"id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	Source         string     `db:"source"`
+	ServiceName    string     `db:"service_name"`
+	Price          int        `db:"price"`
+	StartDate      time.Time  `db:"start_date"`
+	EndDate        *time.Time `db:"end_date"`
+	Status         string     `db:"status"`
+	SubscriptionID *uuid.UUID `db:"subscription_id"`
+	CreatedAt      time.Time  `db:"created_at"`
+	ResolvedAt     *time.Time `db:"resolved_at"`
+}
@@ -0,0 +1,13 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TelegramLinkRow struct {
+	UserID   uuid.UUID `db:"user_id"`
+	ChatID   int64     `db:"chat_id"`
+	LinkedAt time.Time `db:"linked_at"`
+}
@@ -0,0 +1,27 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TrialRow struct {
+	SubscriptionID       uuid.UUID  `db:"subscription_id"`
+	UserID               uuid.UUID  `db:"user_id"`
+	EndsOn               time.Time  `db:"ends_on"`
+	RegularPrice         int        `db:"regular_price"`
+	RegularBillingPeriod string     `db:"regular_billing_period"`
+	EndedAt              *time.Time `db:"ended_at"`
+	Converted            bool       `db:"converted"`
+}
+
+// ConvertedTrialRow is a subscription as it is after its trial converted,
+// with what it cost during the trial and where to reach its owner.
+type ConvertedTrialRow struct {
+	SubscriptionRow
+	TrialPrice         int    `db:"trial_price"`
+	TrialBillingPeriod string `db:"trial_billing_period"`
+	Email              string `db:"email"`
+	TelegramChatID     *int64 `db:"chat_id"`
+}
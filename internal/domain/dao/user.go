@@ -0,0 +1,15 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserRow struct {
+	ID           uuid.UUID `db:"id"`
+	Email        string    `db:"email"`
+	PasswordHash string    `db:"password_hash"`
+	Role         string    `db:"role"`
+	CreatedAt    time.Time `db:"created_at"`
+}
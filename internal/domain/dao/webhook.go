@@ -0,0 +1,59 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookRow struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	Events    []string  `db:"events"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type WebhookDeliveryRow struct {
+	ID             uuid.UUID  `db:"id"`
+	WebhookID      uuid.UUID  `db:"webhook_id"`
+	Event          string     `db:"event"`
+	Sequence       int64      `db:"sequence"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	ResponseStatus *int       `db:"response_status"`
+	LastError      string     `db:"last_error"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+}
+
+// DueDeliveryRow is a claimed delivery with what is needed to send it.
+type DueDeliveryRow struct {
+	ID       uuid.UUID `db:"id"`
+	Event    string    `db:"event"`
+	Sequence int64     `db:"sequence"`
+	Payload  []byte    `db:"payload"`
+	Attempts int       `db:"attempts"`
+	URL      string    `db:"url"`
+	Secret   string    `db:"secret"`
+}
+
+// WebhookAttemptRow is the outcome of one delivery attempt.
+type WebhookAttemptRow struct {
+	ID             uuid.UUID `db:"id"`
+	Status         string    `db:"status"`
+	ResponseStatus *int      `db:"response_status"`
+	LastError      string    `db:"last_error"`
+	NextAttemptAt  time.Time `db:"next_attempt_at"`
+}
+
+// WebhookDeliveryCountRow counts a webhook's deliveries of one event and
+// status.
+type WebhookDeliveryCountRow struct {
+	Event           string     `db:"event"`
+	Status          string     `db:"status"`
+	Count           int        `db:"count"`
+	LastDeliveredAt *time.Time `db:"last_delivered_at"`
+}
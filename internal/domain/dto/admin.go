@@ -0,0 +1,7 @@
+package dto
+
+type RouteResponse struct {
+	Method      string   `json:"method" example:"GET"`
+	Pattern     string   `json:"pattern" example:"/subscriptions/{id}"`
+	Middlewares []string `json:"middlewares" example:"subtracker/internal/handler.observeLatency"`
+}
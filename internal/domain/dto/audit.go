@@ -0,0 +1,20 @@
+package dto
+
+type SubscriptionHistoryRequest struct {
+	Limit int `form:"limit" validate:"gte=0"`
+}
+
+type AuditEntryResponse struct {
+	ID     int64  `json:"id" example:"42"`
+	Action string `json:"action" example:"update" enums:"create,update,delete,restore"`
+	// ActorID is the user who made the change; omitted when none was authenticated.
+	ActorID   string `json:"actor_id,omitempty" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	ChangedAt string `json:"changed_at" example:"2025-07-01T10:00:00Z"`
+	// Changes maps each changed field to its old and new value.
+	Changes map[string]FieldChangeResponse `json:"changes"`
+}
+
+type FieldChangeResponse struct {
+	Before any `json:"before" swaggertype:"string" example:"Netflix"`
+	After  any `json:"after" swaggertype:"string" example:"Netflix Premium"`
+}
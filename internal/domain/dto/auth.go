@@ -0,0 +1,12 @@
+package dto
+
+type LoginRequest struct {
+	Email    string `json:"email"    validate:"required,email" example:"alice@example.com"`
+	Password string `json:"password" validate:"required"       example:"correct horse battery"`
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType   string `json:"token_type" example:"Bearer"`
+	ExpiresAt   string `json:"expires_at" example:"2025-07-02T12:00:00Z"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+type SetFeatureFlagRequest struct {
+	Description string `json:"description" validate:"max=500" example:"Aggregate costs in SQL instead of in the service"`
+	Enabled     *bool  `json:"enabled" validate:"required" example:"true"`
+	// Environments limits the flag to these APP_ENV values; empty means all.
+	Environments []string `json:"environments,omitempty" validate:"omitempty,max=10,unique,dive,min=1,max=64,excludesall=0x2C" example:"staging"`
+	// RolloutPercent defaults to 100 when omitted.
+	RolloutPercent *int `json:"rollout_percent,omitempty" validate:"omitempty,gte=0,lte=100" example:"25"`
+}
+
+type FeatureFlagResponse struct {
+	Name           string   `json:"name" example:"sql_cost_aggregation"`
+	Description    string   `json:"description" example:"Aggregate costs in SQL instead of in the service"`
+	Enabled        bool     `json:"enabled" example:"true"`
+	Environments   []string `json:"environments" example:"staging"`
+	RolloutPercent int      `json:"rollout_percent" example:"25"`
+	UpdatedAt      string   `json:"updated_at" example:"2025-07-01T10:00:00Z"`
+}
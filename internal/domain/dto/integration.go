@@ -0,0 +1,21 @@
+package dto
+
+type AckMessageRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	// Consumer names the service handling the message; each consumer keeps
+	// its own acks.
+	Consumer string `json:"consumer" validate:"required,max=100" example:"billing-sync"`
+	// MessageID identifies the message, e.g. the X-Subtracker-Delivery header
+	// of a webhook delivery.
+	MessageID string `json:"message_id" validate:"required,max=255" example:"0b8a3c5e-2f0d-4c1e-9b7a-8d6e5f4c3b2a"`
+}
+
+type AckMessageResponse struct {
+	UserID    string `json:"user_id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Consumer  string `json:"consumer" example:"billing-sync"`
+	MessageID string `json:"message_id" example:"0b8a3c5e-2f0d-4c1e-9b7a-8d6e5f4c3b2a"`
+	AckedAt   string `json:"acked_at" example:"2025-07-01T10:02:31Z"`
+	// Duplicate means the message was acked before and must not be handled
+	// again.
+	Duplicate bool `json:"duplicate" example:"false"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type IntegrityReportResponse struct {
+	CheckedAt  string         `json:"checked_at" example:"2025-07-01T12:00:00Z"`
+	Healthy    bool           `json:"healthy" example:"false"`
+	Violations map[string]int `json:"violations"`
+}
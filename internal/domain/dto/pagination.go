@@ -0,0 +1,15 @@
+package dto
+
+// Pagination echoes the window that was actually applied to a list request.
+type Pagination struct {
+	Limit  int `json:"limit" example:"10"`
+	Offset int `json:"offset" example:"0"`
+}
+
+// CountedPagination is a Pagination that also says how many items match the
+// request in total, so clients can render page controls.
+type CountedPagination struct {
+	Pagination
+	Total   int  `json:"total" example:"27"`
+	HasMore bool `json:"has_more" example:"true"`
+}
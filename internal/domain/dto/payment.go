@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type PaymentFailedRequest struct {
+	// Period is the month whose charge failed; it may not be a future month.
+	Period string `json:"period" validate:"required,datetime=01-2006" example:"01-2026"`
+}
+
+type PaymentRetriedRequest struct {
+	Period    string `json:"period" validate:"required,datetime=01-2006" example:"01-2026"`
+	Succeeded *bool  `json:"succeeded" validate:"required" example:"true"`
+}
+
+type PaymentFailureResponse struct {
+	SubscriptionID string     `json:"subscription_id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Period         string     `json:"period" example:"01-2026"`
+	Status         string     `json:"status" example:"unpaid" enums:"unpaid,paid"`
+	FailedAt       time.Time  `json:"failed_at" example:"2026-01-03T08:00:00Z"`
+	Retries        int        `json:"retries" example:"1"`
+	LastRetryAt    *time.Time `json:"last_retry_at,omitempty" example:"2026-01-06T08:00:00Z"`
+	PaidAt         *time.Time `json:"paid_at,omitempty" example:"2026-01-06T08:00:00Z"`
+}
+
+type PaymentHistoryResponse struct {
+	SubscriptionID string                   `json:"subscription_id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Status         string                   `json:"status" example:"past_due" enums:"current,past_due"`
+	Failures       []PaymentFailureResponse `json:"failures"`
+}
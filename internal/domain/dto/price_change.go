@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type SchedulePriceChangeRequest struct {
+	Price *int `json:"price" validate:"required,gte=0" example:"399"`
+	// EffectiveDate is the first month billed at the new price; it must be
+	// a future month.
+	EffectiveDate string `json:"effective_date" validate:"required,datetime=01-2006" example:"01-2026"`
+}
+
+type PriceChangeResponse struct {
+	ID             string     `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	SubscriptionID string     `json:"subscription_id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Price          int        `json:"price" example:"399"`
+	EffectiveDate  string     `json:"effective_date" example:"01-2026"`
+	Status         string     `json:"status" example:"pending" enums:"pending,applied"`
+	CreatedAt      time.Time  `json:"created_at" example:"2025-11-20T10:00:00Z"`
+	AppliedAt      *time.Time `json:"applied_at,omitempty" example:"2026-01-01T00:05:00Z"`
+}
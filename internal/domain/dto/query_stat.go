@@ -0,0 +1,10 @@
+package dto
+
+type QueryStatResponse struct {
+	QueryID     int64   `json:"query_id" example:"-3713942186431201231"`
+	Query       string  `json:"query" example:"SELECT id, user_id, service_name FROM subscriptions WHERE user_id = $1"`
+	Calls       int64   `json:"calls" example:"1520"`
+	Rows        int64   `json:"rows" example:"30400"`
+	TotalTimeMs float64 `json:"total_time_ms" example:"5312.4"`
+	MeanTimeMs  float64 `json:"mean_time_ms" example:"3.49"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+type SetReminderRequest struct {
+	Enabled *bool `json:"enabled" validate:"required" example:"true"`
+	// DaysBefore follows the server default when omitted or null.
+	DaysBefore *int `json:"days_before,omitempty" validate:"omitempty,gte=0,lte=60" example:"7"`
+}
+
+type ReminderSettingResponse struct {
+	SubscriptionID string `json:"subscription_id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Enabled        bool   `json:"enabled" example:"true"`
+	// DaysBefore is null while the server default applies.
+	DaysBefore          *int `json:"days_before" example:"7"`
+	EffectiveDaysBefore int  `json:"effective_days_before" example:"7"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+type SavingsRequest struct {
+	UserID string `form:"user_id" validate:"required,uuid4"`
+	Period string `form:"period"  validate:"required"`
+}
+
+type SavingsReportResponse struct {
+	PeriodStart            string                 `json:"period_start" example:"01-2025"`
+	PeriodEnd              string                 `json:"period_end" example:"12-2025"`
+	CancelledCount         int                    `json:"cancelled_count" example:"2"`
+	MonthlySavings         int                    `json:"monthly_savings" example:"798"`
+	ProjectedAnnualSavings int                    `json:"projected_annual_savings" example:"9576"`
+	Cancelled              []SubscriptionResponse `json:"cancelled"`
+}
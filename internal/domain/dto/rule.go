@@ -0,0 +1,32 @@
+package dto
+
+type CreateRuleRequest struct {
+	// UserID scopes the rule to one user; omit it for a global rule.
+	UserID   string `json:"user_id,omitempty" validate:"omitempty,uuid4"   example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Pattern  string `json:"pattern"           validate:"required,max=100" example:"netflix"`
+	Category string `json:"category"          validate:"required,max=100" example:"Entertainment"`
+	Priority int    `json:"priority"          example:"10"`
+}
+
+type RuleResponse struct {
+	ID       string `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	UserID   string `json:"user_id,omitempty" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Pattern  string `json:"pattern" example:"netflix"`
+	Category string `json:"category" example:"Entertainment"`
+	Priority int    `json:"priority" example:"10"`
+}
+
+type ListRulesRequest struct {
+	UserID string `form:"user_id" validate:"omitempty,uuid4"`
+}
+
+type ApplyRulesRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid4" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	// Overwrite recategorizes subscriptions that already have a category.
+	Overwrite bool `json:"overwrite" example:"false"`
+}
+
+type ApplyRulesResponse struct {
+	Checked int `json:"checked" example:"12"`
+	Updated int `json:"updated" example:"5"`
+}
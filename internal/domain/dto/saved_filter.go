@@ -0,0 +1,19 @@
+package dto
+
+type CreateSavedFilterRequest struct {
+	UserID string            `json:"user_id" validate:"required,uuid4"   example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name   string            `json:"name"    validate:"required,max=100" example:"Work tools over 1000"`
+	Params map[string]string `json:"params"  validate:"required"`
+}
+
+type UpdateSavedFilterRequest struct {
+	Name   string            `json:"name"   validate:"required,max=100" example:"Work tools over 1500"`
+	Params map[string]string `json:"params" validate:"required"`
+}
+
+type SavedFilterResponse struct {
+	ID     string            `json:"id" example:"5b0c7a2e-3f51-4c55-9d1e-0b7a2c3e4f51"`
+	UserID string            `json:"user_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Name   string            `json:"name" example:"Work tools over 1000"`
+	Params map[string]string `json:"params"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type DependencyStatusResponse struct {
+	Name    string `json:"name" example:"database"`
+	Status  string `json:"status" example:"ok" enums:"ok,failing"`
+	Problem string `json:"problem,omitempty" example:"unreachable"`
+}
+
+type StatusResponse struct {
+	Status        string                     `json:"status" example:"ok" enums:"ok,degraded"`
+	Version       string                     `json:"version" example:"v1.4.0"`
+	Commit        string                     `json:"commit,omitempty" example:"9bcb46f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6"`
+	StartedAt     string                     `json:"started_at" example:"2026-10-15T08:00:00Z"`
+	UptimeSeconds int64                      `json:"uptime_seconds" example:"86400"`
+	Dependencies  []DependencyStatusResponse `json:"dependencies"`
+}
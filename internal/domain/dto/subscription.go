@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"subtracker/pkg/response"
+)
 
 type CreateSubscriptionRequest struct {
 	ServiceName string `json:"service_name" validate:"required,max=100" example:"Yandex Plus"`
@@ -8,6 +12,12 @@ type CreateSubscriptionRequest struct {
 	UserID      string `json:"user_id"      validate:"required,uuid4"   example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
 	StartDate   string `json:"start_date"   validate:"required,datetime=01-2006" example:"07-2025"`
 	EndDate     string `json:"end_date,omitempty" validate:"omitempty,datetime=01-2006" example:"08-2026"`
+	CostCenter  string `json:"cost_center,omitempty" validate:"omitempty,max=100" example:"Marketing"`
+	Category    string `json:"category,omitempty"    validate:"omitempty,max=100" example:"Entertainment"`
+	// BillingPeriod defaults to monthly.
+	BillingPeriod string `json:"billing_period,omitempty" validate:"omitempty,oneof=monthly yearly weekly" example:"monthly" enums:"monthly,yearly,weekly"`
+	// ExpenseType defaults to subscription.
+	ExpenseType string `json:"expense_type,omitempty" validate:"omitempty,oneof=subscription rent utilities insurance other" example:"subscription" enums:"subscription,rent,utilities,insurance,other"`
 }
 
 type UpdateSubscriptionRequest struct {
@@ -15,15 +25,54 @@ type UpdateSubscriptionRequest struct {
 	Price       int    `json:"price"        validate:"required,gte=0"   example:"499"`
 	StartDate   string `json:"start_date"   validate:"required,datetime=01-2006" example:"07-2025"`
 	EndDate     string `json:"end_date,omitempty" validate:"omitempty,datetime=01-2006" example:"08-2027"`
+	CostCenter  string `json:"cost_center,omitempty" validate:"omitempty,max=100" example:"Marketing"`
+	Category    string `json:"category,omitempty"    validate:"omitempty,max=100" example:"Entertainment"`
+	// BillingPeriod keeps the current period when omitted.
+	BillingPeriod string `json:"billing_period,omitempty" validate:"omitempty,oneof=monthly yearly weekly" example:"yearly" enums:"monthly,yearly,weekly"`
+	// ExpenseType keeps the current type when omitted.
+	ExpenseType string `json:"expense_type,omitempty" validate:"omitempty,oneof=subscription rent utilities insurance other" example:"subscription" enums:"subscription,rent,utilities,insurance,other"`
+}
+
+// PatchSubscriptionRequest changes only the fields present. An empty
+// end_date, cost_center or category clears it.
+type PatchSubscriptionRequest struct {
+	ServiceName   *string `json:"service_name,omitempty"   validate:"omitempty,min=1,max=100" example:"Yandex Plus Family"`
+	Price         *int    `json:"price,omitempty"          validate:"omitempty,gte=0" example:"499"`
+	StartDate     *string `json:"start_date,omitempty"     validate:"omitempty,datetime=01-2006" example:"07-2025"`
+	EndDate       *string `json:"end_date,omitempty"       validate:"omitempty,len=0|datetime=01-2006" example:"08-2027"`
+	CostCenter    *string `json:"cost_center,omitempty"    validate:"omitempty,max=100" example:"Marketing"`
+	Category      *string `json:"category,omitempty"       validate:"omitempty,max=100" example:"Entertainment"`
+	BillingPeriod *string `json:"billing_period,omitempty" validate:"omitempty,oneof=monthly yearly weekly" example:"yearly" enums:"monthly,yearly,weekly"`
+	ExpenseType   *string `json:"expense_type,omitempty"   validate:"omitempty,oneof=subscription rent utilities insurance other" example:"insurance" enums:"subscription,rent,utilities,insurance,other"`
 }
 
 type SubscriptionResponse struct {
-	ID          string `json:"id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
-	ServiceName string `json:"service_name" example:"Yandex Plus"`
-	Price       int    `json:"price" example:"299"`
-	UserID      string `json:"user_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
-	StartDate   string `json:"start_date" example:"07-2025"`
-	EndDate     string `json:"end_date,omitempty" example:"08-2026"`
+	ID            string `json:"id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	ServiceName   string `json:"service_name" example:"Yandex Plus"`
+	Price         int    `json:"price" example:"299"`
+	UserID        string `json:"user_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	StartDate     string `json:"start_date" example:"07-2025"`
+	EndDate       string `json:"end_date,omitempty" example:"08-2026"`
+	CostCenter    string `json:"cost_center,omitempty" example:"Marketing"`
+	Category      string `json:"category,omitempty" example:"Entertainment"`
+	BillingPeriod string `json:"billing_period" example:"monthly" enums:"monthly,yearly,weekly"`
+	ExpenseType   string `json:"expense_type" example:"subscription" enums:"subscription,rent,utilities,insurance,other"`
+}
+
+type ServiceBenchmarkResponse struct {
+	AveragePrice int `json:"average_price" example:"520"`
+	// Difference is the subscription's price minus the average.
+	Difference int `json:"difference" example:"-21"`
+}
+
+type SubscriptionDetailResponse struct {
+	SubscriptionResponse
+	Benchmark *ServiceBenchmarkResponse `json:"benchmark,omitempty"`
+}
+
+type SubscriptionListResponse struct {
+	Items      []SubscriptionResponse `json:"items"`
+	Pagination CountedPagination      `json:"pagination"`
 }
 
 type SubscriptionFilter struct {
@@ -34,24 +83,126 @@ type SubscriptionFilter struct {
 	StartDate   string `form:"start_date"   validate:"omitempty,datetime=01-2006"`
 	EndDate     string `form:"end_date"     validate:"omitempty,datetime=01-2006"`
 	HasEndDate  *bool  `form:"has_end_date" validate:"omitempty"`
-	Limit       int    `form:"limit"        validate:"gte=0,lte=100"`
+	CostCenter  string `form:"cost_center"  validate:"omitempty,max=100"`
+	Category    string `form:"category"     validate:"omitempty,max=100"`
+	PastDue     *bool  `form:"past_due"     validate:"omitempty"`
+	ExpenseType string `form:"expense_type" validate:"omitempty,oneof=subscription rent utilities insurance other"`
+	Limit       int    `form:"limit"        validate:"gte=0"`
 	Offset      int    `form:"offset"       validate:"gte=0"`
+	Sort        string `form:"sort"         validate:"omitempty,max=100"`
+}
+
+type TrashFilter struct {
+	UserID string `form:"user_id" validate:"omitempty,uuid4"`
+	Limit  int    `form:"limit"   validate:"gte=0"`
+	Offset int    `form:"offset"  validate:"gte=0"`
+}
+
+type TrashedSubscriptionResponse struct {
+	SubscriptionResponse
+	DeletedAt string `json:"deleted_at" example:"2025-07-01T10:00:00Z"`
+}
+
+type TrashListResponse struct {
+	Items      []TrashedSubscriptionResponse `json:"items"`
+	Pagination Pagination                    `json:"pagination"`
+}
+
+type RestoreSubscriptionsRequest struct {
+	UserID string   `json:"user_id,omitempty" validate:"omitempty,uuid4" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	IDs    []string `json:"ids" validate:"required,min=1,max=100,dive,uuid" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+}
+
+type RestoreSubscriptionsResponse struct {
+	Restored []string `json:"restored" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	// NotFound lists the IDs that were not in the trash, or not in the
+	// caller's trash.
+	NotFound []string `json:"not_found" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+}
+
+type LookupSubscriptionsRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100,dive,uuid" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+}
+
+type LookupSubscriptionsResponse struct {
+	Items []SubscriptionResponse `json:"items"`
+	// NotFound lists the IDs that do not exist or belong to another user.
+	NotFound []string `json:"not_found" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
 }
 
 type CostRequest struct {
-	UserID      string `form:"user_id"      validate:"required,uuid4"`
-	ServiceName string `form:"service_name" validate:"omitempty,max=100"`
-	PeriodStart string `form:"period_start" validate:"required,datetime=01-2006"`
-	PeriodEnd   string `form:"period_end"   validate:"required,datetime=01-2006"`
+	UserID      string `form:"user_id"      json:"user_id"      validate:"required,uuid4"            example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	ServiceName string `form:"service_name" json:"service_name" validate:"omitempty,max=100"         example:"Yandex Plus"`
+	PeriodStart string `form:"period_start" json:"period_start" validate:"required,datetime=01-2006" example:"01-2025"`
+	PeriodEnd   string `form:"period_end"   json:"period_end"   validate:"required,datetime=01-2006" example:"12-2025"`
+	// ExcludeUnpaid leaves months whose charge failed and is still unpaid out
+	// of the cost.
+	ExcludeUnpaid bool `form:"exclude_unpaid" json:"exclude_unpaid,omitempty" example:"false"`
+}
+
+type CostCenterReportRequest struct {
+	UserID        string `form:"user_id"        validate:"required,uuid4"`
+	ServiceName   string `form:"service_name"   validate:"omitempty,max=100"`
+	PeriodStart   string `form:"period_start"   validate:"required,datetime=01-2006"`
+	PeriodEnd     string `form:"period_end"     validate:"required,datetime=01-2006"`
+	ExcludeUnpaid bool   `form:"exclude_unpaid"`
+	Format        string `form:"format"         validate:"oneof=json csv" default:"json"`
+}
+
+type CostCenterCostResponse struct {
+	CostCenter    string `json:"cost_center" example:"Marketing"`
+	Subscriptions int    `json:"subscriptions" example:"4"`
+	TotalCost     int    `json:"total_cost" example:"11976"`
+}
+
+type CostCenterReportResponse struct {
+	PeriodStart string                   `json:"period_start" example:"01-2025"`
+	PeriodEnd   string                   `json:"period_end" example:"12-2025"`
+	CostCenters []CostCenterCostResponse `json:"cost_centers"`
+	TotalCost   int                      `json:"total_cost" example:"24352"`
 }
 
 type CostFilter struct {
-	UserID      string
-	ServiceName string
-	PeriodStart time.Time
-	PeriodEnd   time.Time
+	UserID        string
+	ServiceName   string
+	PeriodStart   time.Time
+	PeriodEnd     time.Time
+	ExcludeUnpaid bool
 }
 
 type CostResponse struct {
 	TotalCost int `json:"total_cost" example:"2434"`
 }
+
+type CostBatchRequest struct {
+	Items []CostRequest `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type CostBatchItemResponse struct {
+	UserID      string `json:"user_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	ServiceName string `json:"service_name,omitempty" example:"Yandex Plus"`
+	PeriodStart string `json:"period_start" example:"01-2025"`
+	PeriodEnd   string `json:"period_end" example:"12-2025"`
+	TotalCost   int    `json:"total_cost" example:"2434"`
+	Error       string `json:"error,omitempty" example:"database error on cost calculation"`
+}
+
+type CostBatchResponse struct {
+	Results []CostBatchItemResponse `json:"results"`
+}
+
+type CreateBatchItemResponse struct {
+	Status string `json:"status" example:"created" enums:"created,rejected"`
+	// ID is set for created subscriptions, Error for rejected ones.
+	ID        string             `json:"id,omitempty" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Error     string             `json:"error,omitempty" example:"subscription quota of 10 reached"`
+	ErrorCode string             `json:"error_code,omitempty" example:"quota_exceeded"`
+	Warnings  []response.Warning `json:"warnings,omitempty"`
+}
+
+type CreateBatchResponse struct {
+	Created  int `json:"created" example:"2"`
+	Rejected int `json:"rejected" example:"1"`
+	// Results are in the order of the request.
+	Results []CreateBatchItemResponse `json:"results"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "subtracker/pkg/response"
+
+type CreateSuggestionRequest struct {
+	UserID      string `json:"user_id"      validate:"required,uuid4"   example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Source      string `json:"source"       validate:"required,max=50"  example:"bank"`
+	ServiceName string `json:"service_name" validate:"required,max=100" example:"Netflix"`
+	Price       int    `json:"price"        validate:"gte=0"            example:"999"`
+	StartDate   string `json:"start_date"   validate:"required,datetime=01-2006" example:"07-2025"`
+	EndDate     string `json:"end_date,omitempty" validate:"omitempty,datetime=01-2006" example:"08-2026"`
+}
+
+type ListSuggestionsRequest struct {
+	UserID string `form:"user_id" validate:"omitempty,uuid4"`
+	// Status defaults to pending, the review queue itself.
+	Status string `form:"status" validate:"omitempty,oneof=pending accepted rejected merged"`
+}
+
+type MergeSuggestionRequest struct {
+	SubscriptionID string `json:"subscription_id" validate:"required,uuid" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+}
+
+type SuggestionResponse struct {
+	ID             string `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	UserID         string `json:"user_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Source         string `json:"source" example:"bank"`
+	ServiceName    string `json:"service_name" example:"Netflix"`
+	Price          int    `json:"price" example:"999"`
+	StartDate      string `json:"start_date" example:"07-2025"`
+	EndDate        string `json:"end_date,omitempty" example:"08-2026"`
+	Status         string `json:"status" example:"pending" enums:"pending,accepted,rejected,merged"`
+	SubscriptionID string `json:"subscription_id,omitempty" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	CreatedAt      string `json:"created_at" example:"2025-07-01T10:00:00Z"`
+	ResolvedAt     string `json:"resolved_at,omitempty" example:"2025-07-02T09:30:00Z"`
+}
+
+// SuggestionResolutionResponse is returned by accept and merge, which write a
+// subscription and so may raise the same warnings as creating or updating one.
+type SuggestionResolutionResponse struct {
+	Suggestion SuggestionResponse `json:"suggestion"`
+	Warnings   []response.Warning `json:"warnings,omitempty"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+type SyncRequest struct {
+	UserID string `form:"user_id" validate:"required,uuid4"`
+	Since  int    `form:"since"   validate:"gte=0"`
+	Limit  int    `form:"limit"   validate:"gte=0"`
+}
+
+type SyncChangeResponse struct {
+	Op           string                `json:"op" example:"upsert" enums:"upsert,delete"`
+	ID           string                `json:"id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Version      int64                 `json:"version" example:"1042"`
+	Subscription *SubscriptionResponse `json:"subscription,omitempty"`
+}
+
+type SyncResponse struct {
+	Changes []SyncChangeResponse `json:"changes"`
+	Cursor  string               `json:"cursor" example:"1042"`
+	HasMore bool                 `json:"has_more" example:"false"`
+}
+
+type SyncPushChange struct {
+	Op              string                     `json:"op" validate:"required,oneof=upsert delete" example:"upsert" enums:"upsert,delete"`
+	ID              string                     `json:"id" validate:"required,uuid" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	BaseVersion     int64                      `json:"base_version" validate:"gte=0" example:"1042"`
+	ClientUpdatedAt time.Time                  `json:"client_updated_at" validate:"required" example:"2025-07-01T10:00:00Z"`
+	Subscription    *UpdateSubscriptionRequest `json:"subscription,omitempty" validate:"required_if=Op upsert"`
+}
+
+type SyncPushRequest struct {
+	UserID   string           `json:"user_id" validate:"required,uuid4" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Strategy string           `json:"strategy,omitempty" validate:"omitempty,oneof=manual last_writer_wins" example:"manual" enums:"manual,last_writer_wins"`
+	Changes  []SyncPushChange `json:"changes" validate:"required,min=1,max=100,dive"`
+}
+
+type SyncPushResultResponse struct {
+	ID     string              `json:"id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	Status string              `json:"status" example:"applied" enums:"applied,conflict,rejected"`
+	Error  string              `json:"error,omitempty" example:"end date cannot be before start date"`
+	Server *SyncChangeResponse `json:"server,omitempty"`
+}
+
+type SyncPushResponse struct {
+	Results []SyncPushResultResponse `json:"results"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type TelegramLinkCodeResponse struct {
+	Code string `json:"code" example:"q3X9aZ1bT0c2LmNpQrStUw"`
+	// Command is what to send the bot to link a chat.
+	Command   string `json:"command" example:"/start q3X9aZ1bT0c2LmNpQrStUw"`
+	ExpiresAt string `json:"expires_at" example:"2025-07-01T10:15:00Z"`
+}
+
+type TelegramLinkResponse struct {
+	ChatID   int64  `json:"chat_id" example:"123456789"`
+	LinkedAt string `json:"linked_at" example:"2025-07-01T10:05:00Z"`
+}
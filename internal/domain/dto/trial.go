@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type SetTrialRequest struct {
+	// EndsOn is the last day of the trial; the regular price applies from the
+	// day after.
+	EndsOn       string `json:"ends_on" validate:"required,datetime=2006-01-02" example:"2026-01-14"`
+	RegularPrice *int   `json:"regular_price" validate:"required,gte=0" example:"999"`
+	// RegularBillingPeriod defaults to the subscription's current period.
+	RegularBillingPeriod string `json:"regular_billing_period,omitempty" validate:"omitempty,oneof=monthly yearly weekly" example:"monthly" enums:"monthly,yearly,weekly"`
+}
+
+type TrialResponse struct {
+	SubscriptionID       string     `json:"subscription_id" example:"d290f1ee-6c54-4b01-90e6-d701748f0851"`
+	EndsOn               string     `json:"ends_on" example:"2026-01-14"`
+	RegularPrice         int        `json:"regular_price" example:"999"`
+	RegularBillingPeriod string     `json:"regular_billing_period" example:"monthly" enums:"monthly,yearly,weekly"`
+	Status               string     `json:"status" example:"active" enums:"active,converted,lapsed"`
+	EndedAt              *time.Time `json:"ended_at,omitempty" example:"2026-01-15T00:05:00Z"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+type RegisterUserRequest struct {
+	Email string `json:"email" validate:"required,email,max=254" example:"alice@example.com"`
+	// Password is capped at 72 bytes, the most bcrypt hashes.
+	Password string `json:"password" validate:"required,min=8,max=72" example:"correct horse battery"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required" example:"correct horse battery"`
+	NewPassword     string `json:"new_password"     validate:"required,min=8,max=72" example:"staple battery horse"`
+}
+
+type UserResponse struct {
+	ID        string `json:"id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	Email     string `json:"email" example:"alice@example.com"`
+	Role      string `json:"role" example:"user" enums:"user,admin"`
+	CreatedAt string `json:"created_at" example:"2025-07-01T10:00:00Z"`
+}
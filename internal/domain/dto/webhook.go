@@ -0,0 +1,67 @@
+package dto
+
+type CreateWebhookRequest struct {
+	UserID string   `json:"user_id" validate:"required,uuid4" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	URL    string   `json:"url" validate:"required,url,max=2048" example:"https://example.com/hooks/subtracker"`
+	Events []string `json:"events" validate:"required,min=1,max=4,unique,dive,oneof=subscription.created subscription.updated subscription.deleted subscription.trial_converted" example:"subscription.created"`
+}
+
+// UpdateWebhookRequest changes only the fields present.
+type UpdateWebhookRequest struct {
+	URL    *string  `json:"url,omitempty" validate:"omitempty,url,max=2048" example:"https://example.com/hooks/subtracker"`
+	Events []string `json:"events,omitempty" validate:"omitempty,min=1,max=4,unique,dive,oneof=subscription.created subscription.updated subscription.deleted subscription.trial_converted" example:"subscription.deleted"`
+}
+
+type ListWebhooksRequest struct {
+	UserID string `form:"user_id" validate:"omitempty,uuid4"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	Limit int `form:"limit" validate:"gte=0"`
+}
+
+type WebhookResponse struct {
+	ID        string   `json:"id" example:"6f1c2c9e-8d1e-4a57-9a43-2f0f3c1c5a10"`
+	UserID    string   `json:"user_id" example:"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"`
+	URL       string   `json:"url" example:"https://example.com/hooks/subtracker"`
+	Events    []string `json:"events" example:"subscription.created"`
+	CreatedAt string   `json:"created_at" example:"2025-07-01T10:00:00Z"`
+}
+
+type CreateWebhookResponse struct {
+	WebhookResponse
+	// Secret signs every payload. It is returned only here.
+	Secret string `json:"secret" example:"Jx1cJ6Vw0m2Wm9yQn1Qe3A"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string `json:"id" example:"0b8a3c5e-2f0d-4c1e-9b7a-8d6e5f4c3b2a"`
+	Event          string `json:"event" example:"subscription.updated"`
+	Sequence       int64  `json:"sequence" example:"17"`
+	Status         string `json:"status" example:"pending" enums:"pending,delivered,failed"`
+	Attempts       int    `json:"attempts" example:"2"`
+	ResponseStatus *int   `json:"response_status,omitempty" example:"503"`
+	LastError      string `json:"last_error,omitempty" example:"receiver answered 503 Service Unavailable"`
+	// NextAttemptAt is only set while the delivery is pending.
+	NextAttemptAt string `json:"next_attempt_at,omitempty" example:"2025-07-01T10:02:30Z"`
+	CreatedAt     string `json:"created_at" example:"2025-07-01T10:00:00Z"`
+	DeliveredAt   string `json:"delivered_at,omitempty" example:"2025-07-01T10:02:31Z"`
+}
+
+type WebhookDeliveryCountsResponse struct {
+	Pending   int `json:"pending" example:"1"`
+	Delivered int `json:"delivered" example:"42"`
+	Failed    int `json:"failed" example:"3"`
+}
+
+type WebhookEventStatsResponse struct {
+	Event string `json:"event" example:"subscription.updated"`
+	WebhookDeliveryCountsResponse
+}
+
+type WebhookStatsResponse struct {
+	WebhookID string `json:"webhook_id" example:"6f1c2c9e-8d1e-4a57-9a43-2f0f3c1c5a10"`
+	WebhookDeliveryCountsResponse
+	Events          []WebhookEventStatsResponse `json:"events"`
+	LastDeliveredAt string                      `json:"last_delivered_at,omitempty" example:"2025-07-01T10:02:31Z"`
+}
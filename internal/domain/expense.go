@@ -0,0 +1,49 @@
+package domain
+
+import "slices"
+
+// ExpenseType is the kind of recurring expense a subscription records. Costs
+// are computed the same way for every type; the types only differ in what they
+// accept.
+type ExpenseType string
+
+const (
+	ExpenseSubscription ExpenseType = "subscription"
+	ExpenseRent         ExpenseType = "rent"
+	ExpenseUtilities    ExpenseType = "utilities"
+	ExpenseInsurance    ExpenseType = "insurance"
+	ExpenseOther        ExpenseType = "other"
+)
+
+// BillingPeriods lists the periods the type can be charged in; nil allows any.
+// Rent is paid monthly or weekly, utility bills monthly, and insurance
+// premiums monthly or yearly.
+func (t ExpenseType) BillingPeriods() []BillingPeriod {
+	switch t {
+	case ExpenseRent:
+		return []BillingPeriod{BillingMonthly, BillingWeekly}
+	case ExpenseUtilities:
+		return []BillingPeriod{BillingMonthly}
+	case ExpenseInsurance:
+		return []BillingPeriod{BillingMonthly, BillingYearly}
+	default:
+		return nil
+	}
+}
+
+// AllowsBillingPeriod reports whether the type can be charged every p.
+func (t ExpenseType) AllowsBillingPeriod(p BillingPeriod) bool {
+	allowed := t.BillingPeriods()
+	return allowed == nil || slices.Contains(allowed, p)
+}
+
+// RequiresPrice reports whether the expense cannot be free. Only
+// subscriptions have free tiers and trials, and other expenses are left alone.
+func (t ExpenseType) RequiresPrice() bool {
+	switch t {
+	case ExpenseRent, ExpenseUtilities, ExpenseInsurance:
+		return true
+	default:
+		return false
+	}
+}
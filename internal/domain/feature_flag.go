@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"hash/fnv"
+	"slices"
+	"time"
+)
+
+// FeatureFlag gates a feature that is being rolled out.
+type FeatureFlag struct {
+	Name        string
+	Description string
+	Enabled     bool
+	// Environments the flag applies in; none means every environment.
+	Environments []string
+	// RolloutPercent is the share of users the flag is on for.
+	RolloutPercent int
+	UpdatedAt      time.Time
+}
+
+// Allows reports whether the flag is on in environment for userID. A user
+// stays in or out of a rollout as its percentage changes, as long as it is not
+// lowered below their bucket. Without a user only a full rollout applies.
+func (f FeatureFlag) Allows(environment, userID string) bool {
+	if !f.Enabled {
+		return false
+	}
+	if len(f.Environments) > 0 && !slices.Contains(f.Environments, environment) {
+		return false
+	}
+	if f.RolloutPercent >= 100 {
+		return true
+	}
+	if userID == "" {
+		return false
+	}
+	return rolloutBucket(f.Name, userID) < f.RolloutPercent
+}
+
+// rolloutBucket places a user in 0..99 for one flag. Hashing the flag name in
+// spreads each flag's rollout over a different set of users.
+func rolloutBucket(flag, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag))
+	h.Write([]byte{0})
+	h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
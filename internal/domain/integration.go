@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConsumerAck records that a downstream consumer handled a message, such as a
+// webhook delivery, so a redelivered copy can be skipped. Duplicate is set
+// when the message had been acked before; AckedAt is then the first ack's
+// time.
+type ConsumerAck struct {
+	UserID    uuid.UUID
+	Consumer  string
+	MessageID string
+	AckedAt   time.Time
+	Duplicate bool
+}
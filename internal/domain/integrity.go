@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Invariants checked by the integrity job.
+const (
+	InvariantNegativePrice  = "negative_price"
+	InvariantEndBeforeStart = "end_before_start"
+)
+
+// IntegrityReport counts the rows that break each invariant.
+type IntegrityReport struct {
+	CheckedAt  time.Time
+	Violations map[string]int
+}
+
+// Healthy reports whether no invariant is violated.
+func (r IntegrityReport) Healthy() bool {
+	for _, count := range r.Violations {
+		if count > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentStatus tells whether a subscription's charges went through.
+type PaymentStatus string
+
+const (
+	PaymentCurrent PaymentStatus = "current"
+	// PaymentPastDue is a subscription with a failed charge that no retry has
+	// paid yet, so the vendor may interrupt it.
+	PaymentPastDue PaymentStatus = "past_due"
+)
+
+// PaymentFailure is a failed charge for the month of Period. PaidAt is nil
+// until a retry succeeds.
+type PaymentFailure struct {
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	Period         time.Time
+	FailedAt       time.Time
+	Retries        int
+	LastRetryAt    *time.Time
+	PaidAt         *time.Time
+}
+
+func (f PaymentFailure) Unpaid() bool {
+	return f.PaidAt == nil
+}
+
+// PaymentHistory is a subscription's failed charges, oldest month first.
+type PaymentHistory struct {
+	SubscriptionID uuid.UUID
+	Failures       []PaymentFailure
+}
+
+func (h PaymentHistory) Status() PaymentStatus {
+	for _, f := range h.Failures {
+		if f.Unpaid() {
+			return PaymentPastDue
+		}
+	}
+	return PaymentCurrent
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceChange is a new price that a subscription switches to from the month
+// of EffectiveDate on. AppliedAt is nil until the scheduler has written it to
+// the subscription.
+type PriceChange struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	Price          int
+	EffectiveDate  time.Time
+	CreatedAt      time.Time
+	AppliedAt      *time.Time
+}
+
+func (c PriceChange) Pending() bool {
+	return c.AppliedAt == nil
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// QueryStat is how one normalized query has performed since the statistics
+// were last reset, as pg_stat_statements records it.
+type QueryStat struct {
+	QueryID   int64
+	Query     string
+	Calls     int64
+	Rows      int64
+	TotalTime time.Duration
+	MeanTime  time.Duration
+}
@@ -0,0 +1,16 @@
+package domain
+
+// QuotaStatus is how much of the per-user subscription quota is in use.
+// A zero Limit means no quota is configured.
+type QuotaStatus struct {
+	Limit int
+	Used  int
+}
+
+func (q QuotaStatus) Enabled() bool {
+	return q.Limit > 0
+}
+
+func (q QuotaStatus) Remaining() int {
+	return max(q.Limit-q.Used, 0)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReminderSetting controls renewal reminder emails for one subscription.
+type ReminderSetting struct {
+	SubscriptionID uuid.UUID
+	Enabled        bool
+	// DaysBefore is the subscription's own offset; nil follows the server
+	// default.
+	DaysBefore *int
+	// EffectiveDaysBefore is how many days ahead of a renewal the reminder is
+	// actually sent.
+	EffectiveDaysBefore int
+}
+
+// ReminderCandidate is an active subscription whose owner can be reminded.
+type ReminderCandidate struct {
+	Subscription
+	// Email is empty when the owner has no mailbox that is read.
+	Email string
+	// TelegramChatID is the owner's linked chat, if any. Reminders go there
+	// instead of by email while the bot runs.
+	TelegramChatID *int64
+	DaysBefore     int
+}
+
+// DueRenewal returns the subscription's next renewal on or after today and
+// whether a reminder for it is due today. Renewals after the end month are
+// never due.
+func (c ReminderCandidate) DueRenewal(today time.Time) (time.Time, bool) {
+	return c.DueRenewalWithin(today, 0)
+}
+
+// DueRenewalWithin is DueRenewal for a reminder sent up to days early.
+func (c ReminderCandidate) DueRenewalWithin(today time.Time, days int) (time.Time, bool) {
+	renewal := c.BillingPeriod.NextRenewal(c.StartDate, today)
+	if c.EndDate != nil && !renewal.Before(c.EndDate.AddDate(0, 1, 0)) {
+		return renewal, false
+	}
+	return renewal, !today.AddDate(0, 0, days).Before(renewal.AddDate(0, 0, -c.DaysBefore))
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// SavingsReport sums the subscriptions a user cancelled in a period, i.e. those
+// whose end date falls inside it. MonthlySavings is what they would have kept
+// costing every month; ProjectedAnnualSavings projects that over a year.
+type SavingsReport struct {
+	PeriodStart            time.Time
+	PeriodEnd              time.Time
+	Cancelled              []Subscription
+	MonthlySavings         int
+	ProjectedAnnualSavings int
+}
+
+// ServiceBenchmark is the average monthly price users currently pay for a
+// service across the whole install base.
+type ServiceBenchmark struct {
+	ServiceName  string
+	AveragePrice int
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CategoryRule assigns Category to subscriptions whose service name contains
+// Pattern, ignoring case. A nil UserID makes the rule global.
+type CategoryRule struct {
+	ID       uuid.UUID
+	UserID   *uuid.UUID
+	Pattern  string
+	Category string
+	Priority int
+}
+
+func (r CategoryRule) Matches(serviceName string) bool {
+	return strings.Contains(strings.ToLower(serviceName), strings.ToLower(r.Pattern))
+}
+
+// MatchCategory returns the category of the first rule matching serviceName.
+// Rules must already be in precedence order.
+func MatchCategory(rules []CategoryRule, serviceName string) (string, bool) {
+	for _, rule := range rules {
+		if rule.Matches(serviceName) {
+			return rule.Category, true
+		}
+	}
+	return "", false
+}
+
+// RuleApplyResult counts the subscriptions a retroactive rule run looked at
+// and the ones whose category it changed.
+type RuleApplyResult struct {
+	Checked int
+	Updated int
+}
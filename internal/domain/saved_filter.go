@@ -0,0 +1,10 @@
+package domain
+
+import "github.com/google/uuid"
+
+type SavedFilter struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Name   string
+	Params map[string]string
+}
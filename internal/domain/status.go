@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// BuildInfo identifies the running build.
+type BuildInfo struct {
+	Version string
+	// Commit is empty when the build was not stamped with one.
+	Commit string
+}
+
+// DependencyHealth is the outcome of checking one dependency; Problem is
+// empty when it is healthy.
+type DependencyHealth struct {
+	Name    string
+	Problem string
+}
+
+func (d DependencyHealth) Healthy() bool {
+	return d.Problem == ""
+}
+
+// SystemStatus describes what an instance is running and whether the
+// dependencies it checked are healthy.
+type SystemStatus struct {
+	Build        BuildInfo
+	StartedAt    time.Time
+	Uptime       time.Duration
+	Dependencies []DependencyHealth
+}
+
+func (s SystemStatus) Healthy() bool {
+	for _, dependency := range s.Dependencies {
+		if !dependency.Healthy() {
+			return false
+		}
+	}
+	return true
+}
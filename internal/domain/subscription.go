@@ -13,4 +13,93 @@ type Subscription struct {
 	Price       int
 	StartDate   time.Time
 	EndDate     *time.Time
+	// CostCenter is the department or project the spend is recharged to; empty
+	// when unassigned.
+	CostCenter string
+	// Category is set by the user or, when left empty, by the first matching
+	// categorization rule.
+	Category string
+	// BillingPeriod is how often Price is charged.
+	BillingPeriod BillingPeriod
+	// ExpenseType is what kind of recurring expense this is; subscription for
+	// rows that predate types.
+	ExpenseType ExpenseType
+	// Version identifies the stored state and is served as the ETag. On an
+	// update it is the version the caller read, zero for any.
+	Version int64
+}
+
+// SubscriptionPatch changes only the fields that are set. ClearEndDate
+// removes the end date and wins over EndDate.
+type SubscriptionPatch struct {
+	ServiceName   *string
+	Price         *int
+	StartDate     *time.Time
+	EndDate       *time.Time
+	ClearEndDate  bool
+	CostCenter    *string
+	Category      *string
+	BillingPeriod *BillingPeriod
+	ExpenseType   *ExpenseType
+	// Version is the version the caller read, zero for any.
+	Version int64
+}
+
+// Apply returns sub with the patch's fields set.
+func (p SubscriptionPatch) Apply(sub Subscription) Subscription {
+	if p.ServiceName != nil {
+		sub.ServiceName = *p.ServiceName
+	}
+	if p.Price != nil {
+		sub.Price = *p.Price
+	}
+	if p.StartDate != nil {
+		sub.StartDate = *p.StartDate
+	}
+	if p.EndDate != nil {
+		sub.EndDate = p.EndDate
+	}
+	if p.ClearEndDate {
+		sub.EndDate = nil
+	}
+	if p.CostCenter != nil {
+		sub.CostCenter = *p.CostCenter
+	}
+	if p.Category != nil {
+		sub.Category = *p.Category
+	}
+	if p.BillingPeriod != nil {
+		sub.BillingPeriod = *p.BillingPeriod
+	}
+	if p.ExpenseType != nil {
+		sub.ExpenseType = *p.ExpenseType
+	}
+	return sub
+}
+
+// TrashedSubscription is a deleted subscription that can still be restored.
+type TrashedSubscription struct {
+	Subscription
+	DeletedAt time.Time
+}
+
+// RestoreResult splits the IDs of a restore request into the subscriptions
+// brought back and the ones that were not in the caller's trash.
+type RestoreResult struct {
+	Restored []uuid.UUID
+	NotFound []uuid.UUID
+}
+
+// LookupResult splits the IDs of a lookup into the subscriptions found, in
+// the order they were asked for, and the ones that do not exist or are not the
+// caller's.
+type LookupResult struct {
+	Found    []Subscription
+	NotFound []uuid.UUID
+}
+
+// UndoToken lets the caller reverse a delete until ExpiresAt.
+type UndoToken struct {
+	Token     string
+	ExpiresAt time.Time
 }
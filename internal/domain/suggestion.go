@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuggestionStatus string
+
+const (
+	SuggestionPending  SuggestionStatus = "pending"
+	SuggestionAccepted SuggestionStatus = "accepted"
+	SuggestionRejected SuggestionStatus = "rejected"
+	SuggestionMerged   SuggestionStatus = "merged"
+)
+
+// Suggestion is an automatically detected subscription awaiting review.
+// Source names the detector that found it. SubscriptionID is set once the
+// suggestion is accepted or merged.
+type Suggestion struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Source         string
+	ServiceName    string
+	Price          int
+	StartDate      time.Time
+	EndDate        *time.Time
+	Status         SuggestionStatus
+	SubscriptionID *uuid.UUID
+	CreatedAt      time.Time
+	ResolvedAt     *time.Time
+}
+
+// Subscription is the subscription accepting the suggestion creates. It
+// reuses the suggestion's ID, so accepting the same suggestion twice cannot
+// create two subscriptions.
+func (s Suggestion) Subscription() Subscription {
+	return Subscription{
+		ID:          s.ID,
+		UserID:      s.UserID,
+		ServiceName: s.ServiceName,
+		Price:       s.Price,
+		StartDate:   s.StartDate,
+		EndDate:     s.EndDate,
+	}
+}
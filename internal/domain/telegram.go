@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TelegramLink ties a user to the chat the bot talks to them in.
+type TelegramLink struct {
+	UserID   uuid.UUID
+	ChatID   int64
+	LinkedAt time.Time
+}
+
+// TelegramLinkCode is a one-time code that links the chat it is sent from to
+// the user it was issued for, until ExpiresAt.
+type TelegramLinkCode struct {
+	Code      string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TrialStatus string
+
+const (
+	TrialActive    TrialStatus = "active"
+	TrialConverted TrialStatus = "converted"
+	// TrialLapsed is a trial whose subscription ended or was deleted before
+	// the trial did, so it never converted.
+	TrialLapsed TrialStatus = "lapsed"
+)
+
+// Trial is a subscription's trial. The subscription carries its trial price
+// until the day after EndsOn, when it switches to RegularPrice billed every
+// RegularBillingPeriod.
+type Trial struct {
+	SubscriptionID       uuid.UUID
+	UserID               uuid.UUID
+	EndsOn               time.Time
+	RegularPrice         int
+	RegularBillingPeriod BillingPeriod
+	EndedAt              *time.Time
+	Converted            bool
+}
+
+func (t Trial) Status() TrialStatus {
+	switch {
+	case t.EndedAt == nil:
+		return TrialActive
+	case t.Converted:
+		return TrialConverted
+	default:
+		return TrialLapsed
+	}
+}
+
+// ConvertedTrial is a subscription that just left its trial for its regular
+// price, with what it cost before and where to reach its owner.
+type ConvertedTrial struct {
+	Subscription
+	TrialPrice         int
+	TrialBillingPeriod BillingPeriod
+	// Email is empty when the owner has no mailbox that is read.
+	Email          string
+	TelegramChatID *int64
+}
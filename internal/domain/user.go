@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	RoleUser Role = "user"
+	// RoleAdmin may read and change every user's subscriptions.
+	RoleAdmin Role = "admin"
+)
+
+// User is an account. Its password hash never leaves the service layer.
+type User struct {
+	ID        uuid.UUID
+	Email     string
+	Role      Role
+	CreatedAt time.Time
+}
@@ -0,0 +1,126 @@
+// Package validate holds the business rules a subscription must satisfy
+// whichever path writes it, as rules that compose with All.
+package validate
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"subtracker/internal/domain"
+)
+
+// Codes identify each broken rule to clients.
+const (
+	CodeServiceName          = "invalid_service_name"
+	CodePriceOutOfRange      = "price_out_of_range"
+	CodeBillingPeriod        = "invalid_billing_period"
+	CodeEndBeforeStart       = "end_before_start"
+	CodeStartTooFarInPast    = "start_date_too_far_in_past"
+	CodeStartTooFarInFuture  = "start_date_too_far_in_future"
+	CodeExpenseBillingPeriod = "billing_period_not_allowed_for_expense_type"
+	CodeExpensePriceRequired = "price_required_for_expense_type"
+)
+
+const (
+	// MaxServiceNameLength is in characters, not bytes.
+	MaxServiceNameLength = 100
+	// MaxPrice is the largest price the INTEGER price column holds.
+	MaxPrice = math.MaxInt32
+)
+
+// Error is a broken rule.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func broken(code, format string, args ...any) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Rule checks one business rule, returning an *Error when it is broken.
+type Rule func() error
+
+// All checks rules in order and returns the first that is broken.
+func All(rules ...Rule) error {
+	for _, rule := range rules {
+		if err := rule(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServiceName requires a name of 1 to MaxServiceNameLength characters.
+func ServiceName(name string) Rule {
+	return func() error {
+		if name == "" || utf8.RuneCountInString(name) > MaxServiceNameLength {
+			return broken(CodeServiceName, "service_name must be 1 to %d characters", MaxServiceNameLength)
+		}
+		return nil
+	}
+}
+
+// Price requires a price between zero, for free tiers, and MaxPrice.
+func Price(price int) Rule {
+	return func() error {
+		if price < 0 || price > MaxPrice {
+			return broken(CodePriceOutOfRange, "price must be between 0 and %d", MaxPrice)
+		}
+		return nil
+	}
+}
+
+// BillingPeriod requires one of the supported billing periods.
+func BillingPeriod(p domain.BillingPeriod) Rule {
+	return func() error {
+		switch p {
+		case domain.BillingMonthly, domain.BillingYearly, domain.BillingWeekly:
+			return nil
+		}
+		return broken(CodeBillingPeriod, "billing_period must be monthly, yearly or weekly")
+	}
+}
+
+// DateRange requires an end date, when there is one, not before the start.
+func DateRange(start time.Time, end *time.Time) Rule {
+	return func() error {
+		if end != nil && end.Before(start) {
+			return broken(CodeEndBeforeStart, "end_date cannot be before start_date")
+		}
+		return nil
+	}
+}
+
+// StartDate requires a start date at most yearsPast years before now and
+// yearsFuture years after it. A zero bound disables that side of the check.
+func StartDate(start, now time.Time, yearsPast, yearsFuture int) Rule {
+	return func() error {
+		if yearsPast > 0 && start.Before(now.AddDate(-yearsPast, 0, 0)) {
+			return broken(CodeStartTooFarInPast, "start_date cannot be more than %d year(s) in the past", yearsPast)
+		}
+		if yearsFuture > 0 && start.After(now.AddDate(yearsFuture, 0, 0)) {
+			return broken(CodeStartTooFarInFuture, "start_date cannot be more than %d year(s) in the future", yearsFuture)
+		}
+		return nil
+	}
+}
+
+// Expense applies the rules of an expense type to its billing period and price.
+func Expense(t domain.ExpenseType, p domain.BillingPeriod, price int) Rule {
+	return func() error {
+		if !t.AllowsBillingPeriod(p) {
+			return broken(CodeExpenseBillingPeriod, "%s cannot be billed %s", t, p)
+		}
+		if t.RequiresPrice() && price == 0 {
+			return broken(CodeExpensePriceRequired, "price must be above zero for %s", t)
+		}
+		return nil
+	}
+}
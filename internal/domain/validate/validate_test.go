@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	before := now.AddDate(0, -1, 0)
+
+	cases := []struct {
+		name string
+		rule Rule
+		code string
+	}{
+		{"Name", ServiceName("Netflix"), ""},
+		{"Name Of Max Length In Characters", ServiceName(strings.Repeat("я", MaxServiceNameLength)), ""},
+		{"Empty Name", ServiceName(""), CodeServiceName},
+		{"Name Too Long", ServiceName(strings.Repeat("a", MaxServiceNameLength+1)), CodeServiceName},
+		{"Free", Price(0), ""},
+		{"Negative Price", Price(-1), CodePriceOutOfRange},
+		{"Price Above Column Range", Price(MaxPrice + 1), CodePriceOutOfRange},
+		{"Billing Period", BillingPeriod(domain.BillingWeekly), ""},
+		{"Unknown Billing Period", BillingPeriod("daily"), CodeBillingPeriod},
+		{"Open Ended", DateRange(now, nil), ""},
+		{"End Before Start", DateRange(now, &before), CodeEndBeforeStart},
+		{"Start Within Limits", StartDate(now.AddDate(-1, 0, 0), now, 2, 2), ""},
+		{"Start Too Far In Past", StartDate(now.AddDate(-3, 0, 0), now, 2, 2), CodeStartTooFarInPast},
+		{"Start Too Far In Future", StartDate(now.AddDate(3, 0, 0), now, 2, 2), CodeStartTooFarInFuture},
+		{"No Start Limits", StartDate(now.AddDate(-50, 0, 0), now, 0, 0), ""},
+		{"Rent Billed Yearly", Expense(domain.ExpenseRent, domain.BillingYearly, 1000), CodeExpenseBillingPeriod},
+		{"Free Insurance", Expense(domain.ExpenseInsurance, domain.BillingMonthly, 0), CodeExpensePriceRequired},
+		{"Free Subscription", Expense(domain.ExpenseSubscription, domain.BillingMonthly, 0), ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rule()
+			if tc.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var ruleErr *Error
+			assert.True(t, errors.As(err, &ruleErr))
+			assert.Equal(t, tc.code, ruleErr.Code)
+		})
+	}
+}
+
+func TestAllStopsAtFirstBrokenRule(t *testing.T) {
+	err := All(ServiceName("Netflix"), Price(-1), ServiceName(""))
+
+	var ruleErr *Error
+	assert.True(t, errors.As(err, &ruleErr))
+	assert.Equal(t, CodePriceOutOfRange, ruleErr.Code)
+	assert.NoError(t, All())
+}
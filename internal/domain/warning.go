@@ -0,0 +1,7 @@
+package domain
+
+// Warning is a non-fatal remark about data that was accepted anyway.
+type Warning struct {
+	Code    string
+	Message string
+}
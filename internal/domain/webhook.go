@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook events, as sent in X-Subtracker-Event and stored in webhooks.events.
+const (
+	EventSubscriptionCreated = "subscription.created"
+	EventSubscriptionUpdated = "subscription.updated"
+	EventSubscriptionDeleted = "subscription.deleted"
+	// EventSubscriptionTrialConverted follows the subscription.updated event
+	// of a trial switching to its regular price.
+	EventSubscriptionTrialConverted = "subscription.trial_converted"
+)
+
+// Webhook is a URL a user registered to be called on subscription events.
+type Webhook struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	URL    string
+	// Secret signs payloads. It is shown only when the webhook is created.
+	Secret    string
+	Events    []string
+	CreatedAt time.Time
+}
+
+// WebhookPatch changes only the fields that are set.
+type WebhookPatch struct {
+	URL    *string
+	Events []string
+}
+
+// Apply returns hook with the patch's fields set.
+func (p WebhookPatch) Apply(hook Webhook) Webhook {
+	if p.URL != nil {
+		hook.URL = *p.URL
+	}
+	if p.Events != nil {
+		hook.Events = p.Events
+	}
+	return hook
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed is final: every attempt failed.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent, or still to be sent, to a webhook.
+type WebhookDelivery struct {
+	ID        uuid.UUID
+	WebhookID uuid.UUID
+	Event     string
+	// Sequence counts the webhook's deliveries up from 1 in the order their
+	// events happened.
+	Sequence int64
+	Status   DeliveryStatus
+	Attempts int
+	// ResponseStatus is the receiver's answer to the last attempt, nil when
+	// none arrived.
+	ResponseStatus *int
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// DeliveryCounts counts deliveries by status.
+type DeliveryCounts struct {
+	Pending   int
+	Delivered int
+	Failed    int
+}
+
+// Add counts n deliveries of the given status.
+func (c *DeliveryCounts) Add(status DeliveryStatus, n int) {
+	switch status {
+	case DeliveryPending:
+		c.Pending += n
+	case DeliveryDelivered:
+		c.Delivered += n
+	case DeliveryFailed:
+		c.Failed += n
+	}
+}
+
+// EventDeliveryCounts are the deliveries of one event.
+type EventDeliveryCounts struct {
+	Event string
+	DeliveryCounts
+}
+
+// WebhookStats sums up a webhook's delivery history, overall and per event.
+type WebhookStats struct {
+	WebhookID uuid.UUID
+	DeliveryCounts
+	// Events lists the events the webhook is registered for, then any it
+	// received deliveries of before they were dropped from its events.
+	Events []EventDeliveryCounts
+	// LastDeliveredAt is nil until a delivery succeeds.
+	LastDeliveredAt *time.Time
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(FormatCSV, &buf)
+
+	assert.NoError(t, w.WriteRow("service_name", "price"))
+	assert.Zero(t, buf.Len(), "rows are buffered until flushed")
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, "service_name,price\n", buf.String())
+	assert.NoError(t, w.WriteRow("Netflix, Premium", 999))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "service_name,price\n\"Netflix, Premium\",999\n", buf.String())
+}
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(FormatCSV, &buf)
+
+	assert.NoError(t, w.WriteRow("=HYPERLINK(\"https://evil.example\")", "+1", "-cmd", "@SUM(A1)", "\tTab", "Netflix", -499))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "\"'=HYPERLINK(\"\"https://evil.example\"\")\",'+1,'-cmd,'@SUM(A1),'\tTab,Netflix,-499\n", buf.String(),
+		"text starting a formula is quoted, numbers are kept")
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(FormatXLSX, &buf)
+
+	assert.NoError(t, w.WriteRow("service_name", "price"))
+	flushed := buf.Len()
+	assert.NoError(t, w.Flush())
+	assert.Greater(t, buf.Len(), flushed, "flushing hands the sheet so far to the writer")
+	assert.NoError(t, w.WriteRow("Tom & Jerry <Plus>", 999))
+	assert.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+	var names []string
+	var sheet []byte
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			r, err := f.Open()
+			if err != nil {
+				t.Fatalf("failed to open sheet: %v", err)
+			}
+			sheet, _ = io.ReadAll(r)
+		}
+	}
+	assert.ElementsMatch(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"}, names)
+	assert.Contains(t, string(sheet), `<row r="2"><c t="inlineStr"><is><t xml:space="preserve">Tom &amp; Jerry &lt;Plus&gt;</t></is></c><c><v>999</v></c></row>`)
+	assert.Contains(t, string(sheet), `</sheetData></worksheet>`)
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// AdminHandler serves debug endpoints. It is only wired when DEBUG_ENDPOINTS is on.
+type AdminHandler struct {
+	integrity service.IntegrityServiceInterface
+	health    service.HealthServiceInterface
+	flags     service.FeatureFlagServiceInterface
+	logger    logger.Logger
+}
+
+func NewAdminHandler(integrity service.IntegrityServiceInterface, health service.HealthServiceInterface, flags service.FeatureFlagServiceInterface, logger logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		integrity: integrity,
+		health:    health,
+		flags:     flags,
+		logger:    logger,
+	}
+}
+
+// Bounds of the limit parameter of /admin/queries.
+const (
+	topQueriesDefaultLimit = 20
+	topQueriesMaxLimit     = 100
+)
+
+// ListRoutes lists the routes of api, which need not be the router serving the
+// request when admin endpoints have their own listener.
+func (h *AdminHandler) ListRoutes(api chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.listRoutes(w, r, api)
+	}
+}
+
+// @Summary      List Routes
+// @Description  Lists every route of the public API with its method and middleware chain. Only available when DEBUG_ENDPOINTS is enabled.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   dto.RouteResponse
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /admin/routes [get]
+func (h *AdminHandler) listRoutes(w http.ResponseWriter, r *http.Request, api chi.Routes) {
+	h.logger.InfoContext(r.Context(), "ListRoutes request received")
+
+	var routes []dto.RouteResponse
+	walkFn := func(method, pattern string, _ http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		names := make([]string, len(middlewares))
+		for i, mw := range middlewares {
+			names[i] = funcName(mw)
+		}
+		routes = append(routes, dto.RouteResponse{Method: method, Pattern: pattern, Middlewares: names})
+		return nil
+	}
+	if err := chi.Walk(api, walkFn); err != nil {
+		writeError(h.logger, w, r, apperrors.NewInternalServerError("failed to walk routes", err))
+		return
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	response.JSON(w, http.StatusOK, routes)
+}
+
+// @Summary      Check Data Integrity
+// @Description  Counts rows that break data invariants (negative prices, end date before start date).
+// @Description  Only available when DEBUG_ENDPOINTS is enabled.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  dto.IntegrityReportResponse
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /admin/integrity [get]
+func (h *AdminHandler) CheckIntegrity(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "CheckIntegrity request received")
+
+	report, err := h.integrity.Check(r.Context())
+	if err != nil {
+		writeError(h.logger, w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToIntegrityReportDTO(report))
+}
+
+// @Summary      Top Queries
+// @Description  Lists the queries with the highest mean execution time since statistics were last reset, read from
+// @Description  pg_stat_statements, to guide tuning of the repository layer. The extension must be in the database's
+// @Description  shared_preload_libraries and created with CREATE EXTENSION pg_stat_statements.
+// @Description  Only available when DEBUG_ENDPOINTS is enabled.
+// @Tags         Admin
+// @Produce      json
+// @Param        limit  query     int  false  "Number of queries (default 20, max 100)"
+// @Success      200  {array}   dto.QueryStatResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid limit"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Failure      503  {object}  apperrors.AppError "pg_stat_statements is not enabled"
+// @Router       /admin/queries [get]
+func (h *AdminHandler) TopQueries(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "TopQueries request received")
+
+	limit := topQueriesDefaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > topQueriesMaxLimit {
+			writeError(h.logger, w, r, apperrors.NewBadRequest("limit must be between 1 and "+strconv.Itoa(topQueriesMaxLimit), err))
+			return
+		}
+		limit = n
+	}
+
+	stats, err := h.health.TopQueries(r.Context(), limit)
+	if err != nil {
+		writeError(h.logger, w, r, err)
+		return
+	}
+	resp := make([]dto.QueryStatResponse, len(stats))
+	for i, stat := range stats {
+		resp[i] = mapper.ToQueryStatResponse(stat)
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// @Summary      List Feature Flags
+// @Description  Lists every feature flag by name. Only available when DEBUG_ENDPOINTS is enabled.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   dto.FeatureFlagResponse
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /admin/flags [get]
+func (h *AdminHandler) ListFlags(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "ListFlags request received")
+
+	flags, err := h.flags.ListFlags(r.Context())
+	if err != nil {
+		writeError(h.logger, w, r, err)
+		return
+	}
+	resp := make([]dto.FeatureFlagResponse, len(flags))
+	for i, flag := range flags {
+		resp[i] = mapper.ToFeatureFlagResponse(flag)
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// @Summary      Set Feature Flag
+// @Description  Creates or overwrites a feature flag. A flag is on only while enabled, in one of its environments
+// @Description  (none means every APP_ENV), and for rollout_percent of users, each of whom stays in or out of the
+// @Description  rollout as it grows. Other instances pick the change up within FEATURE_FLAG_REFRESH_INTERVAL.
+// @Description  Only available when DEBUG_ENDPOINTS is enabled. Without ADMIN_PORT it requires an admin's bearer token.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        name     path  string                     true  "Flag name: lowercase letters, digits and underscores"
+// @Param        request  body  dto.SetFeatureFlagRequest  true  "Flag settings"
+// @Success      200  {object}  dto.FeatureFlagResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid flag name, request body or fields"
+// @Failure      401  {object}  apperrors.AppError "Missing or invalid bearer token"
+// @Failure      403  {object}  apperrors.AppError "Caller is not an admin"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/flags/{name} [put]
+func (h *AdminHandler) SetFlag(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	h.logger.InfoContext(r.Context(), "SetFlag request received", zap.String("flag", name))
+
+	var req dto.SetFeatureFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(h.logger, w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		writeError(h.logger, w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	flag, err := h.flags.SetFlag(r.Context(), mapper.ToFeatureFlagFromDTO(name, req))
+	if err != nil {
+		writeError(h.logger, w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToFeatureFlagResponse(flag))
+}
+
+// @Summary      Delete Feature Flag
+// @Description  Deletes a feature flag, which turns it off everywhere. Only available when DEBUG_ENDPOINTS is enabled.
+// @Description  Without ADMIN_PORT it requires an admin's bearer token.
+// @Tags         Admin
+// @Produce      json
+// @Param        name  path  string  true  "Flag name"
+// @Success      204  "No Content"
+// @Failure      401  {object}  apperrors.AppError "Missing or invalid bearer token"
+// @Failure      403  {object}  apperrors.AppError "Caller is not an admin"
+// @Failure      404  {object}  apperrors.AppError "Flag not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/flags/{name} [delete]
+func (h *AdminHandler) DeleteFlag(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	h.logger.InfoContext(r.Context(), "DeleteFlag request received", zap.String("flag", name))
+
+	if err := h.flags.DeleteFlag(r.Context(), name); err != nil {
+		writeError(h.logger, w, r, err)
+		return
+	}
+	response.NoContent(w)
+}
+
+func funcName(fn any) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+		return f.Name()
+	}
+	return "unknown"
+}
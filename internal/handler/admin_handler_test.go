@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	mockService := new(mocks.IntegrityServiceInterface)
+	handler := NewAdminHandler(mockService, new(mocks.HealthServiceInterface), nil, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		report := domain.IntegrityReport{
+			CheckedAt:  time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC),
+			Violations: map[string]int{domain.InvariantNegativePrice: 0, domain.InvariantEndBeforeStart: 4},
+		}
+		mockService.On("Check", mock.Anything).Return(report, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.CheckIntegrity(rr, httptest.NewRequest(http.MethodGet, "/admin/integrity", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body dto.IntegrityReportResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.False(t, body.Healthy)
+		assert.Equal(t, "2025-07-01T12:00:00Z", body.CheckedAt)
+		assert.Equal(t, 4, body.Violations[domain.InvariantEndBeforeStart])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Service Error", func(t *testing.T) {
+		mockService.On("Check", mock.Anything).
+			Return(domain.IntegrityReport{}, apperrors.NewInternalServerError("database error on integrity check", nil)).Once()
+
+		rr := httptest.NewRecorder()
+		handler.CheckIntegrity(rr, httptest.NewRequest(http.MethodGet, "/admin/integrity", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestTopQueries(t *testing.T) {
+	mockHealth := new(mocks.HealthServiceInterface)
+	handler := NewAdminHandler(new(mocks.IntegrityServiceInterface), mockHealth, nil, logger.NewNopLogger())
+
+	t.Run("Default Limit", func(t *testing.T) {
+		mockHealth.On("TopQueries", mock.Anything, 20).Return([]domain.QueryStat{
+			{QueryID: 11, Query: "SELECT SUM(price) FROM subscriptions", Calls: 4, Rows: 4, TotalTime: 200 * time.Millisecond, MeanTime: 50500 * time.Microsecond},
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.TopQueries(rr, httptest.NewRequest(http.MethodGet, "/admin/queries", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body []dto.QueryStatResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Len(t, body, 1)
+		assert.Equal(t, 50.5, body[0].MeanTimeMs)
+		assert.Equal(t, 200.0, body[0].TotalTimeMs)
+		mockHealth.AssertExpectations(t)
+	})
+
+	t.Run("Limit Above Maximum", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.TopQueries(rr, httptest.NewRequest(http.MethodGet, "/admin/queries?limit=500", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockHealth.AssertNotCalled(t, "TopQueries", mock.Anything, 500)
+	})
+
+	t.Run("Extension Not Enabled", func(t *testing.T) {
+		mockHealth.On("TopQueries", mock.Anything, 5).
+			Return(nil, apperrors.New(http.StatusServiceUnavailable, "pg_stat_statements is not enabled on this database", nil)).Once()
+
+		rr := httptest.NewRecorder()
+		handler.TopQueries(rr, httptest.NewRequest(http.MethodGet, "/admin/queries?limit=5", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		mockHealth.AssertExpectations(t)
+	})
+}
+
+func TestFeatureFlags(t *testing.T) {
+	mockFlags := new(mocks.FeatureFlagServiceInterface)
+	handler := NewAdminHandler(new(mocks.IntegrityServiceInterface), new(mocks.HealthServiceInterface), mockFlags, logger.NewNopLogger())
+
+	newRequest := func(method, name, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("name", name)
+		req := httptest.NewRequest(method, "/admin/flags/"+name, strings.NewReader(body))
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Set Rejects Rollout Above 100", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.SetFlag(rr, newRequest(http.MethodPut, "new_importer", `{"enabled": true, "rollout_percent": 150}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockFlags.AssertNotCalled(t, "SetFlag", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Set Defaults To Full Rollout", func(t *testing.T) {
+		want := domain.FeatureFlag{Name: "new_importer", Enabled: true, Environments: []string{"staging"}, RolloutPercent: 100}
+		saved := want
+		saved.UpdatedAt = time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+		mockFlags.On("SetFlag", mock.Anything, want).Return(saved, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.SetFlag(rr, newRequest(http.MethodPut, "new_importer", `{"enabled": true, "environments": ["staging"]}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body dto.FeatureFlagResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, 100, body.RolloutPercent)
+		assert.Equal(t, "2025-07-01T10:00:00Z", body.UpdatedAt)
+		mockFlags.AssertExpectations(t)
+	})
+
+	t.Run("Delete Unknown Flag", func(t *testing.T) {
+		mockFlags.On("DeleteFlag", mock.Anything, "new_importer").Return(apperrors.NewNotFound("feature flag not found", nil)).Once()
+
+		rr := httptest.NewRecorder()
+		handler.DeleteFlag(rr, newRequest(http.MethodDelete, "new_importer", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockFlags.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"go.uber.org/zap"
+)
+
+type AuthHandler struct {
+	service service.AuthServiceInterface
+	logger  logger.Logger
+}
+
+func NewAuthHandler(service service.AuthServiceInterface, logger logger.Logger) *AuthHandler {
+	return &AuthHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AuthHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Login
+// @Description  Exchanges an account's email and password for a bearer token. Send it as
+// @Description  `Authorization: Bearer <token>`; protected routes then act on that user only.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials body      dto.LoginRequest true "Email and password"
+// @Success      200         {object}  dto.TokenResponse
+// @Failure      400         {object}  apperrors.AppError "Invalid request body"
+// @Failure      401         {object}  apperrors.AppError "Invalid credentials"
+// @Router       /auth/login [post]
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "Login request received")
+
+	var req dto.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	token, err := h.service.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Login completed successfully", zap.String("user_id", token.UserID.String()))
+
+	response.JSON(w, http.StatusOK, dto.TokenResponse{
+		AccessToken: token.Token,
+		TokenType:   "Bearer",
+		ExpiresAt:   token.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// Authenticate rejects requests without a valid bearer token and stores the
+// token's principal in the request context for the service layer, and its user
+// ID for the request's log lines.
+func (h *AuthHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="subtracker"`)
+			h.handleError(w, r, apperrors.New(http.StatusUnauthorized, "missing bearer token", nil).
+				WithErrorCode(service.ErrCodeInvalidToken))
+			return
+		}
+		principal, err := h.service.Authenticate(token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="subtracker", error="invalid_token"`)
+			h.handleError(w, r, err)
+			return
+		}
+		ctx := logger.ContextWith(r.Context(), zap.String("user_id", principal.UserID.String()))
+		next.ServeHTTP(w, r.WithContext(service.WithPrincipal(ctx, principal)))
+	})
+}
+
+// scopeQuery returns query with user_id resolved by service.ScopeUserID.
+func scopeQuery(ctx context.Context, query url.Values) (url.Values, error) {
+	userID, err := service.ScopeUserID(ctx, query.Get("user_id"))
+	if err != nil {
+		return nil, err
+	}
+	if userID == "" {
+		return query, nil
+	}
+	scoped := url.Values{}
+	for key, values := range query {
+		scoped[key] = values
+	}
+	scoped.Set("user_id", userID)
+	return scoped, nil
+}
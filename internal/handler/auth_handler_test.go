@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestLogin(t *testing.T) {
+	mockService := new(mocks.AuthServiceInterface)
+	handler := NewAuthHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		expiresAt := time.Date(2025, 7, 2, 12, 0, 0, 0, time.UTC)
+		mockService.On("Login", mock.Anything, "alice@example.com", "correct horse battery").Return(domain.AccessToken{UserID: uuid.New(), Token: "signed", ExpiresAt: expiresAt}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"email":"alice@example.com","password":"correct horse battery"}`
+		handler.Login(rr, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp dto.TokenResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "signed", resp.AccessToken)
+		assert.Equal(t, "Bearer", resp.TokenType)
+		assert.Equal(t, "2025-07-02T12:00:00Z", resp.ExpiresAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Wrong Password", func(t *testing.T) {
+		mockService.On("Login", mock.Anything, "alice@example.com", "guess").Return(domain.AccessToken{}, apperrors.New(http.StatusUnauthorized, "invalid credentials", nil)).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"email":"alice@example.com","password":"guess"}`
+		handler.Login(rr, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	mockService := new(mocks.AuthServiceInterface)
+	handler := NewAuthHandler(mockService, logger.NewNopLogger())
+	var seen domain.Principal
+	var logFields []zap.Field
+	protected := handler.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = service.PrincipalFromContext(r.Context())
+		logFields = logger.FieldsFromContext(r.Context())
+	}))
+
+	t.Run("Valid Token", func(t *testing.T) {
+		principal := domain.Principal{UserID: uuid.New(), Role: domain.RoleUser}
+		mockService.On("Authenticate", "good").Return(principal, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+		req.Header.Set("Authorization", "Bearer good")
+		rr := httptest.NewRecorder()
+		protected.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, principal, seen)
+		assert.Equal(t, []zap.Field{zap.String("user_id", principal.UserID.String())}, logFields)
+	})
+
+	t.Run("Missing Token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		protected.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		mockService.On("Authenticate", "bad").Return(domain.Principal{}, apperrors.New(http.StatusUnauthorized, "invalid or expired token", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+		req.Header.Set("Authorization", "Bearer bad")
+		rr := httptest.NewRecorder()
+		protected.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestSubscriptionHandlerAuthScope(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/subscriptions", handler.ListSubscriptions)
+	router.Get("/subscriptions/{id}", handler.GetSubscription)
+	router.Delete("/subscriptions/{id}", handler.DeleteSubscription)
+	caller := uuid.New()
+	asCaller := func(req *http.Request) *http.Request {
+		return req.WithContext(service.WithPrincipal(req.Context(), domain.Principal{UserID: caller, Role: domain.RoleUser}))
+	}
+
+	t.Run("List Defaults To Caller", func(t *testing.T) {
+		mockService.On("ListSubscriptions", mock.Anything, mock.MatchedBy(func(f dto.SubscriptionFilter) bool {
+			return f.UserID == caller.String()
+		})).Return([]domain.Subscription{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, asCaller(httptest.NewRequest(http.MethodGet, "/subscriptions", nil)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("List For Another User Forbidden", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, asCaller(httptest.NewRequest(http.MethodGet, "/subscriptions?user_id="+uuid.NewString(), nil)))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("Admin Lists Every User", func(t *testing.T) {
+		mockService.On("ListSubscriptions", mock.Anything, mock.MatchedBy(func(f dto.SubscriptionFilter) bool {
+			return f.UserID == ""
+		})).Return([]domain.Subscription{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+		req = req.WithContext(service.WithPrincipal(req.Context(), domain.Principal{UserID: uuid.New(), Role: domain.RoleAdmin}))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"subtracker/internal/config"
+
+	"github.com/rs/cors"
+)
+
+// publicPrefixes and adminPrefixes assign paths to the Public and Admin CORS
+// groups; every other path belongs to the API group.
+var (
+	publicPrefixes = []string{"/swagger.json", "/schemas/"}
+	adminPrefixes  = []string{"/admin/", "/metrics", "/debug/"}
+)
+
+// corsGroups applies each route group's CORS policy. The group is picked from
+// the path before routing, because preflight OPTIONS requests match no route
+// and would never reach a group's own middleware.
+func corsGroups(cfg config.CORSConfig) func(http.Handler) http.Handler {
+	api, public, admin := newCORS(cfg.API), newCORS(cfg.Public), newCORS(cfg.Admin)
+	return func(next http.Handler) http.Handler {
+		apiNext, publicNext, adminNext := api(next), public(next), admin(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case hasAnyPrefix(r.URL.Path, adminPrefixes):
+				adminNext.ServeHTTP(w, r)
+			case hasAnyPrefix(r.URL.Path, publicPrefixes):
+				publicNext.ServeHTTP(w, r)
+			default:
+				apiNext.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// newCORS builds the middleware for one policy. A policy without origins adds
+// no CORS headers, so browsers keep other origins out.
+func newCORS(policy config.CORSPolicy) func(http.Handler) http.Handler {
+	if len(policy.AllowedOrigins) == 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins:   policy.AllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", HeaderIfMatch},
+		ExposedHeaders:   []string{"Link", "X-Undo-Token", "X-Undo-Expires-At", HeaderRequestID, HeaderETag},
+		AllowCredentials: policy.AllowCredentials,
+		MaxAge:           300,
+	}).Handler
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// StatusClientClosedRequest is the non-standard status used for requests the
+// client abandoned; nobody reads the body, but it keeps access logs and metrics honest.
+const StatusClientClosedRequest = 499
+
+func writeError(logger logger.Logger, w http.ResponseWriter, r *http.Request, err error) {
+	// Drivers report an aborted query differently, so the request context is
+	// the reliable signal that the failure was caused by the client leaving.
+	if errors.Is(r.Context().Err(), context.Canceled) {
+		logger.InfoContext(r.Context(), "Request cancelled by client",
+			zap.String("url", r.URL.Path),
+			zap.Error(err),
+		)
+		w.WriteHeader(StatusClientClosedRequest)
+		return
+	}
+
+	var appErr *apperrors.AppError
+	isAppError := errors.As(err, &appErr)
+
+	if isAppError && appErr.Code >= 400 && appErr.Code < 500 {
+		logger.WarnContext(r.Context(), "Client Error",
+			zap.Int("status_code", appErr.Code),
+			zap.String("message", appErr.Message),
+			zap.Error(err),
+			zap.String("url", r.URL.Path),
+		)
+	} else {
+		logger.ErrorContext(r.Context(), "Server Error",
+			zap.Error(err),
+			zap.String("url", r.URL.Path),
+		)
+	}
+
+	if isAppError {
+		jsonErr := response.APIError{
+			Code:      appErr.Code,
+			Message:   appErr.Message,
+			ErrorCode: appErr.ErrorCode,
+			Resource:  r.URL.Path,
+		}
+		jsonErr.Send(w)
+		return
+	}
+
+	jsonErr := response.APIError{
+		Code:     http.StatusInternalServerError,
+		Message:  "Internal Server Error",
+		Resource: r.URL.Path,
+	}
+	jsonErr.Send(w)
+}
+
+// routeMethods are the methods probed when reporting what a path does allow.
+var routeMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	response.APIError{
+		Code:     http.StatusNotFound,
+		Message:  "route not found",
+		Resource: r.URL.Path,
+	}.Send(w)
+}
+
+// methodNotAllowed answers with the standard error body and lists the methods
+// registered for the path both in the message and in the Allow header.
+func methodNotAllowed(routes chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		for _, method := range routeMethods {
+			if routes.Match(chi.NewRouteContext(), method, r.URL.Path) {
+				allowed = append(allowed, method)
+			}
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		response.APIError{
+			Code:     http.StatusMethodNotAllowed,
+			Message:  fmt.Sprintf("method %s not allowed; allowed methods: %s", r.Method, strings.Join(allowed, ", ")),
+			Resource: r.URL.Path,
+		}.Send(w)
+	}
+}
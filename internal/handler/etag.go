@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"subtracker/pkg/apperrors"
+)
+
+// Headers of optimistic concurrency on a subscription: GET serves its version
+// as an ETag, and PUT and PATCH must send it back in If-Match.
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion returns the version If-Match asks a write to apply to, zero
+// for "*". A missing header is refused with 428. A header no version can
+// match, such as a weak or malformed tag, fails the precondition with 412.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get(HeaderIfMatch))
+	if value == "" {
+		return 0, apperrors.New(http.StatusPreconditionRequired, "If-Match header is required; send the ETag from GET /subscriptions/{id}", nil)
+	}
+	if value == "*" {
+		return 0, nil
+	}
+	unquoted, ok := strings.CutPrefix(value, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if !ok || err != nil || version <= 0 {
+		return 0, apperrors.New(http.StatusPreconditionFailed, "If-Match does not match the subscription's ETag", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"subtracker/internal/config"
+)
+
+// ExportLimits keeps slow downloads from holding an export's database cursor
+// and connection open: rows are flushed in batches, and a client that does not
+// take a batch in within the flush timeout is cut off.
+type ExportLimits struct {
+	BatchRows    int
+	FlushTimeout time.Duration
+}
+
+func NewExportLimits(cfg config.AppConfig) ExportLimits {
+	return ExportLimits{BatchRows: cfg.ExportBatchRows, FlushTimeout: cfg.ExportFlushTimeout}
+}
+
+// deadlineWriter gives every write to the client at most timeout. The
+// response is buffered, so a deadline only runs out when a flush, or a full
+// buffer, waits on a client that stopped reading; the failed write then aborts
+// the export.
+type deadlineWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func newDeadlineWriter(w http.ResponseWriter, timeout time.Duration) *deadlineWriter {
+	return &deadlineWriter{w: w, rc: http.NewResponseController(w), timeout: timeout}
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	if err := d.setDeadline(time.Now().Add(d.timeout)); err != nil {
+		return 0, err
+	}
+	return d.w.Write(p)
+}
+
+// Flush sends everything buffered so far to the client.
+func (d *deadlineWriter) Flush() error {
+	if err := d.setDeadline(time.Now().Add(d.timeout)); err != nil {
+		return err
+	}
+	return d.rc.Flush()
+}
+
+// Release lifts the deadline so it does not outlive the export on a kept-alive
+// connection.
+func (d *deadlineWriter) Release() {
+	_ = d.setDeadline(time.Time{})
+}
+
+// setDeadline ignores writers that take no deadlines, such as test recorders.
+func (d *deadlineWriter) setDeadline(deadline time.Time) error {
+	if err := d.rc.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
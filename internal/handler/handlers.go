@@ -1,16 +1,71 @@
 package handler
 
 import (
+	"subtracker/internal/config"
 	"subtracker/internal/service"
 	"subtracker/pkg/logger"
 )
 
 type Handlers struct {
 	SubscriptionHandler *SubscriptionHandler
+	SavedFilterHandler  *SavedFilterHandler
+	HealthHandler       *HealthHandler
+	SyncHandler         *SyncHandler
+	ReportHandler       *ReportHandler
+	RuleHandler         *RuleHandler
+	UserHandler         *UserHandler
+	SuggestionHandler   *SuggestionHandler
+	ReminderHandler     *ReminderHandler
+	WebhookHandler      *WebhookHandler
+	PriceChangeHandler  *PriceChangeHandler
+	TrialHandler        *TrialHandler
+	PaymentHandler      *PaymentHandler
+	IntegrationHandler  *IntegrationHandler
+	SchemaHandler       *SchemaHandler
+	// AdminHandler is nil unless debug endpoints are enabled.
+	AdminHandler *AdminHandler
+	// AuthHandler is nil unless authentication is enabled.
+	AuthHandler *AuthHandler
+	// TelegramHandler is nil unless the Telegram bot is enabled.
+	TelegramHandler *TelegramHandler
+	// SeparateAdmin serves metrics and admin routes from AdminRouter only,
+	// keeping them off the public router.
+	SeparateAdmin bool
+	// ReadOnly refuses mutating requests with 503 on both routers.
+	ReadOnly bool
+	// CORS is the cross-origin policy of each route group.
+	CORS config.CORSConfig
 }
 
-func NewHandlers(service *service.Service, logger logger.Logger) *Handlers {
-	return &Handlers{
-		SubscriptionHandler: NewSubscriptionHandler(service.SubscriptionService, logger),
+func NewHandlers(service *service.Service, cfg config.AppConfig, logger logger.Logger) *Handlers {
+	handlers := &Handlers{
+		SubscriptionHandler: NewSubscriptionHandler(service.SubscriptionService, service.SavedFilterService, service.ReportService, NewListLimits(cfg), NewExportLimits(cfg), logger),
+		SavedFilterHandler:  NewSavedFilterHandler(service.SavedFilterService, logger),
+		HealthHandler:       NewHealthHandler(service.HealthService, logger),
+		SyncHandler:         NewSyncHandler(service.SyncService, NewListLimits(cfg), logger),
+		ReportHandler:       NewReportHandler(service.ReportService, logger),
+		RuleHandler:         NewRuleHandler(service.RuleService, logger),
+		UserHandler:         NewUserHandler(service.UserService, logger),
+		SuggestionHandler:   NewSuggestionHandler(service.SuggestionService, logger),
+		ReminderHandler:     NewReminderHandler(service.ReminderService, logger),
+		WebhookHandler:      NewWebhookHandler(service.WebhookService, NewListLimits(cfg), logger),
+		PriceChangeHandler:  NewPriceChangeHandler(service.PriceChangeService, logger),
+		TrialHandler:        NewTrialHandler(service.TrialService, logger),
+		PaymentHandler:      NewPaymentHandler(service.PaymentService, logger),
+		IntegrationHandler:  NewIntegrationHandler(service.IntegrationService, logger),
+		SchemaHandler:       NewSchemaHandler(logger),
+		SeparateAdmin:       cfg.AdminListenerEnabled(),
+		ReadOnly:            cfg.ReadOnly,
+		CORS:                cfg.CORS,
 	}
+	if cfg.DebugEndpoints {
+		handlers.AdminHandler = NewAdminHandler(service.IntegrityService, service.HealthService, service.FeatureFlagService, logger)
+	}
+	if cfg.AuthEnabled() {
+		handlers.AuthHandler = NewAuthHandler(service.AuthService, logger)
+	}
+	if service.TelegramService != nil {
+		handlers.TelegramHandler = NewTelegramHandler(service.TelegramService, logger)
+	}
+	return handlers
 }
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+
+	"go.uber.org/zap"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	service service.HealthServiceInterface
+	logger  logger.Logger
+}
+
+func NewHealthHandler(service service.HealthServiceInterface, logger logger.Logger) *HealthHandler {
+	return &HealthHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// @Summary      Liveness probe
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  response.APIResponse
+// @Router       /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	response.APIResponse{Code: http.StatusOK, Message: "alive"}.Send(w)
+}
+
+// @Summary      Readiness probe
+// @Description  Reports ready only once the database is reachable and all migrations are applied.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  response.APIResponse
+// @Failure      503  {object}  response.APIError "Not ready, message lists failing checks"
+// @Router       /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	if failures := h.service.Readiness(ctx); len(failures) > 0 {
+		h.logger.WarnContext(r.Context(), "Readiness check failed", zap.Strings("failures", failures))
+		response.APIError{
+			Code:     http.StatusServiceUnavailable,
+			Message:  strings.Join(failures, "; "),
+			Resource: r.URL.Path,
+		}.Send(w)
+		return
+	}
+
+	response.APIResponse{Code: http.StatusOK, Message: "ready"}.Send(w)
+}
+
+// @Summary      Instance status
+// @Description  Reports the version and commit this instance runs, how long it has been up and the health of the
+// @Description  dependencies readiness checks. It answers 200 even when a dependency fails; `status` is then degraded.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  dto.StatusResponse
+// @Router       /status [get]
+func (h *HealthHandler) Status(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	response.JSON(w, http.StatusOK, mapper.ToStatusResponse(h.service.Status(ctx)))
+}
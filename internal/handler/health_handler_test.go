@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadiness(t *testing.T) {
+	mockService := new(mocks.HealthServiceInterface)
+	handler := NewHealthHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Ready", func(t *testing.T) {
+		mockService.On("Readiness", mock.Anything).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Not Ready", func(t *testing.T) {
+		mockService.On("Readiness", mock.Anything).Return([]string{"migrations: at version 1, expected 2"}).Once()
+
+		rr := httptest.NewRecorder()
+		handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), "expected 2")
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestStatus(t *testing.T) {
+	mockService := new(mocks.HealthServiceInterface)
+	handler := NewHealthHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Degraded Still Answers", func(t *testing.T) {
+		mockService.On("Status", mock.Anything).Return(domain.SystemStatus{
+			Build:        domain.BuildInfo{Version: "v1.4.0", Commit: "9bcb46f"},
+			StartedAt:    time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC),
+			Uptime:       90 * time.Minute,
+			Dependencies: []domain.DependencyHealth{{Name: "database"}, {Name: "migrations", Problem: "version 2 is dirty"}},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		handler.Status(rr, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body dto.StatusResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, dto.StatusResponse{
+			Status:        "degraded",
+			Version:       "v1.4.0",
+			Commit:        "9bcb46f",
+			StartedAt:     "2026-10-15T08:00:00Z",
+			UptimeSeconds: 5400,
+			Dependencies: []dto.DependencyStatusResponse{
+				{Name: "database", Status: "ok"},
+				{Name: "migrations", Status: "failing", Problem: "version 2 is dirty"},
+			},
+		}, body)
+		mockService.AssertExpectations(t)
+	})
+}
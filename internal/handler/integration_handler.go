@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+)
+
+type IntegrationHandler struct {
+	service service.IntegrationServiceInterface
+	logger  logger.Logger
+}
+
+func NewIntegrationHandler(service service.IntegrationServiceInterface, logger logger.Logger) *IntegrationHandler {
+	return &IntegrationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *IntegrationHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Acknowledge Message
+// @Description  Records that a consumer handled a message, typically a webhook delivery identified by its
+// @Description  X-Subtracker-Delivery header. Deliveries are retried until answered with 2xx, so the same one
+// @Description  can arrive twice; a consumer that acks before acting and skips messages reported as duplicate
+// @Description  handles each exactly once. The first ack answers 201, any later one 200 with duplicate set.
+// @Tags         Integrations
+// @Accept       json
+// @Produce      json
+// @Param        ack  body      dto.AckMessageRequest true "Consumer and message"
+// @Success      201  {object}  dto.AckMessageResponse "First ack"
+// @Success      200  {object}  dto.AckMessageResponse "Message was acked before"
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /integrations/ack [post]
+func (h *IntegrationHandler) AckMessage(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "AckMessage request received")
+
+	var req dto.AckMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+	ack, err := mapper.ToConsumerAckFromDTO(req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid user ID format", err))
+		return
+	}
+
+	ack, err = h.service.AckMessage(r.Context(), ack)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	status := http.StatusCreated
+	if ack.Duplicate {
+		status = http.StatusOK
+	}
+	response.JSON(w, status, mapper.ToAckMessageResponse(ack))
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIntegrationHandler(t *testing.T) {
+	mockService := new(mocks.IntegrationServiceInterface)
+	handler := NewIntegrationHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Post("/integrations/ack", handler.AckMessage)
+	userID := uuid.New()
+	ack := domain.ConsumerAck{UserID: userID, Consumer: "billing-sync", MessageID: "m1"}
+	ackedAt := time.Date(2025, 7, 1, 10, 2, 31, 0, time.UTC)
+	body := `{"user_id":"` + userID.String() + `","consumer":"billing-sync","message_id":"m1"}`
+	serve := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/integrations/ack", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("First Ack", func(t *testing.T) {
+		stored := ack
+		stored.AckedAt = ackedAt
+		mockService.On("AckMessage", mock.Anything, ack).Return(stored, nil).Once()
+
+		rr := serve(body)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var respBody dto.AckMessageResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.False(t, respBody.Duplicate)
+		assert.Equal(t, "2025-07-01T10:02:31Z", respBody.AckedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate Ack", func(t *testing.T) {
+		stored := ack
+		stored.AckedAt = ackedAt
+		stored.Duplicate = true
+		mockService.On("AckMessage", mock.Anything, ack).Return(stored, nil).Once()
+
+		rr := serve(body)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.AckMessageResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.True(t, respBody.Duplicate)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Missing Message ID", func(t *testing.T) {
+		rr := serve(`{"user_id":"` + userID.String() + `","consumer":"billing-sync"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
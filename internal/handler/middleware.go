@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"subtracker/internal/metrics"
+	"subtracker/internal/service"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// HeaderRequestID carries the ID that ties a request to its log lines.
+const HeaderRequestID = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// requestID keeps the caller's X-Request-ID, or generates one when it is missing
+// or malformed, echoes it on the response and stores it in the request context so
+// every line logged for the request carries it.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(HeaderRequestID, id)
+		ctx := logger.ContextWith(r.Context(), zap.String("request_id", id))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// validRequestID accepts IDs short enough and plain enough to log verbatim.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// observeLatency records request duration per chi route pattern. When the request
+// carries a W3C traceparent header its trace ID is attached as an exemplar.
+// Requests whose client went away are also counted separately from errors.
+func observeLatency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		if errors.Is(r.Context().Err(), context.Canceled) {
+			metrics.HTTPRequestsCancelled.WithLabelValues(r.Method, route).Inc()
+		}
+
+		observer := metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, strconv.Itoa(status))
+		elapsed := time.Since(start).Seconds()
+		if traceID := traceIDFromHeader(r.Header.Get("traceparent")); traceID != "" {
+			if eo, ok := observer.(prometheus.ExemplarObserver); ok {
+				eo.ObserveWithExemplar(elapsed, prometheus.Labels{"trace_id": traceID})
+				return
+			}
+		}
+		observer.Observe(elapsed)
+	})
+}
+
+// traceIDFromHeader extracts the trace ID from a "version-traceid-spanid-flags" header.
+func traceIDFromHeader(traceparent string) string {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	return parts[1]
+}
+
+// RequireDatabase answers 503 on every route except health, status and metrics
+// probes until ready reports that the database has been reached.
+func RequireDatabase(ready func() bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/healthz", "/readyz", "/status", "/metrics":
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !ready() {
+				w.Header().Set("Retry-After", "5")
+				response.APIError{
+					Code:     http.StatusServiceUnavailable,
+					Message:  "database is not available yet",
+					Resource: r.URL.Path,
+				}.Send(w)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ErrCodeReadOnly marks requests refused because the server runs read-only.
+const ErrCodeReadOnly = "read_only"
+
+// readOnlyExempt lists POST routes that do not write and so keep working in
+// read-only mode.
+var readOnlyExempt = map[string]bool{
+	"/auth/login":               true,
+	"/subscriptions/cost/batch": true,
+	"/subscriptions/lookup":     true,
+}
+
+// ReadOnly answers 503 to every request that could write, leaving GET, HEAD
+// and OPTIONS requests and the routes in readOnlyExempt untouched.
+func ReadOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method == http.MethodPost && readOnlyExempt[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		response.APIError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   "server is in read-only mode",
+			ErrorCode: ErrCodeReadOnly,
+			Resource:  r.URL.Path,
+		}.Send(w)
+	})
+}
+
+// requireAdmin answers 403 unless Authenticate stored an admin principal.
+// Unlike the services, it does not trust requests without one: it guards
+// routes on the public router, where no principal means auth is disabled.
+func requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal, ok := service.PrincipalFromContext(r.Context()); !ok || !principal.IsAdmin() {
+			response.APIError{
+				Code:     http.StatusForbidden,
+				Message:  "this route requires an admin",
+				Resource: r.URL.Path,
+			}.Send(w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireBearerToken answers 401 unless the request carries token as its
+// bearer token. The comparison takes constant time.
+func requireBearerToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				response.APIError{
+					Code:     http.StatusUnauthorized,
+					Message:  "missing or invalid admin token",
+					Resource: r.URL.Path,
+				}.Send(w)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"subtracker/internal/metrics"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveLatency(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(observeLatency)
+	router.Get("/probe/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/probe/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+
+	var count uint64
+	for _, family := range families {
+		if family.GetName() != "subtracker_http_request_duration_seconds" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["route"] == "/probe/{id}" && labels["status"] == "418" {
+				count += m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestTraceIDFromHeader(t *testing.T) {
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceIDFromHeader("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+	assert.Empty(t, traceIDFromHeader("garbage"))
+	assert.Empty(t, traceIDFromHeader(""))
+}
+
+func TestRequestID(t *testing.T) {
+	var logged string
+	handler := requestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, f := range logger.FieldsFromContext(r.Context()) {
+			if f.Key == "request_id" {
+				logged = f.String
+			}
+		}
+	}))
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "kept", header: "req-42.a:b_c", keep: true},
+		{name: "missing"},
+		{name: "malformed", header: "bad id\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderRequestID, tt.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			id := rr.Header().Get(HeaderRequestID)
+			assert.Equal(t, id, logged)
+			if tt.keep {
+				assert.Equal(t, tt.header, id)
+				return
+			}
+			_, err := uuid.Parse(id)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRequireDatabase(t *testing.T) {
+	ready := false
+	router := chi.NewRouter()
+	router.Use(RequireDatabase(func() bool { return ready }))
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	router.Get("/subscriptions", ok)
+	router.Get("/readyz", ok)
+	router.Get("/status", ok)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/status", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	ready = true
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestReadOnly(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(ReadOnly)
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	router.Get("/subscriptions", ok)
+	router.Post("/subscriptions", ok)
+	router.Delete("/subscriptions/{id}", ok)
+	router.Post("/subscriptions/cost/batch", ok)
+	router.Post("/subscriptions/lookup", ok)
+
+	for _, tc := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/subscriptions", http.StatusOK},
+		{http.MethodPost, "/subscriptions/cost/batch", http.StatusOK},
+		{http.MethodPost, "/subscriptions/lookup", http.StatusOK},
+		{http.MethodPost, "/subscriptions", http.StatusServiceUnavailable},
+		{http.MethodDelete, "/subscriptions/d290f1ee-6c54-4b01-90e6-d701748f0851", http.StatusServiceUnavailable},
+	} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.want, rr.Code, "%s %s", tc.method, tc.path)
+		if tc.want == http.StatusServiceUnavailable {
+			assert.Contains(t, rr.Body.String(), ErrCodeReadOnly)
+		}
+	}
+}
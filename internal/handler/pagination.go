@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"fmt"
+
+	"subtracker/internal/config"
+	"subtracker/internal/domain/dto"
+	"subtracker/pkg/apperrors"
+)
+
+// ListLimits bounds the page size of list endpoints.
+type ListLimits struct {
+	Default int
+	Max     int
+}
+
+func NewListLimits(cfg config.AppConfig) ListLimits {
+	return ListLimits{Default: cfg.ListDefaultLimit, Max: cfg.ListMaxLimit}
+}
+
+// apply returns the limit to use for a list request. A missing or zero limit
+// falls back to the default; anything above the maximum is rejected.
+func (l ListLimits) apply(limit int) (int, error) {
+	if limit > l.Max {
+		return 0, apperrors.NewBadRequest(fmt.Sprintf("limit must not exceed %d", l.Max), nil)
+	}
+	if limit <= 0 {
+		return l.Default, nil
+	}
+	return limit, nil
+}
+
+// countedPage describes a page of listed items out of a list request. count
+// is only asked for the total when the page cannot tell it: a full page may
+// have more after it, and an empty page past the first may have overshot.
+func countedPage(limit, offset, listed int, count func() (int, error)) (dto.CountedPagination, error) {
+	total := offset + listed
+	if listed >= limit || (listed == 0 && offset > 0) {
+		var err error
+		if total, err = count(); err != nil {
+			return dto.CountedPagination{}, err
+		}
+	}
+	return dto.CountedPagination{
+		Pagination: dto.Pagination{Limit: limit, Offset: offset},
+		Total:      total,
+		HasMore:    offset+listed < total,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type PaymentHandler struct {
+	service service.PaymentServiceInterface
+	logger  logger.Logger
+}
+
+func NewPaymentHandler(service service.PaymentServiceInterface, logger logger.Logger) *PaymentHandler {
+	return &PaymentHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *PaymentHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Get Payment Status
+// @Description  Returns whether the subscription is past due, i.e. has a failed charge that no retry has paid yet,
+// @Description  with its failed charges by month.
+// @Tags         Payments
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID (UUID format)"
+// @Success      200  {object}  dto.PaymentHistoryResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/payments [get]
+func (h *PaymentHandler) GetPaymentHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "GetPaymentHistory request received", zap.String("subscription_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+
+	history, err := h.service.GetPaymentHistory(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToPaymentHistoryResponse(history))
+}
+
+// @Summary      Mark Payment Failed
+// @Description  Records that the charge for a month failed, which makes the subscription past due until a retry
+// @Description  succeeds. The month may not be a future one and must fall within the subscription's term.
+// @Description  Marking a month again keeps its retries; marking a paid month reopens it.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string                    true  "Subscription ID (UUID format)"
+// @Param        request  body  dto.PaymentFailedRequest  true  "Month whose charge failed"
+// @Success      201  {object}  dto.PaymentFailureResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format, request body or fields, or the month is in the future or outside the term"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/payments/failed [post]
+func (h *PaymentHandler) RecordFailure(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "RecordFailure request received", zap.String("subscription_id", id))
+
+	subscriptionID, err := uuid.Parse(id)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+	var req dto.PaymentFailedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+	failure, err := mapper.ToPaymentFailureFromDTO(subscriptionID, req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("failed to parse date", err))
+		return
+	}
+
+	failure, err = h.service.RecordFailure(r.Context(), failure)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, mapper.ToPaymentFailureResponse(failure))
+}
+
+// @Summary      Mark Payment Retried
+// @Description  Records a retry of a month's unpaid charge. A successful retry pays it, and the subscription is no
+// @Description  longer past due once no unpaid charge is left.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string                     true  "Subscription ID (UUID format)"
+// @Param        request  body  dto.PaymentRetriedRequest  true  "Month retried and whether the retry went through"
+// @Success      200  {object}  dto.PaymentFailureResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format, request body or fields"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found, or no unpaid charge for the month"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/payments/retried [post]
+func (h *PaymentHandler) RecordRetry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "RecordRetry request received", zap.String("subscription_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+	var req dto.PaymentRetriedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+	period, err := time.Parse("01-2006", req.Period)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("failed to parse date", err))
+		return
+	}
+
+	failure, err := h.service.RecordRetry(r.Context(), id, period, *req.Succeeded)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToPaymentFailureResponse(failure))
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPaymentHandler(t *testing.T) {
+	mockService := new(mocks.PaymentServiceInterface)
+	handler := NewPaymentHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/subscriptions/{id}/payments", handler.GetPaymentHistory)
+	router.Post("/subscriptions/{id}/payments/failed", handler.RecordFailure)
+	router.Post("/subscriptions/{id}/payments/retried", handler.RecordRetry)
+	subID := uuid.New()
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	failedAt := time.Date(2026, 1, 3, 8, 0, 0, 0, time.UTC)
+
+	t.Run("Mark Failed", func(t *testing.T) {
+		mockService.On("RecordFailure", mock.Anything, domain.PaymentFailure{SubscriptionID: subID, Period: january}).
+			Return(domain.PaymentFailure{SubscriptionID: subID, Period: january, FailedAt: failedAt}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+subID.String()+"/payments/failed", bytes.NewBufferString(`{"period":"01-2026"}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var respBody dto.PaymentFailureResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, "01-2026", respBody.Period)
+		assert.Equal(t, "unpaid", respBody.Status)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Mark Retried", func(t *testing.T) {
+		paidAt := failedAt.AddDate(0, 0, 3)
+		mockService.On("RecordRetry", mock.Anything, subID.String(), january, true).
+			Return(domain.PaymentFailure{SubscriptionID: subID, Period: january, FailedAt: failedAt, Retries: 1, LastRetryAt: &paidAt, PaidAt: &paidAt}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+subID.String()+"/payments/retried", bytes.NewBufferString(`{"period":"01-2026","succeeded":true}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.PaymentFailureResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, "paid", respBody.Status)
+		assert.Equal(t, 1, respBody.Retries)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Retry Outcome Is Required", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+subID.String()+"/payments/retried", bytes.NewBufferString(`{"period":"01-2026"}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("No Unpaid Charge", func(t *testing.T) {
+		mockService.On("RecordRetry", mock.Anything, subID.String(), january, false).
+			Return(domain.PaymentFailure{}, apperrors.NewNotFound("no unpaid charge for this month", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+subID.String()+"/payments/retried", bytes.NewBufferString(`{"period":"01-2026","succeeded":false}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		mockService.On("GetPaymentHistory", mock.Anything, subID.String()).
+			Return(domain.PaymentHistory{SubscriptionID: subID, Failures: []domain.PaymentFailure{{SubscriptionID: subID, Period: january, FailedAt: failedAt}}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+subID.String()+"/payments", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.PaymentHistoryResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, "past_due", respBody.Status)
+		assert.Len(t, respBody.Failures, 1)
+		mockService.AssertExpectations(t)
+	})
+}
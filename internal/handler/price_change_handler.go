@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type PriceChangeHandler struct {
+	service service.PriceChangeServiceInterface
+	logger  logger.Logger
+}
+
+func NewPriceChangeHandler(service service.PriceChangeServiceInterface, logger logger.Logger) *PriceChangeHandler {
+	return &PriceChangeHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *PriceChangeHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Schedule Price Change
+// @Description  Schedules the subscription's price to change from the first day of a future month within its
+// @Description  term, e.g. for an announced price hike. Cost forecasts use the new price right away; the stored
+// @Description  price is updated once the month begins. Scheduling a month again replaces its price.
+// @Tags         Price Changes
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string                          true  "Subscription ID (UUID format)"
+// @Param        request  body  dto.SchedulePriceChangeRequest  true  "New price and the month it takes effect"
+// @Success      201  {object}  dto.PriceChangeResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format, request body or fields, or the month is not a future one within the term"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/price-changes [post]
+func (h *PriceChangeHandler) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "SchedulePriceChange request received", zap.String("subscription_id", id))
+
+	subscriptionID, err := uuid.Parse(id)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+	var req dto.SchedulePriceChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+	change, err := mapper.ToPriceChangeFromDTO(subscriptionID, req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("failed to parse date", err))
+		return
+	}
+
+	change, err = h.service.SchedulePriceChange(r.Context(), change)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, mapper.ToPriceChangeResponse(change))
+}
+
+// @Summary      List Price Changes
+// @Description  Returns the subscription's scheduled price changes, pending and applied, by effective month.
+// @Tags         Price Changes
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID (UUID format)"
+// @Success      200  {array}   dto.PriceChangeResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/price-changes [get]
+func (h *PriceChangeHandler) ListPriceChanges(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "ListPriceChanges request received", zap.String("subscription_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+
+	changes, err := h.service.ListPriceChanges(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	result := make([]dto.PriceChangeResponse, len(changes))
+	for i, change := range changes {
+		result[i] = mapper.ToPriceChangeResponse(change)
+	}
+	response.JSON(w, http.StatusOK, result)
+}
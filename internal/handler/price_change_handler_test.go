@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPriceChangeHandler(t *testing.T) {
+	mockService := new(mocks.PriceChangeServiceInterface)
+	handler := NewPriceChangeHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/subscriptions/{id}/price-changes", handler.ListPriceChanges)
+	router.Post("/subscriptions/{id}/price-changes", handler.SchedulePriceChange)
+	subID := uuid.New()
+	effective := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Schedule", func(t *testing.T) {
+		changeID := uuid.New()
+		mockService.On("SchedulePriceChange", mock.Anything, domain.PriceChange{SubscriptionID: subID, Price: 1299, EffectiveDate: effective}).
+			Return(domain.PriceChange{ID: changeID, SubscriptionID: subID, Price: 1299, EffectiveDate: effective, CreatedAt: time.Now()}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+subID.String()+"/price-changes", bytes.NewBufferString(`{"price":1299,"effective_date":"03-2026"}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var respBody dto.PriceChangeResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, changeID.String(), respBody.ID)
+		assert.Equal(t, "03-2026", respBody.EffectiveDate)
+		assert.Equal(t, "pending", respBody.Status)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Effective Date Is Required", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/"+subID.String()+"/price-changes", bytes.NewBufferString(`{"price":1299}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		applied := effective.Add(5 * time.Minute)
+		mockService.On("ListPriceChanges", mock.Anything, subID.String()).
+			Return([]domain.PriceChange{{ID: uuid.New(), SubscriptionID: subID, Price: 1299, EffectiveDate: effective, AppliedAt: &applied}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+subID.String()+"/price-changes", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody []dto.PriceChangeResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Len(t, respBody, 1)
+		assert.Equal(t, "applied", respBody[0].Status)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ReminderHandler struct {
+	service service.ReminderServiceInterface
+	logger  logger.Logger
+}
+
+func NewReminderHandler(service service.ReminderServiceInterface, logger logger.Logger) *ReminderHandler {
+	return &ReminderHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ReminderHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Get Renewal Reminder
+// @Description  Returns when the owner is emailed ahead of the subscription's next renewal. Subscriptions that
+// @Description  never changed the setting are reminded, at the server's default offset.
+// @Tags         Reminders
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID (UUID format)"
+// @Success      200  {object}  dto.ReminderSettingResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/reminder [get]
+func (h *ReminderHandler) GetReminder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "GetReminder request received", zap.String("subscription_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+
+	setting, err := h.service.GetReminder(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToReminderSettingResponse(setting))
+}
+
+// @Summary      Set Renewal Reminder
+// @Description  Turns the renewal reminder email on or off and sets how many days ahead of a renewal it is
+// @Description  sent. Omit days_before to follow the server default.
+// @Tags         Reminders
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string                  true  "Subscription ID (UUID format)"
+// @Param        request  body  dto.SetReminderRequest  true  "Reminder setting"
+// @Success      200  {object}  dto.ReminderSettingResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format, request body or fields"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/reminder [put]
+func (h *ReminderHandler) SetReminder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "SetReminder request received", zap.String("subscription_id", id))
+
+	subscriptionID, err := uuid.Parse(id)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+	var req dto.SetReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	setting, err := h.service.SetReminder(r.Context(), domain.ReminderSetting{
+		SubscriptionID: subscriptionID,
+		Enabled:        *req.Enabled,
+		DaysBefore:     req.DaysBefore,
+	})
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToReminderSettingResponse(setting))
+}
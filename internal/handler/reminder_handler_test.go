@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReminderHandler(t *testing.T) {
+	mockService := new(mocks.ReminderServiceInterface)
+	handler := NewReminderHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/subscriptions/{id}/reminder", handler.GetReminder)
+	router.Put("/subscriptions/{id}/reminder", handler.SetReminder)
+	subID := uuid.New()
+
+	t.Run("Get Default", func(t *testing.T) {
+		mockService.On("GetReminder", mock.Anything, subID.String()).
+			Return(domain.ReminderSetting{SubscriptionID: subID, Enabled: true, EffectiveDaysBefore: 3}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+subID.String()+"/reminder", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"subscription_id":"`+subID.String()+`","enabled":true,"days_before":null,"effective_days_before":3}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Set Own Offset", func(t *testing.T) {
+		days := 7
+		mockService.On("SetReminder", mock.Anything, domain.ReminderSetting{SubscriptionID: subID, Enabled: true, DaysBefore: &days}).
+			Return(domain.ReminderSetting{SubscriptionID: subID, Enabled: true, DaysBefore: &days, EffectiveDaysBefore: 7}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+subID.String()+"/reminder", bytes.NewBufferString(`{"enabled":true,"days_before":7}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.ReminderSettingResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, 7, respBody.EffectiveDaysBefore)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Offset Out Of Range", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+subID.String()+"/reminder", bytes.NewBufferString(`{"enabled":true,"days_before":90}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Enabled Is Required", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+subID.String()+"/reminder", bytes.NewBufferString(`{"days_before":7}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
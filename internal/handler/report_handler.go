@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/binder"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	service service.ReportServiceInterface
+	logger  logger.Logger
+}
+
+func NewReportHandler(service service.ReportServiceInterface, logger logger.Logger) *ReportHandler {
+	return &ReportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ReportHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Savings Report
+// @Description  Summarizes the money a user saves by having cancelled subscriptions: every subscription whose
+// @Description  end date falls in the period counts, with its monthly price as the monthly saving and twelve
+// @Description  times that as the projected annual saving.
+// @Tags         Reports
+// @Produce      json
+// @Param        user_id query     string  true  "User ID (UUID)"
+// @Param        period  query     string  true  "A month (MM-YYYY) or a whole year (YYYY)"
+// @Success      200     {object}  dto.SavingsReportResponse
+// @Failure      400     {object}  apperrors.AppError "Invalid query parameters"
+// @Failure      403     {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500     {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /reports/savings [get]
+func (h *ReportHandler) Savings(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "Savings request received", zap.String("query", r.URL.RawQuery))
+
+	var req dto.SavingsRequest
+	if err := binder.BindQuery(r.URL.Query(), &req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+	periodStart, periodEnd, err := parsePeriod(req.Period)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("period must be MM-YYYY or YYYY", err))
+		return
+	}
+
+	report, err := h.service.Savings(r.Context(), req.UserID, periodStart, periodEnd)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Savings report completed successfully", zap.Int("cancelled", len(report.Cancelled)))
+
+	response.JSON(w, http.StatusOK, mapper.ToSavingsReportResponse(report))
+}
+
+// parsePeriod turns a month (MM-YYYY) or a year (YYYY) into its first and last
+// month, matching how subscription dates are stored.
+func parsePeriod(period string) (time.Time, time.Time, error) {
+	if month, err := time.Parse("01-2006", period); err == nil {
+		return month, month, nil
+	}
+	year, err := time.Parse("2006", period)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("parse period %q: %w", period, err)
+	}
+	return year, year.AddDate(0, 11, 0), nil
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSavings(t *testing.T) {
+	mockService := new(mocks.ReportServiceInterface)
+	handler := NewReportHandler(mockService, logger.NewNopLogger())
+	userID := uuid.New().String()
+
+	t.Run("Whole Year", func(t *testing.T) {
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+		report := domain.SavingsReport{
+			PeriodStart:            from,
+			PeriodEnd:              to,
+			Cancelled:              []domain.Subscription{{ID: uuid.New(), ServiceName: "Netflix", Price: 999, StartDate: from}},
+			MonthlySavings:         999,
+			ProjectedAnnualSavings: 11988,
+		}
+		mockService.On("Savings", mock.Anything, userID, from, to).Return(report, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.Savings(rr, httptest.NewRequest(http.MethodGet, "/reports/savings?user_id="+userID+"&period=2025", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body dto.SavingsReportResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "01-2025", body.PeriodStart)
+		assert.Equal(t, "12-2025", body.PeriodEnd)
+		assert.Equal(t, 1, body.CancelledCount)
+		assert.Equal(t, 11988, body.ProjectedAnnualSavings)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Single Month", func(t *testing.T) {
+		month := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockService.On("Savings", mock.Anything, userID, month, month).Return(domain.SavingsReport{PeriodStart: month, PeriodEnd: month}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.Savings(rr, httptest.NewRequest(http.MethodGet, "/reports/savings?user_id="+userID+"&period=03-2025", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Period", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.Savings(rr, httptest.NewRequest(http.MethodGet, "/reports/savings?user_id="+userID+"&period=Q1-2025", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "Savings")
+	})
+}
@@ -3,31 +3,157 @@ package handler
 import (
 	"net/http"
 
+	"subtracker/internal/metrics"
+
 	"github.com/go-chi/chi/v5"
-	"github.com/rs/cors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func Router(handlers Handlers) http.Handler {
+// Router wires the public API; extra middlewares run after request ID, CORS
+// and latency metrics. Each route group gets its CORS policy from
+// handlers.CORS. Metrics and admin routes are served here too unless
+// handlers.SeparateAdmin moves them to AdminRouter. Served here, feature flag
+// changes need an admin's bearer token.
+func Router(handlers Handlers, middlewares ...func(http.Handler) http.Handler) *chi.Mux {
 	r := chi.NewRouter()
 
-	corsMiddleware := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
+	r.Use(requestID)
+	r.Use(corsGroups(handlers.CORS))
+	r.Use(observeLatency)
+	r.Use(middlewares...)
+	if handlers.ReadOnly {
+		r.Use(ReadOnly)
+	}
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed(r))
+
+	r.Group(func(r chi.Router) {
+		if handlers.AuthHandler != nil {
+			r.Use(handlers.AuthHandler.Authenticate)
+		}
+		r.Post("/subscriptions", handlers.SubscriptionHandler.CreateSubscription)
+		r.Get("/subscriptions", handlers.SubscriptionHandler.ListSubscriptions)
+		r.Get("/subscriptions/export", handlers.SubscriptionHandler.ExportSubscriptions)
+		r.Post("/subscriptions/batch", handlers.SubscriptionHandler.CreateSubscriptionsBatch)
+		r.Post("/subscriptions/lookup", handlers.SubscriptionHandler.LookupSubscriptions)
+		r.Get("/subscriptions/{id}", handlers.SubscriptionHandler.GetSubscription)
+		r.Put("/subscriptions/{id}", handlers.SubscriptionHandler.UpdateSubscription)
+		r.Patch("/subscriptions/{id}", handlers.SubscriptionHandler.PatchSubscription)
+		r.Delete("/subscriptions/{id}", handlers.SubscriptionHandler.DeleteSubscription)
+		r.Get("/subscriptions/{id}/reminder", handlers.ReminderHandler.GetReminder)
+		r.Put("/subscriptions/{id}/reminder", handlers.ReminderHandler.SetReminder)
+		r.Get("/subscriptions/{id}/price-changes", handlers.PriceChangeHandler.ListPriceChanges)
+		r.Post("/subscriptions/{id}/price-changes", handlers.PriceChangeHandler.SchedulePriceChange)
+		r.Get("/subscriptions/{id}/trial", handlers.TrialHandler.GetTrial)
+		r.Put("/subscriptions/{id}/trial", handlers.TrialHandler.SetTrial)
+		r.Get("/subscriptions/{id}/payments", handlers.PaymentHandler.GetPaymentHistory)
+		r.Post("/subscriptions/{id}/payments/failed", handlers.PaymentHandler.RecordFailure)
+		r.Post("/subscriptions/{id}/payments/retried", handlers.PaymentHandler.RecordRetry)
+		r.Get("/subscriptions/{id}/history", handlers.SubscriptionHandler.SubscriptionHistory)
+		r.Get("/subscriptions/trash", handlers.SubscriptionHandler.ListTrash)
+		r.Post("/subscriptions/trash/restore", handlers.SubscriptionHandler.RestoreSubscriptions)
+		r.Post("/undo/{token}", handlers.SubscriptionHandler.UndoDelete)
+		r.Get("/subscriptions/cost", handlers.SubscriptionHandler.CalculateCost)
+		r.Post("/subscriptions/cost/batch", handlers.SubscriptionHandler.CalculateCostBatch)
+		r.Get("/subscriptions/cost/by-cost-center", handlers.SubscriptionHandler.CostByCostCenter)
+		r.Get("/users/{id}", handlers.UserHandler.GetUser)
+		r.Put("/users/{id}/password", handlers.UserHandler.ChangePassword)
+		if handlers.TelegramHandler != nil {
+			r.Post("/users/{id}/telegram/link-code", handlers.TelegramHandler.CreateLinkCode)
+			r.Get("/users/{id}/telegram", handlers.TelegramHandler.GetLink)
+			r.Delete("/users/{id}/telegram", handlers.TelegramHandler.Unlink)
+		}
+		r.Post("/webhooks", handlers.WebhookHandler.CreateWebhook)
+		r.Get("/webhooks", handlers.WebhookHandler.ListWebhooks)
+		r.Get("/webhooks/{id}", handlers.WebhookHandler.GetWebhook)
+		r.Patch("/webhooks/{id}", handlers.WebhookHandler.UpdateWebhook)
+		r.Delete("/webhooks/{id}", handlers.WebhookHandler.DeleteWebhook)
+		r.Get("/webhooks/{id}/deliveries", handlers.WebhookHandler.ListDeliveries)
+		r.Post("/webhooks/{id}/secret", handlers.WebhookHandler.RotateSecret)
+		r.Get("/webhooks/{id}/stats", handlers.WebhookHandler.DeliveryStats)
+		r.Post("/integrations/ack", handlers.IntegrationHandler.AckMessage)
+		r.Post("/suggestions", handlers.SuggestionHandler.SubmitSuggestion)
+		r.Get("/suggestions", handlers.SuggestionHandler.ListSuggestions)
+		r.Post("/suggestions/{id}/accept", handlers.SuggestionHandler.AcceptSuggestion)
+		r.Post("/suggestions/{id}/reject", handlers.SuggestionHandler.RejectSuggestion)
+		r.Post("/suggestions/{id}/merge", handlers.SuggestionHandler.MergeSuggestion)
+
+		r.Post("/saved-filters", handlers.SavedFilterHandler.CreateSavedFilter)
+		r.Get("/saved-filters", handlers.SavedFilterHandler.ListSavedFilters)
+		r.Get("/saved-filters/{id}", handlers.SavedFilterHandler.GetSavedFilter)
+		r.Put("/saved-filters/{id}", handlers.SavedFilterHandler.UpdateSavedFilter)
+		r.Delete("/saved-filters/{id}", handlers.SavedFilterHandler.DeleteSavedFilter)
+
+		r.Post("/rules", handlers.RuleHandler.CreateRule)
+		r.Get("/rules", handlers.RuleHandler.ListRules)
+		r.Delete("/rules/{id}", handlers.RuleHandler.DeleteRule)
+		r.Post("/rules/apply", handlers.RuleHandler.ApplyRules)
+
+		r.Get("/reports/savings", handlers.ReportHandler.Savings)
+
+		r.Get("/sync", handlers.SyncHandler.PullChanges)
+		r.Post("/sync", handlers.SyncHandler.PushChanges)
 	})
-	r.Use(corsMiddleware.Handler)
+	r.Post("/users", handlers.UserHandler.Register)
+	if handlers.AuthHandler != nil {
+		r.Post("/auth/login", handlers.AuthHandler.Login)
+	}
+
+	r.Get("/healthz", handlers.HealthHandler.Liveness)
+	r.Get("/readyz", handlers.HealthHandler.Readiness)
+	r.Get("/status", handlers.HealthHandler.Status)
 
-	r.Post("/subscriptions", handlers.SubscriptionHandler.CreateSubscription)
-	r.Get("/subscriptions", handlers.SubscriptionHandler.ListSubscriptions)
-	r.Get("/subscriptions/{id}", handlers.SubscriptionHandler.GetSubscription)
-	r.Put("/subscriptions/{id}", handlers.SubscriptionHandler.UpdateSubscription)
-	r.Delete("/subscriptions/{id}", handlers.SubscriptionHandler.DeleteSubscription)
-	r.Get("/subscriptions/cost", handlers.SubscriptionHandler.CalculateCost)
+	if !handlers.SeparateAdmin {
+		adminWrites := []func(http.Handler) http.Handler{requireAdmin}
+		if handlers.AuthHandler != nil {
+			adminWrites = []func(http.Handler) http.Handler{handlers.AuthHandler.Authenticate, requireAdmin}
+		}
+		mountAdmin(r, handlers, r, adminWrites...)
+	}
 
 	r.Get("/swagger.json", handlers.SubscriptionHandler.ServeSwaggerJSON)
+	r.Get("/schemas/{name}", handlers.SchemaHandler.GetSchema)
 
 	return r
 }
+
+// AdminRouter serves metrics and, with debug endpoints enabled, the admin
+// routes and pprof on a listener that is not exposed publicly. api is the
+// public router whose routes /admin/routes lists. A non-empty token must be
+// presented as a bearer token on every request.
+func AdminRouter(handlers Handlers, api chi.Routes, token string) *chi.Mux {
+	r := chi.NewRouter()
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed(r))
+	r.Use(requestID)
+	// Preflight requests carry no token, so CORS goes first.
+	r.Use(newCORS(handlers.CORS.Admin))
+	if token != "" {
+		r.Use(requireBearerToken(token))
+	}
+
+	var adminWrites []func(http.Handler) http.Handler
+	if handlers.ReadOnly {
+		adminWrites = append(adminWrites, ReadOnly)
+	}
+	mountAdmin(r, handlers, api, adminWrites...)
+	if handlers.AdminHandler != nil {
+		r.Mount("/debug", middleware.Profiler())
+	}
+	return r
+}
+
+// mountAdmin adds the metrics and admin routes to r. writes guard the routes
+// that change state, which reach every user.
+func mountAdmin(r chi.Router, handlers Handlers, api chi.Routes, writes ...func(http.Handler) http.Handler) {
+	if handlers.AdminHandler != nil {
+		r.Get("/admin/routes", handlers.AdminHandler.ListRoutes(api))
+		r.Get("/admin/integrity", handlers.AdminHandler.CheckIntegrity)
+		r.Get("/admin/queries", handlers.AdminHandler.TopQueries)
+		r.Get("/admin/flags", handlers.AdminHandler.ListFlags)
+		r.With(writes...).Put("/admin/flags/{name}", handlers.AdminHandler.SetFlag)
+		r.With(writes...).Delete("/admin/flags/{name}", handlers.AdminHandler.DeleteFlag)
+	}
+	r.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"subtracker/internal/config"
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRouterFallbacks(t *testing.T) {
+	router := Router(Handlers{
+		SubscriptionHandler: NewSubscriptionHandler(nil, nil, nil, testListLimits, testExportLimits, logger.NewNopLogger()),
+		SavedFilterHandler:  NewSavedFilterHandler(nil, logger.NewNopLogger()),
+		HealthHandler:       NewHealthHandler(nil, logger.NewNopLogger()),
+		SyncHandler:         NewSyncHandler(nil, testListLimits, logger.NewNopLogger()),
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		var body response.APIError
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusNotFound, body.Code)
+		assert.Equal(t, "/nope", body.Resource)
+	})
+
+	t.Run("Method Not Allowed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/subscriptions/d290f1ee-6c54-4b01-90e6-d701748f0851", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Equal(t, "GET, PUT, PATCH, DELETE", rr.Header().Get("Allow"))
+		var body response.APIError
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusMethodNotAllowed, body.Code)
+		assert.Contains(t, body.Message, "GET, PUT, PATCH, DELETE")
+	})
+}
+
+func TestRouterAuthentication(t *testing.T) {
+	router := Router(Handlers{
+		SubscriptionHandler: NewSubscriptionHandler(nil, nil, nil, testListLimits, testExportLimits, logger.NewNopLogger()),
+		SavedFilterHandler:  NewSavedFilterHandler(nil, logger.NewNopLogger()),
+		HealthHandler:       NewHealthHandler(nil, logger.NewNopLogger()),
+		SyncHandler:         NewSyncHandler(nil, testListLimits, logger.NewNopLogger()),
+		AuthHandler:         NewAuthHandler(nil, logger.NewNopLogger()),
+	})
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/saved-filters"},
+		{http.MethodGet, "/saved-filters"},
+		{http.MethodDelete, "/saved-filters/d290f1ee-6c54-4b01-90e6-d701748f0851"},
+		{http.MethodPost, "/rules"},
+		{http.MethodDelete, "/rules/d290f1ee-6c54-4b01-90e6-d701748f0851"},
+		{http.MethodPost, "/rules/apply"},
+		{http.MethodGet, "/reports/savings"},
+		{http.MethodGet, "/sync"},
+		{http.MethodPost, "/sync"},
+	} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(route.method, route.path, nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, route.method+" "+route.path)
+	}
+}
+
+func TestAdminRoutes(t *testing.T) {
+	handlers := Handlers{
+		SubscriptionHandler: NewSubscriptionHandler(nil, nil, nil, testListLimits, testExportLimits, logger.NewNopLogger()),
+		SavedFilterHandler:  NewSavedFilterHandler(nil, logger.NewNopLogger()),
+		HealthHandler:       NewHealthHandler(nil, logger.NewNopLogger()),
+		SyncHandler:         NewSyncHandler(nil, testListLimits, logger.NewNopLogger()),
+	}
+
+	t.Run("Disabled By Default", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		Router(handlers).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/routes", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Lists Routes", func(t *testing.T) {
+		handlers.AdminHandler = NewAdminHandler(nil, nil, nil, logger.NewNopLogger())
+		rr := httptest.NewRecorder()
+		Router(handlers).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/routes", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var routes []dto.RouteResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &routes))
+
+		var found bool
+		for _, route := range routes {
+			if route.Method == http.MethodDelete && route.Pattern == "/subscriptions/{id}" {
+				found = true
+				assert.Contains(t, route.Middlewares, "subtracker/internal/handler.observeLatency")
+			}
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestAdminListener(t *testing.T) {
+	handlers := Handlers{
+		SubscriptionHandler: NewSubscriptionHandler(nil, nil, nil, testListLimits, testExportLimits, logger.NewNopLogger()),
+		SavedFilterHandler:  NewSavedFilterHandler(nil, logger.NewNopLogger()),
+		HealthHandler:       NewHealthHandler(nil, logger.NewNopLogger()),
+		SyncHandler:         NewSyncHandler(nil, testListLimits, logger.NewNopLogger()),
+		AdminHandler:        NewAdminHandler(nil, nil, nil, logger.NewNopLogger()),
+		SeparateAdmin:       true,
+	}
+	api := Router(handlers)
+	admin := AdminRouter(handlers, api, "admin-secret")
+
+	t.Run("Kept Off The Public Router", func(t *testing.T) {
+		for _, path := range []string{"/metrics", "/admin/routes", "/debug/pprof/"} {
+			rr := httptest.NewRecorder()
+			api.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusNotFound, rr.Code, path)
+		}
+	})
+
+	t.Run("Requires The Admin Token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		admin.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Authorization", "Bearer wrong")
+		rr = httptest.NewRecorder()
+		admin.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Lists Public Routes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/routes", nil)
+		req.Header.Set("Authorization", "Bearer admin-secret")
+		rr := httptest.NewRecorder()
+		admin.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var routes []dto.RouteResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &routes))
+		var found bool
+		for _, route := range routes {
+			found = found || route.Pattern == "/subscriptions/{id}"
+			assert.NotEqual(t, "/admin/routes", route.Pattern)
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestAdminFlagWrites(t *testing.T) {
+	authService := new(mocks.AuthServiceInterface)
+	authService.On("Authenticate", "user-token").Return(domain.Principal{UserID: uuid.New(), Role: domain.RoleUser}, nil)
+	authService.On("Authenticate", "admin-token").Return(domain.Principal{UserID: uuid.New(), Role: domain.RoleAdmin}, nil)
+	flags := new(mocks.FeatureFlagServiceInterface)
+	flags.On("DeleteFlag", mock.Anything, "new_checkout").Return(nil)
+
+	handlers := Handlers{
+		SubscriptionHandler: NewSubscriptionHandler(nil, nil, nil, testListLimits, testExportLimits, logger.NewNopLogger()),
+		SavedFilterHandler:  NewSavedFilterHandler(nil, logger.NewNopLogger()),
+		HealthHandler:       NewHealthHandler(nil, logger.NewNopLogger()),
+		SyncHandler:         NewSyncHandler(nil, testListLimits, logger.NewNopLogger()),
+		AdminHandler:        NewAdminHandler(nil, nil, flags, logger.NewNopLogger()),
+	}
+	deleteFlag := func(router http.Handler, token string) int {
+		req := httptest.NewRequest(http.MethodDelete, "/admin/flags/new_checkout", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("Refused On The Public Router Without Authentication", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, deleteFlag(Router(handlers), ""))
+	})
+
+	t.Run("Admins Only On The Public Router", func(t *testing.T) {
+		public := handlers
+		public.AuthHandler = NewAuthHandler(authService, logger.NewNopLogger())
+		router := Router(public)
+
+		assert.Equal(t, http.StatusUnauthorized, deleteFlag(router, ""))
+		assert.Equal(t, http.StatusForbidden, deleteFlag(router, "user-token"))
+		assert.Equal(t, http.StatusNoContent, deleteFlag(router, "admin-token"))
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/routes", nil))
+		assert.Equal(t, http.StatusOK, rr.Code, "reads stay open")
+	})
+
+	t.Run("Refused In Read-Only Mode", func(t *testing.T) {
+		readOnly := handlers
+		readOnly.AuthHandler = NewAuthHandler(authService, logger.NewNopLogger())
+		readOnly.ReadOnly = true
+		assert.Equal(t, http.StatusServiceUnavailable, deleteFlag(Router(readOnly), "admin-token"))
+
+		readOnly.SeparateAdmin = true
+		admin := AdminRouter(readOnly, Router(readOnly), "admin-secret")
+		assert.Equal(t, http.StatusServiceUnavailable, deleteFlag(admin, "admin-secret"))
+	})
+
+	t.Run("Admin Listener Relies On Its Token", func(t *testing.T) {
+		separate := handlers
+		separate.SeparateAdmin = true
+		admin := AdminRouter(separate, Router(separate), "admin-secret")
+
+		assert.Equal(t, http.StatusUnauthorized, deleteFlag(admin, ""))
+		assert.Equal(t, http.StatusNoContent, deleteFlag(admin, "admin-secret"))
+	})
+}
+
+func TestCORSGroups(t *testing.T) {
+	router := Router(Handlers{
+		SubscriptionHandler: NewSubscriptionHandler(nil, nil, nil, testListLimits, testExportLimits, logger.NewNopLogger()),
+		SavedFilterHandler:  NewSavedFilterHandler(nil, logger.NewNopLogger()),
+		HealthHandler:       NewHealthHandler(nil, logger.NewNopLogger()),
+		SyncHandler:         NewSyncHandler(nil, testListLimits, logger.NewNopLogger()),
+		SchemaHandler:       NewSchemaHandler(logger.NewNopLogger()),
+		CORS: config.CORSConfig{
+			API:    config.CORSPolicy{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true},
+			Public: config.CORSPolicy{AllowedOrigins: []string{"*"}},
+		},
+	})
+	request := func(method, path, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Origin", origin)
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("API Preflight From Allowed Origin", func(t *testing.T) {
+		rr := request(http.MethodOptions, "/subscriptions", "https://app.example.com")
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("API Refuses Other Origins", func(t *testing.T) {
+		rr := request(http.MethodOptions, "/subscriptions", "https://evil.example.com")
+
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Public Routes Open To Any Origin", func(t *testing.T) {
+		rr := request(http.MethodGet, "/schemas/create-subscription", "https://forms.example.org")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Admin Routes Closed By Default", func(t *testing.T) {
+		rr := request(http.MethodGet, "/metrics", "https://app.example.com")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
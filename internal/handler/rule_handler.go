@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/binder"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type RuleHandler struct {
+	service service.RuleServiceInterface
+	logger  logger.Logger
+}
+
+func NewRuleHandler(service service.RuleServiceInterface, logger logger.Logger) *RuleHandler {
+	return &RuleHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *RuleHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Create Category Rule
+// @Description  Adds a rule that sets the category of subscriptions whose service name contains the pattern,
+// @Description  ignoring case. New subscriptions created without a category get the category of the first
+// @Description  matching rule: the user's own rules before global ones, then higher priority first.
+// @Description  Omit user_id to create a global rule; only admins may.
+// @Tags         Rules
+// @Accept       json
+// @Produce      json
+// @Param        rule body dto.CreateRuleRequest true "Category rule"
+// @Success      201  {object}  dto.RuleResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "Global rule without admin role, or user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /rules [post]
+func (h *RuleHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "CreateRule request received")
+
+	var req dto.CreateRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	rule, err := mapper.ToRuleDomainFromDTO(req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid user ID", err))
+		return
+	}
+
+	created, err := h.service.CreateRule(r.Context(), rule)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Category rule created successfully", zap.String("rule_id", created.ID.String()))
+
+	response.JSON(w, http.StatusCreated, mapper.ToRuleDTOFromDomain(created))
+}
+
+// @Summary      List Category Rules
+// @Description  Lists the rules that apply to a user, in the order they are tried. Without user_id the
+// @Description  authenticated user's rules are listed, or only the global rules for an admin.
+// @Tags         Rules
+// @Produce      json
+// @Param        user_id query string false "User ID (UUID)"
+// @Success      200  {array}   dto.RuleResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid user ID"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /rules [get]
+func (h *RuleHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "ListRules request received", zap.String("query", r.URL.RawQuery))
+
+	var req dto.ListRulesRequest
+	if err := binder.BindQuery(r.URL.Query(), &req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+
+	rules, err := h.service.ListRules(r.Context(), req.UserID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	responseDTOs := make([]dto.RuleResponse, len(rules))
+	for i, rule := range rules {
+		responseDTOs[i] = mapper.ToRuleDTOFromDomain(rule)
+	}
+	response.JSON(w, http.StatusOK, responseDTOs)
+}
+
+// @Summary      Delete Category Rule
+// @Description  Deletes a rule. Subscriptions it already categorized keep their category.
+// @Tags         Rules
+// @Produce      json
+// @Param        id   path      string  true  "Rule ID (UUID format)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Rule not found"
+// @Failure      403  {object}  apperrors.AppError "Global rule without admin role"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /rules/{id} [delete]
+func (h *RuleHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "DeleteRule request received", zap.String("rule_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid rule ID format", err))
+		return
+	}
+
+	if err := h.service.DeleteRule(r.Context(), id); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Category rule deleted successfully", zap.String("rule_id", id))
+
+	response.NoContent(w)
+}
+
+// @Summary      Apply Category Rules
+// @Description  Categorizes a user's existing subscriptions with the current rules. Subscriptions that already
+// @Description  have a category are left alone unless overwrite is set; no subscription loses its category
+// @Description  because no rule matches it.
+// @Tags         Rules
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ApplyRulesRequest true "User to categorize"
+// @Success      200  {object}  dto.ApplyRulesResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /rules/apply [post]
+func (h *RuleHandler) ApplyRules(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "ApplyRules request received")
+
+	var req dto.ApplyRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	result, err := h.service.ApplyRules(r.Context(), req.UserID, req.Overwrite)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Category rules applied successfully", zap.String("user_id", req.UserID), zap.Int("updated", result.Updated))
+
+	response.JSON(w, http.StatusOK, mapper.ToApplyRulesResponse(result))
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRule(t *testing.T) {
+	mockService := new(mocks.RuleServiceInterface)
+	handler := NewRuleHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Global Rule", func(t *testing.T) {
+		body, _ := json.Marshal(dto.CreateRuleRequest{Pattern: "netflix", Category: "Entertainment", Priority: 10})
+		mockService.On("CreateRule", mock.Anything, mock.MatchedBy(func(rule domain.CategoryRule) bool {
+			return rule.UserID == nil && rule.Pattern == "netflix" && rule.Priority == 10
+		})).Return(domain.CategoryRule{ID: uuid.New(), Pattern: "netflix", Category: "Entertainment", Priority: 10}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/rules", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateRule(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var respBody dto.RuleResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, "Entertainment", respBody.Category)
+		assert.Empty(t, respBody.UserID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		body, _ := json.Marshal(dto.CreateRuleRequest{Pattern: "netflix"})
+
+		req := httptest.NewRequest(http.MethodPost, "/rules", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateRule(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestApplyRules(t *testing.T) {
+	mockService := new(mocks.RuleServiceInterface)
+	handler := NewRuleHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		userID := uuid.NewString()
+		body, _ := json.Marshal(dto.ApplyRulesRequest{UserID: userID, Overwrite: true})
+		mockService.On("ApplyRules", mock.Anything, userID, true).Return(domain.RuleApplyResult{Checked: 4, Updated: 3}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/rules/apply", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ApplyRules(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"checked":4,"updated":3}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Missing User", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/rules/apply", bytes.NewReader([]byte(`{}`)))
+		rr := httptest.NewRecorder()
+		handler.ApplyRules(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SavedFilterHandler struct {
+	service service.SavedFilterServiceInterface
+	logger  logger.Logger
+}
+
+func NewSavedFilterHandler(service service.SavedFilterServiceInterface, logger logger.Logger) *SavedFilterHandler {
+	return &SavedFilterHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *SavedFilterHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Create Saved Filter
+// @Description  Stores a named set of subscription list filters for a user.
+// @Tags         Saved Filters
+// @Accept       json
+// @Produce      json
+// @Param        filter body dto.CreateSavedFilterRequest true "Saved filter"
+// @Success      201  {object}  dto.SavedFilterResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      409  {object}  apperrors.AppError "Saved filter with this name already exists"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /saved-filters [post]
+func (h *SavedFilterHandler) CreateSavedFilter(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "CreateSavedFilter request received")
+
+	var req dto.CreateSavedFilterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	filter, err := mapper.ToSavedFilterDomainFromDTO(req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid user ID", err))
+		return
+	}
+
+	created, err := h.service.CreateSavedFilter(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Saved filter created successfully", zap.String("saved_filter_id", created.ID.String()))
+
+	response.JSON(w, http.StatusCreated, mapper.ToSavedFilterDTOFromDomain(created))
+}
+
+// @Summary      List Saved Filters
+// @Description  Lists the saved filters of a user.
+// @Tags         Saved Filters
+// @Produce      json
+// @Param        user_id query string true "User ID (UUID)"
+// @Success      200  {array}   dto.SavedFilterResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid user ID"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /saved-filters [get]
+func (h *SavedFilterHandler) ListSavedFilters(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	h.logger.InfoContext(r.Context(), "ListSavedFilters request received", zap.String("user_id", userID))
+
+	if _, err := uuid.Parse(userID); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid user ID format", err))
+		return
+	}
+
+	filters, err := h.service.ListSavedFilters(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	responseDTOs := make([]dto.SavedFilterResponse, len(filters))
+	for i, f := range filters {
+		responseDTOs[i] = mapper.ToSavedFilterDTOFromDomain(f)
+	}
+	response.JSON(w, http.StatusOK, responseDTOs)
+}
+
+// @Summary      Get Saved Filter by ID
+// @Tags         Saved Filters
+// @Produce      json
+// @Param        id   path      string  true  "Saved filter ID (UUID format)"
+// @Success      200  {object}  dto.SavedFilterResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Saved filter not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /saved-filters/{id} [get]
+func (h *SavedFilterHandler) GetSavedFilter(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "GetSavedFilter request received", zap.String("saved_filter_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid saved filter ID format", err))
+		return
+	}
+
+	filter, err := h.service.GetSavedFilter(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, mapper.ToSavedFilterDTOFromDomain(filter))
+}
+
+// @Summary      Update Saved Filter
+// @Description  Renames a saved filter or replaces its params. The owner cannot be changed.
+// @Tags         Saved Filters
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                       true  "Saved filter ID (UUID format)"
+// @Param        filter body      dto.UpdateSavedFilterRequest true  "Fields to update"
+// @Success      200    {object}  response.APIResponse
+// @Failure      400    {object}  apperrors.AppError "Invalid ID format or request body"
+// @Failure      404    {object}  apperrors.AppError "Saved filter not found"
+// @Failure      409    {object}  apperrors.AppError "Saved filter with this name already exists"
+// @Failure      500    {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /saved-filters/{id} [put]
+func (h *SavedFilterHandler) UpdateSavedFilter(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "UpdateSavedFilter request received", zap.String("saved_filter_id", idStr))
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid saved filter ID format", err))
+		return
+	}
+
+	var req dto.UpdateSavedFilterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	filter := mapper.ToSavedFilterDomainFromUpdateDTO(req)
+	filter.ID = id
+
+	if err := h.service.UpdateSavedFilter(r.Context(), filter); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Saved filter updated successfully", zap.String("saved_filter_id", idStr))
+
+	response.APIResponse{Code: http.StatusOK, Message: "Saved filter updated successfully"}.Send(w)
+}
+
+// @Summary      Delete Saved Filter
+// @Tags         Saved Filters
+// @Produce      json
+// @Param        id   path      string  true  "Saved filter ID (UUID format)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Saved filter not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /saved-filters/{id} [delete]
+func (h *SavedFilterHandler) DeleteSavedFilter(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "DeleteSavedFilter request received", zap.String("saved_filter_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid saved filter ID format", err))
+		return
+	}
+
+	if err := h.service.DeleteSavedFilter(r.Context(), id); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Saved filter deleted successfully", zap.String("saved_filter_id", id))
+
+	response.NoContent(w)
+}
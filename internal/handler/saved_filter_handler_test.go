@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSavedFilter(t *testing.T) {
+	mockService := new(mocks.SavedFilterServiceInterface)
+	handler := NewSavedFilterHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		reqBody := dto.CreateSavedFilterRequest{
+			UserID: uuid.New().String(),
+			Name:   "Work tools over 1000",
+			Params: map[string]string{"min_price": "1000"},
+		}
+		body, _ := json.Marshal(reqBody)
+		created := domain.SavedFilter{ID: uuid.New(), UserID: uuid.MustParse(reqBody.UserID), Name: reqBody.Name, Params: reqBody.Params}
+		mockService.On("CreateSavedFilter", mock.Anything, mock.AnythingOfType("domain.SavedFilter")).Return(created, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/saved-filters", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSavedFilter(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var respBody dto.SavedFilterResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, created.ID.String(), respBody.ID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		body, _ := json.Marshal(dto.CreateSavedFilterRequest{Name: "No owner"})
+
+		req := httptest.NewRequest(http.MethodPost, "/saved-filters", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSavedFilter(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "CreateSavedFilter")
+	})
+}
+
+func TestListSavedFilters(t *testing.T) {
+	mockService := new(mocks.SavedFilterServiceInterface)
+	handler := NewSavedFilterHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		userID := uuid.New().String()
+		mockService.On("ListSavedFilters", mock.Anything, userID).Return([]domain.SavedFilter{{ID: uuid.New()}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/saved-filters?user_id="+userID, nil)
+		rr := httptest.NewRecorder()
+		handler.ListSavedFilters(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody []dto.SavedFilterResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Len(t, respBody, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Missing User ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/saved-filters", nil)
+		rr := httptest.NewRecorder()
+		handler.ListSavedFilters(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "ListSavedFilters")
+	})
+}
+
+func TestDeleteSavedFilter(t *testing.T) {
+	mockService := new(mocks.SavedFilterServiceInterface)
+	handler := NewSavedFilterHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Delete("/saved-filters/{id}", handler.DeleteSavedFilter)
+
+	t.Run("Success", func(t *testing.T) {
+		id := uuid.New().String()
+		mockService.On("DeleteSavedFilter", mock.Anything, id).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/saved-filters/"+id, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Content-Type"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		id := uuid.New().String()
+		mockService.On("DeleteSavedFilter", mock.Anything, id).Return(apperrors.NewNotFound("not found", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/saved-filters/"+id, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/pkg/jsonschema"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// schemaTypes are the request payloads published under /schemas, keyed by
+// the name clients request them by.
+var schemaTypes = map[string]any{
+	"create-subscription": dto.CreateSubscriptionRequest{},
+	"update-subscription": dto.UpdateSubscriptionRequest{},
+	"subscription-filter": dto.SubscriptionFilter{},
+}
+
+type SchemaHandler struct {
+	schemas map[string]*jsonschema.Schema
+	logger  logger.Logger
+}
+
+// NewSchemaHandler generates every schema up front; the DTOs cannot change
+// while the server runs.
+func NewSchemaHandler(logger logger.Logger) *SchemaHandler {
+	schemas := make(map[string]*jsonschema.Schema, len(schemaTypes))
+	for name, v := range schemaTypes {
+		schemas[name] = jsonschema.Generate(v)
+	}
+	return &SchemaHandler{
+		schemas: schemas,
+		logger:  logger,
+	}
+}
+
+// @Summary      Get a request JSON Schema
+// @Description  Returns a JSON Schema (draft 2020-12) generated from the request DTO and its validation rules, for validating payloads client-side. Cross-field rules, such as max_price not being below min_price, are only enforced by the server. The subscription-filter schema describes the list query parameters.
+// @Tags         Schemas
+// @Produce      json
+// @Param        name  path      string  true  "Schema name"  Enums(create-subscription, update-subscription, subscription-filter)
+// @Success      200   {object}  jsonschema.Schema
+// @Failure      404   {object}  response.APIError "Unknown schema"
+// @Router       /schemas/{name} [get]
+func (h *SchemaHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	schema, ok := h.schemas[name]
+	if !ok {
+		response.APIError{
+			Code:     http.StatusNotFound,
+			Message:  fmt.Sprintf("unknown schema %q", name),
+			Resource: r.URL.Path,
+		}.Send(w)
+		return
+	}
+	response.JSON(w, http.StatusOK, schema)
+}
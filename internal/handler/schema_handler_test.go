@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"subtracker/pkg/jsonschema"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaHandler(t *testing.T) {
+	handler := NewSchemaHandler(logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/schemas/{name}", handler.GetSchema)
+
+	get := func(name string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/schemas/"+name, nil))
+		return rr
+	}
+
+	t.Run("Create Subscription", func(t *testing.T) {
+		rr := get("create-subscription")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var schema jsonschema.Schema
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &schema))
+		assert.Equal(t, "object", schema.Type)
+		assert.ElementsMatch(t, []string{"service_name", "price", "user_id", "start_date"}, schema.Required)
+
+		name := schema.Properties["service_name"]
+		assert.Equal(t, "string", name.Type)
+		assert.Equal(t, 1, *name.MinLength)
+		assert.Equal(t, 100, *name.MaxLength)
+
+		price := schema.Properties["price"]
+		assert.Equal(t, "integer", price.Type)
+		assert.Equal(t, 0.0, *price.Minimum)
+		assert.Equal(t, []any{299.0}, price.Examples)
+
+		assert.Equal(t, "uuid", schema.Properties["user_id"].Format)
+		assert.Equal(t, `^(0[1-9]|1[0-2])-[0-9]{4}$`, schema.Properties["end_date"].Pattern)
+		assert.Equal(t, []any{"monthly", "yearly", "weekly"}, schema.Properties["billing_period"].Enum)
+	})
+
+	t.Run("Subscription Filter", func(t *testing.T) {
+		rr := get("subscription-filter")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var schema jsonschema.Schema
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &schema))
+		assert.Empty(t, schema.Required)
+		assert.Equal(t, "boolean", schema.Properties["has_end_date"].Type)
+		assert.Equal(t, 0.0, *schema.Properties["limit"].Minimum)
+	})
+
+	t.Run("Unknown Schema", func(t *testing.T) {
+		rr := get("user")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), `unknown schema \"user\"`)
+	})
+}
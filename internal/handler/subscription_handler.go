@@ -1,19 +1,26 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
 	"time"
 
+	"subtracker/internal/domain"
 	"subtracker/internal/domain/dto"
+	"subtracker/internal/export"
 	"subtracker/internal/mapper"
 	"subtracker/internal/service"
 	"subtracker/pkg/apperrors"
+	"subtracker/pkg/binder"
 	"subtracker/pkg/logger"
 	"subtracker/pkg/response"
 	"subtracker/pkg/validator"
-	"subtracker/utils"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -21,65 +28,48 @@ import (
 )
 
 type SubscriptionHandler struct {
-	service service.SubscriptionServiceInterface
-	logger  logger.Logger
+	service      service.SubscriptionServiceInterface
+	savedFilters service.SavedFilterServiceInterface
+	reports      service.ReportServiceInterface
+	limits       ListLimits
+	export       ExportLimits
+	logger       logger.Logger
 }
 
-func NewSubscriptionHandler(service service.SubscriptionServiceInterface, logger logger.Logger) *SubscriptionHandler {
+func NewSubscriptionHandler(service service.SubscriptionServiceInterface, savedFilters service.SavedFilterServiceInterface, reports service.ReportServiceInterface, limits ListLimits, exportLimits ExportLimits, logger logger.Logger) *SubscriptionHandler {
 	return &SubscriptionHandler{
-		service: service,
-		logger:  logger,
+		service:      service,
+		savedFilters: savedFilters,
+		reports:      reports,
+		limits:       limits,
+		export:       exportLimits,
+		logger:       logger,
 	}
 }
-func (s *SubscriptionHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
-	var appErr *apperrors.AppError
-	isAppError := errors.As(err, &appErr)
-
-	if isAppError && appErr.Code >= 400 && appErr.Code < 500 {
-		s.logger.Warn("Client Error",
-			zap.Int("status_code", appErr.Code),
-			zap.String("message", appErr.Message),
-			zap.Error(err),
-			zap.String("url", r.URL.Path),
-		)
-	} else {
-		s.logger.Error("Server Error",
-			zap.Error(err),
-			zap.String("url", r.URL.Path),
-		)
-	}
-
-	if isAppError {
-		jsonErr := response.APIError{
-			Code:     appErr.Code,
-			Message:  appErr.Message,
-			Resource: r.URL.Path,
-		}
-		jsonErr.Send(w)
-		return
-	}
 
-	jsonErr := response.APIError{
-		Code:     http.StatusInternalServerError,
-		Message:  "Internal Server Error",
-		Resource: r.URL.Path,
-	}
-	jsonErr.Send(w)
+func (s *SubscriptionHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(s.logger, w, r, err)
 }
 
 // @Summary      Create Subscription
 // @Description  Adds a new subscription to the system based on the provided data.
+// @Description  Non-fatal issues (unusual price, far-future start, probable duplicate, quota nearly reached) are returned in `warnings`.
+// @Description  Rent, utilities and insurance must have a price above zero; rent is billed monthly or weekly, utilities
+// @Description  monthly, and insurance monthly or yearly.
 // @Tags         Subscriptions
 // @Accept       json
 // @Produce      json
 // @Param        subscription body dto.CreateSubscriptionRequest true "Subscription Information"
 // @Success      201  {object}  response.APIResponse
+// @Header       201  {integer} X-Quota-Remaining "Subscriptions the user may still create; only sent when a quota is configured"
 // @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "Subscription quota reached"
 // @Failure      409  {object}  apperrors.AppError "Conflict if subscription with this ID already exists"
 // @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
 // @Router       /subscriptions [post]
 func (s *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
-	s.logger.Info("CreateSubscription request received",
+	s.logger.InfoContext(r.Context(), "CreateSubscription request received",
 		zap.String("method", r.Method),
 		zap.String("url", r.URL.String()),
 	)
@@ -88,7 +78,13 @@ func (s *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.
 		s.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
 		return
 	}
-	s.logger.Debug("Request body decoded and parsed", zap.Any("request_dto", req))
+	s.logger.DebugContext(r.Context(), "Request body decoded and parsed", zap.Any("request_dto", req))
+	userID, err := service.ScopeUserID(r.Context(), req.UserID)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	req.UserID = userID
 	if err := validator.ValidateStruct(req); err != nil {
 		s.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
 		return
@@ -100,57 +96,159 @@ func (s *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if err := s.service.CreateSubscription(r.Context(), sub); err != nil {
+	warnings, err := s.service.CreateSubscription(r.Context(), sub)
+	if err != nil {
 		s.handleError(w, r, err)
 		return
 	}
-	s.logger.Info("Subscription created successfully",
+	s.logger.InfoContext(r.Context(), "Subscription created successfully",
 		zap.String("user_id", req.UserID),
 		zap.String("service_name", req.ServiceName),
+		zap.Int("warnings", len(warnings)),
+	)
+	s.setQuotaHeader(w, r, req.UserID)
+
+	response.APIResponse{
+		Code:     http.StatusCreated,
+		Message:  "Subscription created successfully",
+		Warnings: mapper.ToWarningResponses(warnings),
+	}.Send(w)
+}
+
+// maxCreateBatch caps how many subscriptions one batch create may carry.
+const maxCreateBatch = 100
+
+// @Summary      Create Subscriptions in Batch
+// @Description  Creates up to 100 subscriptions in one request and one transaction. Each item is validated on its
+// @Description  own: an invalid item, or one over its user's quota, is reported in `results` and the rest are still
+// @Description  created. If the write itself fails, nothing is created and the error is returned for the whole request.
+// @Tags         Subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        subscriptions body      []dto.CreateSubscriptionRequest true "Subscriptions to create"
+// @Success      200           {object}  dto.CreateBatchResponse
+// @Failure      400           {object}  apperrors.AppError "Invalid request body, batch size or user"
+// @Failure      409           {object}  apperrors.AppError "A subscription with one of the IDs already exists"
+// @Failure      500           {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/batch [post]
+func (s *SubscriptionHandler) CreateSubscriptionsBatch(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "CreateSubscriptionsBatch request received")
+
+	var req []dto.CreateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if len(req) == 0 || len(req) > maxCreateBatch {
+		s.handleError(w, r, apperrors.NewBadRequest(fmt.Sprintf("batch must contain between 1 and %d subscriptions", maxCreateBatch), nil))
+		return
+	}
+
+	// Items rejected here never reach the service; indexes maps the service's
+	// results back to their position in the request.
+	results := make([]service.CreateResult, len(req))
+	subs := make([]domain.Subscription, 0, len(req))
+	indexes := make([]int, 0, len(req))
+	for i, item := range req {
+		userID, err := service.ScopeUserID(r.Context(), item.UserID)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		item.UserID = userID
+		if err := validator.ValidateStruct(item); err != nil {
+			results[i].Err = apperrors.NewBadRequest(err.Error(), err)
+			continue
+		}
+		sub, err := mapper.ToDomainFromDTO(item)
+		if err != nil {
+			results[i].Err = apperrors.NewBadRequest("failed to parse date", err)
+			continue
+		}
+		subs = append(subs, sub)
+		indexes = append(indexes, i)
+	}
+	if len(subs) > 0 {
+		created, err := s.service.CreateSubscriptions(r.Context(), subs)
+		if err != nil {
+			s.handleError(w, r, err)
+			return
+		}
+		for j, result := range created {
+			results[indexes[j]] = result
+		}
+	}
+
+	responseDTO := dto.CreateBatchResponse{Results: make([]dto.CreateBatchItemResponse, len(results))}
+	for i, result := range results {
+		item := dto.CreateBatchItemResponse{Status: "created"}
+		if result.Err != nil {
+			responseDTO.Rejected++
+			item.Status = "rejected"
+			item.Error = "Internal Server Error"
+			var appErr *apperrors.AppError
+			if errors.As(result.Err, &appErr) {
+				item.Error = appErr.Message
+				item.ErrorCode = appErr.ErrorCode
+			}
+		} else {
+			responseDTO.Created++
+			item.ID = result.ID.String()
+			item.Warnings = mapper.ToWarningResponses(result.Warnings)
+		}
+		responseDTO.Results[i] = item
+	}
+
+	s.logger.InfoContext(r.Context(), "Batch create completed",
+		zap.Int("created", responseDTO.Created),
+		zap.Int("rejected", responseDTO.Rejected),
 	)
 
-	response.APIResponse{Code: http.StatusCreated, Message: "Subscription created successfully"}.Send(w)
+	response.JSON(w, http.StatusOK, responseDTO)
 }
 
 // @Summary      List Subscriptions
-// @Description  Gets a list of subscriptions with filtering and pagination.
+// @Description  Gets a list of subscriptions with filtering and pagination. `pagination.total` counts every
+// @Description  subscription matching the filters and `pagination.has_more` tells whether pages follow this one.
 // @Tags         Subscriptions
 // @Produce      json
-// @Param        user_id      query     string  false  "Filter by User ID (UUID)"
+// @Param        user_id      query     string  false  "Filter by User ID (UUID); defaults to the authenticated user unless they are an admin, for whom omitting it lists every user"
 // @Param        service_name query     string  false  "Filter by Service Name"
 // @Param        min_price    query     int     false  "Filter by minimum price"
 // @Param        max_price    query     int     false  "Filter by maximum price"
 // @Param        start_date   query     string  false  "Filter by start date (format: MM-YYYY)"
 // @Param        end_date     query     string  false  "Filter by end date (format: MM-YYYY)"
 // @Param        has_end_date query     bool    false  "Filter by presence of an end date"
-// @Param        limit        query     int     false  "Pagination limit (default 10, max 100)"
+// @Param        cost_center  query     string  false  "Filter by cost center"
+// @Param        category     query     string  false  "Filter by category"
+// @Param        past_due     query     bool    false  "Filter by whether a failed charge is still unpaid"
+// @Param        expense_type query     string  false  "Filter by expense type" Enums(subscription, rent, utilities, insurance, other)
+// @Param        limit        query     int     false  "Pagination limit (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)"
 // @Param        offset       query     int     false  "Pagination offset (default 0)"
-// @Success      200  {array}   dto.SubscriptionResponse
+// @Param        sort         query     string  false  "Comma-separated sort fields (start_date, end_date, price, service_name); prefix with - for descending (default -start_date)"
+// @Param        saved_filter query     string  false  "Apply a saved filter by ID; explicit query params override its values"
+// @Success      200  {object}  dto.SubscriptionListResponse
 // @Failure      400  {object}  apperrors.AppError "Invalid filter parameters"
 // @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
 // @Router       /subscriptions [get]
 func (s *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
-	s.logger.Info("ListSubscriptions request received",
+	s.logger.InfoContext(r.Context(), "ListSubscriptions request received",
 		zap.String("url", r.URL.String()),
 	)
-	query := r.URL.Query()
-	filter := dto.SubscriptionFilter{
-		UserID:      query.Get("user_id"),
-		ServiceName: query.Get("service_name"),
-		StartDate:   query.Get("start_date"),
-		EndDate:     query.Get("end_date"),
-		MinPrice:    utils.ParseIntOrDefault(query.Get("min_price"), 0),
-		MaxPrice:    utils.ParseIntOrDefault(query.Get("max_price"), 0),
-		HasEndDate:  utils.ParseBoolPointer(query.Get("has_end_date")),
-		Limit:       utils.ParseIntOrDefault(query.Get("limit"), 10),
-		Offset:      utils.ParseIntOrDefault(query.Get("offset"), 0),
+	filter, err := s.bindFilter(r)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
 	}
-	s.logger.Debug("Parsed subscription filter", zap.Any("filter", filter))
-
-	if err := validator.ValidateStruct(filter); err != nil {
-		s.handleError(w, r, apperrors.NewBadRequest("invalid filter parameters", err))
+	limit, err := s.limits.apply(filter.Limit)
+	if err != nil {
+		s.handleError(w, r, err)
 		return
 	}
+	filter.Limit = limit
+	s.logger.DebugContext(r.Context(), "Parsed subscription filter", zap.Any("filter", filter))
 
 	result, err := s.service.ListSubscriptions(r.Context(), filter)
 	if err != nil {
@@ -158,69 +256,256 @@ func (s *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	page, err := countedPage(filter.Limit, filter.Offset, len(result), func() (int, error) {
+		return s.service.CountSubscriptions(r.Context(), filter)
+	})
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+
 	responseDTOs := make([]dto.SubscriptionResponse, len(result))
 	for i, sub := range result {
 		responseDTOs[i] = mapper.ToDTOFromDomain(sub)
 	}
-	s.logger.Info("ListSubscriptions completed successfully",
+	s.logger.InfoContext(r.Context(), "ListSubscriptions completed successfully",
 		zap.Int("subscriptions_found", len(result)),
+		zap.Int("total", page.Total),
+	)
+	response.JSON(w, http.StatusOK, dto.SubscriptionListResponse{
+		Items:      responseDTOs,
+		Pagination: page,
+	})
+}
+
+// bindFilter reads a SubscriptionFilter from the query string, applying the
+// saved filter it names and scoping it to the caller.
+func (s *SubscriptionHandler) bindFilter(r *http.Request) (dto.SubscriptionFilter, error) {
+	query := r.URL.Query()
+	if savedFilterID := query.Get("saved_filter"); savedFilterID != "" {
+		if _, err := uuid.Parse(savedFilterID); err != nil {
+			return dto.SubscriptionFilter{}, apperrors.NewBadRequest("invalid saved filter ID format", err)
+		}
+		saved, err := s.savedFilters.GetSavedFilter(r.Context(), savedFilterID)
+		if err != nil {
+			return dto.SubscriptionFilter{}, err
+		}
+		query = applySavedFilter(query, saved)
+		s.logger.DebugContext(r.Context(), "Applied saved filter", zap.String("saved_filter_id", savedFilterID))
+	}
+	query, err := scopeQuery(r.Context(), query)
+	if err != nil {
+		return dto.SubscriptionFilter{}, err
+	}
+	var filter dto.SubscriptionFilter
+	if err := binder.BindQuery(query, &filter); err != nil {
+		return dto.SubscriptionFilter{}, apperrors.NewBadRequest("invalid filter parameters", err)
+	}
+	return filter, nil
+}
+
+// @Summary      Export Subscriptions
+// @Description  Downloads the subscriptions matching the filter as a CSV or Excel file. Takes the same filters as List Subscriptions,
+// @Description  but no limit applies unless one is given; rows are streamed, so large exports are fine.
+// @Description  Rows are sent in batches of EXPORT_BATCH_ROWS; a client that takes longer than EXPORT_FLUSH_TIMEOUT to
+// @Description  receive a batch is disconnected.
+// @Description  Dates are formatted MM-YYYY and prices are in the smallest currency unit.
+// @Tags         Subscriptions
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format       query     string  false  "File format" Enums(csv, xlsx) default(csv)
+// @Param        user_id      query     string  false  "Filter by User ID (UUID); defaults to the authenticated user unless they are an admin, for whom omitting it exports every user"
+// @Param        service_name query     string  false  "Filter by Service Name"
+// @Param        min_price    query     int     false  "Filter by minimum price"
+// @Param        max_price    query     int     false  "Filter by maximum price"
+// @Param        start_date   query     string  false  "Filter by start date (format: MM-YYYY)"
+// @Param        end_date     query     string  false  "Filter by end date (format: MM-YYYY)"
+// @Param        has_end_date query     bool    false  "Filter by presence of an end date"
+// @Param        cost_center  query     string  false  "Filter by cost center"
+// @Param        category     query     string  false  "Filter by category"
+// @Param        past_due     query     bool    false  "Filter by whether a failed charge is still unpaid"
+// @Param        expense_type query     string  false  "Filter by expense type" Enums(subscription, rent, utilities, insurance, other)
+// @Param        limit        query     int     false  "Maximum number of rows (default unlimited)"
+// @Param        offset       query     int     false  "Rows to skip (default 0)"
+// @Param        sort         query     string  false  "Comma-separated sort fields (start_date, end_date, price, service_name); prefix with - for descending (default -start_date)"
+// @Param        saved_filter query     string  false  "Apply a saved filter by ID; explicit query params override its values"
+// @Success      200  {file}    file
+// @Failure      400  {object}  apperrors.AppError "Invalid format or filter parameters"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/export [get]
+func (s *SubscriptionHandler) ExportSubscriptions(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "ExportSubscriptions request received",
+		zap.String("url", r.URL.String()),
+	)
+	format := export.Format(r.URL.Query().Get("format"))
+	switch format {
+	case "":
+		format = export.FormatCSV
+	case export.FormatCSV, export.FormatXLSX:
+	default:
+		s.handleError(w, r, apperrors.NewBadRequest("format must be csv or xlsx", nil))
+		return
+	}
+	filter, err := s.bindFilter(r)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+
+	// The response starts with the first row, so an error before it can
+	// still be reported as JSON.
+	out := newDeadlineWriter(w, s.export.FlushTimeout)
+	defer out.Release()
+	var file export.Writer
+	start := func() error {
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="subscriptions.%s"`, format))
+		w.WriteHeader(http.StatusOK)
+		file = export.NewWriter(format, out)
+		return file.WriteRow("id", "user_id", "service_name", "price", "billing_period", "start_date", "end_date", "cost_center", "category", "expense_type")
+	}
+	rows := 0
+	err = s.service.ExportSubscriptions(r.Context(), filter, func(sub domain.Subscription) error {
+		if file == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		rows++
+		row := mapper.ToDTOFromDomain(sub)
+		if err := file.WriteRow(row.ID, row.UserID, row.ServiceName, row.Price, row.BillingPeriod, row.StartDate, row.EndDate, row.CostCenter, row.Category, row.ExpenseType); err != nil {
+			return err
+		}
+		if rows%s.export.BatchRows != 0 {
+			return nil
+		}
+		// Returning the write error stops the stream, which closes the
+		// cursor and frees the connection.
+		if err := file.Flush(); err != nil {
+			return err
+		}
+		return out.Flush()
+	})
+	if err == nil && file == nil {
+		err = start()
+	}
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		if file == nil {
+			s.handleError(w, r, err)
+			return
+		}
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			s.logger.WarnContext(r.Context(), "ExportSubscriptions cut off a client too slow to receive a batch", zap.Int("rows_written", rows))
+			return
+		}
+		s.logger.ErrorContext(r.Context(), "ExportSubscriptions aborted after the response started", zap.Error(err), zap.Int("rows_written", rows))
+		return
+	}
+	s.logger.InfoContext(r.Context(), "ExportSubscriptions completed successfully",
+		zap.String("format", string(format)),
+		zap.Int("rows_written", rows),
 	)
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(responseDTOs)
 }
 
 // @Summary      Get Subscription by ID
 // @Description  Retrieves a single subscription by its unique ID.
+// @Description  With benchmark=true the average monthly price other users currently pay for the same service is included,
+// @Description  unless too few users hold it (BENCHMARK_MIN_USERS) to keep their prices private.
 // @Tags         Subscriptions
 // @Produce      json
-// @Param        id   path      string  true  "Subscription ID (UUID format)"
-// @Success      200  {object}  dto.SubscriptionResponse
-// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Param        id        path      string  true   "Subscription ID (UUID format)"
+// @Param        benchmark query     bool    false  "Include the service's average price"
+// @Success      200  {object}  dto.SubscriptionDetailResponse
+// @Header       200  {string}  ETag "Version of the subscription, to send in If-Match when updating it"
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format or query parameters"
 // @Failure      404  {object}  apperrors.AppError "Subscription not found"
 // @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
 // @Router       /subscriptions/{id} [get]
 func (s *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	s.logger.Info("GetSubscription request received", zap.String("subscription_id", id))
+	s.logger.InfoContext(r.Context(), "GetSubscription request received", zap.String("subscription_id", id))
 
 	if _, err := uuid.Parse(id); err != nil {
 		s.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
 		return
 	}
+	withBenchmark := false
+	if raw := r.URL.Query().Get("benchmark"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			s.handleError(w, r, apperrors.NewBadRequest("benchmark must be a boolean", err))
+			return
+		}
+		withBenchmark = parsed
+	}
 
 	subscription, err := s.service.GetSubscription(r.Context(), id)
 	if err != nil {
 		s.handleError(w, r, err)
 		return
 	}
-	s.logger.Info("Subscription found and returned successfully", zap.String("subscription_id", id))
+	s.logger.InfoContext(r.Context(), "Subscription found and returned successfully", zap.String("subscription_id", id))
+	w.Header().Set(HeaderETag, etag(subscription.Version))
+
+	var benchmark *domain.ServiceBenchmark
+	if withBenchmark {
+		benchmark = s.benchmark(r.Context(), subscription.ServiceName)
+	}
+	response.JSON(w, http.StatusOK, mapper.ToSubscriptionDetailResponse(subscription, benchmark))
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(mapper.ToDTOFromDomain(subscription))
+// benchmark looks up the service's average price. It is decoration on top of
+// the subscription, so a failed lookup is logged and left out, not returned.
+func (s *SubscriptionHandler) benchmark(ctx context.Context, serviceName string) *domain.ServiceBenchmark {
+	benchmark, ok, err := s.reports.ServiceBenchmark(ctx, serviceName)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Skipping service benchmark", zap.String("service_name", serviceName), zap.Error(err))
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	return &benchmark
 }
 
 // @Summary      Update Subscription
 // @Description  Updates an existing subscription's details by its ID. UserID cannot be changed.
+// @Description  If-Match must carry the ETag the subscription was read with; a write based on an older version
+// @Description  is rejected with 412. Non-fatal issues are returned in `warnings`.
 // @Tags         Subscriptions
 // @Accept       json
 // @Produce      json
 // @Param        id           path      string                       true  "Subscription ID (UUID format)"
+// @Param        If-Match     header    string                       true  "ETag from GET /subscriptions/{id}, or * for any version"
 // @Param        subscription body      dto.UpdateSubscriptionRequest true  "Fields to update"
 // @Success      200          {object}  response.APIResponse
 // @Failure      400          {object}  apperrors.AppError "Invalid ID format or request body"
 // @Failure      404          {object}  apperrors.AppError "Subscription not found"
+// @Failure      412          {object}  apperrors.AppError "Subscription was changed since the ETag was read"
+// @Failure      428          {object}  apperrors.AppError "If-Match header missing"
 // @Failure      500          {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
 // @Router       /subscriptions/{id} [put]
 func (s *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 
-	s.logger.Info("UpdateSubscription request received", zap.String("subscription_id", idStr))
+	s.logger.InfoContext(r.Context(), "UpdateSubscription request received", zap.String("subscription_id", idStr))
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		s.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
 		return
 	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
 
 	var req dto.UpdateSubscriptionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -228,7 +513,7 @@ func (s *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.
 		return
 	}
 
-	s.logger.Debug("Decoded update request body", zap.Any("request_dto", req))
+	s.logger.DebugContext(r.Context(), "Decoded update request body", zap.Any("request_dto", req))
 
 	if err := validator.ValidateStruct(req); err != nil {
 		s.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
@@ -242,76 +527,346 @@ func (s *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.
 	}
 
 	sub.ID = id
+	sub.Version = version
+
+	warnings, err := s.service.UpdateSubscription(r.Context(), sub)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Subscription updated successfully",
+		zap.String("subscription_id", idStr),
+		zap.Int("warnings", len(warnings)),
+	)
+
+	response.APIResponse{
+		Code:     http.StatusOK,
+		Message:  "Subscription updated successfully",
+		Warnings: mapper.ToWarningResponses(warnings),
+	}.Send(w)
+}
+
+// @Summary      Patch Subscription
+// @Description  Changes only the fields sent, e.g. just the price or just the end date; the rest keep their
+// @Description  stored values. An empty end_date, cost_center or category clears it. If-Match must carry the
+// @Description  ETag the subscription was read with; a write based on an older version is rejected with 412.
+// @Description  Non-fatal issues are returned in `warnings`.
+// @Tags         Subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string                        true  "Subscription ID (UUID format)"
+// @Param        If-Match     header    string                        true  "ETag from GET /subscriptions/{id}, or * for any version"
+// @Param        subscription body      dto.PatchSubscriptionRequest  true  "Fields to change"
+// @Success      200          {object}  response.APIResponse
+// @Failure      400          {object}  apperrors.AppError "Invalid ID format or request body"
+// @Failure      404          {object}  apperrors.AppError "Subscription not found"
+// @Failure      412          {object}  apperrors.AppError "Subscription was changed since the ETag was read"
+// @Failure      428          {object}  apperrors.AppError "If-Match header missing"
+// @Failure      500          {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id} [patch]
+func (s *SubscriptionHandler) PatchSubscription(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	s.logger.InfoContext(r.Context(), "PatchSubscription request received", zap.String("subscription_id", id))
 
-	if err := s.service.UpdateSubscription(r.Context(), sub); err != nil {
+	if _, err := uuid.Parse(id); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
 		s.handleError(w, r, err)
 		return
 	}
+	var req dto.PatchSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+	patch, err := mapper.ToPatchFromDTO(req)
+	if err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("failed to parse date", err))
+		return
+	}
+	patch.Version = version
 
-	s.logger.Info("Subscription updated successfully", zap.String("subscription_id", idStr))
+	warnings, err := s.service.PatchSubscription(r.Context(), id, patch)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	s.logger.InfoContext(r.Context(), "Subscription patched successfully",
+		zap.String("subscription_id", id),
+		zap.Int("warnings", len(warnings)),
+	)
 
-	response.APIResponse{Code: http.StatusOK, Message: "Subscription updated successfully"}.Send(w)
+	response.APIResponse{
+		Code:     http.StatusOK,
+		Message:  "Subscription updated successfully",
+		Warnings: mapper.ToWarningResponses(warnings),
+	}.Send(w)
 }
 
 // @Summary      Delete Subscription
-// @Description  Deletes a subscription by its unique ID.
+// @Description  Moves a subscription to the trash. It stops counting everywhere and can be restored with
+// @Description  POST /subscriptions/trash/restore, or with POST /undo/{token} using the token in X-Undo-Token
+// @Description  until X-Undo-Expires-At.
 // @Tags         Subscriptions
 // @Produce      json
 // @Param        id   path      string  true  "Subscription ID (UUID format)"
 // @Success      204  "No Content"
+// @Header       204  {string}  X-Undo-Token "Token that reverses this delete"
+// @Header       204  {string}  X-Undo-Expires-At "When the undo token expires (RFC 3339)"
 // @Failure      400  {object}  apperrors.AppError "Invalid ID format"
 // @Failure      404  {object}  apperrors.AppError "Subscription not found"
 // @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
 // @Router       /subscriptions/{id} [delete]
 func (s *SubscriptionHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	s.logger.Info("DeleteSubscription request received", zap.String("subscription_id", id))
+	s.logger.InfoContext(r.Context(), "DeleteSubscription request received", zap.String("subscription_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+
+	undo, err := s.service.DeleteSubscription(r.Context(), id)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Subscription deleted successfully", zap.String("subscription_id", id))
+
+	w.Header().Set("X-Undo-Token", undo.Token)
+	w.Header().Set("X-Undo-Expires-At", undo.ExpiresAt.Format(time.RFC3339))
+	response.NoContent(w)
+}
+
+// @Summary      Undo Delete
+// @Description  Reverses a delete while its undo token is valid and returns the restored subscription. A token
+// @Description  works once; unknown, expired and used tokens are all reported as not found. The restored
+// @Description  subscription counts against the quota.
+// @Tags         Subscriptions
+// @Produce      json
+// @Param        token path      string  true  "Undo token from the X-Undo-Token header of the delete response"
+// @Success      200  {object}  dto.SubscriptionResponse
+// @Failure      403  {object}  apperrors.AppError "Subscription quota reached"
+// @Failure      404  {object}  apperrors.AppError "Undo token not found or expired"
+// @Failure      409  {object}  apperrors.AppError "A subscription with the same ID exists again"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /undo/{token} [post]
+func (s *SubscriptionHandler) UndoDelete(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "UndoDelete request received")
+
+	subscription, err := s.service.UndoDelete(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	s.logger.InfoContext(r.Context(), "Subscription delete undone successfully", zap.String("subscription_id", subscription.ID.String()))
+
+	response.JSON(w, http.StatusOK, mapper.ToDTOFromDomain(subscription))
+}
+
+// @Summary      Look Up Subscriptions
+// @Description  Resolves up to 100 subscription IDs in one request. IDs that do not exist or belong to another user
+// @Description  are listed in `not_found` instead of failing the request. Found subscriptions keep the requested order.
+// @Tags         Subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.LookupSubscriptionsRequest true "Subscription IDs"
+// @Success      200  {object}  dto.LookupSubscriptionsResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/lookup [post]
+func (s *SubscriptionHandler) LookupSubscriptions(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "LookupSubscriptions request received")
+
+	var req dto.LookupSubscriptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	result, err := s.service.LookupSubscriptions(r.Context(), req.IDs)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToLookupResponse(result))
+}
+
+// @Summary      Subscription History
+// @Description  Lists the recorded changes of a subscription, newest first: who made each change, when, and every
+// @Description  changed field's value before and after. The history stays readable after the subscription is deleted.
+// @Tags         Subscriptions
+// @Produce      json
+// @Param        id     path   string  true   "Subscription ID (UUID format)"
+// @Param        limit  query  int     false  "Maximum number of entries (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)"
+// @Success      200  {array}   dto.AuditEntryResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format or query parameters"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/history [get]
+func (s *SubscriptionHandler) SubscriptionHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	s.logger.InfoContext(r.Context(), "SubscriptionHistory request received", zap.String("subscription_id", id))
 
 	if _, err := uuid.Parse(id); err != nil {
 		s.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
 		return
 	}
+	var req dto.SubscriptionHistoryRequest
+	if err := binder.BindQuery(r.URL.Query(), &req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+	limit, err := s.limits.apply(req.Limit)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
 
-	if err := s.service.DeleteSubscription(r.Context(), id); err != nil {
+	entries, err := s.service.SubscriptionHistory(r.Context(), id, limit)
+	if err != nil {
 		s.handleError(w, r, err)
 		return
 	}
+	responseDTOs := make([]dto.AuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		responseDTOs[i] = mapper.ToAuditEntryResponse(entry)
+	}
+	response.JSON(w, http.StatusOK, responseDTOs)
+}
+
+// @Summary      List Trash
+// @Description  Lists deleted subscriptions that can still be restored, most recently deleted first.
+// @Tags         Subscriptions
+// @Produce      json
+// @Param        user_id query string false "User ID (UUID); defaults to the authenticated user unless they are an admin, for whom omitting it lists every user"
+// @Param        limit   query int    false "Pagination limit (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)"
+// @Param        offset  query int    false "Pagination offset (default 0)"
+// @Success      200  {object}  dto.TrashListResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid query parameters"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/trash [get]
+func (s *SubscriptionHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "ListTrash request received", zap.String("query", r.URL.RawQuery))
+
+	var filter dto.TrashFilter
+	if err := binder.BindQuery(r.URL.Query(), &filter); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+	limit, err := s.limits.apply(filter.Limit)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	filter.Limit = limit
+
+	trashed, err := s.service.ListTrash(r.Context(), filter)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+
+	responseDTOs := make([]dto.TrashedSubscriptionResponse, len(trashed))
+	for i, sub := range trashed {
+		responseDTOs[i] = mapper.ToTrashedDTOFromDomain(sub)
+	}
+	response.JSON(w, http.StatusOK, dto.TrashListResponse{
+		Items:      responseDTOs,
+		Pagination: dto.Pagination{Limit: filter.Limit, Offset: filter.Offset},
+	})
+}
 
-	s.logger.Info("Subscription deleted successfully", zap.String("subscription_id", id))
+// @Summary      Restore From Trash
+// @Description  Restores deleted subscriptions by ID, all of them or none. IDs that are not in the user's trash are
+// @Description  listed in `not_found` instead of failing the request. Restored subscriptions count against the quota.
+// @Tags         Subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.RestoreSubscriptionsRequest true "Subscriptions to restore"
+// @Success      200  {object}  dto.RestoreSubscriptionsResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "Quota exceeded or user_id is not the authenticated user"
+// @Failure      409  {object}  apperrors.AppError "A subscription with the same ID exists again"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/trash/restore [post]
+func (s *SubscriptionHandler) RestoreSubscriptions(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "RestoreSubscriptions request received")
+
+	var req dto.RestoreSubscriptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	result, err := s.service.RestoreSubscriptions(r.Context(), req.UserID, req.IDs)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	s.logger.InfoContext(r.Context(), "Subscriptions restored successfully",
+		zap.Int("restored", len(result.Restored)),
+		zap.Int("not_found", len(result.NotFound)),
+	)
 
-	w.WriteHeader(http.StatusNoContent)
+	response.JSON(w, http.StatusOK, mapper.ToRestoreResponse(result))
 }
 
 // @Summary      Calculate Total Cost
 // @Description  Calculates the total cost of subscriptions for a user over a specified period.
+// @Description  Yearly and weekly prices are normalized to months (a year of 52 weeks) before summing.
 // @Tags         Subscriptions
 // @Produce      json
 // @Param        user_id      query     string  true   "User ID (UUID format) for whom to calculate the cost"
 // @Param        period_start query     string  true   "Start of the calculation period (format: MM-YYYY)"
 // @Param        period_end   query     string  true   "End of the calculation period (format: MM-YYYY)"
 // @Param        service_name query     string  false  "Optional: filter by a specific service name"
+// @Param        exclude_unpaid query   bool    false  "Leave out months whose charge failed and is still unpaid"
 // @Success      200          {object}  dto.CostResponse
 // @Failure      400          {object}  apperrors.AppError "Invalid or missing parameters"
 // @Failure      500          {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
 // @Router       /subscriptions/cost [get]
 func (s *SubscriptionHandler) CalculateCost(w http.ResponseWriter, r *http.Request) {
-	s.logger.Info("CalculateCost request received", zap.String("query", r.URL.RawQuery))
+	s.logger.InfoContext(r.Context(), "CalculateCost request received", zap.String("query", r.URL.RawQuery))
 
-	query := r.URL.Query()
-	costRequest := dto.CostRequest{
-		UserID:      query.Get("user_id"),
-		ServiceName: query.Get("service_name"),
-		PeriodStart: query.Get("period_start"),
-		PeriodEnd:   query.Get("period_end"),
+	query, err := scopeQuery(r.Context(), r.URL.Query())
+	if err != nil {
+		s.handleError(w, r, err)
+		return
 	}
-
-	s.logger.Debug("Parsed cost request", zap.Any("request_dto", costRequest))
-
-	if err := validator.ValidateStruct(costRequest); err != nil {
+	var costRequest dto.CostRequest
+	if err := binder.BindQuery(query, &costRequest); err != nil {
 		s.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
 		return
 	}
+	s.logger.DebugContext(r.Context(), "Parsed cost request", zap.Any("request_dto", costRequest))
 
 	periodStart, _ := time.Parse("01-2006", costRequest.PeriodStart)
 	periodEnd, _ := time.Parse("01-2006", costRequest.PeriodEnd)
@@ -322,10 +877,11 @@ func (s *SubscriptionHandler) CalculateCost(w http.ResponseWriter, r *http.Reque
 	}
 
 	filter := dto.CostFilter{
-		UserID:      costRequest.UserID,
-		ServiceName: costRequest.ServiceName,
-		PeriodStart: periodStart,
-		PeriodEnd:   periodEnd,
+		UserID:        costRequest.UserID,
+		ServiceName:   costRequest.ServiceName,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		ExcludeUnpaid: costRequest.ExcludeUnpaid,
 	}
 
 	totalCost, err := s.service.CalculateCost(r.Context(), filter)
@@ -334,14 +890,201 @@ func (s *SubscriptionHandler) CalculateCost(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	s.logger.Info("Cost calculation completed successfully", zap.Int("total_cost", totalCost))
+	s.logger.InfoContext(r.Context(), "Cost calculation completed successfully", zap.Int("total_cost", totalCost))
 
 	responseDTO := dto.CostResponse{TotalCost: totalCost}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(responseDTO)
+	response.JSON(w, http.StatusOK, responseDTO)
+}
+
+// @Summary      Cost by Cost Center
+// @Description  Splits the total cost of a user's subscriptions over a period by cost center, for recharging
+// @Description  spend to departments. Subscriptions without a cost center are grouped under an empty name.
+// @Description  With format=csv the report is returned as a CSV attachment with ISO dates, one row per cost center.
+// @Tags         Subscriptions
+// @Produce      json
+// @Produce      text/csv
+// @Param        user_id      query     string  true   "User ID (UUID format) for whom to calculate the cost"
+// @Param        period_start query     string  true   "Start of the calculation period (format: MM-YYYY)"
+// @Param        period_end   query     string  true   "End of the calculation period (format: MM-YYYY)"
+// @Param        service_name query     string  false  "Optional: filter by a specific service name"
+// @Param        exclude_unpaid query   bool    false  "Leave out months whose charge failed and is still unpaid"
+// @Param        format       query     string  false  "Response format" Enums(json, csv) default(json)
+// @Success      200          {object}  dto.CostCenterReportResponse
+// @Failure      400          {object}  apperrors.AppError "Invalid or missing parameters"
+// @Failure      500          {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/cost/by-cost-center [get]
+func (s *SubscriptionHandler) CostByCostCenter(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "CostByCostCenter request received", zap.String("query", r.URL.RawQuery))
+
+	query, err := scopeQuery(r.Context(), r.URL.Query())
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	var req dto.CostCenterReportRequest
+	if err := binder.BindQuery(query, &req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+
+	periodStart, _ := time.Parse("01-2006", req.PeriodStart)
+	periodEnd, _ := time.Parse("01-2006", req.PeriodEnd)
+	if periodEnd.Before(periodStart) {
+		s.handleError(w, r, apperrors.NewBadRequest("period_end cannot be before period_start", nil))
+		return
+	}
+	filter := dto.CostFilter{
+		UserID:        req.UserID,
+		ServiceName:   req.ServiceName,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		ExcludeUnpaid: req.ExcludeUnpaid,
+	}
+
+	groups, err := s.service.CostByCostCenter(r.Context(), filter)
+	if err != nil {
+		s.handleError(w, r, err)
+		return
+	}
+	s.logger.InfoContext(r.Context(), "Cost by cost center completed successfully", zap.Int("cost_centers", len(groups)))
+
+	if req.Format == "csv" {
+		filename := fmt.Sprintf("cost-centers_%s_%s.csv", periodStart.Format("2006-01"), periodEnd.Format("2006-01"))
+		response.CSV(w, filename, costCenterRecords(filter, groups))
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToCostCenterReportResponse(filter, groups))
+}
+
+// costCenterRecords lays the report out for finance imports: ISO dates with the
+// period's first and last day, and the amount as a plain integer.
+func costCenterRecords(filter dto.CostFilter, groups []domain.CostCenterCost) [][]string {
+	from := filter.PeriodStart.Format(time.DateOnly)
+	to := filter.PeriodEnd.AddDate(0, 1, -1).Format(time.DateOnly)
+
+	records := [][]string{{"cost_center", "period_start", "period_end", "subscriptions", "amount"}}
+	for _, group := range groups {
+		records = append(records, []string{
+			group.CostCenter,
+			from,
+			to,
+			strconv.Itoa(group.Subscriptions),
+			strconv.Itoa(group.TotalCost),
+		})
+	}
+	return records
+}
+
+// applySavedFilter merges the saved params into query. Params given explicitly in the
+// request take precedence, while user_id is always pinned to the filter owner.
+func applySavedFilter(query url.Values, saved domain.SavedFilter) url.Values {
+	merged := url.Values{}
+	for key, values := range query {
+		merged[key] = values
+	}
+	for key, value := range saved.Params {
+		if merged.Get(key) == "" {
+			merged.Set(key, value)
+		}
+	}
+	merged.Set("user_id", saved.UserID.String())
+	return merged
+}
+
+// @Summary      Calculate Total Cost in Batch
+// @Description  Calculates total costs for several users/periods in one call. Items are computed concurrently;
+// @Description  a failing item reports its error without failing the whole batch.
+// @Tags         Subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        batch body      dto.CostBatchRequest true "Up to 100 cost requests"
+// @Success      200   {object}  dto.CostBatchResponse
+// @Failure      400   {object}  apperrors.AppError "Invalid request body or items"
+// @Security     BearerAuth
+// @Router       /subscriptions/cost/batch [post]
+func (s *SubscriptionHandler) CalculateCostBatch(w http.ResponseWriter, r *http.Request) {
+	s.logger.InfoContext(r.Context(), "CalculateCostBatch request received")
+
+	var req dto.CostBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	for i := range req.Items {
+		userID, err := service.ScopeUserID(r.Context(), req.Items[i].UserID)
+		if err != nil {
+			s.handleError(w, r, err)
+			return
+		}
+		req.Items[i].UserID = userID
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		s.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	filters := make([]dto.CostFilter, len(req.Items))
+	for i, item := range req.Items {
+		periodStart, _ := time.Parse("01-2006", item.PeriodStart)
+		periodEnd, _ := time.Parse("01-2006", item.PeriodEnd)
+		if periodEnd.Before(periodStart) {
+			s.handleError(w, r, apperrors.NewBadRequest(fmt.Sprintf("items[%d]: period_end cannot be before period_start", i), nil))
+			return
+		}
+		filters[i] = dto.CostFilter{
+			UserID:        item.UserID,
+			ServiceName:   item.ServiceName,
+			PeriodStart:   periodStart,
+			PeriodEnd:     periodEnd,
+			ExcludeUnpaid: item.ExcludeUnpaid,
+		}
+	}
+
+	results := s.service.CalculateCostBatch(r.Context(), filters)
+
+	responseDTO := dto.CostBatchResponse{Results: make([]dto.CostBatchItemResponse, len(results))}
+	failed := 0
+	for i, result := range results {
+		item := dto.CostBatchItemResponse{
+			UserID:      req.Items[i].UserID,
+			ServiceName: req.Items[i].ServiceName,
+			PeriodStart: req.Items[i].PeriodStart,
+			PeriodEnd:   req.Items[i].PeriodEnd,
+			TotalCost:   result.TotalCost,
+		}
+		if result.Err != nil {
+			failed++
+			item.Error = "Internal Server Error"
+			var appErr *apperrors.AppError
+			if errors.As(result.Err, &appErr) {
+				item.Error = appErr.Message
+			}
+		}
+		responseDTO.Results[i] = item
+	}
+
+	s.logger.InfoContext(r.Context(), "Batch cost calculation completed",
+		zap.Int("items", len(results)),
+		zap.Int("failed", failed),
+	)
+
+	response.JSON(w, http.StatusOK, responseDTO)
 }
 
 func (s *SubscriptionHandler) ServeSwaggerJSON(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "./docs/swagger.json")
 }
+
+// setQuotaHeader reports the user's remaining quota in X-Quota-Remaining. A
+// failed lookup only costs the header, since the write already succeeded.
+func (s *SubscriptionHandler) setQuotaHeader(w http.ResponseWriter, r *http.Request, userID string) {
+	quota, err := s.service.QuotaStatus(r.Context(), userID)
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "Failed to read quota status", zap.String("user_id", userID), zap.Error(err))
+		return
+	}
+	if quota.Enabled() {
+		w.Header().Set("X-Quota-Remaining", strconv.Itoa(quota.Remaining()))
+	}
+}
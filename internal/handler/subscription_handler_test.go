@@ -2,15 +2,21 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"subtracker/internal/domain"
 	"subtracker/internal/domain/dto"
+	"subtracker/internal/service"
 	"subtracker/internal/service/mocks"
 	"subtracker/pkg/apperrors"
 	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -20,7 +26,7 @@ import (
 
 func TestCreateSubscription(t *testing.T) {
 	mockService := new(mocks.SubscriptionServiceInterface)
-	handler := NewSubscriptionHandler(mockService, logger.NewNopLogger())
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := dto.CreateSubscriptionRequest{
@@ -31,13 +37,60 @@ func TestCreateSubscription(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockService.On("CreateSubscription", mock.Anything, mock.AnythingOfType("domain.Subscription")).Return(nil).Once()
+		mockService.On("CreateSubscription", mock.Anything, mock.AnythingOfType("domain.Subscription")).Return(nil, nil).Once()
+		mockService.On("QuotaStatus", mock.Anything, reqBody.UserID).Return(domain.QuotaStatus{}, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewReader(body))
 		rr := httptest.NewRecorder()
 		handler.CreateSubscription(rr, req)
 
 		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-Quota-Remaining"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Success With Quota", func(t *testing.T) {
+		reqBody := dto.CreateSubscriptionRequest{
+			ServiceName: "Netflix",
+			Price:       500,
+			UserID:      uuid.New().String(),
+			StartDate:   "01-2025",
+		}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.On("CreateSubscription", mock.Anything, mock.AnythingOfType("domain.Subscription")).Return(nil, nil).Once()
+		mockService.On("QuotaStatus", mock.Anything, reqBody.UserID).Return(domain.QuotaStatus{Limit: 10, Used: 9}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSubscription(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, "1", rr.Header().Get("X-Quota-Remaining"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Success With Warnings", func(t *testing.T) {
+		reqBody := dto.CreateSubscriptionRequest{
+			ServiceName: "Netflix",
+			Price:       1,
+			UserID:      uuid.New().String(),
+			StartDate:   "01-2025",
+		}
+		body, _ := json.Marshal(reqBody)
+		warnings := []domain.Warning{{Code: "probable_duplicate", Message: "duplicate"}}
+
+		mockService.On("CreateSubscription", mock.Anything, mock.AnythingOfType("domain.Subscription")).Return(warnings, nil).Once()
+		mockService.On("QuotaStatus", mock.Anything, reqBody.UserID).Return(domain.QuotaStatus{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSubscription(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var respBody response.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, []response.Warning{{Code: "probable_duplicate", Message: "duplicate"}}, respBody.Warnings)
 		mockService.AssertExpectations(t)
 	})
 
@@ -52,11 +105,103 @@ func TestCreateSubscription(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 		mockService.AssertNotCalled(t, "CreateSubscription")
 	})
+
+	t.Run("Unknown Billing Period", func(t *testing.T) {
+		reqBody := dto.CreateSubscriptionRequest{
+			ServiceName:   "Netflix",
+			Price:         500,
+			UserID:        uuid.New().String(),
+			StartDate:     "01-2025",
+			BillingPeriod: "daily",
+		}
+		body, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSubscription(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "CreateSubscription")
+	})
+
+	t.Run("Unknown Expense Type", func(t *testing.T) {
+		reqBody := dto.CreateSubscriptionRequest{
+			ServiceName: "Gym",
+			Price:       3000,
+			UserID:      uuid.New().String(),
+			StartDate:   "01-2025",
+			ExpenseType: "membership",
+		}
+		body, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSubscription(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "CreateSubscription")
+	})
 }
 
+func TestCreateSubscriptionsBatch(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	userID := uuid.New().String()
+	valid := dto.CreateSubscriptionRequest{ServiceName: "Netflix", Price: 500, UserID: userID, StartDate: "01-2025"}
+
+	t.Run("Reports Each Item", func(t *testing.T) {
+		createdID := uuid.New()
+		quotaErr := apperrors.New(http.StatusForbidden, "subscription quota of 1 reached", nil).WithErrorCode(service.ErrCodeQuotaExceeded)
+		mockService.On("CreateSubscriptions", mock.Anything, mock.MatchedBy(func(subs []domain.Subscription) bool {
+			return len(subs) == 2
+		})).Return([]service.CreateResult{{ID: createdID}, {Err: quotaErr}}, nil).Once()
+		body, _ := json.Marshal([]dto.CreateSubscriptionRequest{valid, {Price: -1}, valid})
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/batch", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSubscriptionsBatch(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.CreateBatchResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, 1, respBody.Created)
+		assert.Equal(t, 2, respBody.Rejected)
+		assert.Equal(t, createdID.String(), respBody.Results[0].ID)
+		assert.Equal(t, "rejected", respBody.Results[1].Status)
+		assert.Contains(t, respBody.Results[1].Error, "Price")
+		assert.Equal(t, service.ErrCodeQuotaExceeded, respBody.Results[2].ErrorCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Empty Batch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/batch", bytes.NewReader([]byte(`[]`)))
+		rr := httptest.NewRecorder()
+		handler.CreateSubscriptionsBatch(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Failed Write", func(t *testing.T) {
+		mockService.On("CreateSubscriptions", mock.Anything, mock.Anything).
+			Return(nil, apperrors.NewBadRequest("user does not exist", nil)).Once()
+		body, _ := json.Marshal([]dto.CreateSubscriptionRequest{valid})
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/batch", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CreateSubscriptionsBatch(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+var testListLimits = ListLimits{Default: 10, Max: 100}
+
+var testExportLimits = ExportLimits{BatchRows: 1, FlushTimeout: time.Second}
+
 func TestListSubscriptions(t *testing.T) {
 	mockService := new(mocks.SubscriptionServiceInterface)
-	handler := NewSubscriptionHandler(mockService, logger.NewNopLogger())
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
 
 	t.Run("Success", func(t *testing.T) {
 		mockResponse := []domain.Subscription{{ID: uuid.New()}}
@@ -67,12 +212,88 @@ func TestListSubscriptions(t *testing.T) {
 		handler.ListSubscriptions(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
-		var responseBody []dto.SubscriptionResponse
+		var responseBody dto.SubscriptionListResponse
+		json.Unmarshal(rr.Body.Bytes(), &responseBody)
+		assert.Len(t, responseBody.Items, 1)
+		assert.Equal(t, 5, responseBody.Pagination.Limit)
+		// A short first page is the whole result, so nothing is counted.
+		assert.Equal(t, 1, responseBody.Pagination.Total)
+		assert.False(t, responseBody.Pagination.HasMore)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "CountSubscriptions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Full Page Is Counted", func(t *testing.T) {
+		mockService.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return([]domain.Subscription{{ID: uuid.New()}}, nil).Once()
+		mockService.On("CountSubscriptions", mock.Anything, mock.MatchedBy(func(f dto.SubscriptionFilter) bool {
+			return f.Limit == 1 && f.Offset == 1
+		})).Return(3, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions?limit=1&offset=1", nil)
+		rr := httptest.NewRecorder()
+		handler.ListSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var responseBody dto.SubscriptionListResponse
 		json.Unmarshal(rr.Body.Bytes(), &responseBody)
-		assert.Len(t, responseBody, 1)
+		assert.Equal(t, 3, responseBody.Pagination.Total)
+		assert.True(t, responseBody.Pagination.HasMore)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Count Error", func(t *testing.T) {
+		mockService.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return([]domain.Subscription{{ID: uuid.New()}}, nil).Once()
+		mockService.On("CountSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
+			Return(0, apperrors.NewInternalServerError("database error on count", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions?limit=1", nil)
+		rr := httptest.NewRecorder()
+		handler.ListSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Applies Default Limit", func(t *testing.T) {
+		mockService.On("ListSubscriptions", mock.Anything, mock.MatchedBy(func(f dto.SubscriptionFilter) bool {
+			return f.Limit == testListLimits.Default
+		})).Return([]domain.Subscription{}, nil).Once()
+		// An empty page past the first cannot tell whether it overshot.
+		mockService.On("CountSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(12, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions?offset=20", nil)
+		rr := httptest.NewRecorder()
+		handler.ListSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var responseBody dto.SubscriptionListResponse
+		json.Unmarshal(rr.Body.Bytes(), &responseBody)
+		assert.Equal(t, dto.CountedPagination{Pagination: dto.Pagination{Limit: testListLimits.Default, Offset: 20}, Total: 12}, responseBody.Pagination)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Binds Typed Params", func(t *testing.T) {
+		mockService.On("ListSubscriptions", mock.Anything, mock.MatchedBy(func(f dto.SubscriptionFilter) bool {
+			return f.MinPrice == 100 && f.MaxPrice == 500 && f.HasEndDate != nil && *f.HasEndDate && f.Sort == "-price"
+		})).Return([]domain.Subscription{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions?min_price=100&max_price=500&has_end_date=1&sort=-price", nil)
+		rr := httptest.NewRecorder()
+		handler.ListSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Malformed Integer", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions?min_price=cheap", nil)
+		rr := httptest.NewRecorder()
+		handler.ListSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "ListSubscriptions")
+	})
+
 	t.Run("Validation Error on Filter", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/subscriptions?limit=200", nil)
 		rr := httptest.NewRecorder()
@@ -83,15 +304,190 @@ func TestListSubscriptions(t *testing.T) {
 	})
 }
 
+func TestListSubscriptionsWithSavedFilter(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	mockSavedFilters := new(mocks.SavedFilterServiceInterface)
+	handler := NewSubscriptionHandler(mockService, mockSavedFilters, new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+
+	t.Run("Applies Saved Params", func(t *testing.T) {
+		saved := domain.SavedFilter{
+			ID:     uuid.New(),
+			UserID: uuid.New(),
+			Params: map[string]string{"service_name": "Netflix", "min_price": "1000"},
+		}
+		mockSavedFilters.On("GetSavedFilter", mock.Anything, saved.ID.String()).Return(saved, nil).Once()
+		mockService.On("ListSubscriptions", mock.Anything, mock.MatchedBy(func(f dto.SubscriptionFilter) bool {
+			return f.UserID == saved.UserID.String() && f.ServiceName == "Netflix" && f.MinPrice == 1500
+		})).Return([]domain.Subscription{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions?saved_filter="+saved.ID.String()+"&min_price=1500", nil)
+		rr := httptest.NewRecorder()
+		handler.ListSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockSavedFilters.AssertExpectations(t)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Saved Filter Not Found", func(t *testing.T) {
+		id := uuid.New().String()
+		mockSavedFilters.On("GetSavedFilter", mock.Anything, id).
+			Return(domain.SavedFilter{}, apperrors.NewNotFound("saved filter not found", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions?saved_filter="+id, nil)
+		rr := httptest.NewRecorder()
+		handler.ListSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockSavedFilters.AssertExpectations(t)
+	})
+}
+
+func TestExportSubscriptions(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	end := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	sub := domain.Subscription{
+		ID:            uuid.MustParse("0194d3a0-0000-7000-8000-000000000001"),
+		UserID:        uuid.MustParse("0194d3a0-0000-7000-8000-000000000002"),
+		ServiceName:   "Netflix, Premium",
+		Price:         999,
+		StartDate:     time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       &end,
+		Category:      "video",
+		BillingPeriod: domain.BillingMonthly,
+		ExpenseType:   domain.ExpenseSubscription,
+	}
+	streams := func(subs ...domain.Subscription) func(context.Context, dto.SubscriptionFilter, func(domain.Subscription) error) error {
+		return func(_ context.Context, _ dto.SubscriptionFilter, fn func(domain.Subscription) error) error {
+			for _, sub := range subs {
+				if err := fn(sub); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	t.Run("CSV Without Default Limit", func(t *testing.T) {
+		mockService.On("ExportSubscriptions", mock.Anything, mock.MatchedBy(func(f dto.SubscriptionFilter) bool {
+			return f.Limit == 0 && f.Category == "video"
+		}), mock.Anything).Return(streams(sub)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/export?category=video", nil)
+		rr := httptest.NewRecorder()
+		handler.ExportSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="subscriptions.csv"`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,user_id,service_name,price,billing_period,start_date,end_date,cost_center,category,expense_type\n"+
+			"0194d3a0-0000-7000-8000-000000000001,0194d3a0-0000-7000-8000-000000000002,\"Netflix, Premium\",999,monthly,11-2025,03-2026,,video,subscription\n",
+			rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("XLSX With No Rows", func(t *testing.T) {
+		mockService.On("ExportSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter"), mock.Anything).Return(streams()).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/export?format=xlsx", nil)
+		rr := httptest.NewRecorder()
+		handler.ExportSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="subscriptions.xlsx"`, rr.Header().Get("Content-Disposition"))
+		assert.True(t, bytes.HasPrefix(rr.Body.Bytes(), []byte("PK")), "body should be a zip archive")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/export?format=pdf", nil)
+		rr := httptest.NewRecorder()
+		handler.ExportSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "ExportSubscriptions")
+	})
+
+	t.Run("Flushes Each Batch", func(t *testing.T) {
+		mockService.On("ExportSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter"), mock.Anything).Return(streams(sub)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/export", nil)
+		rr := httptest.NewRecorder()
+		handler.ExportSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, rr.Flushed)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Slow Client Stops The Stream", func(t *testing.T) {
+		streamed := 0
+		mockService.On("ExportSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter"), mock.Anything).
+			Return(func(_ context.Context, _ dto.SubscriptionFilter, fn func(domain.Subscription) error) error {
+				for range 3 {
+					streamed++
+					if err := fn(sub); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/export", nil)
+		stalled := &stalledWriter{ResponseRecorder: httptest.NewRecorder()}
+		handler.ExportSubscriptions(stalled, req)
+
+		assert.Equal(t, 1, streamed, "the first batch that cannot be sent ends the stream")
+		assert.Zero(t, stalled.Body.Len())
+		if assert.NotEmpty(t, stalled.deadlines) {
+			assert.True(t, stalled.deadlines[0].After(time.Now()))
+			assert.True(t, stalled.deadlines[len(stalled.deadlines)-1].IsZero(), "the deadline is lifted afterwards")
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error Before First Row", func(t *testing.T) {
+		mockService.On("ExportSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter"), mock.Anything).
+			Return(apperrors.NewInternalServerError("database error on list", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/export", nil)
+		rr := httptest.NewRecorder()
+		handler.ExportSubscriptions(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Empty(t, rr.Header().Get("Content-Disposition"))
+		mockService.AssertExpectations(t)
+	})
+}
+
+// stalledWriter is a client that stopped reading: every write runs into the
+// write deadline.
+type stalledWriter struct {
+	*httptest.ResponseRecorder
+	deadlines []time.Time
+}
+
+func (w *stalledWriter) Write([]byte) (int, error) {
+	return 0, os.ErrDeadlineExceeded
+}
+
+func (w *stalledWriter) SetWriteDeadline(deadline time.Time) error {
+	w.deadlines = append(w.deadlines, deadline)
+	return nil
+}
+
 func TestGetSubscription(t *testing.T) {
 	mockService := new(mocks.SubscriptionServiceInterface)
-	handler := NewSubscriptionHandler(mockService, logger.NewNopLogger())
+	mockReports := new(mocks.ReportServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), mockReports, testListLimits, testExportLimits, logger.NewNopLogger())
 	router := chi.NewRouter()
 	router.Get("/subscriptions/{id}", handler.GetSubscription)
 
 	t.Run("Success", func(t *testing.T) {
 		testID := uuid.New()
-		mockResponse := domain.Subscription{ID: testID}
+		mockResponse := domain.Subscription{ID: testID, Version: 42}
 		mockService.On("GetSubscription", mock.Anything, testID.String()).Return(mockResponse, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+testID.String(), nil)
@@ -99,10 +495,42 @@ func TestGetSubscription(t *testing.T) {
 		router.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"42"`, rr.Header().Get("ETag"))
 		var respBody dto.SubscriptionResponse
 		json.Unmarshal(rr.Body.Bytes(), &respBody)
 		assert.Equal(t, testID.String(), respBody.ID)
 		mockService.AssertExpectations(t)
+		mockReports.AssertNotCalled(t, "ServiceBenchmark")
+	})
+
+	t.Run("With Benchmark", func(t *testing.T) {
+		testID := uuid.New()
+		mockService.On("GetSubscription", mock.Anything, testID.String()).Return(domain.Subscription{ID: testID, ServiceName: "Netflix", Price: 499}, nil).Once()
+		mockReports.On("ServiceBenchmark", mock.Anything, "Netflix").Return(domain.ServiceBenchmark{ServiceName: "Netflix", AveragePrice: 520}, true, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions/"+testID.String()+"?benchmark=true", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.SubscriptionDetailResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &respBody))
+		assert.Equal(t, testID.String(), respBody.ID)
+		assert.Equal(t, 520, respBody.Benchmark.AveragePrice)
+		assert.Equal(t, -21, respBody.Benchmark.Difference)
+		mockReports.AssertExpectations(t)
+	})
+
+	t.Run("Benchmark Unavailable", func(t *testing.T) {
+		testID := uuid.New()
+		mockService.On("GetSubscription", mock.Anything, testID.String()).Return(domain.Subscription{ID: testID, ServiceName: "Niche"}, nil).Once()
+		mockReports.On("ServiceBenchmark", mock.Anything, "Niche").Return(domain.ServiceBenchmark{}, false, errors.New("db down")).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions/"+testID.String()+"?benchmark=true", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "benchmark")
+		mockReports.AssertExpectations(t)
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
@@ -118,6 +546,22 @@ func TestGetSubscription(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Client Cancelled", func(t *testing.T) {
+		testID := uuid.New().String()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		repoErr := apperrors.NewInternalServerError("database error on get", context.Canceled)
+		mockService.On("GetSubscription", mock.Anything, testID).Return(domain.Subscription{}, repoErr).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+testID, nil).WithContext(ctx)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, StatusClientClosedRequest, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("Invalid ID Format", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/subscriptions/not-a-uuid", nil)
 		rr := httptest.NewRecorder()
@@ -130,7 +574,7 @@ func TestGetSubscription(t *testing.T) {
 
 func TestUpdateSubscription(t *testing.T) {
 	mockService := new(mocks.SubscriptionServiceInterface)
-	handler := NewSubscriptionHandler(mockService, logger.NewNopLogger())
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
 	router := chi.NewRouter()
 	router.Put("/subscriptions/{id}", handler.UpdateSubscription)
 
@@ -139,9 +583,12 @@ func TestUpdateSubscription(t *testing.T) {
 		reqBody := dto.UpdateSubscriptionRequest{ServiceName: "New Name", Price: 123, StartDate: "02-2025"}
 		body, _ := json.Marshal(reqBody)
 
-		mockService.On("UpdateSubscription", mock.Anything, mock.AnythingOfType("domain.Subscription")).Return(nil).Once()
+		mockService.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(sub domain.Subscription) bool {
+			return sub.ID == testID && sub.Version == 42
+		})).Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+testID.String(), bytes.NewReader(body))
+		req.Header.Set("If-Match", `"42"`)
 		rr := httptest.NewRecorder()
 		router.ServeHTTP(rr, req)
 
@@ -155,36 +602,109 @@ func TestUpdateSubscription(t *testing.T) {
 		body, _ := json.Marshal(reqBody)
 
 		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+testID, bytes.NewReader(body))
+		req.Header.Set("If-Match", `"42"`)
 		rr := httptest.NewRecorder()
 		router.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 		mockService.AssertNotCalled(t, "UpdateSubscription")
 	})
+
+	t.Run("Stale ETag", func(t *testing.T) {
+		testID := uuid.New()
+		body, _ := json.Marshal(dto.UpdateSubscriptionRequest{ServiceName: "New Name", Price: 123, StartDate: "02-2025"})
+		mockService.On("UpdateSubscription", mock.Anything, mock.AnythingOfType("domain.Subscription")).
+			Return(nil, apperrors.New(http.StatusPreconditionFailed, "subscription was changed since it was read", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+testID.String(), bytes.NewReader(body))
+		req.Header.Set("If-Match", `"41"`)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestPatchSubscription(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Patch("/subscriptions/{id}", handler.PatchSubscription)
+
+	t.Run("Success", func(t *testing.T) {
+		testID := uuid.New().String()
+		price := 1299
+		mockService.On("PatchSubscription", mock.Anything, testID, domain.SubscriptionPatch{Price: &price, ClearEndDate: true}).Return(nil, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPatch, "/subscriptions/"+testID, bytes.NewReader([]byte(`{"price":1299,"end_date":""}`)))
+		req.Header.Set("If-Match", "*")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		testID := uuid.New().String()
+
+		req := httptest.NewRequest(http.MethodPatch, "/subscriptions/"+testID, bytes.NewReader([]byte(`{"service_name":""}`)))
+		req.Header.Set("If-Match", `"7"`)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "PatchSubscription")
+	})
+
+	t.Run("If-Match Required", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/subscriptions/"+uuid.NewString(), bytes.NewReader([]byte(`{"price":1299}`)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, rr.Code)
+		mockService.AssertNotCalled(t, "PatchSubscription")
+	})
+
+	t.Run("Weak ETag Never Matches", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/subscriptions/"+uuid.NewString(), bytes.NewReader([]byte(`{"price":1299}`)))
+		req.Header.Set("If-Match", `W/"7"`)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+		mockService.AssertNotCalled(t, "PatchSubscription")
+	})
 }
 
 func TestDeleteSubscription(t *testing.T) {
 	mockService := new(mocks.SubscriptionServiceInterface)
-	handler := NewSubscriptionHandler(mockService, logger.NewNopLogger())
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
 	router := chi.NewRouter()
 	router.Delete("/subscriptions/{id}", handler.DeleteSubscription)
 
 	t.Run("Success", func(t *testing.T) {
 		testID := uuid.New().String()
-		mockService.On("DeleteSubscription", mock.Anything, testID).Return(nil).Once()
+		undo := domain.UndoToken{Token: "undo-token", ExpiresAt: time.Date(2025, 7, 1, 10, 5, 0, 0, time.UTC)}
+		mockService.On("DeleteSubscription", mock.Anything, testID).Return(undo, nil).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/subscriptions/"+testID, nil)
 		rr := httptest.NewRecorder()
 		router.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Content-Type"))
+		assert.Equal(t, "undo-token", rr.Header().Get("X-Undo-Token"))
+		assert.Equal(t, "2025-07-01T10:05:00Z", rr.Header().Get("X-Undo-Expires-At"))
 		mockService.AssertExpectations(t)
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
 		testID := uuid.New().String()
 		repoErr := apperrors.NewNotFound("not found", nil)
-		mockService.On("DeleteSubscription", mock.Anything, testID).Return(repoErr).Once()
+		mockService.On("DeleteSubscription", mock.Anything, testID).Return(domain.UndoToken{}, repoErr).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/subscriptions/"+testID, nil)
 		rr := httptest.NewRecorder()
@@ -195,9 +715,157 @@ func TestDeleteSubscription(t *testing.T) {
 	})
 }
 
+func TestUndoDelete(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Post("/undo/{token}", handler.UndoDelete)
+
+	t.Run("Success", func(t *testing.T) {
+		sub := domain.Subscription{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Netflix", Price: 999, BillingPeriod: domain.BillingMonthly}
+		mockService.On("UndoDelete", mock.Anything, "undo-token").Return(sub, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/undo/undo-token", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.SubscriptionResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, sub.ID.String(), respBody.ID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockService.On("UndoDelete", mock.Anything, "stale").Return(domain.Subscription{}, apperrors.NewNotFound("undo token not found or expired", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/undo/stale", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestLookupSubscriptions(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Post("/subscriptions/lookup", handler.LookupSubscriptions)
+	found, missing := uuid.New(), uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.On("LookupSubscriptions", mock.Anything, []string{found.String(), missing.String()}).Return(domain.LookupResult{
+			Found:    []domain.Subscription{{ID: found, ServiceName: "Netflix", Price: 999, BillingPeriod: domain.BillingMonthly}},
+			NotFound: []uuid.UUID{missing},
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/subscriptions/lookup", bytes.NewReader([]byte(`{"ids":["`+found.String()+`","`+missing.String()+`"]}`))))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.LookupSubscriptionsResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Len(t, respBody.Items, 1)
+		assert.Equal(t, found.String(), respBody.Items[0].ID)
+		assert.Equal(t, []string{missing.String()}, respBody.NotFound)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/subscriptions/lookup", bytes.NewReader([]byte(`{"ids":["nope"]}`))))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSubscriptionHistory(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/subscriptions/{id}/history", handler.SubscriptionHistory)
+	subID := uuid.MustParse("6f1c2c9e-8d1e-4a57-9a43-2f0f3c1c5a10")
+	actorID := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.On("SubscriptionHistory", mock.Anything, subID.String(), testListLimits.Default).Return([]domain.AuditEntry{{
+			ID: 7, EntityID: subID, ActorID: &actorID, Action: domain.AuditUpdate,
+			Changes:   map[string]domain.FieldChange{"price": {Before: 999, After: 1099}},
+			ChangedAt: time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC),
+		}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions/"+subID.String()+"/history", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `[{"id":7,"action":"update","actor_id":"`+actorID.String()+`","changed_at":"2025-07-01T10:00:00Z",
+			"changes":{"price":{"before":999,"after":1099}}}]`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/subscriptions/not-a-uuid/history", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestTrash(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/subscriptions/trash", handler.ListTrash)
+	router.Post("/subscriptions/trash/restore", handler.RestoreSubscriptions)
+	userID := uuid.New()
+
+	t.Run("List", func(t *testing.T) {
+		deletedAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+		mockService.On("ListTrash", mock.Anything, dto.TrashFilter{UserID: userID.String(), Limit: testListLimits.Default}).
+			Return([]domain.TrashedSubscription{{Subscription: domain.Subscription{ID: uuid.New(), UserID: userID, ServiceName: "Netflix"}, DeletedAt: deletedAt}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/trash?user_id="+userID.String(), nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.TrashListResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Len(t, respBody.Items, 1)
+		assert.Equal(t, "2025-07-01T10:00:00Z", respBody.Items[0].DeletedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		restored, missing := uuid.New(), uuid.New()
+		ids := []string{restored.String(), missing.String()}
+		body, _ := json.Marshal(dto.RestoreSubscriptionsRequest{UserID: userID.String(), IDs: ids})
+		mockService.On("RestoreSubscriptions", mock.Anything, userID.String(), ids).
+			Return(domain.RestoreResult{Restored: []uuid.UUID{restored}, NotFound: []uuid.UUID{missing}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/trash/restore", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"restored":["`+restored.String()+`"],"not_found":["`+missing.String()+`"]}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Restore Without IDs", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/trash/restore", bytes.NewReader([]byte(`{"ids":[]}`)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
 func TestCalculateCost(t *testing.T) {
 	mockService := new(mocks.SubscriptionServiceInterface)
-	handler := NewSubscriptionHandler(mockService, logger.NewNopLogger())
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
 
 	t.Run("Success", func(t *testing.T) {
 		mockService.On("CalculateCost", mock.Anything, mock.AnythingOfType("dto.CostFilter")).Return(1500, nil).Once()
@@ -214,6 +882,20 @@ func TestCalculateCost(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Excluding Unpaid Months", func(t *testing.T) {
+		mockService.On("CalculateCost", mock.Anything, mock.MatchedBy(func(f dto.CostFilter) bool {
+			return f.ExcludeUnpaid
+		})).Return(1000, nil).Once()
+
+		url := "/subscriptions/cost?user_id=" + uuid.New().String() + "&period_start=01-2025&period_end=03-2025&exclude_unpaid=true"
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		rr := httptest.NewRecorder()
+		handler.CalculateCost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("Validation Error", func(t *testing.T) {
 		url := "/subscriptions/cost?user_id=not-a-uuid&period_start=01-2025&period_end=03-2025"
 		req := httptest.NewRequest(http.MethodGet, url, nil)
@@ -224,3 +906,105 @@ func TestCalculateCost(t *testing.T) {
 		mockService.AssertNotCalled(t, "CalculateCost")
 	})
 }
+
+func TestCostByCostCenter(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+	groups := []domain.CostCenterCost{
+		{CostCenter: "", Subscriptions: 1, TotalCost: 30},
+		{CostCenter: "Marketing", Subscriptions: 2, TotalCost: 120},
+	}
+	url := "/subscriptions/cost/by-cost-center?user_id=" + uuid.New().String() + "&period_start=01-2025&period_end=03-2025"
+
+	t.Run("JSON", func(t *testing.T) {
+		mockService.On("CostByCostCenter", mock.Anything, mock.AnythingOfType("dto.CostFilter")).Return(groups, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.CostByCostCenter(rr, httptest.NewRequest(http.MethodGet, url, nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.CostCenterReportResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &respBody))
+		assert.Equal(t, 150, respBody.TotalCost)
+		assert.Len(t, respBody.CostCenters, 2)
+		assert.Equal(t, "Marketing", respBody.CostCenters[1].CostCenter)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		mockService.On("CostByCostCenter", mock.Anything, mock.AnythingOfType("dto.CostFilter")).Return(groups, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.CostByCostCenter(rr, httptest.NewRequest(http.MethodGet, url+"&format=csv", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "cost-centers_2025-01_2025-03.csv")
+		assert.Equal(t, "cost_center,period_start,period_end,subscriptions,amount\n"+
+			",2025-01-01,2025-03-31,1,30\n"+
+			"Marketing,2025-01-01,2025-03-31,2,120\n", rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.CostByCostCenter(rr, httptest.NewRequest(http.MethodGet, url+"&format=xml", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestCalculateCostBatch(t *testing.T) {
+	mockService := new(mocks.SubscriptionServiceInterface)
+	handler := NewSubscriptionHandler(mockService, new(mocks.SavedFilterServiceInterface), new(mocks.ReportServiceInterface), testListLimits, testExportLimits, logger.NewNopLogger())
+
+	t.Run("Success With Partial Failure", func(t *testing.T) {
+		reqBody := dto.CostBatchRequest{Items: []dto.CostRequest{
+			{UserID: uuid.New().String(), PeriodStart: "01-2025", PeriodEnd: "03-2025"},
+			{UserID: uuid.New().String(), PeriodStart: "01-2025", PeriodEnd: "12-2025"},
+		}}
+		body, _ := json.Marshal(reqBody)
+		results := []service.CostResult{
+			{TotalCost: 1500},
+			{Err: apperrors.NewInternalServerError("database error on cost calculation", nil)},
+		}
+		mockService.On("CalculateCostBatch", mock.Anything, mock.MatchedBy(func(f []dto.CostFilter) bool {
+			return len(f) == 2 && f[0].UserID == reqBody.Items[0].UserID
+		})).Return(results).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/cost/batch", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CalculateCostBatch(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.CostBatchResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Len(t, respBody.Results, 2)
+		assert.Equal(t, 1500, respBody.Results[0].TotalCost)
+		assert.Empty(t, respBody.Results[0].Error)
+		assert.Equal(t, "database error on cost calculation", respBody.Results[1].Error)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Inverted Period", func(t *testing.T) {
+		reqBody := dto.CostBatchRequest{Items: []dto.CostRequest{
+			{UserID: uuid.New().String(), PeriodStart: "05-2025", PeriodEnd: "03-2025"},
+		}}
+		body, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/cost/batch", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CalculateCostBatch(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "CalculateCostBatch")
+	})
+
+	t.Run("Empty Batch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/subscriptions/cost/batch", bytes.NewReader([]byte(`{"items":[]}`)))
+		rr := httptest.NewRecorder()
+		handler.CalculateCostBatch(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
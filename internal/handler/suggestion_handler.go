@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/binder"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SuggestionHandler struct {
+	service service.SuggestionServiceInterface
+	logger  logger.Logger
+}
+
+func NewSuggestionHandler(service service.SuggestionServiceInterface, logger logger.Logger) *SuggestionHandler {
+	return &SuggestionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *SuggestionHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// suggestionID parses the {id} path param.
+func (h *SuggestionHandler) suggestionID(r *http.Request) (string, error) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", apperrors.NewBadRequest("invalid suggestion ID format", err)
+	}
+	return id, nil
+}
+
+// @Summary      Submit Suggestion
+// @Description  Queues an automatically detected subscription for review. Suggestions never appear among the
+// @Description  user's subscriptions until they are accepted or merged.
+// @Tags         Suggestions
+// @Accept       json
+// @Produce      json
+// @Param        suggestion body dto.CreateSuggestionRequest true "Detected subscription"
+// @Success      201  {object}  dto.SuggestionResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /suggestions [post]
+func (h *SuggestionHandler) SubmitSuggestion(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "SubmitSuggestion request received")
+
+	var req dto.CreateSuggestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	suggestion, err := mapper.ToSuggestionDomainFromDTO(req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid date or user ID format", err))
+		return
+	}
+
+	created, err := h.service.SubmitSuggestion(r.Context(), suggestion)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Suggestion queued successfully",
+		zap.String("suggestion_id", created.ID.String()),
+		zap.String("source", created.Source),
+	)
+
+	response.JSON(w, http.StatusCreated, mapper.ToSuggestionDTOFromDomain(created))
+}
+
+// @Summary      List Suggestions
+// @Description  Lists a user's suggestions, oldest first. Without status only the pending review queue is listed.
+// @Tags         Suggestions
+// @Produce      json
+// @Param        user_id query string false "User ID (UUID); defaults to the authenticated user"
+// @Param        status  query string false "Suggestion status" Enums(pending, accepted, rejected, merged)
+// @Success      200  {array}   dto.SuggestionResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid query parameters"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /suggestions [get]
+func (h *SuggestionHandler) ListSuggestions(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "ListSuggestions request received", zap.String("query", r.URL.RawQuery))
+
+	var req dto.ListSuggestionsRequest
+	if err := binder.BindQuery(r.URL.Query(), &req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+
+	suggestions, err := h.service.ListSuggestions(r.Context(), req.UserID, domain.SuggestionStatus(req.Status))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	responseDTOs := make([]dto.SuggestionResponse, len(suggestions))
+	for i, suggestion := range suggestions {
+		responseDTOs[i] = mapper.ToSuggestionDTOFromDomain(suggestion)
+	}
+	response.JSON(w, http.StatusOK, responseDTOs)
+}
+
+// @Summary      Accept Suggestion
+// @Description  Creates the suggested subscription. The new subscription has the suggestion's ID and is checked
+// @Description  like any other new subscription; non-fatal issues are returned in `warnings`.
+// @Tags         Suggestions
+// @Produce      json
+// @Param        id   path      string  true  "Suggestion ID (UUID format)"
+// @Success      200  {object}  dto.SuggestionResolutionResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format or subscription fields"
+// @Failure      403  {object}  apperrors.AppError "Subscription quota reached"
+// @Failure      404  {object}  apperrors.AppError "Suggestion not found"
+// @Failure      409  {object}  apperrors.AppError "Suggestion already resolved"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /suggestions/{id}/accept [post]
+func (h *SuggestionHandler) AcceptSuggestion(w http.ResponseWriter, r *http.Request) {
+	id, err := h.suggestionID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "AcceptSuggestion request received", zap.String("suggestion_id", id))
+
+	accepted, warnings, err := h.service.AcceptSuggestion(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Suggestion accepted successfully", zap.String("suggestion_id", id), zap.Int("warnings", len(warnings)))
+
+	response.JSON(w, http.StatusOK, mapper.ToSuggestionResolutionResponse(accepted, warnings))
+}
+
+// @Summary      Reject Suggestion
+// @Description  Dismisses a suggestion without touching the user's subscriptions.
+// @Tags         Suggestions
+// @Produce      json
+// @Param        id   path      string  true  "Suggestion ID (UUID format)"
+// @Success      200  {object}  dto.SuggestionResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Suggestion not found"
+// @Failure      409  {object}  apperrors.AppError "Suggestion already resolved"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /suggestions/{id}/reject [post]
+func (h *SuggestionHandler) RejectSuggestion(w http.ResponseWriter, r *http.Request) {
+	id, err := h.suggestionID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "RejectSuggestion request received", zap.String("suggestion_id", id))
+
+	rejected, err := h.service.RejectSuggestion(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Suggestion rejected successfully", zap.String("suggestion_id", id))
+
+	response.JSON(w, http.StatusOK, mapper.ToSuggestionDTOFromDomain(rejected))
+}
+
+// @Summary      Merge Suggestion
+// @Description  Folds a suggestion into a subscription the user already tracks. The subscription takes the
+// @Description  suggested price, and the suggested end date when there is one; everything else is kept.
+// @Description  Non-fatal issues with the updated subscription are returned in `warnings`.
+// @Tags         Suggestions
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                      true  "Suggestion ID (UUID format)"
+// @Param        request body  dto.MergeSuggestionRequest  true  "Subscription to merge into"
+// @Success      200  {object}  dto.SuggestionResolutionResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format, request body, or subscription of another user"
+// @Failure      404  {object}  apperrors.AppError "Suggestion or subscription not found"
+// @Failure      409  {object}  apperrors.AppError "Suggestion already resolved"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /suggestions/{id}/merge [post]
+func (h *SuggestionHandler) MergeSuggestion(w http.ResponseWriter, r *http.Request) {
+	id, err := h.suggestionID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "MergeSuggestion request received", zap.String("suggestion_id", id))
+
+	var req dto.MergeSuggestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	merged, warnings, err := h.service.MergeSuggestion(r.Context(), id, req.SubscriptionID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Suggestion merged successfully",
+		zap.String("suggestion_id", id),
+		zap.String("subscription_id", req.SubscriptionID),
+		zap.Int("warnings", len(warnings)),
+	)
+
+	response.JSON(w, http.StatusOK, mapper.ToSuggestionResolutionResponse(merged, warnings))
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubmitSuggestion(t *testing.T) {
+	mockService := new(mocks.SuggestionServiceInterface)
+	handler := NewSuggestionHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		userID := uuid.NewString()
+		body, _ := json.Marshal(dto.CreateSuggestionRequest{UserID: userID, Source: "bank", ServiceName: "Netflix", Price: 999, StartDate: "07-2025"})
+		mockService.On("SubmitSuggestion", mock.Anything, mock.MatchedBy(func(s domain.Suggestion) bool {
+			return s.UserID.String() == userID && s.Source == "bank" && s.StartDate.Month() == time.July
+		})).Return(domain.Suggestion{ID: uuid.New(), UserID: uuid.MustParse(userID), Source: "bank", ServiceName: "Netflix", Price: 999,
+			StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Status: domain.SuggestionPending}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/suggestions", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.SubmitSuggestion(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var respBody dto.SuggestionResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, "pending", respBody.Status)
+		assert.Equal(t, "07-2025", respBody.StartDate)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		body, _ := json.Marshal(dto.CreateSuggestionRequest{UserID: uuid.NewString(), ServiceName: "Netflix", StartDate: "07-2025"})
+
+		req := httptest.NewRequest(http.MethodPost, "/suggestions", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.SubmitSuggestion(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestResolveSuggestion(t *testing.T) {
+	mockService := new(mocks.SuggestionServiceInterface)
+	handler := NewSuggestionHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Post("/suggestions/{id}/accept", handler.AcceptSuggestion)
+	router.Post("/suggestions/{id}/reject", handler.RejectSuggestion)
+	router.Post("/suggestions/{id}/merge", handler.MergeSuggestion)
+	id := uuid.New()
+	subID := uuid.New()
+
+	t.Run("Accept Returns Warnings", func(t *testing.T) {
+		mockService.On("AcceptSuggestion", mock.Anything, id.String()).
+			Return(domain.Suggestion{ID: id, Status: domain.SuggestionAccepted, SubscriptionID: &id},
+				[]domain.Warning{{Code: "probable_duplicate", Message: "looks like a duplicate"}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/"+id.String()+"/accept", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.SuggestionResolutionResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, "accepted", respBody.Suggestion.Status)
+		assert.Equal(t, id.String(), respBody.Suggestion.SubscriptionID)
+		assert.Len(t, respBody.Warnings, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		body, _ := json.Marshal(dto.MergeSuggestionRequest{SubscriptionID: subID.String()})
+		mockService.On("MergeSuggestion", mock.Anything, id.String(), subID.String()).
+			Return(domain.Suggestion{ID: id, Status: domain.SuggestionMerged, SubscriptionID: &subID}, nil, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/"+id.String()+"/merge", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Merge Without Subscription", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/"+id.String()+"/merge", bytes.NewReader([]byte(`{}`)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/nope/reject", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/binder"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SyncHandler struct {
+	service service.SyncServiceInterface
+	limits  ListLimits
+	logger  logger.Logger
+}
+
+func NewSyncHandler(service service.SyncServiceInterface, limits ListLimits, logger logger.Logger) *SyncHandler {
+	return &SyncHandler{
+		service: service,
+		limits:  limits,
+		logger:  logger,
+	}
+}
+
+func (h *SyncHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Pull Changes
+// @Description  Returns the latest state of every subscription of a user changed since the cursor:
+// @Description  upserts carry the subscription, deletes are tombstones. Start with since=0 and pass
+// @Description  the returned cursor back until has_more is false.
+// @Tags         Sync
+// @Produce      json
+// @Param        user_id query     string  true   "User ID (UUID)"
+// @Param        since   query     int     false  "Cursor returned by the previous call (default 0)"
+// @Param        limit   query     int     false  "Maximum number of changes (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)"
+// @Success      200     {object}  dto.SyncResponse
+// @Failure      400     {object}  apperrors.AppError "Invalid query parameters"
+// @Failure      403     {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500     {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /sync [get]
+func (h *SyncHandler) PullChanges(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "PullChanges request received", zap.String("query", r.URL.RawQuery))
+
+	var req dto.SyncRequest
+	if err := binder.BindQuery(r.URL.Query(), &req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+	limit, err := h.limits.apply(req.Limit)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	set, err := h.service.Changes(r.Context(), req.UserID, int64(req.Since), limit)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "PullChanges completed successfully",
+		zap.Int("changes", len(set.Changes)),
+		zap.Int64("cursor", set.Cursor),
+	)
+
+	response.JSON(w, http.StatusOK, mapper.ToSyncResponse(set))
+}
+
+// @Summary      Push Changes
+// @Description  Applies changes made offline, in order. Each change names the version it was based on;
+// @Description  if the server changed the subscription since, it is a conflict. With strategy=manual
+// @Description  (default) conflicts are returned with the server state for the client to resolve; with
+// @Description  strategy=last_writer_wins the change is applied when client_updated_at is newer than the
+// @Description  server's write. A failing change reports its error without failing the whole batch.
+// @Tags         Sync
+// @Accept       json
+// @Produce      json
+// @Param        batch body      dto.SyncPushRequest true "Up to 100 client changes"
+// @Success      200   {object}  dto.SyncPushResponse
+// @Failure      400   {object}  apperrors.AppError "Invalid request body or changes"
+// @Failure      403   {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Security     BearerAuth
+// @Router       /sync [post]
+func (h *SyncHandler) PushChanges(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "PushChanges request received")
+
+	var req dto.SyncPushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+	if req.Strategy == "" {
+		req.Strategy = domain.ConflictManual
+	}
+
+	userID := uuid.MustParse(req.UserID)
+	changes := make([]domain.ClientChange, len(req.Changes))
+	for i, item := range req.Changes {
+		change, err := mapper.ToClientChangeFromDTO(userID, item)
+		if err != nil {
+			h.handleError(w, r, apperrors.NewBadRequest(fmt.Sprintf("changes[%d]: failed to parse date", i), err))
+			return
+		}
+		changes[i] = change
+	}
+
+	results, err := h.service.Push(r.Context(), req.UserID, req.Strategy, changes)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	h.logger.InfoContext(r.Context(), "PushChanges completed",
+		zap.Int("applied", counts[domain.PushApplied]),
+		zap.Int("conflicts", counts[domain.PushConflict]),
+		zap.Int("rejected", counts[domain.PushRejected]),
+	)
+
+	response.JSON(w, http.StatusOK, mapper.ToSyncPushResponse(results))
+}
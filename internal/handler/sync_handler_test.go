@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullChanges(t *testing.T) {
+	mockService := new(mocks.SyncServiceInterface)
+	handler := NewSyncHandler(mockService, testListLimits, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		userID := uuid.New()
+		sub := domain.Subscription{ID: uuid.New(), UserID: userID, ServiceName: "Netflix", Price: 999, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+		tombstone := uuid.New()
+		set := domain.ChangeSet{
+			Changes: []domain.SubscriptionChange{
+				{Version: 12, SubscriptionID: sub.ID, Subscription: &sub},
+				{Version: 15, SubscriptionID: tombstone, Deleted: true},
+			},
+			Cursor:  15,
+			HasMore: true,
+		}
+		mockService.On("Changes", mock.Anything, userID.String(), int64(7), testListLimits.Default).Return(set, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.PullChanges(rr, httptest.NewRequest(http.MethodGet, "/sync?user_id="+userID.String()+"&since=7", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body dto.SyncResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "15", body.Cursor)
+		assert.True(t, body.HasMore)
+		assert.Len(t, body.Changes, 2)
+		assert.Equal(t, "upsert", body.Changes[0].Op)
+		assert.Equal(t, "Netflix", body.Changes[0].Subscription.ServiceName)
+		assert.Equal(t, "delete", body.Changes[1].Op)
+		assert.Equal(t, tombstone.String(), body.Changes[1].ID)
+		assert.Nil(t, body.Changes[1].Subscription)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Missing User ID", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.PullChanges(rr, httptest.NewRequest(http.MethodGet, "/sync?since=7", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "Changes")
+	})
+}
+
+func TestPushChanges(t *testing.T) {
+	mockService := new(mocks.SyncServiceInterface)
+	handler := NewSyncHandler(mockService, testListLimits, logger.NewNopLogger())
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		applied, conflicted, failed := uuid.New(), uuid.New(), uuid.New()
+		body := `{"user_id":"` + userID.String() + `","changes":[` +
+			`{"op":"upsert","id":"` + applied.String() + `","base_version":3,"client_updated_at":"2025-07-01T10:00:00Z","subscription":{"service_name":"Netflix","price":999,"start_date":"07-2025"}},` +
+			`{"op":"delete","id":"` + conflicted.String() + `","base_version":4,"client_updated_at":"2025-07-01T10:00:00Z"},` +
+			`{"op":"delete","id":"` + failed.String() + `","base_version":5,"client_updated_at":"2025-07-01T10:00:00Z"}]}`
+		results := []domain.PushResult{
+			{SubscriptionID: applied, Status: domain.PushApplied},
+			{SubscriptionID: conflicted, Status: domain.PushConflict, Server: &domain.SubscriptionChange{Version: 9, SubscriptionID: conflicted, Deleted: true}},
+			{SubscriptionID: failed, Status: domain.PushRejected, Err: errors.New("boom")},
+		}
+		mockService.On("Push", mock.Anything, userID.String(), domain.ConflictManual, mock.MatchedBy(func(changes []domain.ClientChange) bool {
+			return len(changes) == 3 && changes[0].Subscription.UserID == userID && changes[0].BaseVersion == 3 && changes[1].Deleted
+		})).Return(results, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handler.PushChanges(rr, httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp dto.SyncPushResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Len(t, resp.Results, 3)
+		assert.Equal(t, "applied", resp.Results[0].Status)
+		assert.Equal(t, "conflict", resp.Results[1].Status)
+		assert.Equal(t, "delete", resp.Results[1].Server.Op)
+		assert.Equal(t, int64(9), resp.Results[1].Server.Version)
+		assert.Equal(t, "Internal Server Error", resp.Results[2].Error)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Upsert Without Subscription", func(t *testing.T) {
+		body := `{"user_id":"` + userID.String() + `","changes":[{"op":"upsert","id":"` + uuid.NewString() + `","client_updated_at":"2025-07-01T10:00:00Z"}]}`
+
+		rr := httptest.NewRecorder()
+		handler.PushChanges(rr, httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "Push")
+	})
+}
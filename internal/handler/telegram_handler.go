@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type TelegramHandler struct {
+	service service.TelegramServiceInterface
+	logger  logger.Logger
+}
+
+func NewTelegramHandler(service service.TelegramServiceInterface, logger logger.Logger) *TelegramHandler {
+	return &TelegramHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *TelegramHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Create Telegram Link Code
+// @Description  Issues a one-time code for linking a Telegram chat. Sending the returned command to the bot
+// @Description  links that chat: the bot then lists and adds subscriptions, and renewal reminders go there
+// @Description  instead of by email. A new code invalidates the previous one. Only available while the bot
+// @Description  is enabled.
+// @Tags         Telegram
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID (UUID format)"
+// @Success      201  {object}  dto.TelegramLinkCodeResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      401  {object}  apperrors.AppError "Missing or invalid bearer token"
+// @Failure      403  {object}  apperrors.AppError "Another user's account"
+// @Failure      404  {object}  apperrors.AppError "User not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /users/{id}/telegram/link-code [post]
+func (h *TelegramHandler) CreateLinkCode(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "CreateLinkCode request received", zap.String("user_id", chi.URLParam(r, "id")))
+
+	id, err := pathUserID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	code, err := h.service.CreateLinkCode(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, mapper.ToTelegramLinkCodeResponse(code))
+}
+
+// @Summary      Get Linked Telegram Chat
+// @Tags         Telegram
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID (UUID format)"
+// @Success      200  {object}  dto.TelegramLinkResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      401  {object}  apperrors.AppError "Missing or invalid bearer token"
+// @Failure      403  {object}  apperrors.AppError "Another user's account"
+// @Failure      404  {object}  apperrors.AppError "No chat linked"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /users/{id}/telegram [get]
+func (h *TelegramHandler) GetLink(w http.ResponseWriter, r *http.Request) {
+	id, err := pathUserID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	link, err := h.service.GetLink(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToTelegramLinkResponse(link))
+}
+
+// @Summary      Unlink Telegram Chat
+// @Description  Stops the bot from acting for the user; renewal reminders go back to email.
+// @Tags         Telegram
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID (UUID format)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      401  {object}  apperrors.AppError "Missing or invalid bearer token"
+// @Failure      403  {object}  apperrors.AppError "Another user's account"
+// @Failure      404  {object}  apperrors.AppError "No chat linked"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /users/{id}/telegram [delete]
+func (h *TelegramHandler) Unlink(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "Unlink Telegram request received", zap.String("user_id", chi.URLParam(r, "id")))
+
+	id, err := pathUserID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if err := h.service.Unlink(r.Context(), id); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.NoContent(w)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTelegramHandler(t *testing.T) {
+	mockService := new(mocks.TelegramServiceInterface)
+	handler := NewTelegramHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Post("/users/{id}/telegram/link-code", handler.CreateLinkCode)
+	router.Get("/users/{id}/telegram", handler.GetLink)
+	router.Delete("/users/{id}/telegram", handler.Unlink)
+	userID := uuid.New().String()
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+		return rr
+	}
+
+	t.Run("Create Link Code", func(t *testing.T) {
+		expiresAt := time.Date(2025, 7, 1, 10, 15, 0, 0, time.UTC)
+		mockService.On("CreateLinkCode", mock.Anything, userID).
+			Return(domain.TelegramLinkCode{Code: "abc", ExpiresAt: expiresAt}, nil).Once()
+
+		rr := serve(http.MethodPost, "/users/"+userID+"/telegram/link-code")
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"code":"abc","command":"/start abc","expires_at":"2025-07-01T10:15:00Z"}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid User ID", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/users/nope/telegram/link-code")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Get Link", func(t *testing.T) {
+		linkedAt := time.Date(2025, 7, 1, 10, 5, 0, 0, time.UTC)
+		mockService.On("GetLink", mock.Anything, userID).
+			Return(domain.TelegramLink{UserID: uuid.MustParse(userID), ChatID: 42, LinkedAt: linkedAt}, nil).Once()
+
+		rr := serve(http.MethodGet, "/users/"+userID+"/telegram")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"chat_id":42,"linked_at":"2025-07-01T10:05:00Z"}`, rr.Body.String())
+	})
+
+	t.Run("Unlink Without Link", func(t *testing.T) {
+		mockService.On("Unlink", mock.Anything, userID).Return(apperrors.NewNotFound("telegram chat not linked", nil)).Once()
+
+		rr := serve(http.MethodDelete, "/users/"+userID+"/telegram")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Unlink", func(t *testing.T) {
+		mockService.On("Unlink", mock.Anything, userID).Return(nil).Once()
+
+		rr := serve(http.MethodDelete, "/users/"+userID+"/telegram")
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
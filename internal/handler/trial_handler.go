@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type TrialHandler struct {
+	service service.TrialServiceInterface
+	logger  logger.Logger
+}
+
+func NewTrialHandler(service service.TrialServiceInterface, logger logger.Logger) *TrialHandler {
+	return &TrialHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *TrialHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// @Summary      Get Trial
+// @Description  Returns the subscription's trial: when it ends, what it converts to and whether it has
+// @Description  converted yet. A trial lapses when the subscription ends or is deleted before the trial does.
+// @Tags         Trials
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID (UUID format)"
+// @Success      200  {object}  dto.TrialResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Subscription or trial not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/trial [get]
+func (h *TrialHandler) GetTrial(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "GetTrial request received", zap.String("subscription_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+
+	trial, err := h.service.GetTrial(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToTrialResponse(trial))
+}
+
+// @Summary      Set Trial
+// @Description  Marks the subscription as on trial until ends_on; its current price is the trial price. The
+// @Description  day after ends_on it switches to regular_price and regular_billing_period, a
+// @Description  subscription.trial_converted webhook is sent and the owner is notified. Setting a trial again
+// @Description  replaces it and starts it over.
+// @Tags         Trials
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string               true  "Subscription ID (UUID format)"
+// @Param        request  body  dto.SetTrialRequest  true  "Trial end and regular price"
+// @Success      200  {object}  dto.TrialResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format, request body or fields, or the trial end has passed"
+// @Failure      404  {object}  apperrors.AppError "Subscription not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/trial [put]
+func (h *TrialHandler) SetTrial(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.logger.InfoContext(r.Context(), "SetTrial request received", zap.String("subscription_id", id))
+
+	subscriptionID, err := uuid.Parse(id)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid subscription ID format", err))
+		return
+	}
+	var req dto.SetTrialRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+	trial, err := mapper.ToTrialFromDTO(subscriptionID, req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("failed to parse date", err))
+		return
+	}
+
+	trial, err = h.service.SetTrial(r.Context(), trial)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToTrialResponse(trial))
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrialHandler(t *testing.T) {
+	mockService := new(mocks.TrialServiceInterface)
+	handler := NewTrialHandler(mockService, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Get("/subscriptions/{id}/trial", handler.GetTrial)
+	router.Put("/subscriptions/{id}/trial", handler.SetTrial)
+	subID := uuid.New()
+	endsOn := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Set", func(t *testing.T) {
+		mockService.On("SetTrial", mock.Anything, domain.Trial{SubscriptionID: subID, EndsOn: endsOn, RegularPrice: 999}).
+			Return(domain.Trial{SubscriptionID: subID, EndsOn: endsOn, RegularPrice: 999, RegularBillingPeriod: domain.BillingMonthly}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+subID.String()+"/trial", bytes.NewBufferString(`{"ends_on":"2026-01-14","regular_price":999}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"subscription_id":"`+subID.String()+`","ends_on":"2026-01-14","regular_price":999,"regular_billing_period":"monthly","status":"active"}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Month Only End Is Rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/subscriptions/"+subID.String()+"/trial", bytes.NewBufferString(`{"ends_on":"01-2026","regular_price":999}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Get Converted", func(t *testing.T) {
+		ended := endsOn.AddDate(0, 0, 1)
+		mockService.On("GetTrial", mock.Anything, subID.String()).
+			Return(domain.Trial{SubscriptionID: subID, EndsOn: endsOn, RegularPrice: 999, RegularBillingPeriod: domain.BillingMonthly, EndedAt: &ended, Converted: true}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+subID.String()+"/trial", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var respBody dto.TrialResponse
+		json.Unmarshal(rr.Body.Bytes(), &respBody)
+		assert.Equal(t, "converted", respBody.Status)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Get Without Trial", func(t *testing.T) {
+		mockService.On("GetTrial", mock.Anything, subID.String()).Return(domain.Trial{}, apperrors.NewNotFound("trial not found", nil)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+subID.String()+"/trial", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type UserHandler struct {
+	service service.UserServiceInterface
+	logger  logger.Logger
+}
+
+func NewUserHandler(service service.UserServiceInterface, logger logger.Logger) *UserHandler {
+	return &UserHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *UserHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// pathUserID parses the {id} path param and, with authentication on, checks
+// that it is the authenticated user.
+func pathUserID(r *http.Request) (string, error) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", apperrors.NewBadRequest("invalid user ID format", err)
+	}
+	return service.ScopeUserID(r.Context(), id)
+}
+
+// @Summary      Register User
+// @Description  Creates an account. Emails are case-insensitive. The returned ID is the user_id that
+// @Description  subscriptions, saved filters and category rules refer to.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        user body      dto.RegisterUserRequest true "Email and password"
+// @Success      201  {object}  dto.UserResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      409  {object}  apperrors.AppError "User with this email already exists"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /users [post]
+func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "Register request received")
+
+	var req dto.RegisterUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	user, err := h.service.Register(r.Context(), req.Email, req.Password)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "User registered successfully", zap.String("user_id", user.ID.String()))
+
+	response.JSON(w, http.StatusCreated, mapper.ToUserDTOFromDomain(user))
+}
+
+// @Summary      Get User Profile
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID (UUID format)"
+// @Success      200  {object}  dto.UserResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      401  {object}  apperrors.AppError "Missing or invalid bearer token"
+// @Failure      403  {object}  apperrors.AppError "Another user's profile"
+// @Failure      404  {object}  apperrors.AppError "User not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /users/{id} [get]
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "GetUser request received", zap.String("user_id", chi.URLParam(r, "id")))
+
+	id, err := pathUserID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	user, err := h.service.GetUser(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToUserDTOFromDomain(user))
+}
+
+// @Summary      Change Password
+// @Description  Replaces the password after checking the current one. Tokens issued earlier stay valid
+// @Description  until they expire.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                     true  "User ID (UUID format)"
+// @Param        request  body      dto.ChangePasswordRequest  true  "Current and new password"
+// @Success      204  "No Content"
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format or request body"
+// @Failure      401  {object}  apperrors.AppError "Missing or invalid bearer token"
+// @Failure      403  {object}  apperrors.AppError "Another user's account or wrong current password"
+// @Failure      404  {object}  apperrors.AppError "User not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Router       /users/{id}/password [put]
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "ChangePassword request received", zap.String("user_id", chi.URLParam(r, "id")))
+
+	id, err := pathUserID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	var req dto.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	if err := h.service.ChangePassword(r.Context(), id, req.CurrentPassword, req.NewPassword); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	h.logger.InfoContext(r.Context(), "Password changed successfully", zap.String("user_id", id))
+
+	response.NoContent(w)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"subtracker/internal/domain"
+	"subtracker/internal/service"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegister(t *testing.T) {
+	mockService := new(mocks.UserServiceInterface)
+	handler := NewUserHandler(mockService, logger.NewNopLogger())
+
+	t.Run("Success", func(t *testing.T) {
+		user := domain.User{ID: uuid.New(), Email: "alice@example.com", Role: domain.RoleUser, CreatedAt: time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)}
+		mockService.On("Register", mock.Anything, "alice@example.com", "correct horse battery").Return(user, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"email":"alice@example.com","password":"correct horse battery"}`
+		handler.Register(rr, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"id":"`+user.ID.String()+`","email":"alice@example.com","role":"user","created_at":"2025-07-01T10:00:00Z"}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Short Password", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.Register(rr, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"email":"alice@example.com","password":"short"}`)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	mockService := new(mocks.UserServiceInterface)
+	handler := NewUserHandler(mockService, logger.NewNopLogger())
+	id := uuid.New()
+
+	newRequest := func(ctx context.Context) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id.String())
+		req := httptest.NewRequest(http.MethodGet, "/users/"+id.String(), nil)
+		return req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.On("GetUser", mock.Anything, id.String()).Return(domain.User{}, apperrors.NewNotFound("user not found", nil)).Once()
+
+		rr := httptest.NewRecorder()
+		handler.GetUser(rr, newRequest(context.Background()))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Another User's Profile", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.GetUser(rr, newRequest(service.WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/service"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/binder"
+	"subtracker/pkg/logger"
+	"subtracker/pkg/response"
+	"subtracker/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	service service.WebhookServiceInterface
+	limits  ListLimits
+	logger  logger.Logger
+}
+
+func NewWebhookHandler(service service.WebhookServiceInterface, limits ListLimits, logger logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		limits:  limits,
+		logger:  logger,
+	}
+}
+
+func (h *WebhookHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(h.logger, w, r, err)
+}
+
+// webhookID parses the {id} path param.
+func (h *WebhookHandler) webhookID(r *http.Request) (string, error) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", apperrors.NewBadRequest("invalid webhook ID format", err)
+	}
+	return id, nil
+}
+
+// @Summary      Create Webhook
+// @Description  Registers a URL that is POSTed a JSON payload whenever one of the user's subscriptions is
+// @Description  created, updated or deleted. Each request carries X-Subtracker-Event, X-Subtracker-Delivery,
+// @Description  X-Subtracker-Sequence, X-Subtracker-Timestamp and X-Subtracker-Signature, the hex HMAC-SHA256
+// @Description  of "<timestamp>.<body>" under the webhook's secret prefixed with "sha256=". The sequence, also
+// @Description  in the payload, counts the webhook's deliveries up from 1 in event order. The secret is returned
+// @Description  only in this response. Any answer other than 2xx is retried with exponential backoff.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook body      dto.CreateWebhookRequest true "URL and events"
+// @Success      201  {object}  dto.CreateWebhookResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid request body or fields"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "CreateWebhook request received")
+
+	var req dto.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	hook, err := mapper.ToWebhookDomainFromDTO(req)
+	if err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid user ID format", err))
+		return
+	}
+
+	created, err := h.service.CreateWebhook(r.Context(), hook)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, mapper.ToCreateWebhookResponse(created))
+}
+
+// @Summary      List Webhooks
+// @Tags         Webhooks
+// @Produce      json
+// @Param        user_id query string false "User ID (UUID); defaults to the authenticated user"
+// @Success      200  {array}   dto.WebhookResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid query parameters"
+// @Failure      403  {object}  apperrors.AppError "user_id is not the authenticated user"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks [get]
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	var req dto.ListWebhooksRequest
+	if err := binder.BindQuery(r.URL.Query(), &req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+
+	hooks, err := h.service.ListWebhooks(r.Context(), req.UserID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	responseDTOs := make([]dto.WebhookResponse, len(hooks))
+	for i, hook := range hooks {
+		responseDTOs[i] = mapper.ToWebhookResponse(hook)
+	}
+	response.JSON(w, http.StatusOK, responseDTOs)
+}
+
+// @Summary      Get Webhook
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID (UUID format)"
+// @Success      200  {object}  dto.WebhookResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Webhook not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := h.webhookID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	hook, err := h.service.GetWebhook(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToWebhookResponse(hook))
+}
+
+// @Summary      Update Webhook
+// @Description  Changes the webhook's URL or the events it is called for; fields left out are kept. Deliveries
+// @Description  already queued are still sent, to the new URL.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string                    true  "Webhook ID (UUID format)"
+// @Param        webhook  body  dto.UpdateWebhookRequest  true  "Fields to change"
+// @Success      200  {object}  dto.WebhookResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format, request body or fields"
+// @Failure      404  {object}  apperrors.AppError "Webhook not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{id} [patch]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "UpdateWebhook request received", zap.String("webhook_id", chi.URLParam(r, "id")))
+
+	id, err := h.webhookID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	var req dto.UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid request body", err))
+		return
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("validation failed", err))
+		return
+	}
+
+	hook, err := h.service.UpdateWebhook(r.Context(), id, mapper.ToWebhookPatchFromDTO(req))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToWebhookResponse(hook))
+}
+
+// @Summary      Rotate Webhook Secret
+// @Description  Replaces the webhook's secret with a new one, returned only in this response. Every delivery sent
+// @Description  from now on, including retries of earlier ones, is signed with the new secret.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID (UUID format)"
+// @Success      200  {object}  dto.CreateWebhookResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Webhook not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{id}/secret [post]
+func (h *WebhookHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "RotateSecret request received", zap.String("webhook_id", chi.URLParam(r, "id")))
+
+	id, err := h.webhookID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	hook, err := h.service.RotateSecret(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToCreateWebhookResponse(hook))
+}
+
+// @Summary      Delete Webhook
+// @Description  Stops calling the URL. Deliveries not sent yet are dropped along with the delivery history.
+// @Tags         Webhooks
+// @Param        id   path      string  true  "Webhook ID (UUID format)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Webhook not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	h.logger.InfoContext(r.Context(), "DeleteWebhook request received", zap.String("webhook_id", chi.URLParam(r, "id")))
+
+	id, err := h.webhookID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if err := h.service.DeleteWebhook(r.Context(), id); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.NoContent(w)
+}
+
+// @Summary      List Webhook Deliveries
+// @Description  Lists the webhook's most recent deliveries, newest first, with their status: pending while
+// @Description  attempts remain, delivered once the receiver answered 2xx, failed after the last attempt.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id     path   string  true   "Webhook ID (UUID format)"
+// @Param        limit  query  int     false  "Maximum number of deliveries (default and maximum are configured by LIST_DEFAULT_LIMIT and LIST_MAX_LIMIT)"
+// @Success      200  {array}   dto.WebhookDeliveryResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format or query parameters"
+// @Failure      404  {object}  apperrors.AppError "Webhook not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := h.webhookID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	var req dto.ListWebhookDeliveriesRequest
+	if err := binder.BindQuery(r.URL.Query(), &req); err != nil {
+		h.handleError(w, r, apperrors.NewBadRequest("invalid query parameters", err))
+		return
+	}
+	limit, err := h.limits.apply(req.Limit)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	responseDTOs := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responseDTOs[i] = mapper.ToWebhookDeliveryResponse(delivery)
+	}
+	response.JSON(w, http.StatusOK, responseDTOs)
+}
+
+// @Summary      Webhook Delivery Stats
+// @Description  Counts the webhook's deliveries by status, overall and for each event, with the time of the last
+// @Description  successful one. Events the webhook is registered for are listed even before their first delivery.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID (UUID format)"
+// @Success      200  {object}  dto.WebhookStatsResponse
+// @Failure      400  {object}  apperrors.AppError "Invalid ID format"
+// @Failure      404  {object}  apperrors.AppError "Webhook not found"
+// @Failure      500  {object}  apperrors.AppError "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{id}/stats [get]
+func (h *WebhookHandler) DeliveryStats(w http.ResponseWriter, r *http.Request) {
+	id, err := h.webhookID(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	stats, err := h.service.DeliveryStats(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, mapper.ToWebhookStatsResponse(stats))
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/service/mocks"
+	"subtracker/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	mockService := new(mocks.WebhookServiceInterface)
+	handler := NewWebhookHandler(mockService, ListLimits{Default: 10, Max: 100}, logger.NewNopLogger())
+	router := chi.NewRouter()
+	router.Post("/webhooks", handler.CreateWebhook)
+	router.Get("/webhooks/{id}", handler.GetWebhook)
+	router.Patch("/webhooks/{id}", handler.UpdateWebhook)
+	router.Get("/webhooks/{id}/deliveries", handler.ListDeliveries)
+	router.Post("/webhooks/{id}/secret", handler.RotateSecret)
+	router.Get("/webhooks/{id}/stats", handler.DeliveryStats)
+	userID := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	hookID := uuid.MustParse("6f1c2c9e-8d1e-4a57-9a43-2f0f3c1c5a10")
+	createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rr
+	}
+
+	t.Run("Create Returns Secret", func(t *testing.T) {
+		mockService.On("CreateWebhook", mock.Anything, domain.Webhook{UserID: userID, URL: "https://example.com/hook", Events: []string{"subscription.created"}}).
+			Return(domain.Webhook{ID: hookID, UserID: userID, URL: "https://example.com/hook", Secret: "s3cret", Events: []string{"subscription.created"}, CreatedAt: createdAt}, nil).Once()
+
+		rr := serve(http.MethodPost, "/webhooks", `{"user_id":"`+userID.String()+`","url":"https://example.com/hook","events":["subscription.created"]}`)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.JSONEq(t, `{"id":"`+hookID.String()+`","user_id":"`+userID.String()+`","url":"https://example.com/hook","events":["subscription.created"],"created_at":"2025-07-01T10:00:00Z","secret":"s3cret"}`, rr.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Event", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/webhooks", `{"user_id":"`+userID.String()+`","url":"https://example.com/hook","events":["subscription.renewed"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Get Omits Secret", func(t *testing.T) {
+		mockService.On("GetWebhook", mock.Anything, hookID.String()).
+			Return(domain.Webhook{ID: hookID, UserID: userID, URL: "https://example.com/hook", Secret: "s3cret", Events: []string{"subscription.created"}, CreatedAt: createdAt}, nil).Once()
+
+		rr := serve(http.MethodGet, "/webhooks/"+hookID.String(), "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "s3cret")
+	})
+
+	t.Run("Deliveries", func(t *testing.T) {
+		status := 503
+		mockService.On("ListDeliveries", mock.Anything, hookID.String(), 10).Return([]domain.WebhookDelivery{{
+			ID: hookID, WebhookID: hookID, Event: "subscription.updated", Sequence: 7, Status: domain.DeliveryPending, Attempts: 1,
+			ResponseStatus: &status, LastError: "receiver answered 503", NextAttemptAt: createdAt.Add(time.Minute), CreatedAt: createdAt,
+		}}, nil).Once()
+
+		rr := serve(http.MethodGet, "/webhooks/"+hookID.String()+"/deliveries", "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `[{"id":"`+hookID.String()+`","event":"subscription.updated","sequence":7,"status":"pending","attempts":1,"response_status":503,
+			"last_error":"receiver answered 503","next_attempt_at":"2025-07-01T10:01:00Z","created_at":"2025-07-01T10:00:00Z"}]`, rr.Body.String())
+	})
+
+	t.Run("Deliveries Limit Too Large", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/webhooks/"+hookID.String()+"/deliveries?limit=500", "")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Update Events", func(t *testing.T) {
+		mockService.On("UpdateWebhook", mock.Anything, hookID.String(), domain.WebhookPatch{Events: []string{"subscription.deleted"}}).
+			Return(domain.Webhook{ID: hookID, UserID: userID, URL: "https://example.com/hook", Secret: "s3cret", Events: []string{"subscription.deleted"}, CreatedAt: createdAt}, nil).Once()
+
+		rr := serve(http.MethodPatch, "/webhooks/"+hookID.String(), `{"events":["subscription.deleted"]}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "s3cret")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Update Without Events", func(t *testing.T) {
+		rr := serve(http.MethodPatch, "/webhooks/"+hookID.String(), `{"events":[]}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Rotate Returns New Secret", func(t *testing.T) {
+		mockService.On("RotateSecret", mock.Anything, hookID.String()).
+			Return(domain.Webhook{ID: hookID, UserID: userID, URL: "https://example.com/hook", Secret: "n3w", Events: []string{"subscription.created"}, CreatedAt: createdAt}, nil).Once()
+
+		rr := serve(http.MethodPost, "/webhooks/"+hookID.String()+"/secret", "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"secret": "n3w"`)
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		deliveredAt := createdAt.Add(2 * time.Minute)
+		mockService.On("DeliveryStats", mock.Anything, hookID.String()).Return(domain.WebhookStats{
+			WebhookID:      hookID,
+			DeliveryCounts: domain.DeliveryCounts{Delivered: 4, Failed: 1},
+			Events: []domain.EventDeliveryCounts{
+				{Event: "subscription.created", DeliveryCounts: domain.DeliveryCounts{Delivered: 4, Failed: 1}},
+			},
+			LastDeliveredAt: &deliveredAt,
+		}, nil).Once()
+
+		rr := serve(http.MethodGet, "/webhooks/"+hookID.String()+"/stats", "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"webhook_id":"`+hookID.String()+`","pending":0,"delivered":4,"failed":1,
+			"events":[{"event":"subscription.created","pending":0,"delivered":4,"failed":1}],"last_delivered_at":"2025-07-01T10:02:00Z"}`, rr.Body.String())
+	})
+}
@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"subtracker/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	m := NewSMTPMailer(config.SMTPConfig{Addr: "localhost:25", From: "reminders@subtracker.local"})
+	m.now = func() time.Time { return time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC) }
+
+	raw := string(m.format(Message{
+		To:      "user@example.com",
+		Subject: "Netflix\r\nBcc: victim@example.com renews soon",
+		Body:    "line one\nline two",
+	}))
+
+	headers, body, ok := strings.Cut(raw, "\r\n\r\n")
+	assert.True(t, ok)
+	assert.Contains(t, headers, "To: user@example.com\r\n")
+	assert.Contains(t, headers, "Date: Tue, 01 Jul 2025 09:00:00 +0000\r\n")
+	assert.NotContains(t, headers, "\r\nBcc:", "line breaks in a header value must not start a new header")
+	assert.Equal(t, "line one\r\nline two", body)
+}
@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+)
+
+// DAO -> DOMAIN
+func ToAuditEntryFromDAO(row dao.AuditRow) domain.AuditEntry {
+	changes := make(map[string]domain.FieldChange, len(row.Changes))
+	for field, change := range row.Changes {
+		changes[field] = domain.FieldChange{Before: change.Before, After: change.After}
+	}
+	return domain.AuditEntry{
+		ID:         row.ID,
+		EntityType: row.EntityType,
+		EntityID:   row.EntityID,
+		OwnerID:    row.OwnerID,
+		ActorID:    row.ActorID,
+		Action:     domain.AuditAction(row.Action),
+		Changes:    changes,
+		ChangedAt:  row.ChangedAt,
+	}
+}
+
+// DOMAIN -> DAO
+func ToAuditDAO(entry domain.AuditEntry) dao.AuditRow {
+	changes := make(map[string]dao.AuditChange, len(entry.Changes))
+	for field, change := range entry.Changes {
+		changes[field] = dao.AuditChange{Before: change.Before, After: change.After}
+	}
+	return dao.AuditRow{
+		ID:         entry.ID,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		OwnerID:    entry.OwnerID,
+		ActorID:    entry.ActorID,
+		Action:     string(entry.Action),
+		Changes:    changes,
+		ChangedAt:  entry.ChangedAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToAuditEntryResponse(entry domain.AuditEntry) dto.AuditEntryResponse {
+	resp := dto.AuditEntryResponse{
+		ID:        entry.ID,
+		Action:    string(entry.Action),
+		ChangedAt: entry.ChangedAt.UTC().Format(time.RFC3339),
+		Changes:   make(map[string]dto.FieldChangeResponse, len(entry.Changes)),
+	}
+	if entry.ActorID != nil {
+		resp.ActorID = entry.ActorID.String()
+	}
+	for field, change := range entry.Changes {
+		resp.Changes[field] = dto.FieldChangeResponse{Before: change.Before, After: change.After}
+	}
+	return resp
+}
@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+)
+
+// DOMAIN -> DTO
+func ToCostCenterReportResponse(filter dto.CostFilter, groups []domain.CostCenterCost) dto.CostCenterReportResponse {
+	resp := dto.CostCenterReportResponse{
+		PeriodStart: filter.PeriodStart.Format("01-2006"),
+		PeriodEnd:   filter.PeriodEnd.Format("01-2006"),
+		CostCenters: make([]dto.CostCenterCostResponse, len(groups)),
+	}
+	for i, group := range groups {
+		resp.CostCenters[i] = dto.CostCenterCostResponse{
+			CostCenter:    group.CostCenter,
+			Subscriptions: group.Subscriptions,
+			TotalCost:     group.TotalCost,
+		}
+		resp.TotalCost += group.TotalCost
+	}
+	return resp
+}
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+)
+
+// DTO -> DOMAIN
+func ToFeatureFlagFromDTO(name string, req dto.SetFeatureFlagRequest) domain.FeatureFlag {
+	rollout := 100
+	if req.RolloutPercent != nil {
+		rollout = *req.RolloutPercent
+	}
+	return domain.FeatureFlag{
+		Name:           name,
+		Description:    req.Description,
+		Enabled:        *req.Enabled,
+		Environments:   req.Environments,
+		RolloutPercent: rollout,
+	}
+}
+
+// DAO -> DOMAIN
+func ToFeatureFlagFromDAO(row dao.FeatureFlagRow) domain.FeatureFlag {
+	return domain.FeatureFlag{
+		Name:           row.Name,
+		Description:    row.Description,
+		Enabled:        row.Enabled,
+		Environments:   row.Environments,
+		RolloutPercent: row.RolloutPercent,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
+
+// DOMAIN -> DAO
+func ToFeatureFlagDAO(flag domain.FeatureFlag) dao.FeatureFlagRow {
+	environments := flag.Environments
+	if environments == nil {
+		environments = []string{}
+	}
+	return dao.FeatureFlagRow{
+		Name:           flag.Name,
+		Description:    flag.Description,
+		Enabled:        flag.Enabled,
+		Environments:   environments,
+		RolloutPercent: flag.RolloutPercent,
+	}
+}
+
+// DOMAIN -> DTO
+func ToFeatureFlagResponse(flag domain.FeatureFlag) dto.FeatureFlagResponse {
+	environments := flag.Environments
+	if environments == nil {
+		environments = []string{}
+	}
+	return dto.FeatureFlagResponse{
+		Name:           flag.Name,
+		Description:    flag.Description,
+		Enabled:        flag.Enabled,
+		Environments:   environments,
+		RolloutPercent: flag.RolloutPercent,
+		UpdatedAt:      flag.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToConsumerAckFromDTO(req dto.AckMessageRequest) (domain.ConsumerAck, error) {
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return domain.ConsumerAck{}, err
+	}
+	return domain.ConsumerAck{
+		UserID:    userID,
+		Consumer:  req.Consumer,
+		MessageID: req.MessageID,
+	}, nil
+}
+
+// DAO -> DOMAIN
+func ToConsumerAckFromDAO(row dao.ConsumerAckRow) domain.ConsumerAck {
+	return domain.ConsumerAck{
+		UserID:    row.UserID,
+		Consumer:  row.Consumer,
+		MessageID: row.MessageID,
+		AckedAt:   row.AckedAt,
+	}
+}
+
+// DOMAIN -> DAO
+func ToConsumerAckDAO(ack domain.ConsumerAck) dao.ConsumerAckRow {
+	return dao.ConsumerAckRow{
+		UserID:    ack.UserID,
+		Consumer:  ack.Consumer,
+		MessageID: ack.MessageID,
+		AckedAt:   ack.AckedAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToAckMessageResponse(ack domain.ConsumerAck) dto.AckMessageResponse {
+	return dto.AckMessageResponse{
+		UserID:    ack.UserID.String(),
+		Consumer:  ack.Consumer,
+		MessageID: ack.MessageID,
+		AckedAt:   ack.AckedAt.UTC().Format(time.RFC3339),
+		Duplicate: ack.Duplicate,
+	}
+}
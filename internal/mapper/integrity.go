@@ -0,0 +1,17 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+)
+
+// DOMAIN -> DTO
+func ToIntegrityReportDTO(report domain.IntegrityReport) dto.IntegrityReportResponse {
+	return dto.IntegrityReportResponse{
+		CheckedAt:  report.CheckedAt.UTC().Format(time.RFC3339),
+		Healthy:    report.Healthy(),
+		Violations: report.Violations,
+	}
+}
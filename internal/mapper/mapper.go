@@ -32,11 +32,15 @@ func ToDomainFromDTO(req dto.CreateSubscriptionRequest) (domain.Subscription, er
 	}
 
 	return domain.Subscription{
-		UserID:      userID,
-		ServiceName: req.ServiceName,
-		Price:       req.Price,
-		StartDate:   start,
-		EndDate:     end,
+		UserID:        userID,
+		ServiceName:   req.ServiceName,
+		Price:         req.Price,
+		StartDate:     start,
+		EndDate:       end,
+		CostCenter:    req.CostCenter,
+		Category:      req.Category,
+		BillingPeriod: domain.BillingPeriod(req.BillingPeriod),
+		ExpenseType:   domain.ExpenseType(req.ExpenseType),
 	}, nil
 }
 
@@ -50,36 +54,50 @@ func ToDTOFromDomain(sub domain.Subscription) dto.SubscriptionResponse {
 	}
 
 	return dto.SubscriptionResponse{
-		ID:          sub.ID.String(),
-		UserID:      sub.UserID.String(),
-		ServiceName: sub.ServiceName,
-		Price:       sub.Price,
-		StartDate:   start,
-		EndDate:     end,
+		ID:            sub.ID.String(),
+		UserID:        sub.UserID.String(),
+		ServiceName:   sub.ServiceName,
+		Price:         sub.Price,
+		StartDate:     start,
+		EndDate:       end,
+		CostCenter:    sub.CostCenter,
+		Category:      sub.Category,
+		BillingPeriod: string(sub.BillingPeriod),
+		ExpenseType:   string(sub.ExpenseType),
 	}
 }
 
 // DAO -> DOMAIN
 func ToDomainFromDAO(row dao.SubscriptionRow) domain.Subscription {
 	return domain.Subscription{
-		ID:          row.ID,
-		UserID:      row.UserID,
-		ServiceName: row.ServiceName,
-		Price:       row.Price,
-		StartDate:   row.StartDate,
-		EndDate:     row.EndDate,
+		ID:            row.ID,
+		UserID:        row.UserID,
+		ServiceName:   row.ServiceName,
+		Price:         row.Price,
+		StartDate:     row.StartDate,
+		EndDate:       row.EndDate,
+		CostCenter:    row.CostCenter,
+		Category:      row.Category,
+		BillingPeriod: domain.BillingPeriod(row.BillingPeriod),
+		ExpenseType:   domain.ExpenseType(row.ExpenseType),
+		Version:       row.Version,
 	}
 }
 
 // DOMAIN -> DAO
 func ToDAOFromDomain(sub domain.Subscription) dao.SubscriptionRow {
 	return dao.SubscriptionRow{
-		ID:          sub.ID,
-		UserID:      sub.UserID,
-		ServiceName: sub.ServiceName,
-		Price:       sub.Price,
-		StartDate:   sub.StartDate,
-		EndDate:     sub.EndDate,
+		ID:            sub.ID,
+		UserID:        sub.UserID,
+		ServiceName:   sub.ServiceName,
+		Price:         sub.Price,
+		StartDate:     sub.StartDate,
+		EndDate:       sub.EndDate,
+		CostCenter:    sub.CostCenter,
+		Category:      sub.Category,
+		BillingPeriod: string(sub.BillingPeriod),
+		ExpenseType:   string(sub.ExpenseType),
+		Version:       sub.Version,
 	}
 }
 
@@ -99,9 +117,49 @@ func ToDomainFromUpdateDTO(req dto.UpdateSubscriptionRequest) (domain.Subscripti
 	}
 
 	return domain.Subscription{
+		ServiceName:   req.ServiceName,
+		Price:         req.Price,
+		StartDate:     start,
+		EndDate:       end,
+		CostCenter:    req.CostCenter,
+		Category:      req.Category,
+		BillingPeriod: domain.BillingPeriod(req.BillingPeriod),
+		ExpenseType:   domain.ExpenseType(req.ExpenseType),
+	}, nil
+}
+
+func ToPatchFromDTO(req dto.PatchSubscriptionRequest) (domain.SubscriptionPatch, error) {
+	patch := domain.SubscriptionPatch{
 		ServiceName: req.ServiceName,
 		Price:       req.Price,
-		StartDate:   start,
-		EndDate:     end,
-	}, nil
+		CostCenter:  req.CostCenter,
+		Category:    req.Category,
+	}
+	if req.StartDate != nil {
+		start, err := time.Parse("01-2006", *req.StartDate)
+		if err != nil {
+			return domain.SubscriptionPatch{}, err
+		}
+		patch.StartDate = &start
+	}
+	if req.EndDate != nil {
+		if *req.EndDate == "" {
+			patch.ClearEndDate = true
+		} else {
+			end, err := time.Parse("01-2006", *req.EndDate)
+			if err != nil {
+				return domain.SubscriptionPatch{}, err
+			}
+			patch.EndDate = &end
+		}
+	}
+	if req.BillingPeriod != nil {
+		period := domain.BillingPeriod(*req.BillingPeriod)
+		patch.BillingPeriod = &period
+	}
+	if req.ExpenseType != nil {
+		expenseType := domain.ExpenseType(*req.ExpenseType)
+		patch.ExpenseType = &expenseType
+	}
+	return patch, nil
 }
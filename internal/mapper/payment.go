@@ -0,0 +1,78 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToPaymentFailureFromDTO(subscriptionID uuid.UUID, req dto.PaymentFailedRequest) (domain.PaymentFailure, error) {
+	period, err := time.Parse("01-2006", req.Period)
+	if err != nil {
+		return domain.PaymentFailure{}, err
+	}
+	return domain.PaymentFailure{
+		SubscriptionID: subscriptionID,
+		Period:         period,
+	}, nil
+}
+
+// DAO -> DOMAIN
+func ToPaymentFailureFromDAO(row dao.PaymentFailureRow) domain.PaymentFailure {
+	return domain.PaymentFailure{
+		SubscriptionID: row.SubscriptionID,
+		UserID:         row.UserID,
+		Period:         row.Period,
+		FailedAt:       row.FailedAt,
+		Retries:        row.Retries,
+		LastRetryAt:    row.LastRetryAt,
+		PaidAt:         row.PaidAt,
+	}
+}
+
+// DOMAIN -> DAO
+func ToPaymentFailureDAO(failure domain.PaymentFailure) dao.PaymentFailureRow {
+	return dao.PaymentFailureRow{
+		SubscriptionID: failure.SubscriptionID,
+		UserID:         failure.UserID,
+		Period:         failure.Period,
+		FailedAt:       failure.FailedAt,
+		Retries:        failure.Retries,
+		LastRetryAt:    failure.LastRetryAt,
+		PaidAt:         failure.PaidAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToPaymentFailureResponse(failure domain.PaymentFailure) dto.PaymentFailureResponse {
+	status := "paid"
+	if failure.Unpaid() {
+		status = "unpaid"
+	}
+	return dto.PaymentFailureResponse{
+		SubscriptionID: failure.SubscriptionID.String(),
+		Period:         failure.Period.Format("01-2006"),
+		Status:         status,
+		FailedAt:       failure.FailedAt,
+		Retries:        failure.Retries,
+		LastRetryAt:    failure.LastRetryAt,
+		PaidAt:         failure.PaidAt,
+	}
+}
+
+func ToPaymentHistoryResponse(history domain.PaymentHistory) dto.PaymentHistoryResponse {
+	failures := make([]dto.PaymentFailureResponse, len(history.Failures))
+	for i, failure := range history.Failures {
+		failures[i] = ToPaymentFailureResponse(failure)
+	}
+	return dto.PaymentHistoryResponse{
+		SubscriptionID: history.SubscriptionID.String(),
+		Status:         string(history.Status()),
+		Failures:       failures,
+	}
+}
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToPriceChangeFromDTO(subscriptionID uuid.UUID, req dto.SchedulePriceChangeRequest) (domain.PriceChange, error) {
+	effective, err := time.Parse("01-2006", req.EffectiveDate)
+	if err != nil {
+		return domain.PriceChange{}, err
+	}
+	return domain.PriceChange{
+		SubscriptionID: subscriptionID,
+		Price:          *req.Price,
+		EffectiveDate:  effective,
+	}, nil
+}
+
+// DAO -> DOMAIN
+func ToPriceChangeFromDAO(row dao.PriceChangeRow) domain.PriceChange {
+	return domain.PriceChange{
+		ID:             row.ID,
+		SubscriptionID: row.SubscriptionID,
+		UserID:         row.UserID,
+		Price:          row.Price,
+		EffectiveDate:  row.EffectiveDate,
+		CreatedAt:      row.CreatedAt,
+		AppliedAt:      row.AppliedAt,
+	}
+}
+
+// DOMAIN -> DAO
+func ToPriceChangeDAO(change domain.PriceChange) dao.PriceChangeRow {
+	return dao.PriceChangeRow{
+		ID:             change.ID,
+		SubscriptionID: change.SubscriptionID,
+		UserID:         change.UserID,
+		Price:          change.Price,
+		EffectiveDate:  change.EffectiveDate,
+		CreatedAt:      change.CreatedAt,
+		AppliedAt:      change.AppliedAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToPriceChangeResponse(change domain.PriceChange) dto.PriceChangeResponse {
+	status := "applied"
+	if change.Pending() {
+		status = "pending"
+	}
+	return dto.PriceChangeResponse{
+		ID:             change.ID.String(),
+		SubscriptionID: change.SubscriptionID.String(),
+		Price:          change.Price,
+		EffectiveDate:  change.EffectiveDate.Format("01-2006"),
+		Status:         status,
+		CreatedAt:      change.CreatedAt,
+		AppliedAt:      change.AppliedAt,
+	}
+}
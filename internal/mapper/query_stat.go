@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+)
+
+// DAO -> DOMAIN
+func ToQueryStatFromDAO(row dao.QueryStatRow) domain.QueryStat {
+	return domain.QueryStat{
+		QueryID:   row.QueryID,
+		Query:     row.Query,
+		Calls:     row.Calls,
+		Rows:      row.Rows,
+		TotalTime: time.Duration(row.TotalExecTime * float64(time.Millisecond)),
+		MeanTime:  time.Duration(row.MeanExecTime * float64(time.Millisecond)),
+	}
+}
+
+// DOMAIN -> DTO
+func ToQueryStatResponse(stat domain.QueryStat) dto.QueryStatResponse {
+	return dto.QueryStatResponse{
+		QueryID:     stat.QueryID,
+		Query:       stat.Query,
+		Calls:       stat.Calls,
+		Rows:        stat.Rows,
+		TotalTimeMs: float64(stat.TotalTime) / float64(time.Millisecond),
+		MeanTimeMs:  float64(stat.MeanTime) / float64(time.Millisecond),
+	}
+}
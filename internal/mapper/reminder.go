@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+)
+
+// DAO -> DOMAIN
+func ToReminderSettingFromDAO(row dao.ReminderSettingRow) domain.ReminderSetting {
+	return domain.ReminderSetting{
+		SubscriptionID: row.SubscriptionID,
+		Enabled:        row.Enabled,
+		DaysBefore:     row.DaysBefore,
+	}
+}
+
+func ToReminderCandidateFromDAO(row dao.ReminderCandidateRow) domain.ReminderCandidate {
+	return domain.ReminderCandidate{
+		Subscription:   ToDomainFromDAO(row.SubscriptionRow),
+		Email:          row.Email,
+		TelegramChatID: row.TelegramChatID,
+		DaysBefore:     row.DaysBefore,
+	}
+}
+
+// DOMAIN -> DAO
+func ToReminderSettingDAO(setting domain.ReminderSetting) dao.ReminderSettingRow {
+	return dao.ReminderSettingRow{
+		SubscriptionID: setting.SubscriptionID,
+		Enabled:        setting.Enabled,
+		DaysBefore:     setting.DaysBefore,
+	}
+}
+
+// DOMAIN -> DTO
+func ToReminderSettingResponse(setting domain.ReminderSetting) dto.ReminderSettingResponse {
+	return dto.ReminderSettingResponse{
+		SubscriptionID:      setting.SubscriptionID.String(),
+		Enabled:             setting.Enabled,
+		DaysBefore:          setting.DaysBefore,
+		EffectiveDaysBefore: setting.EffectiveDaysBefore,
+	}
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+)
+
+// DOMAIN -> DTO
+func ToSavingsReportResponse(report domain.SavingsReport) dto.SavingsReportResponse {
+	resp := dto.SavingsReportResponse{
+		PeriodStart:            report.PeriodStart.Format("01-2006"),
+		PeriodEnd:              report.PeriodEnd.Format("01-2006"),
+		CancelledCount:         len(report.Cancelled),
+		MonthlySavings:         report.MonthlySavings,
+		ProjectedAnnualSavings: report.ProjectedAnnualSavings,
+		Cancelled:              make([]dto.SubscriptionResponse, len(report.Cancelled)),
+	}
+	for i, sub := range report.Cancelled {
+		resp.Cancelled[i] = ToDTOFromDomain(sub)
+	}
+	return resp
+}
+
+func ToSubscriptionDetailResponse(sub domain.Subscription, benchmark *domain.ServiceBenchmark) dto.SubscriptionDetailResponse {
+	resp := dto.SubscriptionDetailResponse{SubscriptionResponse: ToDTOFromDomain(sub)}
+	if benchmark != nil {
+		resp.Benchmark = &dto.ServiceBenchmarkResponse{
+			AveragePrice: benchmark.AveragePrice,
+			Difference:   sub.Price - benchmark.AveragePrice,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,69 @@
+package mapper
+
+import (
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToRuleDomainFromDTO(req dto.CreateRuleRequest) (domain.CategoryRule, error) {
+	rule := domain.CategoryRule{
+		Pattern:  req.Pattern,
+		Category: req.Category,
+		Priority: req.Priority,
+	}
+	if req.UserID != "" {
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return domain.CategoryRule{}, err
+		}
+		rule.UserID = &userID
+	}
+	return rule, nil
+}
+
+// DOMAIN -> DTO
+func ToRuleDTOFromDomain(rule domain.CategoryRule) dto.RuleResponse {
+	resp := dto.RuleResponse{
+		ID:       rule.ID.String(),
+		Pattern:  rule.Pattern,
+		Category: rule.Category,
+		Priority: rule.Priority,
+	}
+	if rule.UserID != nil {
+		resp.UserID = rule.UserID.String()
+	}
+	return resp
+}
+
+func ToApplyRulesResponse(result domain.RuleApplyResult) dto.ApplyRulesResponse {
+	return dto.ApplyRulesResponse{
+		Checked: result.Checked,
+		Updated: result.Updated,
+	}
+}
+
+// DAO -> DOMAIN
+func ToRuleDomainFromDAO(row dao.CategoryRuleRow) domain.CategoryRule {
+	return domain.CategoryRule{
+		ID:       row.ID,
+		UserID:   row.UserID,
+		Pattern:  row.Pattern,
+		Category: row.Category,
+		Priority: row.Priority,
+	}
+}
+
+// DOMAIN -> DAO
+func ToRuleDAOFromDomain(rule domain.CategoryRule) dao.CategoryRuleRow {
+	return dao.CategoryRuleRow{
+		ID:       rule.ID,
+		UserID:   rule.UserID,
+		Pattern:  rule.Pattern,
+		Category: rule.Category,
+		Priority: rule.Priority,
+	}
+}
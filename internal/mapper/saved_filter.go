@@ -0,0 +1,78 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToSavedFilterDomainFromDTO(req dto.CreateSavedFilterRequest) (domain.SavedFilter, error) {
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return domain.SavedFilter{}, err
+	}
+
+	return domain.SavedFilter{
+		UserID: userID,
+		Name:   req.Name,
+		Params: req.Params,
+	}, nil
+}
+
+func ToSavedFilterDomainFromUpdateDTO(req dto.UpdateSavedFilterRequest) domain.SavedFilter {
+	return domain.SavedFilter{
+		Name:   req.Name,
+		Params: req.Params,
+	}
+}
+
+// DOMAIN -> DTO
+func ToSavedFilterDTOFromDomain(f domain.SavedFilter) dto.SavedFilterResponse {
+	return dto.SavedFilterResponse{
+		ID:     f.ID.String(),
+		UserID: f.UserID.String(),
+		Name:   f.Name,
+		Params: f.Params,
+	}
+}
+
+// DAO -> DOMAIN
+func ToSavedFilterDomainFromDAO(row dao.SavedFilterRow) (domain.SavedFilter, error) {
+	params := map[string]string{}
+	if len(row.Params) > 0 {
+		if err := json.Unmarshal(row.Params, &params); err != nil {
+			return domain.SavedFilter{}, err
+		}
+	}
+
+	return domain.SavedFilter{
+		ID:     row.ID,
+		UserID: row.UserID,
+		Name:   row.Name,
+		Params: params,
+	}, nil
+}
+
+// DOMAIN -> DAO
+func ToSavedFilterDAOFromDomain(f domain.SavedFilter) (dao.SavedFilterRow, error) {
+	params := f.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return dao.SavedFilterRow{}, err
+	}
+
+	return dao.SavedFilterRow{
+		ID:     f.ID,
+		UserID: f.UserID,
+		Name:   f.Name,
+		Params: raw,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+)
+
+// DOMAIN -> DTO
+func ToStatusResponse(status domain.SystemStatus) dto.StatusResponse {
+	dependencies := make([]dto.DependencyStatusResponse, len(status.Dependencies))
+	for i, dependency := range status.Dependencies {
+		dependencies[i] = dto.DependencyStatusResponse{Name: dependency.Name, Status: "ok", Problem: dependency.Problem}
+		if !dependency.Healthy() {
+			dependencies[i].Status = "failing"
+		}
+	}
+	resp := dto.StatusResponse{
+		Status:        "ok",
+		Version:       status.Build.Version,
+		Commit:        status.Build.Commit,
+		StartedAt:     status.StartedAt.UTC().Format(time.RFC3339),
+		UptimeSeconds: int64(status.Uptime / time.Second),
+		Dependencies:  dependencies,
+	}
+	if !status.Healthy() {
+		resp.Status = "degraded"
+	}
+	return resp
+}
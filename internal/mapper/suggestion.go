@@ -0,0 +1,104 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToSuggestionDomainFromDTO(req dto.CreateSuggestionRequest) (domain.Suggestion, error) {
+	start, err := time.Parse("01-2006", req.StartDate)
+	if err != nil {
+		return domain.Suggestion{}, err
+	}
+	var end *time.Time
+	if req.EndDate != "" {
+		t, err := time.Parse("01-2006", req.EndDate)
+		if err != nil {
+			return domain.Suggestion{}, err
+		}
+		end = &t
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return domain.Suggestion{}, err
+	}
+	return domain.Suggestion{
+		UserID:      userID,
+		Source:      req.Source,
+		ServiceName: req.ServiceName,
+		Price:       req.Price,
+		StartDate:   start,
+		EndDate:     end,
+	}, nil
+}
+
+// DOMAIN -> DTO
+func ToSuggestionDTOFromDomain(s domain.Suggestion) dto.SuggestionResponse {
+	resp := dto.SuggestionResponse{
+		ID:          s.ID.String(),
+		UserID:      s.UserID.String(),
+		Source:      s.Source,
+		ServiceName: s.ServiceName,
+		Price:       s.Price,
+		StartDate:   s.StartDate.Format("01-2006"),
+		Status:      string(s.Status),
+		CreatedAt:   s.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if s.EndDate != nil {
+		resp.EndDate = s.EndDate.Format("01-2006")
+	}
+	if s.SubscriptionID != nil {
+		resp.SubscriptionID = s.SubscriptionID.String()
+	}
+	if s.ResolvedAt != nil {
+		resp.ResolvedAt = s.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func ToSuggestionResolutionResponse(s domain.Suggestion, warnings []domain.Warning) dto.SuggestionResolutionResponse {
+	return dto.SuggestionResolutionResponse{
+		Suggestion: ToSuggestionDTOFromDomain(s),
+		Warnings:   ToWarningResponses(warnings),
+	}
+}
+
+// DAO -> DOMAIN
+func ToSuggestionDomainFromDAO(row dao.SuggestionRow) domain.Suggestion {
+	return domain.Suggestion{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		Source:         row.Source,
+		ServiceName:    row.ServiceName,
+		Price:          row.Price,
+		StartDate:      row.StartDate,
+		EndDate:        row.EndDate,
+		Status:         domain.SuggestionStatus(row.Status),
+		SubscriptionID: row.SubscriptionID,
+		CreatedAt:      row.CreatedAt,
+		ResolvedAt:     row.ResolvedAt,
+	}
+}
+
+// DOMAIN -> DAO
+func ToSuggestionDAOFromDomain(s domain.Suggestion) dao.SuggestionRow {
+	return dao.SuggestionRow{
+		ID:             s.ID,
+		UserID:         s.UserID,
+		Source:         s.Source,
+		ServiceName:    s.ServiceName,
+		Price:          s.Price,
+		StartDate:      s.StartDate,
+		EndDate:        s.EndDate,
+		Status:         string(s.Status),
+		SubscriptionID: s.SubscriptionID,
+		CreatedAt:      s.CreatedAt,
+		ResolvedAt:     s.ResolvedAt,
+	}
+}
@@ -0,0 +1,123 @@
+package mapper
+
+import (
+	"errors"
+	"strconv"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+	"subtracker/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+// DAO -> DOMAIN
+func ToSubscriptionChangeFromDAO(row dao.SubscriptionChangeRow) domain.SubscriptionChange {
+	change := domain.SubscriptionChange{
+		Version:        row.Seq,
+		SubscriptionID: row.SubscriptionID,
+	}
+	// An upsert whose row is gone was superseded by a write not yet in the log.
+	if row.Op == dao.ChangeOpDelete || row.ServiceName == nil {
+		change.Deleted = true
+		return change
+	}
+	change.Subscription = &domain.Subscription{
+		ID:          row.SubscriptionID,
+		UserID:      row.UserID,
+		ServiceName: *row.ServiceName,
+		Price:       *row.Price,
+		StartDate:   *row.StartDate,
+		EndDate:     row.EndDate,
+	}
+	if row.CostCenter != nil {
+		change.Subscription.CostCenter = *row.CostCenter
+	}
+	if row.Category != nil {
+		change.Subscription.Category = *row.Category
+	}
+	if row.BillingPeriod != nil {
+		change.Subscription.BillingPeriod = domain.BillingPeriod(*row.BillingPeriod)
+	}
+	if row.ExpenseType != nil {
+		change.Subscription.ExpenseType = domain.ExpenseType(*row.ExpenseType)
+	}
+	return change
+}
+
+// DTO -> DOMAIN
+func ToClientChangeFromDTO(userID uuid.UUID, req dto.SyncPushChange) (domain.ClientChange, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return domain.ClientChange{}, err
+	}
+	change := domain.ClientChange{
+		SubscriptionID:  id,
+		Deleted:         req.Op == dao.ChangeOpDelete,
+		BaseVersion:     req.BaseVersion,
+		ClientUpdatedAt: req.ClientUpdatedAt,
+	}
+	if change.Deleted {
+		return change, nil
+	}
+	sub, err := ToDomainFromUpdateDTO(*req.Subscription)
+	if err != nil {
+		return domain.ClientChange{}, err
+	}
+	sub.ID = id
+	sub.UserID = userID
+	change.Subscription = sub
+	return change, nil
+}
+
+// DOMAIN -> DTO
+func ToSyncResponse(set domain.ChangeSet) dto.SyncResponse {
+	changes := make([]dto.SyncChangeResponse, len(set.Changes))
+	for i, c := range set.Changes {
+		changes[i] = toSyncChangeResponse(c)
+	}
+	return dto.SyncResponse{
+		Changes: changes,
+		Cursor:  strconv.FormatInt(set.Cursor, 10),
+		HasMore: set.HasMore,
+	}
+}
+
+func ToSyncPushResponse(results []domain.PushResult) dto.SyncPushResponse {
+	resp := dto.SyncPushResponse{Results: make([]dto.SyncPushResultResponse, len(results))}
+	for i, result := range results {
+		item := dto.SyncPushResultResponse{
+			ID:     result.SubscriptionID.String(),
+			Status: result.Status,
+		}
+		if result.Err != nil {
+			item.Error = "Internal Server Error"
+			var appErr *apperrors.AppError
+			if errors.As(result.Err, &appErr) {
+				item.Error = appErr.Message
+			}
+		}
+		if result.Server != nil {
+			server := toSyncChangeResponse(*result.Server)
+			item.Server = &server
+		}
+		resp.Results[i] = item
+	}
+	return resp
+}
+
+func toSyncChangeResponse(c domain.SubscriptionChange) dto.SyncChangeResponse {
+	change := dto.SyncChangeResponse{
+		Op:      dao.ChangeOpUpsert,
+		ID:      c.SubscriptionID.String(),
+		Version: c.Version,
+	}
+	if c.Deleted {
+		change.Op = dao.ChangeOpDelete
+		return change
+	}
+	sub := ToDTOFromDomain(*c.Subscription)
+	change.Subscription = &sub
+	return change
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+)
+
+// DAO -> DOMAIN
+func ToTelegramLinkFromDAO(row dao.TelegramLinkRow) domain.TelegramLink {
+	return domain.TelegramLink{
+		UserID:   row.UserID,
+		ChatID:   row.ChatID,
+		LinkedAt: row.LinkedAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToTelegramLinkResponse(link domain.TelegramLink) dto.TelegramLinkResponse {
+	return dto.TelegramLinkResponse{
+		ChatID:   link.ChatID,
+		LinkedAt: link.LinkedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ToTelegramLinkCodeResponse(code domain.TelegramLinkCode) dto.TelegramLinkCodeResponse {
+	return dto.TelegramLinkCodeResponse{
+		Code:      code.Code,
+		Command:   "/start " + code.Code,
+		ExpiresAt: code.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
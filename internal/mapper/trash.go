@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DAO -> DOMAIN
+func ToTrashedDomainFromDAO(row dao.TrashedSubscriptionRow) domain.TrashedSubscription {
+	return domain.TrashedSubscription{
+		Subscription: ToDomainFromDAO(row.SubscriptionRow),
+		DeletedAt:    row.DeletedAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToTrashedDTOFromDomain(sub domain.TrashedSubscription) dto.TrashedSubscriptionResponse {
+	return dto.TrashedSubscriptionResponse{
+		SubscriptionResponse: ToDTOFromDomain(sub.Subscription),
+		DeletedAt:            sub.DeletedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ToRestoreResponse(result domain.RestoreResult) dto.RestoreSubscriptionsResponse {
+	return dto.RestoreSubscriptionsResponse{
+		Restored: uuidStrings(result.Restored),
+		NotFound: uuidStrings(result.NotFound),
+	}
+}
+
+func ToLookupResponse(result domain.LookupResult) dto.LookupSubscriptionsResponse {
+	items := make([]dto.SubscriptionResponse, len(result.Found))
+	for i, sub := range result.Found {
+		items[i] = ToDTOFromDomain(sub)
+	}
+	return dto.LookupSubscriptionsResponse{
+		Items:    items,
+		NotFound: uuidStrings(result.NotFound),
+	}
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
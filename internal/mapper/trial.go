@@ -0,0 +1,73 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToTrialFromDTO(subscriptionID uuid.UUID, req dto.SetTrialRequest) (domain.Trial, error) {
+	endsOn, err := time.Parse("2006-01-02", req.EndsOn)
+	if err != nil {
+		return domain.Trial{}, err
+	}
+	return domain.Trial{
+		SubscriptionID:       subscriptionID,
+		EndsOn:               endsOn,
+		RegularPrice:         *req.RegularPrice,
+		RegularBillingPeriod: domain.BillingPeriod(req.RegularBillingPeriod),
+	}, nil
+}
+
+// DAO -> DOMAIN
+func ToTrialFromDAO(row dao.TrialRow) domain.Trial {
+	return domain.Trial{
+		SubscriptionID:       row.SubscriptionID,
+		UserID:               row.UserID,
+		EndsOn:               row.EndsOn,
+		RegularPrice:         row.RegularPrice,
+		RegularBillingPeriod: domain.BillingPeriod(row.RegularBillingPeriod),
+		EndedAt:              row.EndedAt,
+		Converted:            row.Converted,
+	}
+}
+
+func ToConvertedTrialFromDAO(row dao.ConvertedTrialRow) domain.ConvertedTrial {
+	return domain.ConvertedTrial{
+		Subscription:       ToDomainFromDAO(row.SubscriptionRow),
+		TrialPrice:         row.TrialPrice,
+		TrialBillingPeriod: domain.BillingPeriod(row.TrialBillingPeriod),
+		Email:              row.Email,
+		TelegramChatID:     row.TelegramChatID,
+	}
+}
+
+// DOMAIN -> DAO
+func ToTrialDAO(trial domain.Trial) dao.TrialRow {
+	return dao.TrialRow{
+		SubscriptionID:       trial.SubscriptionID,
+		UserID:               trial.UserID,
+		EndsOn:               trial.EndsOn,
+		RegularPrice:         trial.RegularPrice,
+		RegularBillingPeriod: string(trial.RegularBillingPeriod),
+		EndedAt:              trial.EndedAt,
+		Converted:            trial.Converted,
+	}
+}
+
+// DOMAIN -> DTO
+func ToTrialResponse(trial domain.Trial) dto.TrialResponse {
+	return dto.TrialResponse{
+		SubscriptionID:       trial.SubscriptionID.String(),
+		EndsOn:               trial.EndsOn.Format("2006-01-02"),
+		RegularPrice:         trial.RegularPrice,
+		RegularBillingPeriod: string(trial.RegularBillingPeriod),
+		Status:               string(trial.Status()),
+		EndedAt:              trial.EndedAt,
+	}
+}
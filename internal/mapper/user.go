@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+)
+
+// DAO -> DOMAIN
+func ToUserDomainFromDAO(row dao.UserRow) domain.User {
+	return domain.User{
+		ID:        row.ID,
+		Email:     row.Email,
+		Role:      domain.Role(row.Role),
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToUserDTOFromDomain(user domain.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:        user.ID.String(),
+		Email:     user.Email,
+		Role:      string(user.Role),
+		CreatedAt: user.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"subtracker/internal/domain"
+	"subtracker/pkg/response"
+)
+
+// DOMAIN -> RESPONSE
+func ToWarningResponses(warnings []domain.Warning) []response.Warning {
+	if len(warnings) == 0 {
+		return nil
+	}
+	result := make([]response.Warning, len(warnings))
+	for i, w := range warnings {
+		result[i] = response.Warning{Code: w.Code, Message: w.Message}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package mapper
+
+import (
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+
+	"github.com/google/uuid"
+)
+
+// DTO -> DOMAIN
+func ToWebhookDomainFromDTO(req dto.CreateWebhookRequest) (domain.Webhook, error) {
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return domain.Webhook{
+		UserID: userID,
+		URL:    req.URL,
+		Events: req.Events,
+	}, nil
+}
+
+func ToWebhookPatchFromDTO(req dto.UpdateWebhookRequest) domain.WebhookPatch {
+	return domain.WebhookPatch{
+		URL:    req.URL,
+		Events: req.Events,
+	}
+}
+
+// DAO -> DOMAIN
+func ToWebhookFromDAO(row dao.WebhookRow) domain.Webhook {
+	return domain.Webhook{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		URL:       row.URL,
+		Secret:    row.Secret,
+		Events:    row.Events,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func ToWebhookDeliveryFromDAO(row dao.WebhookDeliveryRow) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:             row.ID,
+		WebhookID:      row.WebhookID,
+		Event:          row.Event,
+		Sequence:       row.Sequence,
+		Status:         domain.DeliveryStatus(row.Status),
+		Attempts:       row.Attempts,
+		ResponseStatus: row.ResponseStatus,
+		LastError:      row.LastError,
+		NextAttemptAt:  row.NextAttemptAt,
+		CreatedAt:      row.CreatedAt,
+		DeliveredAt:    row.DeliveredAt,
+	}
+}
+
+// DOMAIN -> DAO
+func ToWebhookDAO(w domain.Webhook) dao.WebhookRow {
+	return dao.WebhookRow{
+		ID:        w.ID,
+		UserID:    w.UserID,
+		URL:       w.URL,
+		Secret:    w.Secret,
+		Events:    w.Events,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+// DOMAIN -> DTO
+func ToWebhookResponse(w domain.Webhook) dto.WebhookResponse {
+	return dto.WebhookResponse{
+		ID:        w.ID.String(),
+		UserID:    w.UserID.String(),
+		URL:       w.URL,
+		Events:    w.Events,
+		CreatedAt: w.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ToCreateWebhookResponse(w domain.Webhook) dto.CreateWebhookResponse {
+	return dto.CreateWebhookResponse{
+		WebhookResponse: ToWebhookResponse(w),
+		Secret:          w.Secret,
+	}
+}
+
+func ToWebhookDeliveryResponse(d domain.WebhookDelivery) dto.WebhookDeliveryResponse {
+	resp := dto.WebhookDeliveryResponse{
+		ID:             d.ID.String(),
+		Event:          d.Event,
+		Sequence:       d.Sequence,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if d.Status == domain.DeliveryPending {
+		resp.NextAttemptAt = d.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	if d.DeliveredAt != nil {
+		resp.DeliveredAt = d.DeliveredAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func ToWebhookStatsResponse(stats domain.WebhookStats) dto.WebhookStatsResponse {
+	resp := dto.WebhookStatsResponse{
+		WebhookID:                     stats.WebhookID.String(),
+		WebhookDeliveryCountsResponse: toDeliveryCountsResponse(stats.DeliveryCounts),
+		Events:                        make([]dto.WebhookEventStatsResponse, len(stats.Events)),
+	}
+	for i, event := range stats.Events {
+		resp.Events[i] = dto.WebhookEventStatsResponse{
+			Event:                         event.Event,
+			WebhookDeliveryCountsResponse: toDeliveryCountsResponse(event.DeliveryCounts),
+		}
+	}
+	if stats.LastDeliveredAt != nil {
+		resp.LastDeliveredAt = stats.LastDeliveredAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func toDeliveryCountsResponse(c domain.DeliveryCounts) dto.WebhookDeliveryCountsResponse {
+	return dto.WebhookDeliveryCountsResponse{
+		Pending:   c.Pending,
+		Delivered: c.Delivered,
+		Failed:    c.Failed,
+	}
+}
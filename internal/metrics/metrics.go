@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const namespace = "subtracker"
+
+var (
+	ActiveSubscriptions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_subscriptions",
+		Help:      "Subscriptions that have started and not yet ended.",
+	})
+	TrackedMonthlySpend = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tracked_monthly_spend",
+		Help:      "Sum of monthly prices of active subscriptions.",
+	})
+	SubscriptionsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "subscriptions_created_total",
+		Help:      "Subscriptions created through the API.",
+	})
+	SubscriptionsDeleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "subscriptions_deleted_total",
+		Help:      "Subscriptions deleted through the API.",
+	})
+
+	// HTTPRequestDuration buckets are aligned with the latency SLOs: 100ms for
+	// CRUD reads, 250ms for writes and 1s for cost calculations.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by chi route pattern.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"method", "route", "status"})
+	HTTPRequestsCancelled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_cancelled_total",
+		Help:      "Requests abandoned by the client before a response was written.",
+	}, []string{"method", "route"})
+	IntegrityViolations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "integrity_violations",
+		Help:      "Rows breaking a data invariant at the last integrity check.",
+	}, []string{"invariant"})
+	RemindersSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reminders_sent_total",
+		Help:      "Renewal reminders by channel and result (sent or failed).",
+	}, []string{"channel", "result"})
+	WebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts by result (delivered, retried or failed).",
+	}, []string{"result"})
+)
+
+// Registry holds every subtracker metric plus the Go runtime and process collectors.
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		ActiveSubscriptions,
+		TrackedMonthlySpend,
+		SubscriptionsCreated,
+		SubscriptionsDeleted,
+		HTTPRequestDuration,
+		HTTPRequestsCancelled,
+		IntegrityViolations,
+		RemindersSent,
+		WebhookDeliveries,
+	)
+}
+
+// StatsSource reports aggregate figures behind the KPI gauges.
+type StatsSource interface {
+	SubscriptionStats(ctx context.Context, at time.Time) (active int, monthlySpend int, err error)
+}
+
+// RefreshKPIs updates the KPI gauges from source every interval until ctx is done.
+// Aggregates are computed off the request path so scrapes never hit the database;
+// they cover the subscriptions active at clock's current time.
+func RefreshKPIs(ctx context.Context, source StatsSource, interval time.Duration, clock clock.Clock, logger logger.Logger) {
+	refresh := func() {
+		active, spend, err := source.SubscriptionStats(ctx, clock.Now())
+		if err != nil {
+			logger.Warn("Failed to refresh KPI metrics", zap.Error(err))
+			return
+		}
+		ActiveSubscriptions.Set(float64(active))
+		TrackedMonthlySpend.Set(float64(spend))
+	}
+
+	refresh()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.uber.org/zap"
+)
+
+type AuditRepositoryInterface interface {
+	CreateEntry(ctx context.Context, row dao.AuditRow) error
+	ListEntries(ctx context.Context, entityType, entityID string, limit int) ([]dao.AuditRow, error)
+}
+
+type AuditRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewAuditRepository(db *sql.DB, logger logger.Logger) *AuditRepository {
+	return &AuditRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AuditRepository) CreateEntry(ctx context.Context, row dao.AuditRow) error {
+	query := `INSERT INTO audit_log (entity_type, entity_id, owner_id, actor_id, action, changes, changed_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	r.logger.DebugContext(ctx, "Executing CreateEntry query",
+		zap.String("sql", query),
+		zap.String("entity_type", row.EntityType),
+		zap.String("entity_id", row.EntityID.String()),
+		zap.String("action", row.Action),
+	)
+
+	changes, err := json.Marshal(row.Changes)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to encode audit changes", zap.Error(err))
+		return apperrors.NewInternalServerError("failed to encode audit changes", err)
+	}
+	if _, err := r.db.ExecContext(ctx, query, row.EntityType, row.EntityID, row.OwnerID, row.ActorID, row.Action, changes, row.ChangedAt); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to create audit entry", zap.Error(err), zap.String("entity_id", row.EntityID.String()))
+		return apperrors.NewInternalServerError("database error on create audit entry", err)
+	}
+	return nil
+}
+
+// ListEntries returns the entity's most recent audit entries, newest first.
+func (r *AuditRepository) ListEntries(ctx context.Context, entityType, entityID string, limit int) ([]dao.AuditRow, error) {
+	queryBuilder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("id", "entity_type", "entity_id", "owner_id", "actor_id", "action", "changes", "changed_at").
+		From("audit_log").
+		Where(sq.Eq{"entity_type": entityType}).
+		Where(sq.Eq{"entity_id": entityID})
+	queryBuilder, err := paginate(queryBuilder, Page{Limit: limit}, auditSort)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to build SQL query for ListEntries", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("failed to build list audit entries query", err)
+	}
+	r.logger.DebugContext(ctx, "Executing ListEntries query",
+		zap.String("sql", query),
+		zap.String("entity_type", entityType),
+		zap.String("entity_id", entityID),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list audit entries", zap.Error(err), zap.String("entity_id", entityID))
+		return nil, apperrors.NewInternalServerError("database error on list audit entries", err)
+	}
+	defer rows.Close()
+
+	var result []dao.AuditRow
+	for rows.Next() {
+		var row dao.AuditRow
+		var changes []byte
+		if err := rows.Scan(&row.ID, &row.EntityType, &row.EntityID, &row.OwnerID, &row.ActorID, &row.Action, &changes, &row.ChangedAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan audit entry row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan audit entry", err)
+		}
+		if err := json.Unmarshal(changes, &row.Changes); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to decode audit changes", zap.Error(err), zap.Int64("audit_id", row.ID))
+			return nil, apperrors.NewInternalServerError("failed to decode audit changes", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate audit entries", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list audit entries", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAuditRepo(t *testing.T) (*AuditRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(passthroughConverter{}))
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewAuditRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestCreateAuditEntry(t *testing.T) {
+	repo, mock := newTestAuditRepo(t)
+	actorID := uuid.New()
+	row := dao.AuditRow{
+		EntityType: "subscription",
+		EntityID:   uuid.New(),
+		OwnerID:    uuid.New(),
+		ActorID:    &actorID,
+		Action:     "update",
+		Changes:    map[string]dao.AuditChange{"price": {Before: 999, After: 1099}},
+		ChangedAt:  time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC),
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_log (entity_type, entity_id, owner_id, actor_id, action, changes, changed_at)`)).
+		WithArgs(row.EntityType, row.EntityID, row.OwnerID, row.ActorID, row.Action, []byte(`{"price":{"before":999,"after":1099}}`), row.ChangedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.CreateEntry(context.Background(), row)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListAuditEntries(t *testing.T) {
+	repo, mock := newTestAuditRepo(t)
+	entityID, ownerID := uuid.New(), uuid.New()
+	changedAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM audit_log WHERE entity_type = $1 AND entity_id = $2 ORDER BY id DESC LIMIT 20`)).
+		WithArgs("subscription", entityID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "entity_id", "owner_id", "actor_id", "action", "changes", "changed_at"}).
+			AddRow(int64(2), "subscription", entityID, ownerID, nil, "delete", []byte(`{"service_name":{"before":"Netflix","after":null}}`), changedAt))
+
+	rows, err := repo.ListEntries(context.Background(), "subscription", entityID.String(), 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Nil(t, rows[0].ActorID)
+	assert.Equal(t, map[string]dao.AuditChange{"service_name": {Before: "Netflix", After: nil}}, rows[0].Changes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
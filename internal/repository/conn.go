@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
 	"time"
 
 	"subtracker/internal/config"
@@ -13,33 +15,112 @@ import (
 	"go.uber.org/zap"
 )
 
-func ConnectDB(ctx context.Context, cfg config.PostgresConfig, logger logger.Logger) (*sql.DB, error) {
+const (
+	initialConnectBackoff = 250 * time.Millisecond
+	maxConnectBackoff     = 10 * time.Second
+)
+
+// Availability reports whether the database has been reached at least once.
+type Availability struct {
+	ready atomic.Bool
+}
+
+func (a *Availability) Ready() bool {
+	return a.ready.Load()
+}
+
+func openDB(cfg config.PostgresConfig) (*sql.DB, string, error) {
 	connStr := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable"+
+			" default_query_exec_mode=%s statement_cache_capacity=%d description_cache_capacity=%d",
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
+		cfg.QueryExecMode, cfg.StatementCacheCapacity, cfg.DescriptionCacheCapacity,
 	)
 
 	db, err := sql.Open("pgx", connStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open DB: %w", err)
+		return nil, "", fmt.Errorf("failed to open DB: %w", err)
 	}
+	return db, connStr, nil
+}
 
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+// ConnectDB opens the pool and blocks until the database answers a ping or
+// cfg.ConnectTimeout elapses.
+func ConnectDB(ctx context.Context, cfg config.PostgresConfig, logger logger.Logger) (*sql.DB, error) {
+	db, connStr, err := openDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
 	defer cancel()
 
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
+	if err := waitForDB(ctx, db, logger); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("timeout: failed to connect to DB within the deadline: %w", err)
+	}
+	logger.Info("Connected to the database successfully", zap.String("dsn", connStr))
+	return db, nil
+}
+
+// ConnectDBInBackground opens the pool and returns immediately, retrying the
+// first ping in the background until ctx is done. The returned Availability
+// flips to ready once the database has answered.
+func ConnectDBInBackground(ctx context.Context, cfg config.PostgresConfig, logger logger.Logger) (*sql.DB, *Availability, error) {
+	db, connStr, err := openDB(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	availability := &Availability{}
+	go func() {
+		if err := waitForDB(ctx, db, logger); err != nil {
+			logger.Error("Gave up connecting to the database", zap.Error(err))
+			return
+		}
+		availability.ready.Store(true)
+		logger.Info("Connected to the database successfully", zap.String("dsn", connStr))
+	}()
+	return db, availability, nil
+}
+
+// OpenReportingDB opens the pool used by ReportingRepository. It does not
+// connect eagerly; the primary pool is responsible for startup checks.
+func OpenReportingDB(cfg config.PostgresConfig) (*sql.DB, error) {
+	db, _, err := openDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.ReportingMaxConns)
+	db.SetMaxIdleConns(cfg.ReportingMaxConns)
+	return db, nil
+}
 
-	for {
+// waitForDB pings right away and then backs off exponentially with full jitter.
+func waitForDB(ctx context.Context, db *sql.DB, logger logger.Logger) error {
+	backoff := initialConnectBackoff
+	for attempt := 1; ; attempt++ {
+		logger.Debug("Attempting to connect to the database", zap.Int("attempt", attempt))
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait := rand.N(backoff) + time.Millisecond
+		logger.Warn("Database not reachable yet",
+			zap.Int("attempt", attempt),
+			zap.Duration("retry_in", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("timeout: failed to connect to DB within the deadline: %w", ctx.Err())
-		case <-ticker.C:
-			logger.Debug("Attempting to connect to the database")
-			if err := db.PingContext(ctx); err == nil {
-				logger.Info("Connected to the database successfully", zap.String("dsn", connStr))
-				return db, nil
-			}
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
 		}
+
+		backoff = min(backoff*2, maxConnectBackoff)
 	}
 }
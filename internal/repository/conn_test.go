@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"subtracker/internal/config"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForDB(t *testing.T) {
+	t.Run("Retries Until Ping Succeeds", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		assert.NoError(t, err)
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		mock.ExpectPing()
+
+		err = waitForDB(context.Background(), db, logger.NewNopLogger())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Gives Up When Context Ends", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		assert.NoError(t, err)
+		for range 10 {
+			mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err = waitForDB(ctx, db, logger.NewNopLogger())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestOpenDBStatementCache(t *testing.T) {
+	db, connStr, err := openDB(config.PostgresConfig{
+		DBHost: "db", DBPort: "5432", DBUser: "postgres", DBPassword: "secret", DBName: "subtracker",
+		QueryExecMode: "cache_describe", StatementCacheCapacity: 0, DescriptionCacheCapacity: 128,
+	})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	parsed, err := pgx.ParseConfig(connStr)
+	assert.NoError(t, err)
+	assert.Equal(t, pgx.QueryExecModeCacheDescribe, parsed.DefaultQueryExecMode)
+	assert.Equal(t, 0, parsed.StatementCacheCapacity)
+	assert.Equal(t, 128, parsed.DescriptionCacheCapacity)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type FeatureFlagRepositoryInterface interface {
+	ListFeatureFlags(ctx context.Context) ([]dao.FeatureFlagRow, error)
+	SetFeatureFlag(ctx context.Context, row dao.FeatureFlagRow) (dao.FeatureFlagRow, error)
+	DeleteFeatureFlag(ctx context.Context, name string) error
+}
+
+type FeatureFlagRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewFeatureFlagRepository(db *sql.DB, logger logger.Logger) *FeatureFlagRepository {
+	return &FeatureFlagRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// environments are read back with array_to_string; environment names contain
+// no commas.
+const featureFlagColumns = `name, description, enabled, array_to_string(environments, ','), rollout_percent, updated_at`
+
+func scanFeatureFlag(scan func(dest ...any) error) (dao.FeatureFlagRow, error) {
+	var row dao.FeatureFlagRow
+	var environments string
+	if err := scan(&row.Name, &row.Description, &row.Enabled, &environments, &row.RolloutPercent, &row.UpdatedAt); err != nil {
+		return dao.FeatureFlagRow{}, err
+	}
+	row.Environments = []string{}
+	if environments != "" {
+		row.Environments = strings.Split(environments, ",")
+	}
+	return row, nil
+}
+
+// ListFeatureFlags returns every flag ordered by name.
+func (r *FeatureFlagRepository) ListFeatureFlags(ctx context.Context) ([]dao.FeatureFlagRow, error) {
+	query := `SELECT ` + featureFlagColumns + ` FROM feature_flags ORDER BY name`
+	r.logger.DebugContext(ctx, "Executing ListFeatureFlags query", zap.String("sql", query))
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list feature flags", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list feature flags", err)
+	}
+	defer rows.Close()
+
+	var result []dao.FeatureFlagRow
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows.Scan)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan feature flag row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan feature flag", err)
+		}
+		result = append(result, flag)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate feature flags", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list feature flags", err)
+	}
+	return result, nil
+}
+
+// SetFeatureFlag creates the flag or overwrites it, returning it as stored.
+func (r *FeatureFlagRepository) SetFeatureFlag(ctx context.Context, row dao.FeatureFlagRow) (dao.FeatureFlagRow, error) {
+	query := `INSERT INTO feature_flags (name, description, enabled, environments, rollout_percent) VALUES ($1, $2, $3, $4::text[], $5)
+	ON CONFLICT (name) DO UPDATE SET description = EXCLUDED.description, enabled = EXCLUDED.enabled,
+		environments = EXCLUDED.environments, rollout_percent = EXCLUDED.rollout_percent, updated_at = now()
+	RETURNING ` + featureFlagColumns
+	r.logger.DebugContext(ctx, "Executing SetFeatureFlag query",
+		zap.String("sql", query),
+		zap.String("flag", row.Name),
+	)
+
+	saved, err := scanFeatureFlag(r.db.QueryRowContext(ctx, query, row.Name, row.Description, row.Enabled, row.Environments, row.RolloutPercent).Scan)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to save feature flag", zap.Error(err), zap.String("flag", row.Name))
+		return dao.FeatureFlagRow{}, apperrors.NewInternalServerError("database error on set feature flag", err)
+	}
+	return saved, nil
+}
+
+func (r *FeatureFlagRepository) DeleteFeatureFlag(ctx context.Context, name string) error {
+	query := `DELETE FROM feature_flags WHERE name = $1`
+	r.logger.DebugContext(ctx, "Executing DeleteFeatureFlag query",
+		zap.String("sql", query),
+		zap.String("flag", name),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, name)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to delete feature flag", zap.Error(err), zap.String("flag", name))
+		return apperrors.NewInternalServerError("database error on delete feature flag", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after feature flag delete", zap.Error(err), zap.String("flag", name))
+		return apperrors.NewInternalServerError("database error on delete feature flag result", err)
+	}
+	if rowsAffected == 0 {
+		r.logger.WarnContext(ctx, "Delete attempt on non-existent feature flag", zap.String("flag", name))
+		return apperrors.NewNotFound("feature flag not found", nil)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFeatureFlagRepo(t *testing.T) (*FeatureFlagRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(passthroughConverter{}))
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewFeatureFlagRepository(db, logger.NewNopLogger()), mock
+}
+
+var featureFlagColumnNames = []string{"name", "description", "enabled", "environments", "rollout_percent", "updated_at"}
+
+func TestListFeatureFlags(t *testing.T) {
+	repo, mock := newTestFeatureFlagRepo(t)
+	updated := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM feature_flags ORDER BY name`)).
+		WillReturnRows(sqlmock.NewRows(featureFlagColumnNames).
+			AddRow("new_importer", "", true, "", 100, updated).
+			AddRow("sql_cost_aggregation", "Aggregate costs in SQL", true, "staging,production", 25, updated))
+
+	rows, err := repo.ListFeatureFlags(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{}, rows[0].Environments)
+	assert.Equal(t, []string{"staging", "production"}, rows[1].Environments)
+	assert.Equal(t, 25, rows[1].RolloutPercent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetFeatureFlag(t *testing.T) {
+	repo, mock := newTestFeatureFlagRepo(t)
+	row := dao.FeatureFlagRow{Name: "sql_cost_aggregation", Enabled: true, Environments: []string{"staging"}, RolloutPercent: 25}
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO feature_flags (name, description, enabled, environments, rollout_percent) VALUES ($1, $2, $3, $4::text[], $5)`)).
+		WithArgs(row.Name, row.Description, row.Enabled, row.Environments, row.RolloutPercent).
+		WillReturnRows(sqlmock.NewRows(featureFlagColumnNames).AddRow(row.Name, "", true, "staging", 25, time.Now()))
+
+	saved, err := repo.SetFeatureFlag(context.Background(), row)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"staging"}, saved.Environments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteFeatureFlag_NotFound(t *testing.T) {
+	repo, mock := newTestFeatureFlagRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM feature_flags WHERE name = $1`)).WithArgs("new_importer").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteFeatureFlag(context.Background(), "new_importer")
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
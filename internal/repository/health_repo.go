@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type HealthRepositoryInterface interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+	TopQueries(ctx context.Context, limit int) ([]dao.QueryStatRow, error)
+}
+
+type HealthRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewHealthRepository(db *sql.DB, logger logger.Logger) *HealthRepository {
+	return &HealthRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *HealthRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// MigrationVersion reads the state golang-migrate keeps in schema_migrations.
+func (r *HealthRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	r.logger.DebugContext(ctx, "Executing MigrationVersion query", zap.String("sql", query))
+
+	var version uint
+	var dirty bool
+	if err := r.db.QueryRowContext(ctx, query).Scan(&version, &dirty); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
+
+// TopQueries returns this database's limit queries with the highest mean
+// execution time, read from pg_stat_statements. Queries of other roles whose
+// text the connection may not see are left out.
+func (r *HealthRepository) TopQueries(ctx context.Context, limit int) ([]dao.QueryStatRow, error) {
+	query := `SELECT queryid, query, calls, rows, total_exec_time, mean_exec_time FROM pg_stat_statements
+	WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database()) AND queryid IS NOT NULL
+	ORDER BY mean_exec_time DESC LIMIT $1`
+	r.logger.DebugContext(ctx, "Executing TopQueries query", zap.String("sql", query), zap.Int("limit", limit))
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		// 42P01: the extension is not created; 55000: it is not in
+		// shared_preload_libraries.
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == "42P01" || pgErr.Code == "55000") {
+			r.logger.WarnContext(ctx, "Top queries requested but pg_stat_statements is not enabled", zap.String("code", pgErr.Code))
+			return nil, apperrors.New(http.StatusServiceUnavailable, "pg_stat_statements is not enabled on this database", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to read query statistics", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on top queries", err)
+	}
+	defer rows.Close()
+
+	var result []dao.QueryStatRow
+	for rows.Next() {
+		var q dao.QueryStatRow
+		if err := rows.Scan(&q.QueryID, &q.Query, &q.Calls, &q.Rows, &q.TotalExecTime, &q.MeanExecTime); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan query statistics row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan query statistics", err)
+		}
+		result = append(result, q)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate query statistics", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on top queries", err)
+	}
+	return result, nil
+}
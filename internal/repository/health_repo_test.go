@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHealthRepo(t *testing.T) (*HealthRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewHealthRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestTopQueries(t *testing.T) {
+	query := regexp.QuoteMeta(`FROM pg_stat_statements`)
+
+	t.Run("Slowest First", func(t *testing.T) {
+		repo, mock := newTestHealthRepo(t)
+		mock.ExpectQuery(query).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"queryid", "query", "calls", "rows", "total_exec_time", "mean_exec_time"}).
+				AddRow(int64(11), "SELECT SUM(price) FROM subscriptions", int64(4), int64(4), 200.0, 50.0).
+				AddRow(int64(12), "SELECT id FROM subscriptions WHERE id = $1", int64(1000), int64(1000), 500.0, 0.5))
+
+		rows, err := repo.TopQueries(context.Background(), 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, int64(11), rows[0].QueryID)
+		assert.Equal(t, 50.0, rows[0].MeanExecTime)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Extension Missing", func(t *testing.T) {
+		repo, mock := newTestHealthRepo(t)
+		mock.ExpectQuery(query).WillReturnError(&pgconn.PgError{Code: "42P01"})
+
+		_, err := repo.TopQueries(context.Background(), 20)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusServiceUnavailable, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		repo, mock := newTestHealthRepo(t)
+		mock.ExpectQuery(query).WillReturnError(errors.New("connection reset"))
+
+		_, err := repo.TopQueries(context.Background(), 20)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type IntegrationRepositoryInterface interface {
+	AckMessage(ctx context.Context, row dao.ConsumerAckRow) (dao.ConsumerAckRow, bool, error)
+}
+
+type IntegrationRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewIntegrationRepository(db *sql.DB, logger logger.Logger) *IntegrationRepository {
+	return &IntegrationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// AckMessage stores the ack unless the consumer acked the message before, and
+// returns the stored ack with whether this call created it. The statement's
+// snapshot cannot see an ack committed by a concurrent first call, which
+// leaves both branches empty; it is then run again and finds that ack.
+func (r *IntegrationRepository) AckMessage(ctx context.Context, row dao.ConsumerAckRow) (dao.ConsumerAckRow, bool, error) {
+	query := `WITH inserted AS (
+		INSERT INTO consumer_acks (user_id, consumer, message_id, acked_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, consumer, message_id) DO NOTHING
+		RETURNING acked_at
+	)
+	SELECT acked_at, true FROM inserted
+	UNION ALL
+	SELECT acked_at, false FROM consumer_acks WHERE user_id = $1 AND consumer = $2 AND message_id = $3`
+	r.logger.DebugContext(ctx, "Executing AckMessage query",
+		zap.String("sql", query),
+		zap.String("user_id", row.UserID.String()),
+		zap.String("consumer", row.Consumer),
+		zap.String("message_id", row.MessageID),
+	)
+
+	ack := row
+	var created bool
+	err := r.db.QueryRowContext(ctx, query, row.UserID, row.Consumer, row.MessageID, row.AckedAt).Scan(&ack.AckedAt, &created)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = r.db.QueryRowContext(ctx, query, row.UserID, row.Consumer, row.MessageID, row.AckedAt).Scan(&ack.AckedAt, &created)
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			r.logger.WarnContext(ctx, "Ack rejected: user does not exist", zap.String("user_id", row.UserID.String()))
+			return dao.ConsumerAckRow{}, false, apperrors.NewBadRequest("user does not exist", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to ack message", zap.Error(err), zap.String("consumer", row.Consumer), zap.String("message_id", row.MessageID))
+		return dao.ConsumerAckRow{}, false, apperrors.NewInternalServerError("database error on ack message", err)
+	}
+	return ack, created, nil
+}
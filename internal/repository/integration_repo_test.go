@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIntegrationRepo(t *testing.T) (*IntegrationRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewIntegrationRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestAckMessage(t *testing.T) {
+	ackedAt := time.Date(2025, 7, 1, 10, 2, 31, 0, time.UTC)
+	row := dao.ConsumerAckRow{UserID: uuid.New(), Consumer: "billing-sync", MessageID: "m1", AckedAt: ackedAt}
+	query := regexp.QuoteMeta(`ON CONFLICT (user_id, consumer, message_id) DO NOTHING`)
+
+	t.Run("First Ack", func(t *testing.T) {
+		repo, mock := newTestIntegrationRepo(t)
+		mock.ExpectQuery(query).WithArgs(row.UserID, row.Consumer, row.MessageID, ackedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"acked_at", "bool"}).AddRow(ackedAt, true))
+
+		ack, created, err := repo.AckMessage(context.Background(), row)
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, row, ack)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate Keeps First Time", func(t *testing.T) {
+		repo, mock := newTestIntegrationRepo(t)
+		firstAckedAt := ackedAt.Add(-time.Hour)
+		mock.ExpectQuery(query).WithArgs(row.UserID, row.Consumer, row.MessageID, ackedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"acked_at", "bool"}).AddRow(firstAckedAt, false))
+
+		ack, created, err := repo.AckMessage(context.Background(), row)
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, firstAckedAt, ack.AckedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Concurrent First Ack Is Found On Retry", func(t *testing.T) {
+		repo, mock := newTestIntegrationRepo(t)
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"acked_at", "bool"}))
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"acked_at", "bool"}).AddRow(ackedAt, false))
+
+		_, created, err := repo.AckMessage(context.Background(), row)
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown User", func(t *testing.T) {
+		repo, mock := newTestIntegrationRepo(t)
+		mock.ExpectQuery(query).WillReturnError(&pgconn.PgError{Code: "23503"})
+
+		_, _, err := repo.AckMessage(context.Background(), row)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -8,6 +8,8 @@ import (
 	dto "subtracker/internal/domain/dto"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // SubscriptionRepositoryInterface is an autogenerated mock type for the SubscriptionRepositoryInterface type
@@ -15,6 +17,62 @@ type SubscriptionRepositoryInterface struct {
 	mock.Mock
 }
 
+// CountSubscriptions provides a mock function with given fields: ctx, subFilter
+func (_m *SubscriptionRepositoryInterface) CountSubscriptions(ctx context.Context, subFilter dto.SubscriptionFilter) (int, error) {
+	ret := _m.Called(ctx, subFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSubscriptions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) (int, error)); ok {
+		return rf(ctx, subFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) int); ok {
+		r0 = rf(ctx, subFilter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.SubscriptionFilter) error); ok {
+		r1 = rf(ctx, subFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUserSubscriptions provides a mock function with given fields: ctx, userID
+func (_m *SubscriptionRepositoryInterface) CountUserSubscriptions(ctx context.Context, userID string) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUserSubscriptions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateSubscription provides a mock function with given fields: ctx, subDao
 func (_m *SubscriptionRepositoryInterface) CreateSubscription(ctx context.Context, subDao dao.SubscriptionRow) error {
 	ret := _m.Called(ctx, subDao)
@@ -33,17 +91,35 @@ func (_m *SubscriptionRepositoryInterface) CreateSubscription(ctx context.Contex
 	return r0
 }
 
-// DeleteSubscription provides a mock function with given fields: ctx, id
-func (_m *SubscriptionRepositoryInterface) DeleteSubscription(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// CreateSubscriptions provides a mock function with given fields: ctx, rows
+func (_m *SubscriptionRepositoryInterface) CreateSubscriptions(ctx context.Context, rows []dao.SubscriptionRow) error {
+	ret := _m.Called(ctx, rows)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscriptions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []dao.SubscriptionRow) error); ok {
+		r0 = rf(ctx, rows)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id, undoTokenHash, undoExpiresAt
+func (_m *SubscriptionRepositoryInterface) DeleteSubscription(ctx context.Context, id string, undoTokenHash string, undoExpiresAt time.Time) error {
+	ret := _m.Called(ctx, id, undoTokenHash, undoExpiresAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteSubscription")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, undoTokenHash, undoExpiresAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -79,29 +155,29 @@ func (_m *SubscriptionRepositoryInterface) GetSubscription(ctx context.Context,
 	return r0, r1
 }
 
-// ListForCostCalculation provides a mock function with given fields: ctx, filter
-func (_m *SubscriptionRepositoryInterface) ListForCostCalculation(ctx context.Context, filter dto.CostFilter) ([]dao.SubscriptionRow, error) {
-	ret := _m.Called(ctx, filter)
+// GetSubscriptions provides a mock function with given fields: ctx, ids, userID
+func (_m *SubscriptionRepositoryInterface) GetSubscriptions(ctx context.Context, ids []string, userID string) ([]dao.SubscriptionRow, error) {
+	ret := _m.Called(ctx, ids, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListForCostCalculation")
+		panic("no return value specified for GetSubscriptions")
 	}
 
 	var r0 []dao.SubscriptionRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, dto.CostFilter) ([]dao.SubscriptionRow, error)); ok {
-		return rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) ([]dao.SubscriptionRow, error)); ok {
+		return rf(ctx, ids, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, dto.CostFilter) []dao.SubscriptionRow); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) []dao.SubscriptionRow); ok {
+		r0 = rf(ctx, ids, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dao.SubscriptionRow)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, dto.CostFilter) error); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, ids, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -139,6 +215,130 @@ func (_m *SubscriptionRepositoryInterface) ListSubscriptions(ctx context.Context
 	return r0, r1
 }
 
+// ListTrash provides a mock function with given fields: ctx, filter
+func (_m *SubscriptionRepositoryInterface) ListTrash(ctx context.Context, filter dto.TrashFilter) ([]dao.TrashedSubscriptionRow, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrash")
+	}
+
+	var r0 []dao.TrashedSubscriptionRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TrashFilter) ([]dao.TrashedSubscriptionRow, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TrashFilter) []dao.TrashedSubscriptionRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dao.TrashedSubscriptionRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TrashFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreSubscriptions provides a mock function with given fields: ctx, userID, ids
+func (_m *SubscriptionRepositoryInterface) RestoreSubscriptions(ctx context.Context, userID string, ids []string) ([]dao.SubscriptionRow, error) {
+	ret := _m.Called(ctx, userID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSubscriptions")
+	}
+
+	var r0 []dao.SubscriptionRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]dao.SubscriptionRow, error)); ok {
+		return rf(ctx, userID, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []dao.SubscriptionRow); ok {
+		r0 = rf(ctx, userID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dao.SubscriptionRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamSubscriptions provides a mock function with given fields: ctx, subFilter, fn
+func (_m *SubscriptionRepositoryInterface) StreamSubscriptions(ctx context.Context, subFilter dto.SubscriptionFilter, fn func(dao.SubscriptionRow) error) error {
+	ret := _m.Called(ctx, subFilter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamSubscriptions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter, func(dao.SubscriptionRow) error) error); ok {
+		r0 = rf(ctx, subFilter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndoDelete provides a mock function with given fields: ctx, undoTokenHash, userID
+func (_m *SubscriptionRepositoryInterface) UndoDelete(ctx context.Context, undoTokenHash string, userID string) (dao.SubscriptionRow, error) {
+	ret := _m.Called(ctx, undoTokenHash, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoDelete")
+	}
+
+	var r0 dao.SubscriptionRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (dao.SubscriptionRow, error)); ok {
+		return rf(ctx, undoTokenHash, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) dao.SubscriptionRow); ok {
+		r0 = rf(ctx, undoTokenHash, userID)
+	} else {
+		r0 = ret.Get(0).(dao.SubscriptionRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, undoTokenHash, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCategory provides a mock function with given fields: ctx, id, category
+func (_m *SubscriptionRepositoryInterface) UpdateCategory(ctx context.Context, id string, category string) error {
+	ret := _m.Called(ctx, id, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateSubscription provides a mock function with given fields: ctx, subDao
 func (_m *SubscriptionRepositoryInterface) UpdateSubscription(ctx context.Context, subDao dao.SubscriptionRow) error {
 	ret := _m.Called(ctx, subDao)
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"subtracker/pkg/apperrors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Page is the window and ordering requested for a list query. Sort is a
+// comma-separated list of fields, each optionally prefixed with "-" for
+// descending order. A zero Limit means no limit.
+type Page struct {
+	Limit  int
+	Offset int
+	Sort   string
+}
+
+// sortSpec maps the public sort field names of one list endpoint to columns.
+// Results are always tie-broken by key, id unless set, so offsets stay stable
+// between pages.
+type sortSpec struct {
+	columns  map[string]string
+	fallback string
+	key      string
+}
+
+var subscriptionSort = sortSpec{
+	columns: map[string]string{
+		"start_date":   "start_date",
+		"end_date":     "end_date",
+		"price":        "price",
+		"service_name": "service_name",
+	},
+	fallback: "-start_date",
+}
+
+var savedFilterSort = sortSpec{
+	columns:  map[string]string{"name": "name"},
+	fallback: "name",
+}
+
+var trashSort = sortSpec{
+	columns:  map[string]string{"deleted_at": "deleted_at"},
+	fallback: "-deleted_at",
+}
+
+var auditSort = sortSpec{
+	columns:  map[string]string{"id": "id"},
+	fallback: "-id",
+}
+
+var webhookDeliverySort = sortSpec{
+	columns:  map[string]string{"created_at": "created_at"},
+	fallback: "-created_at",
+}
+
+var changeSort = sortSpec{
+	columns:  map[string]string{"seq": "seq"},
+	fallback: "seq",
+	key:      "seq",
+}
+
+// paginate applies ordering, limit and offset to a select query.
+func paginate(qb sq.SelectBuilder, page Page, spec sortSpec) (sq.SelectBuilder, error) {
+	orderBy, err := spec.orderBy(page.Sort)
+	if err != nil {
+		return qb, err
+	}
+	qb = qb.OrderBy(orderBy...)
+	if page.Limit > 0 {
+		qb = qb.Limit(uint64(page.Limit))
+	}
+	if page.Offset > 0 {
+		qb = qb.Offset(uint64(page.Offset))
+	}
+	return qb, nil
+}
+
+func (s sortSpec) orderBy(sort string) ([]string, error) {
+	if strings.TrimSpace(sort) == "" {
+		sort = s.fallback
+	}
+
+	key := s.key
+	if key == "" {
+		key = "id"
+	}
+
+	var clauses []string
+	ordered := false
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+			field = field[1:]
+		}
+		column, ok := s.columns[field]
+		if !ok {
+			return nil, apperrors.NewBadRequest(fmt.Sprintf("unsupported sort field %q", field), nil)
+		}
+		ordered = ordered || column == key
+		clauses = append(clauses, column+" "+direction)
+	}
+	if ordered {
+		return clauses, nil
+	}
+	return append(clauses, key+" ASC"), nil
+}
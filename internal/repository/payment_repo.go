@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type PaymentRepositoryInterface interface {
+	ListPaymentFailures(ctx context.Context, subscriptionID string) ([]dao.PaymentFailureRow, error)
+	RecordPaymentFailure(ctx context.Context, row dao.PaymentFailureRow) (dao.PaymentFailureRow, error)
+	RecordPaymentRetry(ctx context.Context, subscriptionID string, period time.Time, succeeded bool, now time.Time) (dao.PaymentFailureRow, error)
+}
+
+type PaymentRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewPaymentRepository(db *sql.DB, logger logger.Logger) *PaymentRepository {
+	return &PaymentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const paymentFailureColumns = `subscription_id, user_id, period, failed_at, retries, last_retry_at, paid_at`
+
+// ListPaymentFailures returns the subscription's failed charges, paid and
+// unpaid, by month.
+func (r *PaymentRepository) ListPaymentFailures(ctx context.Context, subscriptionID string) ([]dao.PaymentFailureRow, error) {
+	query := `SELECT ` + paymentFailureColumns + ` FROM payment_failures WHERE subscription_id = $1 ORDER BY period`
+	r.logger.DebugContext(ctx, "Executing ListPaymentFailures query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list payment failures", zap.Error(err), zap.String("subscription_id", subscriptionID))
+		return nil, apperrors.NewInternalServerError("database error on list payment failures", err)
+	}
+	defer rows.Close()
+
+	var result []dao.PaymentFailureRow
+	for rows.Next() {
+		var f dao.PaymentFailureRow
+		if err := rows.Scan(&f.SubscriptionID, &f.UserID, &f.Period, &f.FailedAt, &f.Retries, &f.LastRetryAt, &f.PaidAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan payment failure row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan payment failure", err)
+		}
+		result = append(result, f)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate payment failures", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list payment failures", err)
+	}
+	return result, nil
+}
+
+// RecordPaymentFailure stores a failed charge. Recording a month that already
+// failed keeps its history and, when a retry had paid it, marks it unpaid
+// again.
+func (r *PaymentRepository) RecordPaymentFailure(ctx context.Context, row dao.PaymentFailureRow) (dao.PaymentFailureRow, error) {
+	query := `INSERT INTO payment_failures (subscription_id, user_id, period, failed_at) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (subscription_id, period) DO UPDATE SET paid_at = NULL
+	RETURNING ` + paymentFailureColumns
+	r.logger.DebugContext(ctx, "Executing RecordPaymentFailure query",
+		zap.String("sql", query),
+		zap.String("subscription_id", row.SubscriptionID.String()),
+		zap.Time("period", row.Period),
+	)
+
+	var f dao.PaymentFailureRow
+	err := r.db.QueryRowContext(ctx, query, row.SubscriptionID, row.UserID, row.Period, row.FailedAt).
+		Scan(&f.SubscriptionID, &f.UserID, &f.Period, &f.FailedAt, &f.Retries, &f.LastRetryAt, &f.PaidAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to record payment failure", zap.Error(err), zap.String("subscription_id", row.SubscriptionID.String()))
+		return dao.PaymentFailureRow{}, apperrors.NewInternalServerError("database error on record payment failure", err)
+	}
+	return f, nil
+}
+
+// RecordPaymentRetry counts a retry of the month's unpaid charge and, when it
+// succeeded, marks the charge paid.
+func (r *PaymentRepository) RecordPaymentRetry(ctx context.Context, subscriptionID string, period time.Time, succeeded bool, now time.Time) (dao.PaymentFailureRow, error) {
+	query := `UPDATE payment_failures SET retries = retries + 1, last_retry_at = $3, paid_at = CASE WHEN $4::boolean THEN $3 END
+	WHERE subscription_id = $1 AND period = $2 AND paid_at IS NULL
+	RETURNING ` + paymentFailureColumns
+	r.logger.DebugContext(ctx, "Executing RecordPaymentRetry query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID),
+		zap.Time("period", period),
+		zap.Bool("succeeded", succeeded),
+	)
+
+	var f dao.PaymentFailureRow
+	err := r.db.QueryRowContext(ctx, query, subscriptionID, period, now, succeeded).
+		Scan(&f.SubscriptionID, &f.UserID, &f.Period, &f.FailedAt, &f.Retries, &f.LastRetryAt, &f.PaidAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dao.PaymentFailureRow{}, apperrors.NewNotFound("no unpaid charge for this month", err)
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to record payment retry", zap.Error(err), zap.String("subscription_id", subscriptionID))
+		return dao.PaymentFailureRow{}, apperrors.NewInternalServerError("database error on record payment retry", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPaymentRepo(t *testing.T) (*PaymentRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewPaymentRepository(db, logger.NewNopLogger()), mock
+}
+
+var paymentFailureRowColumns = []string{"subscription_id", "user_id", "period", "failed_at", "retries", "last_retry_at", "paid_at"}
+
+func TestRecordPaymentFailure(t *testing.T) {
+	repo, mock := newTestPaymentRepo(t)
+	failedAt := time.Date(2026, 1, 3, 8, 0, 0, 0, time.UTC)
+	row := dao.PaymentFailureRow{SubscriptionID: uuid.New(), UserID: uuid.New(), Period: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), FailedAt: failedAt}
+	firstFailedAt := time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO payment_failures (subscription_id, user_id, period, failed_at) VALUES ($1, $2, $3, $4)`)).
+		WithArgs(row.SubscriptionID, row.UserID, row.Period, row.FailedAt).
+		WillReturnRows(sqlmock.NewRows(paymentFailureRowColumns).AddRow(row.SubscriptionID, row.UserID, row.Period, firstFailedAt, 2, failedAt, nil))
+
+	saved, err := repo.RecordPaymentFailure(context.Background(), row)
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstFailedAt, saved.FailedAt, "failing a month again keeps its history")
+	assert.Equal(t, 2, saved.Retries)
+	assert.Nil(t, saved.PaidAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordPaymentRetry(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE payment_failures SET retries = retries + 1, last_retry_at = $3, paid_at = CASE WHEN $4::boolean THEN $3 END`)
+	subID, userID := uuid.New(), uuid.New()
+	period := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 1, 6, 8, 0, 0, 0, time.UTC)
+
+	t.Run("Succeeded", func(t *testing.T) {
+		repo, mock := newTestPaymentRepo(t)
+		mock.ExpectQuery(query).WithArgs(subID.String(), period, now, true).
+			WillReturnRows(sqlmock.NewRows(paymentFailureRowColumns).AddRow(subID, userID, period, now.AddDate(0, 0, -3), 1, now, now))
+
+		row, err := repo.RecordPaymentRetry(context.Background(), subID.String(), period, true, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, row.Retries)
+		assert.Equal(t, &now, row.PaidAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Unpaid Charge", func(t *testing.T) {
+		repo, mock := newTestPaymentRepo(t)
+		mock.ExpectQuery(query).WithArgs(subID.String(), period, now, false).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.RecordPaymentRetry(context.Background(), subID.String(), period, false, now)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListPaymentFailures(t *testing.T) {
+	repo, mock := newTestPaymentRepo(t)
+	subID := uuid.New()
+	mock.ExpectQuery(`FROM payment_failures WHERE subscription_id = \$1 ORDER BY period`).WithArgs(subID.String()).
+		WillReturnRows(sqlmock.NewRows(paymentFailureRowColumns).
+			AddRow(subID, uuid.New(), time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), time.Now(), 1, time.Now(), time.Now()).
+			AddRow(subID, uuid.New(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Now(), 0, nil, nil))
+
+	rows, err := repo.ListPaymentFailures(context.Background(), subID.String())
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Nil(t, rows[1].PaidAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type PriceChangeRepositoryInterface interface {
+	SchedulePriceChange(ctx context.Context, row dao.PriceChangeRow) (dao.PriceChangeRow, error)
+	ListPriceChanges(ctx context.Context, subscriptionID string) ([]dao.PriceChangeRow, error)
+	ApplyDuePriceChanges(ctx context.Context, now time.Time) ([]dao.AppliedPriceChangeRow, error)
+}
+
+type PriceChangeRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewPriceChangeRepository(db *sql.DB, logger logger.Logger) *PriceChangeRepository {
+	return &PriceChangeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SchedulePriceChange stores a pending price change. Scheduling a month that
+// already has one replaces its price, so the returned row keeps that change's
+// ID and creation time.
+func (r *PriceChangeRepository) SchedulePriceChange(ctx context.Context, row dao.PriceChangeRow) (dao.PriceChangeRow, error) {
+	query := `INSERT INTO price_changes (id, subscription_id, user_id, price, effective_date) VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (subscription_id, effective_date) DO UPDATE SET price = EXCLUDED.price
+	RETURNING id, created_at`
+	r.logger.DebugContext(ctx, "Executing SchedulePriceChange query",
+		zap.String("sql", query),
+		zap.String("subscription_id", row.SubscriptionID.String()),
+	)
+
+	err := r.db.QueryRowContext(ctx, query, row.ID, row.SubscriptionID, row.UserID, row.Price, row.EffectiveDate).Scan(&row.ID, &row.CreatedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to schedule price change", zap.Error(err), zap.String("subscription_id", row.SubscriptionID.String()))
+		return dao.PriceChangeRow{}, apperrors.NewInternalServerError("database error on schedule price change", err)
+	}
+	return row, nil
+}
+
+// ListPriceChanges returns the subscription's price changes, applied and
+// pending, by effective date.
+func (r *PriceChangeRepository) ListPriceChanges(ctx context.Context, subscriptionID string) ([]dao.PriceChangeRow, error) {
+	query := `SELECT id, subscription_id, user_id, price, effective_date, created_at, applied_at FROM price_changes
+	WHERE subscription_id = $1 ORDER BY effective_date`
+	r.logger.DebugContext(ctx, "Executing ListPriceChanges query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list price changes", zap.Error(err), zap.String("subscription_id", subscriptionID))
+		return nil, apperrors.NewInternalServerError("database error on list price changes", err)
+	}
+	defer rows.Close()
+
+	var result []dao.PriceChangeRow
+	for rows.Next() {
+		var c dao.PriceChangeRow
+		if err := rows.Scan(&c.ID, &c.SubscriptionID, &c.UserID, &c.Price, &c.EffectiveDate, &c.CreatedAt, &c.AppliedAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan price change row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan price change", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate price changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list price changes", err)
+	}
+	return result, nil
+}
+
+// ApplyDuePriceChanges writes every pending change effective by now to its
+// subscription and marks it applied, in one transaction. When several changes
+// of a subscription are due, the latest one wins. Claiming the changes first
+// makes a concurrent run by another instance wait and then find nothing due.
+func (r *PriceChangeRepository) ApplyDuePriceChanges(ctx context.Context, now time.Time) ([]dao.AppliedPriceChangeRow, error) {
+	claimQuery := `UPDATE price_changes SET applied_at = $1 WHERE applied_at IS NULL AND effective_date <= $1
+	RETURNING subscription_id, user_id, price, effective_date`
+	applyQuery := `UPDATE subscriptions s SET price = $1
+	FROM (SELECT price FROM subscriptions WHERE id = $2 AND user_id = $3 FOR UPDATE) previous
+	WHERE s.id = $2 AND s.user_id = $3
+	RETURNING s.id, s.user_id, s.service_name, s.price, s.start_date, s.end_date, s.cost_center, s.category, s.billing_period, s.expense_type, previous.price`
+	r.logger.DebugContext(ctx, "Executing ApplyDuePriceChanges query",
+		zap.String("sql", claimQuery),
+		zap.Time("now", now),
+	)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to begin price change transaction", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on apply price changes", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, claimQuery, now)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to claim due price changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on apply price changes", err)
+	}
+	latest := make(map[uuid.UUID]dao.PriceChangeRow)
+	var order []uuid.UUID
+	for rows.Next() {
+		var c dao.PriceChangeRow
+		if err := rows.Scan(&c.SubscriptionID, &c.UserID, &c.Price, &c.EffectiveDate); err != nil {
+			rows.Close()
+			r.logger.ErrorContext(ctx, "Failed to scan due price change", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan price change", err)
+		}
+		current, seen := latest[c.SubscriptionID]
+		if !seen {
+			order = append(order, c.SubscriptionID)
+		}
+		if !seen || c.EffectiveDate.After(current.EffectiveDate) {
+			latest[c.SubscriptionID] = c
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate due price changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on apply price changes", err)
+	}
+
+	var applied []dao.AppliedPriceChangeRow
+	for _, id := range order {
+		c := latest[id]
+		var a dao.AppliedPriceChangeRow
+		err := tx.QueryRowContext(ctx, applyQuery, c.Price, c.SubscriptionID, c.UserID).
+			Scan(&a.ID, &a.UserID, &a.ServiceName, &a.Price, &a.StartDate, &a.EndDate, &a.CostCenter, &a.Category, &a.BillingPeriod, &a.ExpenseType, &a.PreviousPrice)
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.DebugContext(ctx, "Skipping price change of deleted subscription", zap.String("subscription_id", id.String()))
+			continue
+		}
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to apply price change", zap.Error(err), zap.String("subscription_id", id.String()))
+			return nil, apperrors.NewInternalServerError("database error on apply price changes", err)
+		}
+		applied = append(applied, a)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to commit price changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on apply price changes", err)
+	}
+	return applied, nil
+}
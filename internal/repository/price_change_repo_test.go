@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPriceChangeRepo(t *testing.T) (*PriceChangeRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewPriceChangeRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestSchedulePriceChange(t *testing.T) {
+	repo, mock := newTestPriceChangeRepo(t)
+	row := dao.PriceChangeRow{ID: uuid.New(), SubscriptionID: uuid.New(), UserID: uuid.New(), Price: 399, EffectiveDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	existingID := uuid.New()
+	createdAt := time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO price_changes (id, subscription_id, user_id, price, effective_date) VALUES ($1, $2, $3, $4, $5)`)).
+		WithArgs(row.ID, row.SubscriptionID, row.UserID, row.Price, row.EffectiveDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(existingID, createdAt))
+
+	saved, err := repo.SchedulePriceChange(context.Background(), row)
+
+	assert.NoError(t, err)
+	assert.Equal(t, existingID, saved.ID, "rescheduling a month keeps its change")
+	assert.Equal(t, createdAt, saved.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyDuePriceChanges(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 5, 0, 0, time.UTC)
+	claimQuery := regexp.QuoteMeta(`UPDATE price_changes SET applied_at = $1 WHERE applied_at IS NULL AND effective_date <= $1`)
+	applyQuery := regexp.QuoteMeta(`UPDATE subscriptions s SET price = $1`)
+	subColumns := []string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type", "previous_price"}
+
+	t.Run("Latest Due Change Wins", func(t *testing.T) {
+		repo, mock := newTestPriceChangeRepo(t)
+		subID, gone, userID := uuid.New(), uuid.New(), uuid.New()
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimQuery).WithArgs(now).
+			WillReturnRows(sqlmock.NewRows([]string{"subscription_id", "user_id", "price", "effective_date"}).
+				AddRow(subID, userID, 399, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).
+				AddRow(subID, userID, 449, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)).
+				AddRow(gone, userID, 100, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+		mock.ExpectQuery(applyQuery).WithArgs(449, subID, userID).
+			WillReturnRows(sqlmock.NewRows(subColumns).AddRow(subID, userID, "Netflix", 449, start, nil, "", "", "monthly", "subscription", 299))
+		mock.ExpectQuery(applyQuery).WithArgs(100, gone, userID).
+			WillReturnRows(sqlmock.NewRows(subColumns))
+		mock.ExpectCommit()
+
+		applied, err := repo.ApplyDuePriceChanges(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1, "the change of a deleted subscription is skipped")
+		assert.Equal(t, 449, applied[0].Price)
+		assert.Equal(t, 299, applied[0].PreviousPrice)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls Back On Failure", func(t *testing.T) {
+		repo, mock := newTestPriceChangeRepo(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimQuery).WithArgs(now).
+			WillReturnRows(sqlmock.NewRows([]string{"subscription_id", "user_id", "price", "effective_date"}).
+				AddRow(uuid.New(), uuid.New(), 399, now))
+		mock.ExpectQuery(applyQuery).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		_, err := repo.ApplyDuePriceChanges(context.Background(), now)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// legacyEmailPattern matches the placeholder emails given to accounts created
+// for pre-existing user IDs; nobody reads those mailboxes.
+const legacyEmailPattern = "%@legacy.invalid"
+
+type ReminderRepositoryInterface interface {
+	GetReminderSetting(ctx context.Context, subscriptionID string) (dao.ReminderSettingRow, error)
+	SetReminderSetting(ctx context.Context, row dao.ReminderSettingRow) error
+	ListReminderCandidates(ctx context.Context, defaultDaysBefore int) ([]dao.ReminderCandidateRow, error)
+	ClaimReminder(ctx context.Context, subscriptionID uuid.UUID, renewal time.Time) (bool, error)
+	ReleaseReminder(ctx context.Context, subscriptionID uuid.UUID, renewal time.Time) error
+}
+
+type ReminderRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewReminderRepository(db *sql.DB, logger logger.Logger) *ReminderRepository {
+	return &ReminderRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ReminderRepository) GetReminderSetting(ctx context.Context, subscriptionID string) (dao.ReminderSettingRow, error) {
+	query := `SELECT subscription_id, enabled, days_before FROM subscription_reminders WHERE subscription_id = $1`
+	r.logger.DebugContext(ctx, "Executing GetReminderSetting query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID),
+	)
+
+	var row dao.ReminderSettingRow
+	if err := r.db.QueryRowContext(ctx, query, subscriptionID).Scan(&row.SubscriptionID, &row.Enabled, &row.DaysBefore); err != nil {
+		if err == sql.ErrNoRows {
+			return dao.ReminderSettingRow{}, apperrors.NewNotFound("reminder setting not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to get reminder setting", zap.Error(err), zap.String("subscription_id", subscriptionID))
+		return dao.ReminderSettingRow{}, apperrors.NewInternalServerError("database error on get reminder setting", err)
+	}
+	return row, nil
+}
+
+func (r *ReminderRepository) SetReminderSetting(ctx context.Context, row dao.ReminderSettingRow) error {
+	query := `INSERT INTO subscription_reminders (subscription_id, enabled, days_before) VALUES ($1, $2, $3)
+	ON CONFLICT (subscription_id) DO UPDATE SET enabled = EXCLUDED.enabled, days_before = EXCLUDED.days_before`
+	r.logger.DebugContext(ctx, "Executing SetReminderSetting query",
+		zap.String("sql", query),
+		zap.String("subscription_id", row.SubscriptionID.String()),
+	)
+
+	if _, err := r.db.ExecContext(ctx, query, row.SubscriptionID, row.Enabled, row.DaysBefore); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to save reminder setting", zap.Error(err), zap.String("subscription_id", row.SubscriptionID.String()))
+		return apperrors.NewInternalServerError("database error on set reminder setting", err)
+	}
+	return nil
+}
+
+// ListReminderCandidates returns every subscription that has not ended before
+// the current month and has reminders enabled, with the offset to remind at and
+// where to reach its owner: their email, empty for placeholder accounts, and
+// their linked Telegram chat. Owners reachable neither way are left out.
+func (r *ReminderRepository) ListReminderCandidates(ctx context.Context, defaultDaysBefore int) ([]dao.ReminderCandidateRow, error) {
+	query := `SELECT s.id, s.user_id, s.service_name, s.price, s.start_date, s.end_date, s.cost_center, s.category, s.billing_period, s.expense_type,
+		CASE WHEN u.email LIKE $2 THEN '' ELSE u.email END, tl.chat_id, COALESCE(sr.days_before, $1)
+	FROM subscriptions s
+	JOIN users u ON u.id = s.user_id
+	LEFT JOIN subscription_reminders sr ON sr.subscription_id = s.id
+	LEFT JOIN telegram_links tl ON tl.user_id = s.user_id
+	WHERE (s.end_date IS NULL OR s.end_date >= date_trunc('month', CURRENT_DATE))
+		AND COALESCE(sr.enabled, TRUE)
+		AND (u.email NOT LIKE $2 OR tl.chat_id IS NOT NULL)`
+	r.logger.DebugContext(ctx, "Executing ListReminderCandidates query", zap.String("sql", query))
+
+	rows, err := r.db.QueryContext(ctx, query, defaultDaysBefore, legacyEmailPattern)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list reminder candidates", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list reminder candidates", err)
+	}
+	defer rows.Close()
+
+	var result []dao.ReminderCandidateRow
+	for rows.Next() {
+		var c dao.ReminderCandidateRow
+		if err := rows.Scan(&c.ID, &c.UserID, &c.ServiceName, &c.Price, &c.StartDate, &c.EndDate, &c.CostCenter, &c.Category, &c.BillingPeriod, &c.ExpenseType, &c.Email, &c.TelegramChatID, &c.DaysBefore); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan reminder candidate row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan reminder candidate", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate reminder candidates", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list reminder candidates", err)
+	}
+	return result, nil
+}
+
+// ClaimReminder records that the reminder for the given renewal is being sent.
+// It reports false when it was already claimed, by this or another instance.
+func (r *ReminderRepository) ClaimReminder(ctx context.Context, subscriptionID uuid.UUID, renewal time.Time) (bool, error) {
+	query := `INSERT INTO sent_reminders (subscription_id, renewal_date) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	r.logger.DebugContext(ctx, "Executing ClaimReminder query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID.String()),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, subscriptionID, renewal)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to claim reminder", zap.Error(err), zap.String("subscription_id", subscriptionID.String()))
+		return false, apperrors.NewInternalServerError("database error on claim reminder", err)
+	}
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternalServerError("database error on claim reminder result", err)
+	}
+	return claimed == 1, nil
+}
+
+// ReleaseReminder drops a claim whose email could not be sent, so a later run
+// retries it.
+func (r *ReminderRepository) ReleaseReminder(ctx context.Context, subscriptionID uuid.UUID, renewal time.Time) error {
+	query := `DELETE FROM sent_reminders WHERE subscription_id = $1 AND renewal_date = $2`
+	r.logger.DebugContext(ctx, "Executing ReleaseReminder query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID.String()),
+	)
+
+	if _, err := r.db.ExecContext(ctx, query, subscriptionID, renewal); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to release reminder", zap.Error(err), zap.String("subscription_id", subscriptionID.String()))
+		return apperrors.NewInternalServerError("database error on release reminder", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReminderRepo(t *testing.T) (*ReminderRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewReminderRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestGetReminderSetting(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT subscription_id, enabled, days_before FROM subscription_reminders WHERE subscription_id = $1`)
+
+	t.Run("Found", func(t *testing.T) {
+		repo, mock := newTestReminderRepo(t)
+		id := uuid.New()
+		mock.ExpectQuery(query).WithArgs(id.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"subscription_id", "enabled", "days_before"}).AddRow(id, false, 7))
+
+		row, err := repo.GetReminderSetting(context.Background(), id.String())
+
+		assert.NoError(t, err)
+		assert.False(t, row.Enabled)
+		assert.Equal(t, 7, *row.DaysBefore)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Set", func(t *testing.T) {
+		repo, mock := newTestReminderRepo(t)
+		id := uuid.New().String()
+		mock.ExpectQuery(query).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetReminderSetting(context.Background(), id)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSetReminderSetting(t *testing.T) {
+	repo, mock := newTestReminderRepo(t)
+	row := dao.ReminderSettingRow{SubscriptionID: uuid.New(), Enabled: true}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO subscription_reminders (subscription_id, enabled, days_before) VALUES ($1, $2, $3)`)).
+		WithArgs(row.SubscriptionID, true, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.SetReminderSetting(context.Background(), row))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListReminderCandidates(t *testing.T) {
+	repo, mock := newTestReminderRepo(t)
+	subID, userID := uuid.New(), uuid.New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`COALESCE(sr.days_before, $1)`)).WithArgs(3, legacyEmailPattern).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type", "email", "chat_id", "days_before"}).
+			AddRow(subID, userID, "Netflix", 999, start, nil, "", "", "monthly", "subscription", "user@example.com", nil, 3).
+			AddRow(uuid.New(), uuid.New(), "Spotify", 199, start, nil, "", "", "monthly", "subscription", "", int64(42), 3))
+
+	rows, err := repo.ListReminderCandidates(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "user@example.com", rows[0].Email)
+	assert.Nil(t, rows[0].TelegramChatID)
+	assert.Equal(t, subID, rows[0].ID)
+	assert.Empty(t, rows[1].Email, "placeholder accounts are reminded through Telegram only")
+	assert.Equal(t, int64(42), *rows[1].TelegramChatID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimReminder(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO sent_reminders (subscription_id, renewal_date) VALUES ($1, $2) ON CONFLICT DO NOTHING`)
+	subID := uuid.New()
+	renewal := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Claimed", func(t *testing.T) {
+		repo, mock := newTestReminderRepo(t)
+		mock.ExpectExec(query).WithArgs(subID, renewal).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		claimed, err := repo.ClaimReminder(context.Background(), subID, renewal)
+
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Sent", func(t *testing.T) {
+		repo, mock := newTestReminderRepo(t)
+		mock.ExpectExec(query).WithArgs(subID, renewal).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		claimed, err := repo.ClaimReminder(context.Background(), subID, renewal)
+
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.uber.org/zap"
+)
+
+// ReportingRepositoryInterface holds the read-only aggregate queries. It runs on
+// its own pool so heavy reports cannot starve the CRUD endpoints of connections.
+type ReportingRepositoryInterface interface {
+	ListForCostCalculation(ctx context.Context, filter dto.CostFilter) ([]dao.SubscriptionRow, error)
+	SubscriptionStats(ctx context.Context, at time.Time) (active int, monthlySpend int, err error)
+	IntegrityViolations(ctx context.Context) (dao.IntegrityCounts, error)
+	ListCancelled(ctx context.Context, userID string, from, to time.Time) ([]dao.SubscriptionRow, error)
+	ListPendingPriceChanges(ctx context.Context, userID string, until time.Time) ([]dao.PriceChangeRow, error)
+	ListUnpaidPeriods(ctx context.Context, userID string, from, to time.Time) ([]dao.PaymentFailureRow, error)
+	ServiceBenchmark(ctx context.Context, serviceName string, at time.Time) (dao.ServiceBenchmarkRow, error)
+}
+
+// monthlyPriceSQL normalizes a subscription's price to one month, matching
+// domain.BillingPeriod.
+const monthlyPriceSQL = `CASE billing_period WHEN 'yearly' THEN price / 12.0 WHEN 'weekly' THEN price * 52 / 12.0 ELSE price END`
+
+type ReportingRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewReportingRepository(db *sql.DB, logger logger.Logger) *ReportingRepository {
+	return &ReportingRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ReportingRepository) ListForCostCalculation(ctx context.Context, filter dto.CostFilter) ([]dao.SubscriptionRow, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	queryBuilder := psql.Select("id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type").
+		From("subscriptions")
+
+	queryBuilder = queryBuilder.Where(sq.Eq{"user_id": filter.UserID})
+	if filter.ServiceName != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"service_name": filter.ServiceName})
+	}
+	queryBuilder = queryBuilder.Where(sq.LtOrEq{"start_date": filter.PeriodEnd}).
+		Where(sq.Or{
+			sq.Eq{"end_date": nil},
+			sq.GtOrEq{"end_date": filter.PeriodStart},
+		})
+
+	sql, args, err := queryBuilder.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to build SQL for ListForCostCalculation", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("failed to build cost query", err)
+	}
+
+	r.logger.DebugContext(ctx, "Executing ListForCostCalculation query", zap.String("sql", sql), zap.Any("args", args))
+
+	rows, err := r.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to execute cost calculation query", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on cost calculation", err)
+	}
+	defer rows.Close()
+
+	var result []dao.SubscriptionRow
+	for rows.Next() {
+		var sub dao.SubscriptionRow
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan subscription row for cost", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan for cost", err)
+		}
+		result = append(result, sub)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate subscriptions for cost", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on cost calculation", err)
+	}
+	return result, nil
+}
+
+// SubscriptionStats counts the subscriptions active at the given time and
+// their combined price normalized to one month.
+func (r *ReportingRepository) SubscriptionStats(ctx context.Context, at time.Time) (int, int, error) {
+	query := `SELECT COUNT(*), COALESCE(ROUND(SUM(` + monthlyPriceSQL + `)), 0)::int FROM subscriptions WHERE start_date <= $1 AND (end_date IS NULL OR end_date >= $1)`
+	r.logger.DebugContext(ctx, "Executing SubscriptionStats query", zap.String("sql", query))
+
+	var active, spend int
+	if err := r.db.QueryRowContext(ctx, query, at).Scan(&active, &spend); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to query subscription stats", zap.Error(err))
+		return 0, 0, apperrors.NewInternalServerError("database error on stats", err)
+	}
+	return active, spend, nil
+}
+
+// IntegrityViolations counts rows that break invariants the schema is supposed
+// to guarantee, catching constraints dropped or bypassed by manual edits.
+func (r *ReportingRepository) IntegrityViolations(ctx context.Context) (dao.IntegrityCounts, error) {
+	query := `SELECT COUNT(*) FILTER (WHERE price < 0), COUNT(*) FILTER (WHERE end_date < start_date) FROM subscriptions`
+	r.logger.DebugContext(ctx, "Executing IntegrityViolations query", zap.String("sql", query))
+
+	var counts dao.IntegrityCounts
+	if err := r.db.QueryRowContext(ctx, query).Scan(&counts.NegativePrice, &counts.EndBeforeStart); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to query integrity violations", zap.Error(err))
+		return dao.IntegrityCounts{}, apperrors.NewInternalServerError("database error on integrity check", err)
+	}
+	return counts, nil
+}
+
+// ListCancelled returns a user's subscriptions whose end date falls within
+// [from, to], oldest cancellation first.
+func (r *ReportingRepository) ListCancelled(ctx context.Context, userID string, from, to time.Time) ([]dao.SubscriptionRow, error) {
+	query := `SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions
+	WHERE user_id = $1 AND end_date >= $2 AND end_date <= $3
+	ORDER BY end_date, id`
+	r.logger.DebugContext(ctx, "Executing ListCancelled query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+		zap.Time("from", from),
+		zap.Time("to", to),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list cancelled subscriptions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on savings report", err)
+	}
+	defer rows.Close()
+
+	var result []dao.SubscriptionRow
+	for rows.Next() {
+		var sub dao.SubscriptionRow
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan cancelled subscription row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan for savings", err)
+		}
+		result = append(result, sub)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate cancelled subscriptions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on savings report", err)
+	}
+	return result, nil
+}
+
+// ListPendingPriceChanges returns the user's price changes that are not yet
+// applied and take effect by until, by subscription and effective date.
+func (r *ReportingRepository) ListPendingPriceChanges(ctx context.Context, userID string, until time.Time) ([]dao.PriceChangeRow, error) {
+	query := `SELECT id, subscription_id, user_id, price, effective_date, created_at FROM price_changes
+	WHERE user_id = $1 AND applied_at IS NULL AND effective_date <= $2
+	ORDER BY subscription_id, effective_date`
+	r.logger.DebugContext(ctx, "Executing ListPendingPriceChanges query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+		zap.Time("until", until),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, userID, until)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list pending price changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on cost calculation", err)
+	}
+	defer rows.Close()
+
+	var result []dao.PriceChangeRow
+	for rows.Next() {
+		var c dao.PriceChangeRow
+		if err := rows.Scan(&c.ID, &c.SubscriptionID, &c.UserID, &c.Price, &c.EffectiveDate, &c.CreatedAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan pending price change row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan for cost", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate pending price changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on cost calculation", err)
+	}
+	return result, nil
+}
+
+// ListUnpaidPeriods returns the user's failed charges for months within
+// [from, to] that no retry has paid, by subscription and month.
+func (r *ReportingRepository) ListUnpaidPeriods(ctx context.Context, userID string, from, to time.Time) ([]dao.PaymentFailureRow, error) {
+	query := `SELECT subscription_id, user_id, period, failed_at, retries, last_retry_at, paid_at FROM payment_failures
+	WHERE user_id = $1 AND paid_at IS NULL AND period >= $2 AND period <= $3
+	ORDER BY subscription_id, period`
+	r.logger.DebugContext(ctx, "Executing ListUnpaidPeriods query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+		zap.Time("from", from),
+		zap.Time("to", to),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list unpaid periods", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on cost calculation", err)
+	}
+	defer rows.Close()
+
+	var result []dao.PaymentFailureRow
+	for rows.Next() {
+		var f dao.PaymentFailureRow
+		if err := rows.Scan(&f.SubscriptionID, &f.UserID, &f.Period, &f.FailedAt, &f.Retries, &f.LastRetryAt, &f.PaidAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan unpaid period row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan for cost", err)
+		}
+		result = append(result, f)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate unpaid periods", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on cost calculation", err)
+	}
+	return result, nil
+}
+
+// ServiceBenchmark averages the monthly price of every subscription to a
+// service active at the given time, matching the name case-insensitively, and
+// counts the distinct users behind the average.
+func (r *ReportingRepository) ServiceBenchmark(ctx context.Context, serviceName string, at time.Time) (dao.ServiceBenchmarkRow, error) {
+	query := `SELECT COUNT(DISTINCT user_id), COALESCE(ROUND(AVG(` + monthlyPriceSQL + `)), 0)::int FROM subscriptions
+	WHERE lower(service_name) = lower($1) AND start_date <= $2 AND (end_date IS NULL OR end_date >= $2)`
+	r.logger.DebugContext(ctx, "Executing ServiceBenchmark query",
+		zap.String("sql", query),
+		zap.String("service_name", serviceName),
+	)
+
+	var row dao.ServiceBenchmarkRow
+	if err := r.db.QueryRowContext(ctx, query, serviceName, at).Scan(&row.Users, &row.AveragePrice); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to query service benchmark", zap.Error(err))
+		return dao.ServiceBenchmarkRow{}, apperrors.NewInternalServerError("database error on benchmark", err)
+	}
+	return row, nil
+}
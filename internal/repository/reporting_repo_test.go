@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dto"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReportingRepo(t *testing.T) (*ReportingRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	repo := NewReportingRepository(db, logger.NewNopLogger())
+	return repo, mock
+}
+
+func TestListForCostCalculation(t *testing.T) {
+	t.Run("Success with Full Filter", func(t *testing.T) {
+		repo, mock := newTestReportingRepo(t)
+		userID := uuid.New()
+		filter := dto.CostFilter{
+			UserID:      userID.String(),
+			ServiceName: "Netflix",
+			PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		}
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(uuid.New(), userID, "Netflix", 100, time.Now(), nil, "", "", "monthly", "subscription")
+
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions WHERE user_id = $1 AND service_name = $2 AND start_date <= $3 AND (end_date IS NULL OR end_date >= $4)")
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(filter.UserID, filter.ServiceName, filter.PeriodEnd, filter.PeriodStart).
+			WillReturnRows(rows)
+
+		result, err := repo.ListForCostCalculation(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success with UserID only", func(t *testing.T) {
+		repo, mock := newTestReportingRepo(t)
+		userID := uuid.New()
+		filter := dto.CostFilter{
+			UserID:      userID.String(),
+			PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		}
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(uuid.New(), userID, "Netflix", 100, time.Now(), nil, "", "", "monthly", "subscription").
+			AddRow(uuid.New(), userID, "Spotify", 200, time.Now(), nil, "", "", "monthly", "subscription")
+
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions WHERE user_id = $1 AND start_date <= $2 AND (end_date IS NULL OR end_date >= $3)")
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(filter.UserID, filter.PeriodEnd, filter.PeriodStart).
+			WillReturnRows(rows)
+
+		result, err := repo.ListForCostCalculation(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Interrupted Iteration Is An Error", func(t *testing.T) {
+		repo, mock := newTestReportingRepo(t)
+		userID := uuid.New()
+		filter := dto.CostFilter{
+			UserID:      userID.String(),
+			PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		}
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(uuid.New(), userID, "Netflix", 100, time.Now(), nil, "", "", "monthly", "subscription").
+			AddRow(uuid.New(), userID, "Spotify", 200, time.Now(), nil, "", "", "monthly", "subscription").
+			RowError(1, context.Canceled)
+
+		mock.ExpectQuery(".*").WillReturnRows(rows)
+
+		result, err := repo.ListForCostCalculation(context.Background(), filter)
+
+		assert.Nil(t, result, "a partial set of rows must not be returned")
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DB Error on Query", func(t *testing.T) {
+		repo, mock := newTestReportingRepo(t)
+		dbErr := errors.New("something went wrong")
+		filter := dto.CostFilter{
+			UserID:      uuid.New().String(),
+			PeriodStart: time.Now(),
+			PeriodEnd:   time.Now(),
+		}
+
+		mock.ExpectQuery(".*").WillReturnError(dbErr)
+
+		_, err := repo.ListForCostCalculation(context.Background(), filter)
+
+		assert.Error(t, err)
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSubscriptionStats(t *testing.T) {
+	repo, mock := newTestReportingRepo(t)
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`SELECT COUNT(*), COALESCE(ROUND(SUM(CASE billing_period WHEN 'yearly' THEN price / 12.0 WHEN 'weekly' THEN price * 52 / 12.0 ELSE price END)), 0)::int FROM subscriptions WHERE start_date <= $1 AND (end_date IS NULL OR end_date >= $1)`)
+	mock.ExpectQuery(query).WithArgs(at).WillReturnRows(sqlmock.NewRows([]string{"count", "sum"}).AddRow(12, 4350))
+
+	active, spend, err := repo.SubscriptionStats(context.Background(), at)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, active)
+	assert.Equal(t, 4350, spend)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIntegrityViolations(t *testing.T) {
+	repo, mock := newTestReportingRepo(t)
+	query := regexp.QuoteMeta(`SELECT COUNT(*) FILTER (WHERE price < 0), COUNT(*) FILTER (WHERE end_date < start_date) FROM subscriptions`)
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"negative", "inverted"}).AddRow(2, 1))
+
+	counts, err := repo.IntegrityViolations(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, counts.NegativePrice)
+	assert.Equal(t, 1, counts.EndBeforeStart)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListCancelled(t *testing.T) {
+	repo, mock := newTestReportingRepo(t)
+	userID := uuid.New().String()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	ended := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE user_id = \$1 AND end_date >= \$2 AND end_date <= \$3`).WithArgs(userID, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(uuid.New(), userID, "Netflix", 999, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ended, "", "", "monthly", "subscription"))
+
+	rows, err := repo.ListCancelled(context.Background(), userID, from, to)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, ended, *rows[0].EndDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListPendingPriceChanges(t *testing.T) {
+	repo, mock := newTestReportingRepo(t)
+	userID := uuid.New().String()
+	until := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	effective := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE user_id = \$1 AND applied_at IS NULL AND effective_date <= \$2`).WithArgs(userID, until).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "user_id", "price", "effective_date", "created_at"}).
+			AddRow(uuid.New(), uuid.New(), userID, 399, effective, time.Now()))
+
+	rows, err := repo.ListPendingPriceChanges(context.Background(), userID, until)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, effective, rows[0].EffectiveDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUnpaidPeriods(t *testing.T) {
+	repo, mock := newTestReportingRepo(t)
+	userID := uuid.New().String()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	period := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE user_id = \$1 AND paid_at IS NULL AND period >= \$2 AND period <= \$3`).WithArgs(userID, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"subscription_id", "user_id", "period", "failed_at", "retries", "last_retry_at", "paid_at"}).
+			AddRow(uuid.New(), userID, period, time.Now(), 2, time.Now(), nil))
+
+	rows, err := repo.ListUnpaidPeriods(context.Background(), userID, from, to)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, period, rows[0].Period)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestServiceBenchmark(t *testing.T) {
+	repo, mock := newTestReportingRepo(t)
+	at := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE lower\(service_name\) = lower\(\$1\)`).WithArgs("Netflix", at).
+		WillReturnRows(sqlmock.NewRows([]string{"users", "average_price"}).AddRow(12, 520))
+
+	row, err := repo.ServiceBenchmark(context.Background(), "Netflix", at)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, row.Users)
+	assert.Equal(t, 520, row.AveragePrice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
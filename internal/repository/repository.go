@@ -8,10 +8,42 @@ import (
 
 type Repository struct {
 	SubscriptionRepository *SubscriptionRepository
+	SavedFilterRepository  *SavedFilterRepository
+	HealthRepository       *HealthRepository
+	ReportingRepository    *ReportingRepository
+	SyncRepository         *SyncRepository
+	RuleRepository         *RuleRepository
+	UserRepository         *UserRepository
+	SuggestionRepository   *SuggestionRepository
+	ReminderRepository     *ReminderRepository
+	TelegramRepository     *TelegramRepository
+	WebhookRepository      *WebhookRepository
+	AuditRepository        *AuditRepository
+	PriceChangeRepository  *PriceChangeRepository
+	TrialRepository        *TrialRepository
+	PaymentRepository      *PaymentRepository
+	IntegrationRepository  *IntegrationRepository
+	FeatureFlagRepository  *FeatureFlagRepository
 }
 
-func NewRepository(db *sql.DB, logger logger.Logger) *Repository {
+func NewRepository(db, reportingDB *sql.DB, logger logger.Logger) *Repository {
 	return &Repository{
-		NewSubscriptionRepository(db, logger),
+		SubscriptionRepository: NewSubscriptionRepository(db, logger),
+		SavedFilterRepository:  NewSavedFilterRepository(db, logger),
+		HealthRepository:       NewHealthRepository(db, logger),
+		ReportingRepository:    NewReportingRepository(reportingDB, logger),
+		SyncRepository:         NewSyncRepository(db, logger),
+		RuleRepository:         NewRuleRepository(db, logger),
+		UserRepository:         NewUserRepository(db, logger),
+		SuggestionRepository:   NewSuggestionRepository(db, logger),
+		ReminderRepository:     NewReminderRepository(db, logger),
+		TelegramRepository:     NewTelegramRepository(db, logger),
+		WebhookRepository:      NewWebhookRepository(db, logger),
+		AuditRepository:        NewAuditRepository(db, logger),
+		PriceChangeRepository:  NewPriceChangeRepository(db, logger),
+		TrialRepository:        NewTrialRepository(db, logger),
+		PaymentRepository:      NewPaymentRepository(db, logger),
+		IntegrationRepository:  NewIntegrationRepository(db, logger),
+		FeatureFlagRepository:  NewFeatureFlagRepository(db, logger),
 	}
 }
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type RuleRepositoryInterface interface {
+	CreateRule(ctx context.Context, row dao.CategoryRuleRow) error
+	ListRules(ctx context.Context, userID string) ([]dao.CategoryRuleRow, error)
+	GetRule(ctx context.Context, id string) (dao.CategoryRuleRow, error)
+	DeleteRule(ctx context.Context, id string) error
+}
+
+type RuleRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewRuleRepository(db *sql.DB, logger logger.Logger) *RuleRepository {
+	return &RuleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *RuleRepository) CreateRule(ctx context.Context, row dao.CategoryRuleRow) error {
+	query := `INSERT INTO category_rules (id, user_id, pattern, category, priority) VALUES ($1, $2, $3, $4, $5)`
+	r.logger.DebugContext(ctx, "Executing CreateRule query",
+		zap.String("sql", query),
+		zap.String("rule_id", row.ID.String()),
+	)
+	if _, err := r.db.ExecContext(ctx, query, row.ID, row.UserID, row.Pattern, row.Category, row.Priority); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			r.logger.WarnContext(ctx, "Create category rule rejected: user does not exist", zap.Error(err))
+			return apperrors.NewBadRequest("user does not exist", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to create category rule in database", zap.Error(err))
+		return apperrors.NewInternalServerError("database error on create rule", err)
+	}
+	return nil
+}
+
+// ListRules returns the global rules, preceded by the user's own when userID
+// is set, in the order they are tried: user rules first, then by descending
+// priority, then oldest first.
+func (r *RuleRepository) ListRules(ctx context.Context, userID string) ([]dao.CategoryRuleRow, error) {
+	query := `SELECT id, user_id, pattern, category, priority, created_at FROM category_rules
+	WHERE user_id IS NULL OR user_id = $1
+	ORDER BY user_id IS NULL, priority DESC, created_at, id`
+	r.logger.DebugContext(ctx, "Executing ListRules query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+	)
+
+	var owner *string
+	if userID != "" {
+		owner = &userID
+	}
+	rows, err := r.db.QueryContext(ctx, query, owner)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list category rules", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list rules", err)
+	}
+	defer rows.Close()
+
+	var result []dao.CategoryRuleRow
+	for rows.Next() {
+		var rule dao.CategoryRuleRow
+		if err := rows.Scan(&rule.ID, &rule.UserID, &rule.Pattern, &rule.Category, &rule.Priority, &rule.CreatedAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan category rule row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan rule", err)
+		}
+		result = append(result, rule)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate category rules", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list rules", err)
+	}
+	return result, nil
+}
+
+func (r *RuleRepository) GetRule(ctx context.Context, id string) (dao.CategoryRuleRow, error) {
+	query := `SELECT id, user_id, pattern, category, priority, created_at FROM category_rules WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing GetRule query",
+		zap.String("sql", query),
+		zap.String("id", id),
+	)
+
+	var rule dao.CategoryRuleRow
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&rule.ID, &rule.UserID, &rule.Pattern, &rule.Category, &rule.Priority, &rule.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			r.logger.WarnContext(ctx, "Category rule not found in DB", zap.String("id", id))
+			return dao.CategoryRuleRow{}, apperrors.NewNotFound("rule not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to get category rule from DB", zap.Error(err), zap.String("id", id))
+		return dao.CategoryRuleRow{}, apperrors.NewInternalServerError("database error on get rule", err)
+	}
+	return rule, nil
+}
+
+func (r *RuleRepository) DeleteRule(ctx context.Context, id string) error {
+	query := `DELETE FROM category_rules WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing DeleteRule query",
+		zap.String("sql", query),
+		zap.String("id", id),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to execute category rule delete query", zap.Error(err), zap.String("id", id))
+		return apperrors.NewInternalServerError("database error on delete rule", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after category rule delete", zap.Error(err), zap.String("id", id))
+		return apperrors.NewInternalServerError("database error on delete rule result", err)
+	}
+
+	if rowsAffected == 0 {
+		r.logger.WarnContext(ctx, "Delete attempt on non-existent category rule", zap.String("id", id))
+		return apperrors.NewNotFound("rule to delete not found", nil)
+	}
+
+	return nil
+}
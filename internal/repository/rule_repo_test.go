@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRuleRepo(t *testing.T) (*RuleRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewRuleRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestCreateRule(t *testing.T) {
+	repo, mock := newTestRuleRepo(t)
+	userID := uuid.New()
+	row := dao.CategoryRuleRow{ID: uuid.New(), UserID: &userID, Pattern: "netflix", Category: "Entertainment", Priority: 5}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO category_rules (id, user_id, pattern, category, priority) VALUES ($1, $2, $3, $4, $5)`)).
+		WithArgs(row.ID, row.UserID, row.Pattern, row.Category, row.Priority).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.NoError(t, repo.CreateRule(context.Background(), row))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRules(t *testing.T) {
+	columns := []string{"id", "user_id", "pattern", "category", "priority", "created_at"}
+
+	t.Run("User and Global Rules", func(t *testing.T) {
+		repo, mock := newTestRuleRepo(t)
+		userID := uuid.New()
+		mock.ExpectQuery(`ORDER BY user_id IS NULL, priority DESC, created_at, id`).WithArgs(userID.String()).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(uuid.New(), userID, "netflix", "Movies", 0, time.Now()).
+				AddRow(uuid.New(), nil, "netflix", "Entertainment", 10, time.Now()))
+
+		rows, err := repo.ListRules(context.Background(), userID.String())
+
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, userID, *rows[0].UserID)
+		assert.Nil(t, rows[1].UserID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Global Only", func(t *testing.T) {
+		repo, mock := newTestRuleRepo(t)
+		mock.ExpectQuery(`FROM category_rules`).WithArgs(nil).WillReturnRows(sqlmock.NewRows(columns))
+
+		rows, err := repo.ListRules(context.Background(), "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, rows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetRule(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT id, user_id, pattern, category, priority, created_at FROM category_rules WHERE id = $1`)
+
+	t.Run("Global Rule", func(t *testing.T) {
+		repo, mock := newTestRuleRepo(t)
+		id := uuid.New()
+		mock.ExpectQuery(query).WithArgs(id.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "pattern", "category", "priority", "created_at"}).
+				AddRow(id, nil, "netflix", "Entertainment", 5, time.Now()))
+
+		rule, err := repo.GetRule(context.Background(), id.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, rule.ID)
+		assert.Nil(t, rule.UserID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		repo, mock := newTestRuleRepo(t)
+		id := uuid.NewString()
+		mock.ExpectQuery(query).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "pattern", "category", "priority", "created_at"}))
+
+		_, err := repo.GetRule(context.Background(), id)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteRule_NotFound(t *testing.T) {
+	repo, mock := newTestRuleRepo(t)
+	id := uuid.NewString()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM category_rules WHERE id = $1`)).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteRule(context.Background(), id)
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type SavedFilterRepositoryInterface interface {
+	CreateSavedFilter(ctx context.Context, row dao.SavedFilterRow) error
+	ListSavedFilters(ctx context.Context, userID string) ([]dao.SavedFilterRow, error)
+	GetSavedFilter(ctx context.Context, id string) (dao.SavedFilterRow, error)
+	UpdateSavedFilter(ctx context.Context, row dao.SavedFilterRow) error
+	DeleteSavedFilter(ctx context.Context, id string) error
+}
+
+type SavedFilterRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewSavedFilterRepository(db *sql.DB, logger logger.Logger) *SavedFilterRepository {
+	return &SavedFilterRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *SavedFilterRepository) CreateSavedFilter(ctx context.Context, row dao.SavedFilterRow) error {
+	query := `INSERT INTO saved_filters (id, user_id, name, params) VALUES ($1, $2, $3, $4)`
+	r.logger.DebugContext(ctx, "Executing CreateSavedFilter query",
+		zap.String("sql", query),
+		zap.String("saved_filter_id", row.ID.String()),
+		zap.String("user_id", row.UserID.String()),
+	)
+	_, err := r.db.ExecContext(ctx, query, row.ID, row.UserID, row.Name, row.Params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			r.logger.WarnContext(ctx, "Create saved filter conflict: unique constraint violation",
+				zap.String("user_id", row.UserID.String()),
+				zap.String("name", row.Name),
+				zap.Error(err),
+			)
+			return apperrors.New(http.StatusConflict, "saved filter with this name already exists", err)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			r.logger.WarnContext(ctx, "Create saved filter rejected: user does not exist", zap.String("user_id", row.UserID.String()))
+			return apperrors.NewBadRequest("user does not exist", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to create saved filter in database", zap.Error(err))
+		return apperrors.NewInternalServerError("database error on create saved filter", err)
+	}
+	return nil
+}
+
+func (r *SavedFilterRepository) ListSavedFilters(ctx context.Context, userID string) ([]dao.SavedFilterRow, error) {
+	queryBuilder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("id", "user_id", "name", "params").
+		From("saved_filters").
+		Where(sq.Eq{"user_id": userID})
+	queryBuilder, err := paginate(queryBuilder, Page{}, savedFilterSort)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to build SQL query for ListSavedFilters", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("failed to build list saved filters query", err)
+	}
+	r.logger.DebugContext(ctx, "Executing ListSavedFilters query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list saved filters", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list saved filters", err)
+	}
+	defer rows.Close()
+
+	var result []dao.SavedFilterRow
+	for rows.Next() {
+		var f dao.SavedFilterRow
+		if err := rows.Scan(&f.ID, &f.UserID, &f.Name, &f.Params); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan saved filter row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan saved filter", err)
+		}
+		result = append(result, f)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate saved filters", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list saved filters", err)
+	}
+	return result, nil
+}
+
+func (r *SavedFilterRepository) GetSavedFilter(ctx context.Context, id string) (dao.SavedFilterRow, error) {
+	query := `SELECT id, user_id, name, params FROM saved_filters WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing GetSavedFilter query",
+		zap.String("sql", query),
+		zap.String("id", id),
+	)
+
+	var f dao.SavedFilterRow
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&f.ID, &f.UserID, &f.Name, &f.Params); err != nil {
+		if err == sql.ErrNoRows {
+			r.logger.WarnContext(ctx, "Saved filter not found in DB", zap.String("id", id))
+			return dao.SavedFilterRow{}, apperrors.NewNotFound("saved filter not found", err)
+		}
+
+		r.logger.ErrorContext(ctx, "Failed to scan/get saved filter from DB", zap.Error(err), zap.String("id", id))
+		return dao.SavedFilterRow{}, apperrors.NewInternalServerError("database error on get saved filter", err)
+	}
+
+	return f, nil
+}
+
+func (r *SavedFilterRepository) UpdateSavedFilter(ctx context.Context, row dao.SavedFilterRow) error {
+	query := `UPDATE saved_filters SET name = $1, params = $2 WHERE id = $3`
+	r.logger.DebugContext(ctx, "Executing UpdateSavedFilter query",
+		zap.String("sql", query),
+		zap.String("id", row.ID.String()),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, row.Name, row.Params, row.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			r.logger.WarnContext(ctx, "Update saved filter conflict: unique constraint violation",
+				zap.String("id", row.ID.String()),
+				zap.String("name", row.Name),
+				zap.Error(err),
+			)
+			return apperrors.New(http.StatusConflict, "saved filter with this name already exists", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to execute saved filter update query", zap.Error(err), zap.String("id", row.ID.String()))
+		return apperrors.NewInternalServerError("database error on update saved filter", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after saved filter update", zap.Error(err), zap.String("id", row.ID.String()))
+		return apperrors.NewInternalServerError("database error on update saved filter result", err)
+	}
+
+	if rowsAffected == 0 {
+		r.logger.WarnContext(ctx, "Update attempt on non-existent saved filter", zap.String("id", row.ID.String()))
+		return apperrors.NewNotFound("saved filter to update not found", nil)
+	}
+
+	return nil
+}
+
+func (r *SavedFilterRepository) DeleteSavedFilter(ctx context.Context, id string) error {
+	query := `DELETE FROM saved_filters WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing DeleteSavedFilter query",
+		zap.String("sql", query),
+		zap.String("id", id),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to execute saved filter delete query", zap.Error(err), zap.String("id", id))
+		return apperrors.NewInternalServerError("database error on delete saved filter", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after saved filter delete", zap.Error(err), zap.String("id", id))
+		return apperrors.NewInternalServerError("database error on delete saved filter result", err)
+	}
+
+	if rowsAffected == 0 {
+		r.logger.WarnContext(ctx, "Delete attempt on non-existent saved filter", zap.String("id", id))
+		return apperrors.NewNotFound("saved filter to delete not found", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSavedFilterRepo(t *testing.T) (*SavedFilterRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewSavedFilterRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestCreateSavedFilter(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO saved_filters (id, user_id, name, params) VALUES ($1, $2, $3, $4)`)
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestSavedFilterRepo(t)
+		row := dao.SavedFilterRow{ID: uuid.New(), UserID: uuid.New(), Name: "Work tools", Params: []byte(`{"min_price":"1000"}`)}
+		mock.ExpectExec(query).
+			WithArgs(row.ID, row.UserID, row.Name, row.Params).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.CreateSavedFilter(context.Background(), row)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Conflict on Duplicate Name", func(t *testing.T) {
+		repo, mock := newTestSavedFilterRepo(t)
+		mock.ExpectExec(query).WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		err := repo.CreateSavedFilter(context.Background(), dao.SavedFilterRow{})
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListSavedFilters(t *testing.T) {
+	repo, mock := newTestSavedFilterRepo(t)
+	userID := uuid.New()
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "params"}).
+		AddRow(uuid.New(), userID, "A", []byte(`{}`)).
+		AddRow(uuid.New(), userID, "B", []byte(`{"service_name":"Netflix"}`))
+	query := regexp.QuoteMeta(`SELECT id, user_id, name, params FROM saved_filters WHERE user_id = $1 ORDER BY name ASC, id ASC`)
+	mock.ExpectQuery(query).WithArgs(userID.String()).WillReturnRows(rows)
+
+	result, err := repo.ListSavedFilters(context.Background(), userID.String())
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSavedFilter(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT id, user_id, name, params FROM saved_filters WHERE id = $1`)
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestSavedFilterRepo(t)
+		id := uuid.New()
+		rows := sqlmock.NewRows([]string{"id", "user_id", "name", "params"}).
+			AddRow(id, uuid.New(), "A", []byte(`{}`))
+		mock.ExpectQuery(query).WithArgs(id.String()).WillReturnRows(rows)
+
+		result, err := repo.GetSavedFilter(context.Background(), id.String())
+		assert.NoError(t, err)
+		assert.Equal(t, id, result.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		repo, mock := newTestSavedFilterRepo(t)
+		id := uuid.New().String()
+		mock.ExpectQuery(query).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetSavedFilter(context.Background(), id)
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateSavedFilter(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE saved_filters SET name = $1, params = $2 WHERE id = $3`)
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestSavedFilterRepo(t)
+		row := dao.SavedFilterRow{ID: uuid.New(), Name: "Renamed", Params: []byte(`{}`)}
+		mock.ExpectExec(query).WithArgs(row.Name, row.Params, row.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateSavedFilter(context.Background(), row)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		repo, mock := newTestSavedFilterRepo(t)
+		row := dao.SavedFilterRow{ID: uuid.New()}
+		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateSavedFilter(context.Background(), row)
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteSavedFilter(t *testing.T) {
+	repo, mock := newTestSavedFilterRepo(t)
+	id := uuid.New().String()
+	query := regexp.QuoteMeta(`DELETE FROM saved_filters WHERE id = $1`)
+	mock.ExpectExec(query).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteSavedFilter(context.Background(), id)
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	"subtracker/internal/domain/dao"
 	"subtracker/internal/domain/dto"
@@ -18,11 +19,19 @@ import (
 
 type SubscriptionRepositoryInterface interface {
 	CreateSubscription(ctx context.Context, subDao dao.SubscriptionRow) error
+	CreateSubscriptions(ctx context.Context, rows []dao.SubscriptionRow) error
 	ListSubscriptions(ctx context.Context, subFilter dto.SubscriptionFilter) ([]dao.SubscriptionRow, error)
+	CountSubscriptions(ctx context.Context, subFilter dto.SubscriptionFilter) (int, error)
+	StreamSubscriptions(ctx context.Context, subFilter dto.SubscriptionFilter, fn func(dao.SubscriptionRow) error) error
 	GetSubscription(ctx context.Context, id string) (dao.SubscriptionRow, error)
+	GetSubscriptions(ctx context.Context, ids []string, userID string) ([]dao.SubscriptionRow, error)
 	UpdateSubscription(ctx context.Context, subDao dao.SubscriptionRow) error
-	DeleteSubscription(ctx context.Context, id string) error
-	ListForCostCalculation(ctx context.Context, filter dto.CostFilter) ([]dao.SubscriptionRow, error)
+	UpdateCategory(ctx context.Context, id, category string) error
+	DeleteSubscription(ctx context.Context, id, undoTokenHash string, undoExpiresAt time.Time) error
+	UndoDelete(ctx context.Context, undoTokenHash, userID string) (dao.SubscriptionRow, error)
+	ListTrash(ctx context.Context, filter dto.TrashFilter) ([]dao.TrashedSubscriptionRow, error)
+	RestoreSubscriptions(ctx context.Context, userID string, ids []string) ([]dao.SubscriptionRow, error)
+	CountUserSubscriptions(ctx context.Context, userID string) (int, error)
 }
 
 type SubscriptionRepository struct {
@@ -37,40 +46,171 @@ func NewSubscriptionRepository(db *sql.DB, logger logger.Logger) *SubscriptionRe
 	}
 }
 
+const insertSubscriptionQuery = `INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
 func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, subDao dao.SubscriptionRow) error {
-	query := `INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date) VALUES ($1, $2, $3, $4, $5, $6)`
-	r.logger.Debug("Executing CreateSubscription query",
-		zap.String("sql", query),
+	r.logger.DebugContext(ctx, "Executing CreateSubscription query",
+		zap.String("sql", insertSubscriptionQuery),
 		zap.String("subscription_id", subDao.ID.String()),
 		zap.String("user_id", subDao.UserID.String()),
 	)
-	_, err := r.db.ExecContext(ctx, query, subDao.ID, subDao.UserID, subDao.ServiceName, subDao.Price, subDao.StartDate, subDao.EndDate)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			r.logger.Warn("Create subscription conflict: unique constraint violation",
-				zap.String("subscription_id", subDao.ID.String()),
-				zap.Error(err),
-			)
-			return apperrors.New(http.StatusConflict, "subscription with this ID already exists", err)
+	_, err := r.db.ExecContext(ctx, insertSubscriptionQuery, subDao.ID, subDao.UserID, subDao.ServiceName, subDao.Price, subDao.StartDate, subDao.EndDate, subDao.CostCenter, subDao.Category, subDao.BillingPeriod, subDao.ExpenseType)
+	if err != nil {
+		return r.createError(ctx, subDao, err)
+	}
+	return nil
+}
+
+// CreateSubscriptions inserts all rows in one transaction: if any insert
+// fails, none of them are kept.
+func (r *SubscriptionRepository) CreateSubscriptions(ctx context.Context, rows []dao.SubscriptionRow) error {
+	r.logger.DebugContext(ctx, "Executing CreateSubscriptions query",
+		zap.String("sql", insertSubscriptionQuery),
+		zap.Int("rows", len(rows)),
+	)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to begin batch create transaction", zap.Error(err))
+		return apperrors.NewInternalServerError("database error on batch create", err)
+	}
+	defer tx.Rollback()
+
+	for _, row := range rows {
+		_, err := tx.ExecContext(ctx, insertSubscriptionQuery, row.ID, row.UserID, row.ServiceName, row.Price, row.StartDate, row.EndDate, row.CostCenter, row.Category, row.BillingPeriod, row.ExpenseType)
+		if err != nil {
+			return r.createError(ctx, row, err)
 		}
-		r.logger.Error("Failed to create subscription in database", zap.Error(err))
-		return apperrors.NewInternalServerError("database error on create", err)
+	}
+	if err := tx.Commit(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to commit batch create", zap.Error(err))
+		return apperrors.NewInternalServerError("database error on batch create", err)
 	}
 	return nil
 }
 
+// createError maps a failed insert of subDao to an AppError.
+func (r *SubscriptionRepository) createError(ctx context.Context, subDao dao.SubscriptionRow, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		r.logger.WarnContext(ctx, "Create subscription conflict: unique constraint violation",
+			zap.String("subscription_id", subDao.ID.String()),
+			zap.Error(err),
+		)
+		return apperrors.New(http.StatusConflict, "subscription with this ID already exists", err)
+	}
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		r.logger.WarnContext(ctx, "Create subscription rejected: user does not exist", zap.String("user_id", subDao.UserID.String()))
+		return apperrors.NewBadRequest("user does not exist", err)
+	}
+	r.logger.ErrorContext(ctx, "Failed to create subscription in database", zap.Error(err))
+	return apperrors.NewInternalServerError("database error on create", err)
+}
+
 func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context, f dto.SubscriptionFilter) ([]dao.SubscriptionRow, error) {
+	var result []dao.SubscriptionRow
+	err := r.StreamSubscriptions(ctx, f, func(sub dao.SubscriptionRow) error {
+		result = append(result, sub)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StreamSubscriptions passes the subscriptions matching f to fn one row at a
+// time, in list order, without holding the result set in memory. Unlike the
+// list endpoint it applies no default limit. An error from fn stops the
+// iteration and is returned as is.
+func (r *SubscriptionRepository) StreamSubscriptions(ctx context.Context, f dto.SubscriptionFilter, fn func(dao.SubscriptionRow) error) error {
+	sql, args, err := r.listQuery(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	r.logger.DebugContext(ctx, "Executing ListSubscriptions", zap.String("sql", sql), zap.Any("args", args))
+
+	rows, err := r.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list subscriptions", zap.Error(err))
+		return apperrors.NewInternalServerError("database error on list", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sub dao.SubscriptionRow
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan subscription row", zap.Error(err))
+			return apperrors.NewInternalServerError("database error on scan", err)
+		}
+		if err := fn(sub); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate subscription rows", zap.Error(err))
+		return apperrors.NewInternalServerError("database error on list", err)
+	}
+	return nil
+}
+
+// unpaidChargeSQL selects a subscription's failed charges that no retry has
+// paid; a subscription with any is past due.
+const unpaidChargeSQL = `SELECT 1 FROM payment_failures pf WHERE pf.subscription_id = subscriptions.id AND pf.paid_at IS NULL`
+
+func (r *SubscriptionRepository) listQuery(ctx context.Context, f dto.SubscriptionFilter) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	queryBuilder := filterSubscriptions(psql.Select("id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type").
+		From("subscriptions"), f)
+	queryBuilder, err := paginate(queryBuilder, Page{Limit: f.Limit, Offset: f.Offset, Sort: f.Sort}, subscriptionSort)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql, args, err := queryBuilder.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to build SQL query for ListSubscriptions", zap.Error(err))
+		return "", nil, apperrors.NewInternalServerError("failed to build list query", err)
+	}
+	return sql, args, nil
+}
+
+// CountSubscriptions returns how many subscriptions match f, ignoring its
+// limit, offset and sort.
+func (r *SubscriptionRepository) CountSubscriptions(ctx context.Context, f dto.SubscriptionFilter) (int, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	queryBuilder := psql.Select("id", "user_id", "service_name", "price", "start_date", "end_date").
-		From("subscriptions")
+	sql, args, err := filterSubscriptions(psql.Select("COUNT(*)").From("subscriptions"), f).ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to build SQL query for CountSubscriptions", zap.Error(err))
+		return 0, apperrors.NewInternalServerError("failed to build count query", err)
+	}
+	r.logger.DebugContext(ctx, "Executing CountSubscriptions", zap.String("sql", sql), zap.Any("args", args))
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to count subscriptions", zap.Error(err))
+		return 0, apperrors.NewInternalServerError("database error on count", err)
+	}
+	return count, nil
+}
 
+// filterSubscriptions adds the conditions of f to queryBuilder.
+func filterSubscriptions(queryBuilder sq.SelectBuilder, f dto.SubscriptionFilter) sq.SelectBuilder {
 	if f.UserID != "" {
 		queryBuilder = queryBuilder.Where(sq.Eq{"user_id": f.UserID})
 	}
 	if f.ServiceName != "" {
 		queryBuilder = queryBuilder.Where(sq.Eq{"service_name": f.ServiceName})
 	}
+	if f.CostCenter != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"cost_center": f.CostCenter})
+	}
+	if f.Category != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"category": f.Category})
+	}
+	if f.ExpenseType != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"expense_type": f.ExpenseType})
+	}
 	if f.MinPrice > 0 {
 		queryBuilder = queryBuilder.Where(sq.GtOrEq{"price": f.MinPrice})
 	}
@@ -91,152 +231,331 @@ func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context, f dto.Su
 			queryBuilder = queryBuilder.Where(sq.Eq{"end_date": nil})
 		}
 	}
-	queryBuilder = queryBuilder.OrderBy("start_date DESC").
-		Limit(uint64(f.Limit)).
-		Offset(uint64(f.Offset))
-
-	sql, args, err := queryBuilder.ToSql()
-	if err != nil {
-		r.logger.Error("Failed to build SQL query for ListSubscriptions", zap.Error(err))
-		return nil, apperrors.NewInternalServerError("failed to build list query", err)
-	}
-
-	r.logger.Debug("Executing ListSubscriptions", zap.String("sql", sql), zap.Any("args", args))
-
-	rows, err := r.db.QueryContext(ctx, sql, args...)
-	if err != nil {
-		r.logger.Error("Failed to list subscriptions", zap.Error(err))
-		return nil, apperrors.NewInternalServerError("database error on list", err)
-	}
-	defer rows.Close()
-
-	var result []dao.SubscriptionRow
-	for rows.Next() {
-		var sub dao.SubscriptionRow
-		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate); err != nil {
-			r.logger.Error("Failed to scan subscription row", zap.Error(err))
-			return nil, apperrors.NewInternalServerError("database error on scan", err)
+	if f.PastDue != nil {
+		if *f.PastDue {
+			queryBuilder = queryBuilder.Where("EXISTS (" + unpaidChargeSQL + ")")
+		} else {
+			queryBuilder = queryBuilder.Where("NOT EXISTS (" + unpaidChargeSQL + ")")
 		}
-		result = append(result, sub)
 	}
-	return result, nil
+	return queryBuilder
 }
 
+// GetSubscription looks a subscription up by ID. The owner is resolved through
+// subscription_ids first, so only that user's partition is read.
 func (r *SubscriptionRepository) GetSubscription(ctx context.Context, id string) (dao.SubscriptionRow, error) {
-	query := `SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE id = $1`
+	query := `SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type, version FROM subscriptions
+	WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)`
 	row := r.db.QueryRowContext(ctx, query, id)
-	r.logger.Debug("Executing GetSubscription query",
+	r.logger.DebugContext(ctx, "Executing GetSubscription query",
 		zap.String("sql", query),
 		zap.String("id", id),
 	)
 	var sub dao.SubscriptionRow
-	if err := row.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate); err != nil {
+	if err := row.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType, &sub.Version); err != nil {
 		if err == sql.ErrNoRows {
-			r.logger.Warn("Subscription not found in DB", zap.String("id", id))
+			r.logger.WarnContext(ctx, "Subscription not found in DB", zap.String("id", id))
 			return dao.SubscriptionRow{}, apperrors.NewNotFound("subscription not found", err)
 		}
 
-		r.logger.Error("Failed to scan/get subscription from DB", zap.Error(err), zap.String("id", id))
+		r.logger.ErrorContext(ctx, "Failed to scan/get subscription from DB", zap.Error(err), zap.String("id", id))
 		return dao.SubscriptionRow{}, apperrors.NewInternalServerError("database error on get", err)
 	}
 
 	return sub, nil
 }
 
+// GetSubscriptions returns the subscriptions with the given IDs in one query,
+// in no particular order. Only userID's are returned unless userID is empty;
+// unknown IDs are skipped.
+func (r *SubscriptionRepository) GetSubscriptions(ctx context.Context, ids []string, userID string) ([]dao.SubscriptionRow, error) {
+	query := `SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions
+	WHERE id = ANY($1::text[]::uuid[]) AND ($2::uuid IS NULL OR user_id = $2)`
+	r.logger.DebugContext(ctx, "Executing GetSubscriptions query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+		zap.Int("ids", len(ids)),
+	)
+
+	var owner *string
+	if userID != "" {
+		owner = &userID
+	}
+	rows, err := r.db.QueryContext(ctx, query, ids, owner)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to get subscriptions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on get subscriptions", err)
+	}
+	defer rows.Close()
+
+	var result []dao.SubscriptionRow
+	for rows.Next() {
+		var sub dao.SubscriptionRow
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan subscription row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan subscription", err)
+		}
+		result = append(result, sub)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate subscriptions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on get subscriptions", err)
+	}
+	return result, nil
+}
+
+// UpdateSubscription overwrites the subscription's fields. A non-zero
+// subDao.Version makes the write conditional on the subscription still being at
+// that version, so a change made since it was read is not lost.
 func (r *SubscriptionRepository) UpdateSubscription(ctx context.Context, subDao dao.SubscriptionRow) error {
-	query := `UPDATE subscriptions SET service_name = $1, price = $2, start_date = $3, end_date = $4 WHERE id = $5`
+	query := `UPDATE subscriptions SET service_name = $1, price = $2, start_date = $3, end_date = $4, cost_center = $5, category = $6, billing_period = $7, expense_type = $8
+	WHERE id = $9 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $9) AND ($10::bigint = 0 OR version = $10)`
 
-	r.logger.Debug("Executing UpdateSubscription query",
+	r.logger.DebugContext(ctx, "Executing UpdateSubscription query",
 		zap.String("sql", query),
 		zap.String("id", subDao.ID.String()),
 	)
 
-	result, err := r.db.ExecContext(ctx, query, subDao.ServiceName, subDao.Price, subDao.StartDate, subDao.EndDate, subDao.ID)
+	result, err := r.db.ExecContext(ctx, query, subDao.ServiceName, subDao.Price, subDao.StartDate, subDao.EndDate, subDao.CostCenter, subDao.Category, subDao.BillingPeriod, subDao.ExpenseType, subDao.ID, subDao.Version)
 	if err != nil {
-		r.logger.Error("Failed to execute update query", zap.Error(err), zap.String("id", subDao.ID.String()))
+		r.logger.ErrorContext(ctx, "Failed to execute update query", zap.Error(err), zap.String("id", subDao.ID.String()))
 		return apperrors.NewInternalServerError("database error on update", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		r.logger.Error("Failed to get rows affected after update", zap.Error(err), zap.String("id", subDao.ID.String()))
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after update", zap.Error(err), zap.String("id", subDao.ID.String()))
 		return apperrors.NewInternalServerError("database error on update result", err)
 	}
 
+	if rowsAffected == 0 && subDao.Version != 0 {
+		r.logger.WarnContext(ctx, "Update lost the race with another write", zap.String("id", subDao.ID.String()), zap.Int64("version", subDao.Version))
+		return apperrors.New(http.StatusPreconditionFailed, "subscription was changed or deleted since it was read", nil)
+	}
 	if rowsAffected == 0 {
-		r.logger.Warn("Update attempt on non-existent subscription", zap.String("id", subDao.ID.String()))
+		r.logger.WarnContext(ctx, "Update attempt on non-existent subscription", zap.String("id", subDao.ID.String()))
 		return apperrors.NewNotFound("subscription to update not found", nil)
 	}
 
 	return nil
 }
 
-func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, id string) error {
-	query := `DELETE FROM subscriptions WHERE id = $1`
+// UpdateCategory sets only the category, so a rule run cannot overwrite
+// fields edited since the subscription was read.
+func (r *SubscriptionRepository) UpdateCategory(ctx context.Context, id, category string) error {
+	query := `UPDATE subscriptions SET category = $1 WHERE id = $2 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $2)`
+
+	r.logger.DebugContext(ctx, "Executing UpdateCategory query",
+		zap.String("sql", query),
+		zap.String("id", id),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, category, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to execute category update query", zap.Error(err), zap.String("id", id))
+		return apperrors.NewInternalServerError("database error on update category", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after category update", zap.Error(err), zap.String("id", id))
+		return apperrors.NewInternalServerError("database error on update category result", err)
+	}
+
+	if rowsAffected == 0 {
+		r.logger.WarnContext(ctx, "Category update attempt on non-existent subscription", zap.String("id", id))
+		return apperrors.NewNotFound("subscription to update not found", nil)
+	}
+
+	return nil
+}
 
-	r.logger.Debug("Executing DeleteSubscription query",
+// DeleteSubscription moves the subscription to the trash and records the hash
+// of its undo token. A subscription trashed again after being restored or
+// recreated replaces its older copy and token.
+func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, id, undoTokenHash string, undoExpiresAt time.Time) error {
+	query := `WITH trashed AS (
+		DELETE FROM subscriptions WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)
+		RETURNING id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type
+	)
+	INSERT INTO deleted_subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type, undo_token_hash, undo_expires_at)
+	SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type, $2, $3 FROM trashed
+	ON CONFLICT (id) DO UPDATE SET user_id = EXCLUDED.user_id, service_name = EXCLUDED.service_name, price = EXCLUDED.price,
+		start_date = EXCLUDED.start_date, end_date = EXCLUDED.end_date, cost_center = EXCLUDED.cost_center,
+		category = EXCLUDED.category, billing_period = EXCLUDED.billing_period, expense_type = EXCLUDED.expense_type, deleted_at = now(),
+		undo_token_hash = EXCLUDED.undo_token_hash, undo_expires_at = EXCLUDED.undo_expires_at`
+
+	r.logger.DebugContext(ctx, "Executing DeleteSubscription query",
 		zap.String("sql", query),
 		zap.String("id", id),
 	)
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, id, undoTokenHash, undoExpiresAt)
 	if err != nil {
-		r.logger.Error("Failed to execute delete query", zap.Error(err), zap.String("id", id))
+		r.logger.ErrorContext(ctx, "Failed to execute delete query", zap.Error(err), zap.String("id", id))
 		return apperrors.NewInternalServerError("database error on delete", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		r.logger.Error("Failed to get rows affected after delete", zap.Error(err), zap.String("id", id))
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after delete", zap.Error(err), zap.String("id", id))
 		return apperrors.NewInternalServerError("database error on delete result", err)
 	}
 
 	if rowsAffected == 0 {
-		r.logger.Warn("Delete attempt on non-existent subscription", zap.String("id", id))
+		r.logger.WarnContext(ctx, "Delete attempt on non-existent subscription", zap.String("id", id))
 		return apperrors.NewNotFound("subscription to delete not found", nil)
 	}
 
 	return nil
 }
 
-func (r *SubscriptionRepository) ListForCostCalculation(ctx context.Context, filter dto.CostFilter) ([]dao.SubscriptionRow, error) {
+// UndoDelete restores the trashed subscription whose undo token hashes to
+// undoTokenHash, provided the token has not expired. Only userID's
+// subscription is restored unless userID is empty. An unknown, expired or
+// already used token is reported as not found.
+func (r *SubscriptionRepository) UndoDelete(ctx context.Context, undoTokenHash, userID string) (dao.SubscriptionRow, error) {
+	query := `WITH restored AS (
+		DELETE FROM deleted_subscriptions WHERE undo_token_hash = $1 AND undo_expires_at > now() AND ($2::uuid IS NULL OR user_id = $2)
+		RETURNING id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type
+	)
+	INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type)
+	SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM restored
+	RETURNING id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type`
+	r.logger.DebugContext(ctx, "Executing UndoDelete query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+	)
+
+	var owner *string
+	if userID != "" {
+		owner = &userID
+	}
+	var sub dao.SubscriptionRow
+	err := r.db.QueryRowContext(ctx, query, undoTokenHash, owner).
+		Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "Undo attempt with an unknown or expired token", zap.String("user_id", userID))
+		return dao.SubscriptionRow{}, apperrors.NewNotFound("undo token not found or expired", err)
+	}
+	if err != nil {
+		return dao.SubscriptionRow{}, r.restoreError(ctx, err)
+	}
+	return sub, nil
+}
+
+// ListTrash returns trashed subscriptions, most recently deleted first. An
+// empty UserID lists every user's.
+func (r *SubscriptionRepository) ListTrash(ctx context.Context, f dto.TrashFilter) ([]dao.TrashedSubscriptionRow, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	queryBuilder := psql.Select("id", "user_id", "service_name", "price", "start_date", "end_date").
-		From("subscriptions")
+	queryBuilder := psql.Select("id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type", "deleted_at").
+		From("deleted_subscriptions")
 
-	queryBuilder = queryBuilder.Where(sq.Eq{"user_id": filter.UserID})
-	if filter.ServiceName != "" {
-		queryBuilder = queryBuilder.Where(sq.Eq{"service_name": filter.ServiceName})
+	if f.UserID != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"user_id": f.UserID})
+	}
+	queryBuilder, err := paginate(queryBuilder, Page{Limit: f.Limit, Offset: f.Offset}, trashSort)
+	if err != nil {
+		return nil, err
 	}
-	queryBuilder = queryBuilder.Where(sq.LtOrEq{"start_date": filter.PeriodEnd}).
-		Where(sq.Or{
-			sq.Eq{"end_date": nil},
-			sq.GtOrEq{"end_date": filter.PeriodStart},
-		})
 
 	sql, args, err := queryBuilder.ToSql()
 	if err != nil {
-		r.logger.Error("Failed to build SQL for ListForCostCalculation", zap.Error(err))
-		return nil, apperrors.NewInternalServerError("failed to build cost query", err)
+		r.logger.ErrorContext(ctx, "Failed to build SQL query for ListTrash", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("failed to build trash query", err)
 	}
 
-	r.logger.Debug("Executing ListForCostCalculation query", zap.String("sql", sql), zap.Any("args", args))
+	r.logger.DebugContext(ctx, "Executing ListTrash", zap.String("sql", sql), zap.Any("args", args))
 
 	rows, err := r.db.QueryContext(ctx, sql, args...)
 	if err != nil {
-		r.logger.Error("Failed to execute cost calculation query", zap.Error(err))
-		return nil, apperrors.NewInternalServerError("database error on cost calculation", err)
+		r.logger.ErrorContext(ctx, "Failed to list trashed subscriptions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list trash", err)
 	}
 	defer rows.Close()
 
-	var result []dao.SubscriptionRow
+	var result []dao.TrashedSubscriptionRow
 	for rows.Next() {
-		var sub dao.SubscriptionRow
-		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate); err != nil {
-			r.logger.Error("Failed to scan subscription row for cost", zap.Error(err))
-			return nil, apperrors.NewInternalServerError("database error on scan for cost", err)
+		var sub dao.TrashedSubscriptionRow
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType, &sub.DeletedAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan trashed subscription row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan trash", err)
 		}
 		result = append(result, sub)
 	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate trashed subscriptions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list trash", err)
+	}
 	return result, nil
 }
+
+// RestoreSubscriptions moves the given subscriptions from the trash back to
+// subscriptions in one statement, so either all of them are restored or none
+// is. Only userID's subscriptions are restored unless userID is empty. It
+// returns the IDs that were restored; the rest were not in the trash.
+func (r *SubscriptionRepository) RestoreSubscriptions(ctx context.Context, userID string, ids []string) ([]dao.SubscriptionRow, error) {
+	query := `WITH restored AS (
+		DELETE FROM deleted_subscriptions WHERE id = ANY($1::text[]::uuid[]) AND ($2::uuid IS NULL OR user_id = $2)
+		RETURNING id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type
+	)
+	INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type)
+	SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM restored
+	RETURNING id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type`
+	r.logger.DebugContext(ctx, "Executing RestoreSubscriptions query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+		zap.Int("ids", len(ids)),
+	)
+
+	var owner *string
+	if userID != "" {
+		owner = &userID
+	}
+	rows, err := r.db.QueryContext(ctx, query, ids, owner)
+	if err != nil {
+		return nil, r.restoreError(ctx, err)
+	}
+	defer rows.Close()
+
+	var restored []dao.SubscriptionRow
+	for rows.Next() {
+		var sub dao.SubscriptionRow
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate, &sub.CostCenter, &sub.Category, &sub.BillingPeriod, &sub.ExpenseType); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan restored subscription", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan restore", err)
+		}
+		restored = append(restored, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.restoreError(ctx, err)
+	}
+	return restored, nil
+}
+
+// restoreError maps a failed restore. The insert runs while rows are read, so
+// a conflict can surface from the query or from the iteration.
+func (r *SubscriptionRepository) restoreError(ctx context.Context, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		r.logger.WarnContext(ctx, "Restore conflict: a subscription with the same ID exists again", zap.Error(err))
+		return apperrors.New(http.StatusConflict, "a subscription with the same ID as a trashed one already exists", err)
+	}
+	r.logger.ErrorContext(ctx, "Failed to restore subscriptions", zap.Error(err))
+	return apperrors.NewInternalServerError("database error on restore", err)
+}
+
+func (r *SubscriptionRepository) CountUserSubscriptions(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM subscriptions WHERE user_id = $1`
+	r.logger.DebugContext(ctx, "Executing CountUserSubscriptions query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+	)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to count user subscriptions", zap.Error(err), zap.String("user_id", userID))
+		return 0, apperrors.NewInternalServerError("database error on count", err)
+	}
+	return count, nil
+}
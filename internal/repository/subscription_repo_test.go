@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"net/http"
 	"regexp"
@@ -37,9 +38,9 @@ func TestCreateSubscription(t *testing.T) {
 			UserID:      uuid.New(),
 			ServiceName: "Netflix",
 		}
-		query := regexp.QuoteMeta(`INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date) VALUES ($1, $2, $3, $4, $5, $6)`)
+		query := regexp.QuoteMeta(`INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`)
 		mock.ExpectExec(query).
-			WithArgs(subToCreate.ID, subToCreate.UserID, subToCreate.ServiceName, subToCreate.Price, subToCreate.StartDate, subToCreate.EndDate).
+			WithArgs(subToCreate.ID, subToCreate.UserID, subToCreate.ServiceName, subToCreate.Price, subToCreate.StartDate, subToCreate.EndDate, subToCreate.CostCenter, subToCreate.Category, subToCreate.BillingPeriod, subToCreate.ExpenseType).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := repo.CreateSubscription(context.Background(), subToCreate)
@@ -50,7 +51,7 @@ func TestCreateSubscription(t *testing.T) {
 	t.Run("Conflict on Duplicate ID", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		pgErr := &pgconn.PgError{Code: "23505"}
-		query := regexp.QuoteMeta(`INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date) VALUES ($1, $2, $3, $4, $5, $6)`)
+		query := regexp.QuoteMeta(`INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`)
 		mock.ExpectExec(query).WillReturnError(pgErr)
 
 		err := repo.CreateSubscription(context.Background(), dao.SubscriptionRow{})
@@ -62,18 +63,57 @@ func TestCreateSubscription(t *testing.T) {
 	})
 }
 
+func TestCreateSubscriptions(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`)
+	rows := []dao.SubscriptionRow{
+		{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Netflix"},
+		{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Spotify"},
+	}
+
+	t.Run("Commits All Rows", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectBegin()
+		for _, row := range rows {
+			mock.ExpectExec(query).
+				WithArgs(row.ID, row.UserID, row.ServiceName, row.Price, row.StartDate, row.EndDate, row.CostCenter, row.Category, row.BillingPeriod, row.ExpenseType).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectCommit()
+
+		err := repo.CreateSubscriptions(context.Background(), rows)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls Back On Failed Insert", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(query).WillReturnError(&pgconn.PgError{Code: "23503"})
+		mock.ExpectRollback()
+
+		err := repo.CreateSubscriptions(context.Background(), rows)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestListSubscriptions(t *testing.T) {
 	t.Run("Success with UserID filter", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		userID := uuid.New()
-		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date"}).
-			AddRow(uuid.New(), userID, "Netflix", 1000, time.Now(), nil)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(uuid.New(), userID, "Netflix", 1000, time.Now(), nil, "", "", "monthly", "subscription")
 		filter := dto.SubscriptionFilter{
 			UserID: userID.String(),
 			Limit:  10,
 			Offset: 0,
 		}
-		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE user_id = $1 ORDER BY start_date DESC LIMIT 10 OFFSET 0")
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions WHERE user_id = $1 ORDER BY start_date DESC, id ASC LIMIT 10")
 		mock.ExpectQuery(expectedQuery).
 			WithArgs(filter.UserID).
 			WillReturnRows(rows)
@@ -87,8 +127,8 @@ func TestListSubscriptions(t *testing.T) {
 	t.Run("Success with Multiple filters", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		userID := uuid.New()
-		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date"}).
-			AddRow(uuid.New(), userID, "Yandex Plus", 500, time.Now(), nil)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(uuid.New(), userID, "Yandex Plus", 500, time.Now(), nil, "", "", "monthly", "subscription")
 		filter := dto.SubscriptionFilter{
 			UserID:      userID.String(),
 			ServiceName: "Yandex Plus",
@@ -96,7 +136,7 @@ func TestListSubscriptions(t *testing.T) {
 			Limit:       5,
 			Offset:      0,
 		}
-		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE user_id = $1 AND service_name = $2 AND price >= $3 ORDER BY start_date DESC LIMIT 5 OFFSET 0")
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions WHERE user_id = $1 AND service_name = $2 AND price >= $3 ORDER BY start_date DESC, id ASC LIMIT 5")
 		mock.ExpectQuery(expectedQuery).
 			WithArgs(filter.UserID, filter.ServiceName, filter.MinPrice).
 			WillReturnRows(rows)
@@ -109,9 +149,9 @@ func TestListSubscriptions(t *testing.T) {
 
 	t.Run("Success with No Filters (Pagination only)", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
-		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date"})
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"})
 		filter := dto.SubscriptionFilter{Limit: 20, Offset: 10}
-		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions ORDER BY start_date DESC LIMIT 20 OFFSET 10")
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions ORDER BY start_date DESC, id ASC LIMIT 20 OFFSET 10")
 		mock.ExpectQuery(expectedQuery).
 			WithArgs(). // Аргументов нет
 			WillReturnRows(rows)
@@ -123,24 +163,60 @@ func TestListSubscriptions(t *testing.T) {
 	})
 }
 
+func TestCountSubscriptions(t *testing.T) {
+	t.Run("Ignores The Page", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		filter := dto.SubscriptionFilter{
+			UserID:   uuid.New().String(),
+			MinPrice: 300,
+			Limit:    5,
+			Offset:   10,
+			Sort:     "price",
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM subscriptions WHERE user_id = $1 AND price >= $2")).
+			WithArgs(filter.UserID, filter.MinPrice).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(27))
+
+		count, err := repo.CountSubscriptions(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.Equal(t, 27, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DB Error", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM subscriptions")).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.CountSubscriptions(context.Background(), dto.SubscriptionFilter{})
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestGetSubscription(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		expectedID := uuid.New()
 		expectedRow := dao.SubscriptionRow{ID: expectedID}
-		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date"}).
-			AddRow(expectedRow.ID, uuid.New(), "Netflix", 100, time.Now(), nil)
-		query := regexp.QuoteMeta(`SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE id = $1`)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type", "version"}).
+			AddRow(expectedRow.ID, uuid.New(), "Netflix", 100, time.Now(), nil, "", "", "monthly", "subscription", 42)
+		query := regexp.QuoteMeta(`SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type, version FROM subscriptions
+	WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)`)
 		mock.ExpectQuery(query).WithArgs(expectedID.String()).WillReturnRows(rows)
 		result, err := repo.GetSubscription(context.Background(), expectedID.String())
 		assert.NoError(t, err)
 		assert.Equal(t, expectedRow.ID, result.ID)
+		assert.Equal(t, int64(42), result.Version)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 	t.Run("Not Found", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		testID := uuid.New().String()
-		query := regexp.QuoteMeta(`SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE id = $1`)
+		query := regexp.QuoteMeta(`SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type, version FROM subscriptions
+	WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)`)
 		mock.ExpectQuery(query).WithArgs(testID).WillReturnError(sql.ErrNoRows)
 		_, err := repo.GetSubscription(context.Background(), testID)
 		assert.Error(t, err)
@@ -154,7 +230,8 @@ func TestGetSubscription(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		testID := uuid.New().String()
 		dbErr := errors.New("connection failed")
-		query := regexp.QuoteMeta(`SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE id = $1`)
+		query := regexp.QuoteMeta(`SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type, version FROM subscriptions
+	WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)`)
 		mock.ExpectQuery(query).WithArgs(testID).WillReturnError(dbErr)
 		_, err := repo.GetSubscription(context.Background(), testID)
 		assert.Error(t, err)
@@ -175,9 +252,10 @@ func TestUpdateSubscription(t *testing.T) {
 			ServiceName: "Updated Service",
 			Price:       999,
 		}
-		query := regexp.QuoteMeta(`UPDATE subscriptions SET service_name = $1, price = $2, start_date = $3, end_date = $4 WHERE id = $5`)
+		query := regexp.QuoteMeta(`UPDATE subscriptions SET service_name = $1, price = $2, start_date = $3, end_date = $4, cost_center = $5, category = $6, billing_period = $7, expense_type = $8
+	WHERE id = $9 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $9) AND ($10::bigint = 0 OR version = $10)`)
 		mock.ExpectExec(query).
-			WithArgs(subToUpdate.ServiceName, subToUpdate.Price, subToUpdate.StartDate, subToUpdate.EndDate, subToUpdate.ID).
+			WithArgs(subToUpdate.ServiceName, subToUpdate.Price, subToUpdate.StartDate, subToUpdate.EndDate, subToUpdate.CostCenter, subToUpdate.Category, subToUpdate.BillingPeriod, subToUpdate.ExpenseType, subToUpdate.ID, subToUpdate.Version).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 		err := repo.UpdateSubscription(ctx, subToUpdate)
 		assert.NoError(t, err)
@@ -186,9 +264,10 @@ func TestUpdateSubscription(t *testing.T) {
 	t.Run("Not Found", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		subToUpdate := dao.SubscriptionRow{ID: uuid.New()}
-		query := regexp.QuoteMeta(`UPDATE subscriptions SET service_name = $1, price = $2, start_date = $3, end_date = $4 WHERE id = $5`)
+		query := regexp.QuoteMeta(`UPDATE subscriptions SET service_name = $1, price = $2, start_date = $3, end_date = $4, cost_center = $5, category = $6, billing_period = $7, expense_type = $8
+	WHERE id = $9 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $9) AND ($10::bigint = 0 OR version = $10)`)
 		mock.ExpectExec(query).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), subToUpdate.ID).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), subToUpdate.ID, int64(0)).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 		err := repo.UpdateSubscription(ctx, subToUpdate)
 		assert.Error(t, err)
@@ -197,24 +276,37 @@ func TestUpdateSubscription(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, appErr.Code)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+	t.Run("Changed Since Read", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		subToUpdate := dao.SubscriptionRow{ID: uuid.New(), Version: 41}
+		mock.ExpectExec(`AND \(\$10::bigint = 0 OR version = \$10\)`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), subToUpdate.ID, int64(41)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.UpdateSubscription(ctx, subToUpdate)
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusPreconditionFailed, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestDeleteSubscription(t *testing.T) {
+	expiresAt := time.Date(2025, 7, 1, 10, 5, 0, 0, time.UTC)
 	t.Run("Success", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		testID := uuid.New().String()
-		query := regexp.QuoteMeta(`DELETE FROM subscriptions WHERE id = $1`)
-		mock.ExpectExec(query).WithArgs(testID).WillReturnResult(sqlmock.NewResult(0, 1))
-		err := repo.DeleteSubscription(context.Background(), testID)
+		query := regexp.QuoteMeta(`DELETE FROM subscriptions WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)`) + `(?s).*` + regexp.QuoteMeta(`INSERT INTO deleted_subscriptions`)
+		mock.ExpectExec(query).WithArgs(testID, "token-hash", expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := repo.DeleteSubscription(context.Background(), testID, "token-hash", expiresAt)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 	t.Run("Not Found", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		testID := uuid.New().String()
-		query := regexp.QuoteMeta(`DELETE FROM subscriptions WHERE id = $1`)
-		mock.ExpectExec(query).WithArgs(testID).WillReturnResult(sqlmock.NewResult(0, 0))
-		err := repo.DeleteSubscription(context.Background(), testID)
+		query := regexp.QuoteMeta(`DELETE FROM subscriptions WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)`)
+		mock.ExpectExec(query).WithArgs(testID, "token-hash", expiresAt).WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.DeleteSubscription(context.Background(), testID, "token-hash", expiresAt)
 		assert.Error(t, err)
 		var appErr *apperrors.AppError
 		assert.True(t, errors.As(err, &appErr))
@@ -225,9 +317,9 @@ func TestDeleteSubscription(t *testing.T) {
 		repo, mock := newTestRepo(t)
 		testID := uuid.New().String()
 		dbErr := errors.New("connection broken")
-		query := regexp.QuoteMeta(`DELETE FROM subscriptions WHERE id = $1`)
-		mock.ExpectExec(query).WithArgs(testID).WillReturnError(dbErr)
-		err := repo.DeleteSubscription(context.Background(), testID)
+		query := regexp.QuoteMeta(`DELETE FROM subscriptions WHERE id = $1 AND user_id = (SELECT user_id FROM subscription_ids WHERE id = $1)`)
+		mock.ExpectExec(query).WithArgs(testID, "token-hash", expiresAt).WillReturnError(dbErr)
+		err := repo.DeleteSubscription(context.Background(), testID, "token-hash", expiresAt)
 		assert.Error(t, err)
 		var appErr *apperrors.AppError
 		assert.True(t, errors.As(err, &appErr))
@@ -236,73 +328,273 @@ func TestDeleteSubscription(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
-func TestListForCostCalculation(t *testing.T) {
-	t.Run("Success with Full Filter", func(t *testing.T) {
-		repo, mock := newTestRepo(t)
-		userID := uuid.New()
-		filter := dto.CostFilter{
-			UserID:      userID.String(),
-			ServiceName: "Netflix",
-			PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-			PeriodEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
-		}
-		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date"}).
-			AddRow(uuid.New(), userID, "Netflix", 100, time.Now(), nil)
 
-		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE user_id = $1 AND service_name = $2 AND start_date <= $3 AND (end_date IS NULL OR end_date >= $4)")
+func TestUndoDelete(t *testing.T) {
+	query := regexp.QuoteMeta(`DELETE FROM deleted_subscriptions WHERE undo_token_hash = $1 AND undo_expires_at > now()`) + `(?s).*` + regexp.QuoteMeta(`INSERT INTO subscriptions`)
+	userID := uuid.New()
 
-		mock.ExpectQuery(expectedQuery).
-			WithArgs(filter.UserID, filter.ServiceName, filter.PeriodEnd, filter.PeriodStart).
-			WillReturnRows(rows)
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		subID := uuid.New()
+		startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(subID, userID, "Netflix", 999, startDate, nil, "", "", "monthly", "subscription")
+		mock.ExpectQuery(query).WithArgs("token-hash", userID.String()).WillReturnRows(rows)
 
-		result, err := repo.ListForCostCalculation(context.Background(), filter)
+		sub, err := repo.UndoDelete(context.Background(), "token-hash", userID.String())
 		assert.NoError(t, err)
-		assert.Len(t, result, 1)
+		assert.Equal(t, subID, sub.ID)
+		assert.Equal(t, "Netflix", sub.ServiceName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("Unknown Or Expired Token", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectQuery(query).WithArgs("token-hash", nil).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.UndoDelete(context.Background(), "token-hash", "")
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+	t.Run("Recreated Meanwhile", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectQuery(query).WithArgs("token-hash", nil).WillReturnError(&pgconn.PgError{Code: "23505"})
 
-	t.Run("Success with UserID only", func(t *testing.T) {
+		_, err := repo.UndoDelete(context.Background(), "token-hash", "")
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestQueryCancellation(t *testing.T) {
+	t.Run("ListSubscriptions Propagates Cancellation", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
-		userID := uuid.New()
-		filter := dto.CostFilter{
-			UserID:      userID.String(),
-			PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-			PeriodEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
-		}
-		rows := sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date"}).
-			AddRow(uuid.New(), userID, "Netflix", 100, time.Now(), nil).
-			AddRow(uuid.New(), userID, "Spotify", 200, time.Now(), nil)
+		mock.ExpectQuery(".*").WillDelayFor(time.Second).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+				AddRow(uuid.New(), uuid.New(), "Netflix", 999, time.Now(), nil, "", "", "monthly", "subscription"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err := repo.ListSubscriptions(ctx, dto.SubscriptionFilter{Limit: 10})
+		assert.ErrorIs(t, err, sqlmock.ErrCancelled)
+	})
+
+	t.Run("GetSubscription Propagates Cancellation", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectQuery(".*").WillDelayFor(time.Second).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type", "version"}).
+				AddRow(uuid.New(), uuid.New(), "Netflix", 999, time.Now(), nil, "", "", "monthly", "subscription", int64(1)))
 
-		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions WHERE user_id = $1 AND start_date <= $2 AND (end_date IS NULL OR end_date >= $3)")
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
 
+		_, err := repo.GetSubscription(ctx, uuid.New().String())
+		assert.ErrorIs(t, err, sqlmock.ErrCancelled)
+	})
+}
+
+func TestListSubscriptionsSorting(t *testing.T) {
+	t.Run("Custom Sort", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions ORDER BY price DESC, service_name ASC, id ASC LIMIT 10")
 		mock.ExpectQuery(expectedQuery).
-			WithArgs(filter.UserID, filter.PeriodEnd, filter.PeriodStart).
-			WillReturnRows(rows)
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}))
 
-		result, err := repo.ListForCostCalculation(context.Background(), filter)
+		_, err := repo.ListSubscriptions(context.Background(), dto.SubscriptionFilter{Limit: 10, Sort: "-price, service_name"})
 		assert.NoError(t, err)
-		assert.Len(t, result, 2)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("DB Error on Query", func(t *testing.T) {
+	t.Run("Past Due", func(t *testing.T) {
 		repo, mock := newTestRepo(t)
-		dbErr := errors.New("something went wrong")
-		filter := dto.CostFilter{
-			UserID:      uuid.New().String(),
-			PeriodStart: time.Now(),
-			PeriodEnd:   time.Now(),
-		}
+		pastDue := true
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions WHERE EXISTS (SELECT 1 FROM payment_failures pf WHERE pf.subscription_id = subscriptions.id AND pf.paid_at IS NULL) ORDER BY start_date DESC, id ASC LIMIT 10")
+		mock.ExpectQuery(expectedQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}))
 
-		mock.ExpectQuery(".*").WillReturnError(dbErr)
+		_, err := repo.ListSubscriptions(context.Background(), dto.SubscriptionFilter{Limit: 10, PastDue: &pastDue})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 
-		_, err := repo.ListForCostCalculation(context.Background(), filter)
+	t.Run("Expense Type", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions WHERE expense_type = $1 ORDER BY start_date DESC, id ASC LIMIT 10")
+		mock.ExpectQuery(expectedQuery).WithArgs("rent").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+				AddRow(uuid.New(), uuid.New(), "Flat", 120000, time.Now(), nil, "", "", "monthly", "rent"))
 
-		assert.Error(t, err)
+		rows, err := repo.ListSubscriptions(context.Background(), dto.SubscriptionFilter{Limit: 10, ExpenseType: "rent"})
+		assert.NoError(t, err)
+		assert.Equal(t, "rent", rows[0].ExpenseType)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsupported Sort Field", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+
+		_, err := repo.ListSubscriptions(context.Background(), dto.SubscriptionFilter{Limit: 10, Sort: "user_id; DROP TABLE subscriptions"})
 		var appErr *apperrors.AppError
 		assert.True(t, errors.As(err, &appErr))
-		assert.Equal(t, http.StatusInternalServerError, appErr.Code)
-		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStreamSubscriptions(t *testing.T) {
+	columns := []string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}
+	expectedQuery := regexp.QuoteMeta("SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM subscriptions WHERE user_id = $1 ORDER BY start_date DESC, id ASC")
+	userID := uuid.New()
+
+	t.Run("Passes Every Row Without A Limit", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectQuery(expectedQuery + "$").
+			WithArgs(userID.String()).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(uuid.New(), userID, "Netflix", 1000, time.Now(), nil, "", "", "monthly", "subscription").
+				AddRow(uuid.New(), userID, "Spotify", 500, time.Now(), nil, "", "", "monthly", "subscription"))
+
+		var names []string
+		err := repo.StreamSubscriptions(context.Background(), dto.SubscriptionFilter{UserID: userID.String()}, func(row dao.SubscriptionRow) error {
+			names = append(names, row.ServiceName)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Netflix", "Spotify"}, names)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Callback Error Stops Iteration", func(t *testing.T) {
+		repo, mock := newTestRepo(t)
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(userID.String()).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(uuid.New(), userID, "Netflix", 1000, time.Now(), nil, "", "", "monthly", "subscription").
+				AddRow(uuid.New(), userID, "Spotify", 500, time.Now(), nil, "", "", "monthly", "subscription"))
+		stop := errors.New("client went away")
+
+		calls := 0
+		err := repo.StreamSubscriptions(context.Background(), dto.SubscriptionFilter{UserID: userID.String()}, func(dao.SubscriptionRow) error {
+			calls++
+			return stop
+		})
+
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestCountUserSubscriptions(t *testing.T) {
+	repo, mock := newTestRepo(t)
+	userID := uuid.New().String()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM subscriptions WHERE user_id = $1`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := repo.CountUserSubscriptions(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateSubscription_UnknownUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := NewSubscriptionRepository(db, logger.NewNopLogger())
+	mock.ExpectExec(`INSERT INTO subscriptions`).WillReturnError(&pgconn.PgError{Code: "23503"})
+
+	err = repo.CreateSubscription(context.Background(), dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New()})
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+// passthroughConverter lets array arguments reach sqlmock the way pgx accepts
+// them.
+type passthroughConverter struct{}
+
+func (passthroughConverter) ConvertValue(v any) (driver.Value, error) { return v, nil }
+
+func TestListTrash(t *testing.T) {
+	repo, mock := newTestRepo(t)
+	userID := uuid.New()
+	deletedAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`SELECT id, user_id, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type, deleted_at FROM deleted_subscriptions WHERE user_id = $1 ORDER BY deleted_at DESC, id ASC LIMIT 10`)
+	mock.ExpectQuery(query).WithArgs(userID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type", "deleted_at"}).
+			AddRow(uuid.New(), userID, "Netflix", 999, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil, "", "", "yearly", "subscription", deletedAt))
+
+	rows, err := repo.ListTrash(context.Background(), dto.TrashFilter{UserID: userID.String(), Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, deletedAt, rows[0].DeletedAt)
+	assert.Equal(t, "yearly", rows[0].BillingPeriod)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubscriptions(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(passthroughConverter{}))
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	repo := NewSubscriptionRepository(db, logger.NewNopLogger())
+	userID := uuid.NewString()
+	found := uuid.New()
+	ids := []string{found.String(), uuid.NewString()}
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE id = ANY($1::text[]::uuid[]) AND ($2::uuid IS NULL OR user_id = $2)`)).WithArgs(ids, &userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(found, userID, "Netflix", 999, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil, "", "", "monthly", "subscription"))
+
+	rows, err := repo.GetSubscriptions(context.Background(), ids, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, found, rows[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreSubscriptions(t *testing.T) {
+	newRepo := func(t *testing.T) (*SubscriptionRepository, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(passthroughConverter{}))
+		if err != nil {
+			t.Fatalf("failed to open sqlmock database: %v", err)
+		}
+		return NewSubscriptionRepository(db, logger.NewNopLogger()), mock
+	}
+	query := regexp.QuoteMeta(`DELETE FROM deleted_subscriptions WHERE id = ANY($1::text[]::uuid[]) AND ($2::uuid IS NULL OR user_id = $2)`)
+	userID := uuid.NewString()
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newRepo(t)
+		restored := uuid.New()
+		ids := []string{restored.String(), uuid.NewString()}
+		mock.ExpectQuery(query).WithArgs(ids, &userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+				AddRow(restored, userID, "Netflix", 999, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil, "", "", "monthly", "subscription"))
+
+		got, err := repo.RestoreSubscriptions(context.Background(), userID, ids)
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, restored, got[0].ID)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("ID Taken Again", func(t *testing.T) {
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(query).WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		_, err := repo.RestoreSubscriptions(context.Background(), userID, []string{uuid.NewString()})
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
 }
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+const suggestionColumns = `id, user_id, source, service_name, price, start_date, end_date, status, subscription_id, created_at, resolved_at`
+
+type SuggestionRepositoryInterface interface {
+	CreateSuggestion(ctx context.Context, row dao.SuggestionRow) (dao.SuggestionRow, error)
+	ListSuggestions(ctx context.Context, userID, status string) ([]dao.SuggestionRow, error)
+	GetSuggestion(ctx context.Context, id string) (dao.SuggestionRow, error)
+	ResolveSuggestion(ctx context.Context, id, status string, subscriptionID *uuid.UUID) (dao.SuggestionRow, error)
+}
+
+type SuggestionRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewSuggestionRepository(db *sql.DB, logger logger.Logger) *SuggestionRepository {
+	return &SuggestionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateSuggestion inserts a pending suggestion and returns it with its
+// creation time.
+func (r *SuggestionRepository) CreateSuggestion(ctx context.Context, row dao.SuggestionRow) (dao.SuggestionRow, error) {
+	query := `INSERT INTO subscription_suggestions (id, user_id, source, service_name, price, start_date, end_date)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING status, created_at`
+	r.logger.DebugContext(ctx, "Executing CreateSuggestion query",
+		zap.String("sql", query),
+		zap.String("suggestion_id", row.ID.String()),
+	)
+	err := r.db.QueryRowContext(ctx, query, row.ID, row.UserID, row.Source, row.ServiceName, row.Price, row.StartDate, row.EndDate).
+		Scan(&row.Status, &row.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			r.logger.WarnContext(ctx, "Create suggestion rejected: user does not exist", zap.Error(err))
+			return dao.SuggestionRow{}, apperrors.NewBadRequest("user does not exist", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to create suggestion in database", zap.Error(err))
+		return dao.SuggestionRow{}, apperrors.NewInternalServerError("database error on create suggestion", err)
+	}
+	return row, nil
+}
+
+// ListSuggestions returns the suggestions with the given status, oldest
+// first. An empty userID lists every user's.
+func (r *SuggestionRepository) ListSuggestions(ctx context.Context, userID, status string) ([]dao.SuggestionRow, error) {
+	query := `SELECT ` + suggestionColumns + ` FROM subscription_suggestions
+	WHERE ($1::uuid IS NULL OR user_id = $1) AND status = $2
+	ORDER BY created_at, id`
+	r.logger.DebugContext(ctx, "Executing ListSuggestions query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+		zap.String("status", status),
+	)
+
+	var owner *string
+	if userID != "" {
+		owner = &userID
+	}
+	rows, err := r.db.QueryContext(ctx, query, owner, status)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list suggestions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list suggestions", err)
+	}
+	defer rows.Close()
+
+	var result []dao.SuggestionRow
+	for rows.Next() {
+		suggestion, err := scanSuggestion(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan suggestion row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan suggestion", err)
+		}
+		result = append(result, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate suggestions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list suggestions", err)
+	}
+	return result, nil
+}
+
+func (r *SuggestionRepository) GetSuggestion(ctx context.Context, id string) (dao.SuggestionRow, error) {
+	query := `SELECT ` + suggestionColumns + ` FROM subscription_suggestions WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing GetSuggestion query",
+		zap.String("sql", query),
+		zap.String("id", id),
+	)
+
+	suggestion, err := scanSuggestion(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			r.logger.WarnContext(ctx, "Suggestion not found in DB", zap.String("id", id))
+			return dao.SuggestionRow{}, apperrors.NewNotFound("suggestion not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to scan/get suggestion from DB", zap.Error(err), zap.String("id", id))
+		return dao.SuggestionRow{}, apperrors.NewInternalServerError("database error on get suggestion", err)
+	}
+	return suggestion, nil
+}
+
+// ResolveSuggestion moves a pending suggestion to status. Only pending
+// suggestions are resolved, so two reviewers racing on the same suggestion
+// cannot both succeed; the loser gets a conflict.
+func (r *SuggestionRepository) ResolveSuggestion(ctx context.Context, id, status string, subscriptionID *uuid.UUID) (dao.SuggestionRow, error) {
+	query := `UPDATE subscription_suggestions SET status = $2, subscription_id = $3, resolved_at = now()
+	WHERE id = $1 AND status = 'pending'
+	RETURNING ` + suggestionColumns
+	r.logger.DebugContext(ctx, "Executing ResolveSuggestion query",
+		zap.String("sql", query),
+		zap.String("id", id),
+		zap.String("status", status),
+	)
+
+	suggestion, err := scanSuggestion(r.db.QueryRowContext(ctx, query, id, status, subscriptionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			r.logger.WarnContext(ctx, "Resolve attempt on suggestion that is not pending", zap.String("id", id))
+			return dao.SuggestionRow{}, apperrors.New(http.StatusConflict, "suggestion is already resolved", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to resolve suggestion", zap.Error(err), zap.String("id", id))
+		return dao.SuggestionRow{}, apperrors.NewInternalServerError("database error on resolve suggestion", err)
+	}
+	return suggestion, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSuggestion(row rowScanner) (dao.SuggestionRow, error) {
+	var s dao.SuggestionRow
+	err := row.Scan(&s.ID, &s.UserID, &s.Source, &s.ServiceName, &s.Price, &s.StartDate, &s.EndDate,
+		&s.Status, &s.SubscriptionID, &s.CreatedAt, &s.ResolvedAt)
+	return s, err
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSuggestionRepo(t *testing.T) (*SuggestionRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewSuggestionRepository(db, logger.NewNopLogger()), mock
+}
+
+var suggestionColumnNames = []string{"id", "user_id", "source", "service_name", "price", "start_date", "end_date", "status", "subscription_id", "created_at", "resolved_at"}
+
+func TestCreateSuggestion(t *testing.T) {
+	row := dao.SuggestionRow{ID: uuid.New(), UserID: uuid.New(), Source: "bank", ServiceName: "Netflix", Price: 999, StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}
+	query := regexp.QuoteMeta(`INSERT INTO subscription_suggestions (id, user_id, source, service_name, price, start_date, end_date)`)
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestSuggestionRepo(t)
+		createdAt := time.Now()
+		mock.ExpectQuery(query).
+			WithArgs(row.ID, row.UserID, row.Source, row.ServiceName, row.Price, row.StartDate, row.EndDate).
+			WillReturnRows(sqlmock.NewRows([]string{"status", "created_at"}).AddRow("pending", createdAt))
+
+		created, err := repo.CreateSuggestion(context.Background(), row)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", created.Status)
+		assert.Equal(t, createdAt, created.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown User", func(t *testing.T) {
+		repo, mock := newTestSuggestionRepo(t)
+		mock.ExpectQuery(query).WillReturnError(&pgconn.PgError{Code: "23503"})
+
+		_, err := repo.CreateSuggestion(context.Background(), row)
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	})
+}
+
+func TestListSuggestions(t *testing.T) {
+	repo, mock := newTestSuggestionRepo(t)
+	userID := uuid.New()
+	subID := uuid.New()
+	resolvedAt := time.Now()
+	mock.ExpectQuery(`WHERE \(\$1::uuid IS NULL OR user_id = \$1\) AND status = \$2`).WithArgs(userID.String(), "accepted").
+		WillReturnRows(sqlmock.NewRows(suggestionColumnNames).
+			AddRow(uuid.New(), userID, "email", "Spotify", 599, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil, "accepted", subID, time.Now(), resolvedAt))
+
+	rows, err := repo.ListSuggestions(context.Background(), userID.String(), "accepted")
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, subID, *rows[0].SubscriptionID)
+	assert.Nil(t, rows[0].EndDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveSuggestion(t *testing.T) {
+	query := regexp.QuoteMeta(`WHERE id = $1 AND status = 'pending'`)
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestSuggestionRepo(t)
+		id, subID := uuid.New(), uuid.New()
+		mock.ExpectQuery(query).WithArgs(id.String(), "merged", &subID).
+			WillReturnRows(sqlmock.NewRows(suggestionColumnNames).
+				AddRow(id, uuid.New(), "bank", "Netflix", 999, time.Now(), nil, "merged", subID, time.Now(), time.Now()))
+
+		row, err := repo.ResolveSuggestion(context.Background(), id.String(), "merged", &subID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "merged", row.Status)
+		assert.NotNil(t, row.ResolvedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Resolved", func(t *testing.T) {
+		repo, mock := newTestSuggestionRepo(t)
+		mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.ResolveSuggestion(context.Background(), uuid.NewString(), "rejected", nil)
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+}
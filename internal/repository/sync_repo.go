@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.uber.org/zap"
+)
+
+type SyncRepositoryInterface interface {
+	ListChanges(ctx context.Context, userID string, since int64, limit int) ([]dao.SubscriptionChangeRow, error)
+	LatestVersion(ctx context.Context, subscriptionID string) (dao.ChangeVersion, bool, error)
+}
+
+type SyncRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewSyncRepository(db *sql.DB, logger logger.Logger) *SyncRepository {
+	return &SyncRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListChanges returns, per subscription, only the newest change after since,
+// ordered by sequence so the last row's seq is a safe cursor for the next page.
+func (r *SyncRepository) ListChanges(ctx context.Context, userID string, since int64, limit int) ([]dao.SubscriptionChangeRow, error) {
+	latest := sq.Select("DISTINCT ON (c.subscription_id) c.seq", "c.subscription_id", "c.user_id", "c.op", "s.service_name", "s.price", "s.start_date", "s.end_date", "s.cost_center", "s.category", "s.billing_period", "s.expense_type").
+		From("subscription_changes c").
+		LeftJoin("subscriptions s ON c.op = 'upsert' AND s.id = c.subscription_id AND s.user_id = c.user_id").
+		Where(sq.Eq{"c.user_id": userID}).
+		Where(sq.Gt{"c.seq": since}).
+		OrderBy("c.subscription_id", "c.seq DESC")
+	queryBuilder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("seq", "subscription_id", "user_id", "op", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type").
+		FromSelect(latest, "latest")
+	queryBuilder, err := paginate(queryBuilder, Page{Limit: limit}, changeSort)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to build SQL query for ListChanges", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("failed to build list changes query", err)
+	}
+	r.logger.DebugContext(ctx, "Executing ListChanges query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+		zap.Int64("since", since),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list subscription changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list changes", err)
+	}
+	defer rows.Close()
+
+	var result []dao.SubscriptionChangeRow
+	for rows.Next() {
+		var c dao.SubscriptionChangeRow
+		if err := rows.Scan(&c.Seq, &c.SubscriptionID, &c.UserID, &c.Op, &c.ServiceName, &c.Price, &c.StartDate, &c.EndDate, &c.CostCenter, &c.Category, &c.BillingPeriod, &c.ExpenseType); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan subscription change row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan change", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate subscription changes", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list changes", err)
+	}
+	return result, nil
+}
+
+// LatestVersion returns the newest changelog entry of a subscription; found is
+// false when the subscription has never been written.
+func (r *SyncRepository) LatestVersion(ctx context.Context, subscriptionID string) (dao.ChangeVersion, bool, error) {
+	query := `SELECT seq, user_id, op, changed_at FROM subscription_changes WHERE subscription_id = $1 ORDER BY seq DESC LIMIT 1`
+	r.logger.DebugContext(ctx, "Executing LatestVersion query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID),
+	)
+
+	var v dao.ChangeVersion
+	err := r.db.QueryRowContext(ctx, query, subscriptionID).Scan(&v.Seq, &v.UserID, &v.Op, &v.ChangedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dao.ChangeVersion{}, false, nil
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to read latest change version", zap.Error(err), zap.String("subscription_id", subscriptionID))
+		return dao.ChangeVersion{}, false, apperrors.NewInternalServerError("database error on latest version", err)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSyncRepo(t *testing.T) (*SyncRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	repo := NewSyncRepository(db, logger.NewNopLogger())
+	return repo, mock
+}
+
+func TestListChanges(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestSyncRepo(t)
+		userID := uuid.New()
+		upserted, deleted := uuid.New(), uuid.New()
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"seq", "subscription_id", "user_id", "op", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type"}).
+			AddRow(int64(11), upserted, userID, dao.ChangeOpUpsert, "Netflix", 999, start, nil, "Marketing", "Streaming", "monthly", "subscription").
+			AddRow(int64(14), deleted, userID, dao.ChangeOpDelete, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		query := regexp.QuoteMeta(`SELECT seq, subscription_id, user_id, op, service_name, price, start_date, end_date, cost_center, category, billing_period, expense_type FROM (SELECT DISTINCT ON (c.subscription_id) c.seq,`) +
+			`.*` + regexp.QuoteMeta(`WHERE c.user_id = $1 AND c.seq > $2 ORDER BY c.subscription_id, c.seq DESC) AS latest ORDER BY seq ASC LIMIT 50`)
+		mock.ExpectQuery(query).WithArgs(userID.String(), int64(10)).WillReturnRows(rows)
+
+		result, err := repo.ListChanges(context.Background(), userID.String(), 10, 50)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "Netflix", *result[0].ServiceName)
+		assert.Equal(t, dao.ChangeOpDelete, result[1].Op)
+		assert.Nil(t, result[1].ServiceName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DB Error", func(t *testing.T) {
+		repo, mock := newTestSyncRepo(t)
+		dbErr := errors.New("connection reset")
+		mock.ExpectQuery(".*").WillReturnError(dbErr)
+
+		_, err := repo.ListChanges(context.Background(), uuid.New().String(), 0, 50)
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusInternalServerError, appErr.Code)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLatestVersion(t *testing.T) {
+	subID := uuid.New().String()
+
+	t.Run("Found", func(t *testing.T) {
+		repo, mock := newTestSyncRepo(t)
+		userID := uuid.New()
+		changedAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`ORDER BY seq DESC LIMIT 1`).WithArgs(subID).
+			WillReturnRows(sqlmock.NewRows([]string{"seq", "user_id", "op", "changed_at"}).AddRow(20, userID, dao.ChangeOpUpsert, changedAt))
+
+		v, found, err := repo.LatestVersion(context.Background(), subID)
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, dao.ChangeVersion{Seq: 20, UserID: userID, Op: dao.ChangeOpUpsert, ChangedAt: changedAt}, v)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Never Written", func(t *testing.T) {
+		repo, mock := newTestSyncRepo(t)
+		mock.ExpectQuery(`ORDER BY seq DESC LIMIT 1`).WithArgs(subID).WillReturnError(sql.ErrNoRows)
+
+		_, found, err := repo.LatestVersion(context.Background(), subID)
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
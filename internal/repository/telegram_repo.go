@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type TelegramRepositoryInterface interface {
+	CreateLinkCode(ctx context.Context, userID uuid.UUID, codeHash string, expiresAt time.Time) error
+	LinkChat(ctx context.Context, codeHash string, chatID int64) (dao.TelegramLinkRow, error)
+	GetLinkByUser(ctx context.Context, userID string) (dao.TelegramLinkRow, error)
+	GetLinkByChat(ctx context.Context, chatID int64) (dao.TelegramLinkRow, error)
+	DeleteLink(ctx context.Context, userID string) error
+}
+
+type TelegramRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewTelegramRepository(db *sql.DB, logger logger.Logger) *TelegramRepository {
+	return &TelegramRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateLinkCode stores a new link code for the user. It replaces any code the
+// user still had, so only the latest one works, and sweeps expired codes.
+func (r *TelegramRepository) CreateLinkCode(ctx context.Context, userID uuid.UUID, codeHash string, expiresAt time.Time) error {
+	query := `WITH replaced AS (
+		DELETE FROM telegram_link_codes WHERE user_id = $1 OR expires_at <= now()
+	)
+	INSERT INTO telegram_link_codes (code_hash, user_id, expires_at) VALUES ($2, $1, $3)`
+	r.logger.DebugContext(ctx, "Executing CreateLinkCode query",
+		zap.String("sql", query),
+		zap.String("user_id", userID.String()),
+	)
+
+	if _, err := r.db.ExecContext(ctx, query, userID, codeHash, expiresAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			r.logger.WarnContext(ctx, "Create link code rejected: user does not exist", zap.String("user_id", userID.String()))
+			return apperrors.NewNotFound("user not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to create link code", zap.Error(err), zap.String("user_id", userID.String()))
+		return apperrors.NewInternalServerError("database error on create link code", err)
+	}
+	return nil
+}
+
+// LinkChat redeems a link code, linking chatID to the code's user. The chat
+// moves over if another user had it linked, and replaces any chat the user
+// had linked before.
+func (r *TelegramRepository) LinkChat(ctx context.Context, codeHash string, chatID int64) (dao.TelegramLinkRow, error) {
+	query := `WITH code AS (
+		DELETE FROM telegram_link_codes WHERE code_hash = $1 AND expires_at > now()
+		RETURNING user_id
+	), moved AS (
+		DELETE FROM telegram_links WHERE chat_id = $2 AND user_id <> (SELECT user_id FROM code)
+	)
+	INSERT INTO telegram_links (user_id, chat_id) SELECT user_id, $2 FROM code
+	ON CONFLICT (user_id) DO UPDATE SET chat_id = EXCLUDED.chat_id, linked_at = now()
+	RETURNING user_id, chat_id, linked_at`
+	r.logger.DebugContext(ctx, "Executing LinkChat query",
+		zap.String("sql", query),
+		zap.Int64("chat_id", chatID),
+	)
+
+	var row dao.TelegramLinkRow
+	err := r.db.QueryRowContext(ctx, query, codeHash, chatID).Scan(&row.UserID, &row.ChatID, &row.LinkedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.WarnContext(ctx, "Link attempt with an unknown or expired code", zap.Int64("chat_id", chatID))
+		return dao.TelegramLinkRow{}, apperrors.NewNotFound("link code not found or expired", err)
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to link chat", zap.Error(err), zap.Int64("chat_id", chatID))
+		return dao.TelegramLinkRow{}, apperrors.NewInternalServerError("database error on link chat", err)
+	}
+	return row, nil
+}
+
+func (r *TelegramRepository) GetLinkByUser(ctx context.Context, userID string) (dao.TelegramLinkRow, error) {
+	return r.getLink(ctx, "user_id", userID)
+}
+
+func (r *TelegramRepository) GetLinkByChat(ctx context.Context, chatID int64) (dao.TelegramLinkRow, error) {
+	return r.getLink(ctx, "chat_id", chatID)
+}
+
+// getLink looks a link up by one of its unique columns; column is never user
+// input.
+func (r *TelegramRepository) getLink(ctx context.Context, column string, value any) (dao.TelegramLinkRow, error) {
+	query := `SELECT user_id, chat_id, linked_at FROM telegram_links WHERE ` + column + ` = $1`
+	r.logger.DebugContext(ctx, "Executing GetLink query", zap.String("sql", query))
+
+	var row dao.TelegramLinkRow
+	if err := r.db.QueryRowContext(ctx, query, value).Scan(&row.UserID, &row.ChatID, &row.LinkedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dao.TelegramLinkRow{}, apperrors.NewNotFound("telegram chat not linked", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to get telegram link", zap.Error(err), zap.String("by", column))
+		return dao.TelegramLinkRow{}, apperrors.NewInternalServerError("database error on get telegram link", err)
+	}
+	return row, nil
+}
+
+func (r *TelegramRepository) DeleteLink(ctx context.Context, userID string) error {
+	query := `DELETE FROM telegram_links WHERE user_id = $1`
+	r.logger.DebugContext(ctx, "Executing DeleteLink query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to delete telegram link", zap.Error(err), zap.String("user_id", userID))
+		return apperrors.NewInternalServerError("database error on delete telegram link", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewInternalServerError("database error on delete telegram link result", err)
+	}
+	if deleted == 0 {
+		return apperrors.NewNotFound("telegram chat not linked", nil)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTelegramRepo(t *testing.T) (*TelegramRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewTelegramRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestCreateLinkCode(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO telegram_link_codes (code_hash, user_id, expires_at) VALUES ($2, $1, $3)`)
+	userID := uuid.New()
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	t.Run("Created", func(t *testing.T) {
+		repo, mock := newTestTelegramRepo(t)
+		mock.ExpectExec(query).WithArgs(userID, "hash", expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.CreateLinkCode(context.Background(), userID, "hash", expiresAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown User", func(t *testing.T) {
+		repo, mock := newTestTelegramRepo(t)
+		mock.ExpectExec(query).WithArgs(userID, "hash", expiresAt).WillReturnError(&pgconn.PgError{Code: "23503"})
+
+		err := repo.CreateLinkCode(context.Background(), userID, "hash", expiresAt)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLinkChat(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO telegram_links (user_id, chat_id) SELECT user_id, $2 FROM code`)
+
+	t.Run("Linked", func(t *testing.T) {
+		repo, mock := newTestTelegramRepo(t)
+		userID, linkedAt := uuid.New(), time.Now()
+		mock.ExpectQuery(query).WithArgs("hash", int64(42)).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "chat_id", "linked_at"}).AddRow(userID, 42, linkedAt))
+
+		row, err := repo.LinkChat(context.Background(), "hash", 42)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, row.UserID)
+		assert.Equal(t, int64(42), row.ChatID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Or Expired Code", func(t *testing.T) {
+		repo, mock := newTestTelegramRepo(t)
+		mock.ExpectQuery(query).WithArgs("hash", int64(42)).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.LinkChat(context.Background(), "hash", 42)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetLinkByChat(t *testing.T) {
+	repo, mock := newTestTelegramRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, chat_id, linked_at FROM telegram_links WHERE chat_id = $1`)).
+		WithArgs(int64(42)).WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetLinkByChat(context.Background(), 42)
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteLink(t *testing.T) {
+	query := regexp.QuoteMeta(`DELETE FROM telegram_links WHERE user_id = $1`)
+	userID := uuid.New().String()
+
+	t.Run("Deleted", func(t *testing.T) {
+		repo, mock := newTestTelegramRepo(t)
+		mock.ExpectExec(query).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.DeleteLink(context.Background(), userID))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Linked", func(t *testing.T) {
+		repo, mock := newTestTelegramRepo(t)
+		mock.ExpectExec(query).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.DeleteLink(context.Background(), userID)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
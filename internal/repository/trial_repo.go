@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type TrialRepositoryInterface interface {
+	GetTrial(ctx context.Context, subscriptionID string) (dao.TrialRow, error)
+	SetTrial(ctx context.Context, row dao.TrialRow) (dao.TrialRow, error)
+	ConvertDueTrials(ctx context.Context, today, now time.Time) ([]dao.ConvertedTrialRow, error)
+}
+
+type TrialRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewTrialRepository(db *sql.DB, logger logger.Logger) *TrialRepository {
+	return &TrialRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TrialRepository) GetTrial(ctx context.Context, subscriptionID string) (dao.TrialRow, error) {
+	query := `SELECT subscription_id, user_id, ends_on, regular_price, regular_billing_period, ended_at, converted
+	FROM subscription_trials WHERE subscription_id = $1`
+	r.logger.DebugContext(ctx, "Executing GetTrial query",
+		zap.String("sql", query),
+		zap.String("subscription_id", subscriptionID),
+	)
+
+	var row dao.TrialRow
+	err := r.db.QueryRowContext(ctx, query, subscriptionID).
+		Scan(&row.SubscriptionID, &row.UserID, &row.EndsOn, &row.RegularPrice, &row.RegularBillingPeriod, &row.EndedAt, &row.Converted)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dao.TrialRow{}, apperrors.NewNotFound("trial not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to get trial", zap.Error(err), zap.String("subscription_id", subscriptionID))
+		return dao.TrialRow{}, apperrors.NewInternalServerError("database error on get trial", err)
+	}
+	return row, nil
+}
+
+// SetTrial starts or replaces the subscription's trial. Replacing an ended
+// trial starts it over.
+func (r *TrialRepository) SetTrial(ctx context.Context, row dao.TrialRow) (dao.TrialRow, error) {
+	query := `INSERT INTO subscription_trials (subscription_id, user_id, ends_on, regular_price, regular_billing_period) VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (subscription_id) DO UPDATE SET ends_on = EXCLUDED.ends_on, regular_price = EXCLUDED.regular_price,
+		regular_billing_period = EXCLUDED.regular_billing_period, ended_at = NULL, converted = FALSE`
+	r.logger.DebugContext(ctx, "Executing SetTrial query",
+		zap.String("sql", query),
+		zap.String("subscription_id", row.SubscriptionID.String()),
+	)
+
+	if _, err := r.db.ExecContext(ctx, query, row.SubscriptionID, row.UserID, row.EndsOn, row.RegularPrice, row.RegularBillingPeriod); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to save trial", zap.Error(err), zap.String("subscription_id", row.SubscriptionID.String()))
+		return dao.TrialRow{}, apperrors.NewInternalServerError("database error on set trial", err)
+	}
+	row.EndedAt = nil
+	row.Converted = false
+	return row, nil
+}
+
+// ConvertDueTrials ends every trial whose last day is before today, in one
+// transaction. A trial whose subscription still runs switches it to the
+// regular price and billing period and queues a subscription.trial_converted
+// webhook; one whose subscription ended during the trial, or was deleted,
+// lapses. Only the converted subscriptions are returned, with their owner's
+// contacts.
+func (r *TrialRepository) ConvertDueTrials(ctx context.Context, today, now time.Time) ([]dao.ConvertedTrialRow, error) {
+	claimQuery := `UPDATE subscription_trials SET ended_at = $1 WHERE ended_at IS NULL AND ends_on < $2
+	RETURNING subscription_id, user_id, ends_on, regular_price, regular_billing_period`
+	convertQuery := `UPDATE subscriptions s SET price = $1, billing_period = $2
+	FROM (SELECT price, billing_period FROM subscriptions WHERE id = $3 AND user_id = $4 FOR UPDATE) trial,
+		users u LEFT JOIN telegram_links tl ON tl.user_id = u.id
+	WHERE s.id = $3 AND s.user_id = $4 AND u.id = s.user_id AND (s.end_date IS NULL OR s.end_date > $5)
+	RETURNING s.id, s.user_id, s.service_name, s.price, s.start_date, s.end_date, s.cost_center, s.category, s.billing_period, s.expense_type,
+		trial.price, trial.billing_period, CASE WHEN u.email LIKE $6 THEN '' ELSE u.email END, tl.chat_id`
+	markQuery := `UPDATE subscription_trials SET converted = TRUE WHERE subscription_id = $1`
+	eventQuery := `SELECT enqueue_subscription_webhook('subscription.trial_converted', s) FROM subscriptions s WHERE s.id = $1 AND s.user_id = $2`
+	r.logger.DebugContext(ctx, "Executing ConvertDueTrials query",
+		zap.String("sql", claimQuery),
+		zap.Time("today", today),
+	)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to begin trial conversion transaction", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on convert trials", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, claimQuery, now, today)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to claim due trials", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on convert trials", err)
+	}
+	var due []dao.TrialRow
+	for rows.Next() {
+		var t dao.TrialRow
+		if err := rows.Scan(&t.SubscriptionID, &t.UserID, &t.EndsOn, &t.RegularPrice, &t.RegularBillingPeriod); err != nil {
+			rows.Close()
+			r.logger.ErrorContext(ctx, "Failed to scan due trial", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan trial", err)
+		}
+		due = append(due, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate due trials", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on convert trials", err)
+	}
+
+	var converted []dao.ConvertedTrialRow
+	for _, t := range due {
+		var c dao.ConvertedTrialRow
+		err := tx.QueryRowContext(ctx, convertQuery, t.RegularPrice, t.RegularBillingPeriod, t.SubscriptionID, t.UserID, t.EndsOn, legacyEmailPattern).
+			Scan(&c.ID, &c.UserID, &c.ServiceName, &c.Price, &c.StartDate, &c.EndDate, &c.CostCenter, &c.Category, &c.BillingPeriod, &c.ExpenseType,
+				&c.TrialPrice, &c.TrialBillingPeriod, &c.Email, &c.TelegramChatID)
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.DebugContext(ctx, "Trial lapsed without converting", zap.String("subscription_id", t.SubscriptionID.String()))
+			continue
+		}
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to convert trial", zap.Error(err), zap.String("subscription_id", t.SubscriptionID.String()))
+			return nil, apperrors.NewInternalServerError("database error on convert trials", err)
+		}
+		if _, err := tx.ExecContext(ctx, markQuery, t.SubscriptionID); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to mark trial converted", zap.Error(err), zap.String("subscription_id", t.SubscriptionID.String()))
+			return nil, apperrors.NewInternalServerError("database error on convert trials", err)
+		}
+		if _, err := tx.ExecContext(ctx, eventQuery, t.SubscriptionID, t.UserID); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to queue trial conversion webhook", zap.Error(err), zap.String("subscription_id", t.SubscriptionID.String()))
+			return nil, apperrors.NewInternalServerError("database error on convert trials", err)
+		}
+		converted = append(converted, c)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to commit trial conversions", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on convert trials", err)
+	}
+	return converted, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTrialRepo(t *testing.T) (*TrialRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewTrialRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestGetTrial(t *testing.T) {
+	t.Run("Not Found", func(t *testing.T) {
+		repo, mock := newTestTrialRepo(t)
+		subID := uuid.New().String()
+		mock.ExpectQuery(`FROM subscription_trials WHERE subscription_id = \$1`).WithArgs(subID).
+			WillReturnRows(sqlmock.NewRows([]string{"subscription_id"}))
+
+		_, err := repo.GetTrial(context.Background(), subID)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSetTrial(t *testing.T) {
+	repo, mock := newTestTrialRepo(t)
+	row := dao.TrialRow{SubscriptionID: uuid.New(), UserID: uuid.New(), EndsOn: time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC), RegularPrice: 999, RegularBillingPeriod: "monthly"}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO subscription_trials (subscription_id, user_id, ends_on, regular_price, regular_billing_period) VALUES ($1, $2, $3, $4, $5)`)).
+		WithArgs(row.SubscriptionID, row.UserID, row.EndsOn, row.RegularPrice, row.RegularBillingPeriod).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	saved, err := repo.SetTrial(context.Background(), row)
+
+	assert.NoError(t, err)
+	assert.Equal(t, row, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConvertDueTrials(t *testing.T) {
+	today := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	now := today.Add(5 * time.Minute)
+	claimQuery := regexp.QuoteMeta(`UPDATE subscription_trials SET ended_at = $1 WHERE ended_at IS NULL AND ends_on < $2`)
+	convertQuery := regexp.QuoteMeta(`UPDATE subscriptions s SET price = $1, billing_period = $2`)
+	trialColumns := []string{"subscription_id", "user_id", "ends_on", "regular_price", "regular_billing_period"}
+	subColumns := []string{"id", "user_id", "service_name", "price", "start_date", "end_date", "cost_center", "category", "billing_period", "expense_type", "trial_price", "trial_billing_period", "email", "chat_id"}
+
+	t.Run("Converts Running And Skips Ended", func(t *testing.T) {
+		repo, mock := newTestTrialRepo(t)
+		running, ended, userID := uuid.New(), uuid.New(), uuid.New()
+		endsOn := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimQuery).WithArgs(now, today).
+			WillReturnRows(sqlmock.NewRows(trialColumns).
+				AddRow(running, userID, endsOn, 999, "monthly").
+				AddRow(ended, userID, endsOn, 499, "monthly"))
+		mock.ExpectQuery(convertQuery).WithArgs(999, "monthly", running, userID, endsOn, legacyEmailPattern).
+			WillReturnRows(sqlmock.NewRows(subColumns).
+				AddRow(running, userID, "Netflix", 999, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), nil, "", "", "monthly", "subscription", 0, "monthly", "user@example.com", nil))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE subscription_trials SET converted = TRUE WHERE subscription_id = $1`)).WithArgs(running).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT enqueue_subscription_webhook('subscription.trial_converted', s)`)).WithArgs(running, userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(convertQuery).WithArgs(499, "monthly", ended, userID, endsOn, legacyEmailPattern).
+			WillReturnRows(sqlmock.NewRows(subColumns))
+		mock.ExpectCommit()
+
+		converted, err := repo.ConvertDueTrials(context.Background(), today, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, converted, 1, "the subscription that ended during its trial lapses")
+		assert.Equal(t, 999, converted[0].Price)
+		assert.Equal(t, 0, converted[0].TrialPrice)
+		assert.Equal(t, "user@example.com", converted[0].Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls Back On Failure", func(t *testing.T) {
+		repo, mock := newTestTrialRepo(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimQuery).WithArgs(now, today).
+			WillReturnRows(sqlmock.NewRows(trialColumns).AddRow(uuid.New(), uuid.New(), today, 999, "monthly"))
+		mock.ExpectQuery(convertQuery).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		_, err := repo.ConvertDueTrials(context.Background(), today, now)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type UserRepositoryInterface interface {
+	CreateUser(ctx context.Context, row dao.UserRow) (dao.UserRow, error)
+	GetUser(ctx context.Context, id string) (dao.UserRow, error)
+	GetUserByEmail(ctx context.Context, email string) (dao.UserRow, error)
+	UpdatePasswordHash(ctx context.Context, id, passwordHash string) error
+}
+
+type UserRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewUserRepository(db *sql.DB, logger logger.Logger) *UserRepository {
+	return &UserRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateUser inserts the user and returns it with its default role and
+// creation time.
+func (r *UserRepository) CreateUser(ctx context.Context, row dao.UserRow) (dao.UserRow, error) {
+	query := `INSERT INTO users (id, email, password_hash) VALUES ($1, $2, $3) RETURNING role, created_at`
+	r.logger.DebugContext(ctx, "Executing CreateUser query",
+		zap.String("sql", query),
+		zap.String("user_id", row.ID.String()),
+	)
+	err := r.db.QueryRowContext(ctx, query, row.ID, row.Email, row.PasswordHash).Scan(&row.Role, &row.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			r.logger.WarnContext(ctx, "Create user conflict: unique constraint violation", zap.Error(err))
+			return dao.UserRow{}, apperrors.New(http.StatusConflict, "user with this email already exists", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to create user in database", zap.Error(err))
+		return dao.UserRow{}, apperrors.NewInternalServerError("database error on create user", err)
+	}
+	return row, nil
+}
+
+func (r *UserRepository) GetUser(ctx context.Context, id string) (dao.UserRow, error) {
+	query := `SELECT id, email, password_hash, role, created_at FROM users WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing GetUser query",
+		zap.String("sql", query),
+		zap.String("user_id", id),
+	)
+	return r.getUser(ctx, query, id)
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (dao.UserRow, error) {
+	query := `SELECT id, email, password_hash, role, created_at FROM users WHERE email = $1`
+	r.logger.DebugContext(ctx, "Executing GetUserByEmail query", zap.String("sql", query))
+	return r.getUser(ctx, query, email)
+}
+
+func (r *UserRepository) getUser(ctx context.Context, query string, arg string) (dao.UserRow, error) {
+	var user dao.UserRow
+	if err := r.db.QueryRowContext(ctx, query, arg).Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			r.logger.WarnContext(ctx, "User not found in DB")
+			return dao.UserRow{}, apperrors.NewNotFound("user not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to scan/get user from DB", zap.Error(err))
+		return dao.UserRow{}, apperrors.NewInternalServerError("database error on get user", err)
+	}
+	return user, nil
+}
+
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1 WHERE id = $2`
+	r.logger.DebugContext(ctx, "Executing UpdatePasswordHash query",
+		zap.String("sql", query),
+		zap.String("user_id", id),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, passwordHash, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to execute password update query", zap.Error(err), zap.String("user_id", id))
+		return apperrors.NewInternalServerError("database error on update password", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to get rows affected after password update", zap.Error(err), zap.String("user_id", id))
+		return apperrors.NewInternalServerError("database error on update password result", err)
+	}
+
+	if rowsAffected == 0 {
+		r.logger.WarnContext(ctx, "Password update attempt on non-existent user", zap.String("user_id", id))
+		return apperrors.NewNotFound("user not found", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestUserRepo(t *testing.T) (*UserRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewUserRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestCreateUser(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO users (id, email, password_hash) VALUES ($1, $2, $3) RETURNING role, created_at`)
+	row := dao.UserRow{ID: uuid.New(), Email: "alice@example.com", PasswordHash: "hash"}
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock := newTestUserRepo(t)
+		createdAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(query).WithArgs(row.ID, row.Email, row.PasswordHash).
+			WillReturnRows(sqlmock.NewRows([]string{"role", "created_at"}).AddRow("user", createdAt))
+
+		created, err := repo.CreateUser(context.Background(), row)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user", created.Role)
+		assert.Equal(t, createdAt, created.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Conflict on Duplicate Email", func(t *testing.T) {
+		repo, mock := newTestUserRepo(t)
+		mock.ExpectQuery(query).WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		_, err := repo.CreateUser(context.Background(), row)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+}
+
+func TestGetUserByEmail_NotFound(t *testing.T) {
+	repo, mock := newTestUserRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, email, password_hash, role, created_at FROM users WHERE email = $1`)).
+		WithArgs("bob@example.com").WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetUserByEmail(context.Background(), "bob@example.com")
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
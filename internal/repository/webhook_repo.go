@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+type WebhookRepositoryInterface interface {
+	CreateWebhook(ctx context.Context, row dao.WebhookRow) (dao.WebhookRow, error)
+	ListWebhooks(ctx context.Context, userID string) ([]dao.WebhookRow, error)
+	GetWebhook(ctx context.Context, id string) (dao.WebhookRow, error)
+	UpdateWebhook(ctx context.Context, row dao.WebhookRow) (dao.WebhookRow, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, webhookID string, limit int) ([]dao.WebhookDeliveryRow, error)
+	CountDeliveries(ctx context.Context, webhookID string) ([]dao.WebhookDeliveryCountRow, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]dao.DueDeliveryRow, error)
+	RecordAttempt(ctx context.Context, row dao.WebhookAttemptRow) error
+}
+
+type WebhookRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewWebhookRepository(db *sql.DB, logger logger.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// events are read back with array_to_string; event names contain no commas.
+const webhookColumns = `id, user_id, url, secret, array_to_string(events, ','), created_at`
+
+func scanWebhook(scan func(dest ...any) error) (dao.WebhookRow, error) {
+	var row dao.WebhookRow
+	var events string
+	if err := scan(&row.ID, &row.UserID, &row.URL, &row.Secret, &events, &row.CreatedAt); err != nil {
+		return dao.WebhookRow{}, err
+	}
+	row.Events = strings.Split(events, ",")
+	return row, nil
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, row dao.WebhookRow) (dao.WebhookRow, error) {
+	query := `INSERT INTO webhooks (id, user_id, url, secret, events) VALUES ($1, $2, $3, $4, $5::text[])
+	RETURNING ` + webhookColumns
+	r.logger.DebugContext(ctx, "Executing CreateWebhook query",
+		zap.String("sql", query),
+		zap.String("webhook_id", row.ID.String()),
+		zap.String("user_id", row.UserID.String()),
+	)
+
+	created, err := scanWebhook(r.db.QueryRowContext(ctx, query, row.ID, row.UserID, row.URL, row.Secret, row.Events).Scan)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			r.logger.WarnContext(ctx, "Create webhook rejected: user does not exist", zap.String("user_id", row.UserID.String()))
+			return dao.WebhookRow{}, apperrors.NewBadRequest("user does not exist", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to create webhook", zap.Error(err))
+		return dao.WebhookRow{}, apperrors.NewInternalServerError("database error on create webhook", err)
+	}
+	return created, nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context, userID string) ([]dao.WebhookRow, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE user_id = $1 ORDER BY created_at, id`
+	r.logger.DebugContext(ctx, "Executing ListWebhooks query",
+		zap.String("sql", query),
+		zap.String("user_id", userID),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list webhooks", zap.Error(err), zap.String("user_id", userID))
+		return nil, apperrors.NewInternalServerError("database error on list webhooks", err)
+	}
+	defer rows.Close()
+
+	var result []dao.WebhookRow
+	for rows.Next() {
+		row, err := scanWebhook(rows.Scan)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan webhook row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan webhook", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate webhooks", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list webhooks", err)
+	}
+	return result, nil
+}
+
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id string) (dao.WebhookRow, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing GetWebhook query",
+		zap.String("sql", query),
+		zap.String("webhook_id", id),
+	)
+
+	row, err := scanWebhook(r.db.QueryRowContext(ctx, query, id).Scan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dao.WebhookRow{}, apperrors.NewNotFound("webhook not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to get webhook", zap.Error(err), zap.String("webhook_id", id))
+		return dao.WebhookRow{}, apperrors.NewInternalServerError("database error on get webhook", err)
+	}
+	return row, nil
+}
+
+// UpdateWebhook overwrites the webhook's URL, secret and events. Deliveries
+// already queued keep their event and are signed with the secret current when
+// they are sent.
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, row dao.WebhookRow) (dao.WebhookRow, error) {
+	query := `UPDATE webhooks SET url = $2, secret = $3, events = $4::text[] WHERE id = $1
+	RETURNING ` + webhookColumns
+	r.logger.DebugContext(ctx, "Executing UpdateWebhook query",
+		zap.String("sql", query),
+		zap.String("webhook_id", row.ID.String()),
+	)
+
+	updated, err := scanWebhook(r.db.QueryRowContext(ctx, query, row.ID, row.URL, row.Secret, row.Events).Scan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dao.WebhookRow{}, apperrors.NewNotFound("webhook to update not found", err)
+		}
+		r.logger.ErrorContext(ctx, "Failed to update webhook", zap.Error(err), zap.String("webhook_id", row.ID.String()))
+		return dao.WebhookRow{}, apperrors.NewInternalServerError("database error on update webhook", err)
+	}
+	return updated, nil
+}
+
+// DeleteWebhook removes the webhook together with its delivery history.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	query := `DELETE FROM webhooks WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing DeleteWebhook query",
+		zap.String("sql", query),
+		zap.String("webhook_id", id),
+	)
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to delete webhook", zap.Error(err), zap.String("webhook_id", id))
+		return apperrors.NewInternalServerError("database error on delete webhook", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewInternalServerError("database error on delete webhook result", err)
+	}
+	if deleted == 0 {
+		return apperrors.NewNotFound("webhook to delete not found", nil)
+	}
+	return nil
+}
+
+// ListDeliveries returns the webhook's most recent deliveries, newest first.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]dao.WebhookDeliveryRow, error) {
+	queryBuilder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("id", "webhook_id", "event", "sequence", "status", "attempts", "response_status", "last_error", "next_attempt_at", "created_at", "delivered_at").
+		From("webhook_deliveries").
+		Where(sq.Eq{"webhook_id": webhookID})
+	queryBuilder, err := paginate(queryBuilder, Page{Limit: limit}, webhookDeliverySort)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to build SQL query for ListDeliveries", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("failed to build list webhook deliveries query", err)
+	}
+	r.logger.DebugContext(ctx, "Executing ListDeliveries query",
+		zap.String("sql", query),
+		zap.String("webhook_id", webhookID),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to list webhook deliveries", zap.Error(err), zap.String("webhook_id", webhookID))
+		return nil, apperrors.NewInternalServerError("database error on list webhook deliveries", err)
+	}
+	defer rows.Close()
+
+	var result []dao.WebhookDeliveryRow
+	for rows.Next() {
+		var d dao.WebhookDeliveryRow
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Sequence, &d.Status, &d.Attempts, &d.ResponseStatus, &d.LastError, &d.NextAttemptAt, &d.CreatedAt, &d.DeliveredAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan webhook delivery row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan webhook delivery", err)
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate webhook deliveries", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on list webhook deliveries", err)
+	}
+	return result, nil
+}
+
+// CountDeliveries counts the webhook's deliveries by event and status.
+func (r *WebhookRepository) CountDeliveries(ctx context.Context, webhookID string) ([]dao.WebhookDeliveryCountRow, error) {
+	query := `SELECT event, status, COUNT(*), MAX(delivered_at) FROM webhook_deliveries
+	WHERE webhook_id = $1 GROUP BY event, status ORDER BY event, status`
+	r.logger.DebugContext(ctx, "Executing CountDeliveries query",
+		zap.String("sql", query),
+		zap.String("webhook_id", webhookID),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, webhookID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to count webhook deliveries", zap.Error(err), zap.String("webhook_id", webhookID))
+		return nil, apperrors.NewInternalServerError("database error on count webhook deliveries", err)
+	}
+	defer rows.Close()
+
+	var result []dao.WebhookDeliveryCountRow
+	for rows.Next() {
+		var c dao.WebhookDeliveryCountRow
+		if err := rows.Scan(&c.Event, &c.Status, &c.Count, &c.LastDeliveredAt); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan webhook delivery count row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan webhook delivery count", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate webhook delivery counts", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on count webhook deliveries", err)
+	}
+	return result, nil
+}
+
+// ClaimDueDeliveries takes up to limit pending deliveries whose next attempt
+// is due, counting the attempt and pushing next_attempt_at out by lease so no
+// other instance picks them up meanwhile. A claim whose outcome is never
+// recorded, e.g. after a crash, is retried once the lease runs out.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]dao.DueDeliveryRow, error) {
+	query := `WITH due AS (
+		SELECT id FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= now()
+		ORDER BY next_attempt_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	UPDATE webhook_deliveries d
+	SET attempts = d.attempts + 1, next_attempt_at = now() + $2 * interval '1 millisecond'
+	FROM due, webhooks w
+	WHERE d.id = due.id AND w.id = d.webhook_id
+	RETURNING d.id, d.event, d.sequence, d.payload, d.attempts, w.url, w.secret`
+	r.logger.DebugContext(ctx, "Executing ClaimDueDeliveries query", zap.String("sql", query))
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to claim webhook deliveries", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on claim webhook deliveries", err)
+	}
+	defer rows.Close()
+
+	var result []dao.DueDeliveryRow
+	for rows.Next() {
+		var d dao.DueDeliveryRow
+		if err := rows.Scan(&d.ID, &d.Event, &d.Sequence, &d.Payload, &d.Attempts, &d.URL, &d.Secret); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to scan claimed delivery row", zap.Error(err))
+			return nil, apperrors.NewInternalServerError("database error on scan claimed delivery", err)
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to iterate claimed deliveries", zap.Error(err))
+		return nil, apperrors.NewInternalServerError("database error on claim webhook deliveries", err)
+	}
+	return result, nil
+}
+
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, row dao.WebhookAttemptRow) error {
+	query := `UPDATE webhook_deliveries
+	SET status = $2, response_status = $3, last_error = $4, next_attempt_at = $5,
+		delivered_at = CASE WHEN $2 = 'delivered' THEN now() END
+	WHERE id = $1`
+	r.logger.DebugContext(ctx, "Executing RecordAttempt query",
+		zap.String("sql", query),
+		zap.String("delivery_id", row.ID.String()),
+		zap.String("status", row.Status),
+	)
+
+	if _, err := r.db.ExecContext(ctx, query, row.ID, row.Status, row.ResponseStatus, row.LastError, row.NextAttemptAt); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to record webhook attempt", zap.Error(err), zap.String("delivery_id", row.ID.String()))
+		return apperrors.NewInternalServerError("database error on record webhook attempt", err)
+	}
+	return nil
+}
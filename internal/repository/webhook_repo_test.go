@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWebhookRepo(t *testing.T) (*WebhookRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(passthroughConverter{}))
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return NewWebhookRepository(db, logger.NewNopLogger()), mock
+}
+
+func TestCreateWebhook(t *testing.T) {
+	query := regexp.QuoteMeta(`INSERT INTO webhooks (id, user_id, url, secret, events) VALUES ($1, $2, $3, $4, $5::text[])`)
+	row := dao.WebhookRow{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		URL:    "https://example.com/hook",
+		Secret: "secret",
+		Events: []string{"subscription.created", "subscription.deleted"},
+	}
+
+	t.Run("Created", func(t *testing.T) {
+		repo, mock := newTestWebhookRepo(t)
+		createdAt := time.Now()
+		mock.ExpectQuery(query).WithArgs(row.ID, row.UserID, row.URL, row.Secret, row.Events).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "url", "secret", "events", "created_at"}).
+				AddRow(row.ID, row.UserID, row.URL, row.Secret, "subscription.created,subscription.deleted", createdAt))
+
+		created, err := repo.CreateWebhook(context.Background(), row)
+
+		assert.NoError(t, err)
+		assert.Equal(t, row.Events, created.Events)
+		assert.Equal(t, createdAt, created.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown User", func(t *testing.T) {
+		repo, mock := newTestWebhookRepo(t)
+		mock.ExpectQuery(query).WillReturnError(&pgconn.PgError{Code: "23503"})
+
+		_, err := repo.CreateWebhook(context.Background(), row)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetWebhookNotFound(t *testing.T) {
+	repo, mock := newTestWebhookRepo(t)
+	id := uuid.New().String()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM webhooks WHERE id = $1`)).WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.GetWebhook(context.Background(), id)
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	query := regexp.QuoteMeta(`UPDATE webhooks SET url = $2, secret = $3, events = $4::text[] WHERE id = $1`)
+	row := dao.WebhookRow{ID: uuid.New(), URL: "https://example.com/hook", Secret: "rotated", Events: []string{"subscription.deleted"}}
+
+	t.Run("Updated", func(t *testing.T) {
+		repo, mock := newTestWebhookRepo(t)
+		mock.ExpectQuery(query).WithArgs(row.ID, row.URL, row.Secret, row.Events).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "url", "secret", "events", "created_at"}).
+				AddRow(row.ID, uuid.New(), row.URL, row.Secret, "subscription.deleted", time.Now()))
+
+		updated, err := repo.UpdateWebhook(context.Background(), row)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "rotated", updated.Secret)
+		assert.Equal(t, row.Events, updated.Events)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		repo, mock := newTestWebhookRepo(t)
+		mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.UpdateWebhook(context.Background(), row)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListDeliveries(t *testing.T) {
+	repo, mock := newTestWebhookRepo(t)
+	webhookID, deliveryID := uuid.New(), uuid.New()
+	createdAt := time.Date(2025, 7, 1, 10, 2, 30, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY created_at DESC, id ASC LIMIT 20`)).
+		WithArgs(webhookID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event", "sequence", "status", "attempts", "response_status", "last_error", "next_attempt_at", "created_at", "delivered_at"}).
+			AddRow(deliveryID, webhookID, "subscription.created", int64(1), "pending", 0, nil, "", createdAt, createdAt, nil))
+
+	rows, err := repo.ListDeliveries(context.Background(), webhookID.String(), 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, deliveryID, rows[0].ID)
+	assert.Nil(t, rows[0].DeliveredAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountDeliveries(t *testing.T) {
+	repo, mock := newTestWebhookRepo(t)
+	id := uuid.New().String()
+	deliveredAt := time.Date(2025, 7, 1, 10, 2, 31, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT event, status, COUNT(*), MAX(delivered_at) FROM webhook_deliveries
+	WHERE webhook_id = $1 GROUP BY event, status`)).WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"event", "status", "count", "last_delivered_at"}).
+			AddRow("subscription.created", "delivered", 4, deliveredAt).
+			AddRow("subscription.created", "failed", 1, nil))
+
+	rows, err := repo.CountDeliveries(context.Background(), id)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, deliveredAt, *rows[0].LastDeliveredAt)
+	assert.Nil(t, rows[1].LastDeliveredAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDueDeliveries(t *testing.T) {
+	repo, mock := newTestWebhookRepo(t)
+	id := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).WithArgs(50, int64(60000)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event", "sequence", "payload", "attempts", "url", "secret"}).
+			AddRow(id, "subscription.updated", int64(4), []byte(`{}`), 1, "https://example.com/hook", "secret"))
+
+	rows, err := repo.ClaimDueDeliveries(context.Background(), 50, time.Minute)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, id, rows[0].ID)
+	assert.Equal(t, 1, rows[0].Attempts)
+	assert.Equal(t, int64(4), rows[0].Sequence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordAttempt(t *testing.T) {
+	repo, mock := newTestWebhookRepo(t)
+	status := 503
+	row := dao.WebhookAttemptRow{ID: uuid.New(), Status: "pending", ResponseStatus: &status, LastError: "receiver answered 503", NextAttemptAt: time.Now()}
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE webhook_deliveries`)).
+		WithArgs(row.ID, "pending", &status, row.LastError, row.NextAttemptAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.RecordAttempt(context.Background(), row))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// auditTrail records changes in the audit log. A change is committed by the
+// time it is recorded, so a failed write is logged instead of failing the
+// request. A nil repo disables recording.
+type auditTrail struct {
+	repo   repository.AuditRepositoryInterface
+	clock  clock.Clock
+	logger logger.Logger
+}
+
+func (a auditTrail) enabled() bool {
+	return a.repo != nil
+}
+
+// subscription records the change from before to after; before is nil for a
+// created or restored subscription and after is nil for a deleted one. An
+// update that changed nothing is not recorded.
+func (a auditTrail) subscription(ctx context.Context, action domain.AuditAction, before, after *domain.Subscription) {
+	if !a.enabled() {
+		return
+	}
+	changes := domain.DiffSubscriptions(before, after)
+	if len(changes) == 0 {
+		return
+	}
+	sub := after
+	if sub == nil {
+		sub = before
+	}
+	entry := domain.AuditEntry{
+		EntityType: domain.AuditEntitySubscription,
+		EntityID:   sub.ID,
+		OwnerID:    sub.UserID,
+		Action:     action,
+		Changes:    changes,
+		ChangedAt:  a.clock.Now().UTC(),
+	}
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		entry.ActorID = &principal.UserID
+	}
+	if err := a.repo.CreateEntry(ctx, mapper.ToAuditDAO(entry)); err != nil {
+		a.logger.ErrorContext(ctx, "Failed to record audit entry",
+			zap.String("subscription_id", sub.ID.String()),
+			zap.String("action", string(action)),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	ErrCodeInvalidCredentials = "invalid_credentials"
+	ErrCodeInvalidToken       = "invalid_token"
+
+	tokenIssuer = "subtracker"
+)
+
+// tokenClaims carries the role so requests need no user lookup. A role change
+// takes effect once the user's current token expires.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Role domain.Role `json:"role,omitempty"`
+}
+
+type AuthServiceInterface interface {
+	Login(ctx context.Context, email, password string) (domain.AccessToken, error)
+	Authenticate(token string) (domain.Principal, error)
+}
+
+// AuthService issues and verifies HS256 access tokens whose subject is the
+// user ID.
+type AuthService struct {
+	users  repository.UserRepositoryInterface
+	secret []byte
+	ttl    time.Duration
+	logger logger.Logger
+	clock  clock.Clock
+}
+
+func NewAuthService(users repository.UserRepositoryInterface, secret string, ttl time.Duration, clock clock.Clock, logger logger.Logger) *AuthService {
+	return &AuthService{
+		users:  users,
+		secret: []byte(secret),
+		ttl:    ttl,
+		logger: logger,
+		clock:  clock,
+	}
+}
+
+// Login exchanges an account's email and password for a token acting as that
+// user. An unknown email and a wrong password are indistinguishable.
+func (s *AuthService) Login(ctx context.Context, email, password string) (domain.AccessToken, error) {
+	user, err := s.users.GetUserByEmail(ctx, normalizeEmail(email))
+	found := err == nil
+	if !found && !isNotFound(err) {
+		return domain.AccessToken{}, err
+	}
+	hash := user.PasswordHash
+	if !found {
+		hash = string(dummyPasswordHash)
+	}
+	if !checkPassword(hash, password) || !found {
+		s.logger.WarnContext(ctx, "Login rejected")
+		return domain.AccessToken{}, apperrors.New(http.StatusUnauthorized, "invalid credentials", nil).
+			WithErrorCode(ErrCodeInvalidCredentials)
+	}
+	userID := user.ID.String()
+
+	now := s.clock.Now()
+	expiresAt := now.Add(s.ttl)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: domain.Role(user.Role),
+	}).SignedString(s.secret)
+	if err != nil {
+		return domain.AccessToken{}, apperrors.NewInternalServerError("failed to sign token", err)
+	}
+	s.logger.InfoContext(ctx, "Access token issued", zap.String("user_id", userID), zap.Time("expires_at", expiresAt))
+	return domain.AccessToken{UserID: user.ID, Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// Authenticate verifies a token and returns the user it was issued to.
+func (s *AuthService) Authenticate(token string) (domain.Principal, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.clock.Now),
+	)
+	if err != nil {
+		return domain.Principal{}, invalidToken(err)
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return domain.Principal{}, invalidToken(err)
+	}
+	role := claims.Role
+	if role != domain.RoleAdmin {
+		role = domain.RoleUser
+	}
+	return domain.Principal{UserID: userID, Role: role}, nil
+}
+
+func invalidToken(err error) error {
+	return apperrors.New(http.StatusUnauthorized, "invalid or expired token", err).WithErrorCode(ErrCodeInvalidToken)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthService(t *testing.T) {
+	secret := "0123456789abcdef0123456789abcdef"
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	hash, err := hashPassword("correct horse battery")
+	assert.NoError(t, err)
+	user := dao.UserRow{ID: userID, Email: "alice@example.com", PasswordHash: hash, Role: "admin"}
+
+	newService := func() *AuthService {
+		users := new(mocks.UserRepositoryInterface)
+		users.On("GetUserByEmail", mock.Anything, "alice@example.com").Return(user, nil).Maybe()
+		users.On("GetUserByEmail", mock.Anything, mock.Anything).Return(dao.UserRow{}, apperrors.NewNotFound("user not found", nil)).Maybe()
+		service := NewAuthService(users, secret, time.Hour, clock.NewFrozen(now), logger.NewNopLogger())
+		return service
+	}
+
+	t.Run("Login And Authenticate", func(t *testing.T) {
+		service := newService()
+
+		token, err := service.Login(context.Background(), " Alice@Example.com", "correct horse battery")
+		assert.NoError(t, err)
+		assert.Equal(t, userID, token.UserID)
+		assert.Equal(t, now.Add(time.Hour), token.ExpiresAt)
+
+		got, err := service.Authenticate(token.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Principal{UserID: userID, Role: domain.RoleAdmin}, got)
+	})
+
+	for _, tc := range []struct{ name, email, password string }{
+		{"Wrong Password", "alice@example.com", "guess"},
+		{"Unknown Email", "bob@example.com", "correct horse battery"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newService().Login(context.Background(), tc.email, tc.password)
+
+			var appErr *apperrors.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusUnauthorized, appErr.Code)
+			assert.Equal(t, ErrCodeInvalidCredentials, appErr.ErrorCode)
+		})
+	}
+
+	t.Run("Expired Token", func(t *testing.T) {
+		service := newService()
+		token, err := service.Login(context.Background(), "alice@example.com", "correct horse battery")
+		assert.NoError(t, err)
+
+		service.clock = clock.NewFrozen(now.Add(2 * time.Hour))
+		_, err = service.Authenticate(token.Token)
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, ErrCodeInvalidToken, appErr.ErrorCode)
+	})
+
+	t.Run("Foreign Signature", func(t *testing.T) {
+		forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   userID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		}).SignedString([]byte("another-secret-another-secret-xx"))
+		assert.NoError(t, err)
+
+		_, err = newService().Authenticate(forged)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unsigned Token", func(t *testing.T) {
+		unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   userID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		assert.NoError(t, err)
+
+		_, err = newService().Authenticate(unsigned)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"subtracker/internal/domain"
+	"subtracker/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+type principalKey struct{}
+
+// WithPrincipal attaches the authenticated caller to ctx. Services enforce
+// ownership only for contexts that carry one; without authentication every
+// caller is trusted as before.
+func WithPrincipal(ctx context.Context, principal domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(domain.Principal)
+	return principal, ok
+}
+
+// ScopeUserID resolves the user an operation acts on. A regular user always
+// acts on themself and naming anyone else is forbidden. An admin acts on the
+// requested user, where an empty ID means every user.
+func ScopeUserID(ctx context.Context, requested string) (string, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return requested, nil
+	}
+	if requested != "" && requested != principal.UserID.String() {
+		return "", apperrors.New(http.StatusForbidden, "user_id does not match the authenticated user", nil)
+	}
+	return principal.UserID.String(), nil
+}
+
+// authorizeOwner hides resources of other users from a regular user by
+// reporting them as not found.
+func authorizeOwner(ctx context.Context, owner uuid.UUID, resource string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if ok && !principal.IsAdmin() && principal.UserID != owner {
+		return apperrors.NewNotFound(resource+" not found", nil)
+	}
+	return nil
+}
+
+// authorizeAdmin limits an operation that reaches every user, such as a
+// global rule, to admins.
+func authorizeAdmin(ctx context.Context, operation string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if ok && !principal.IsAdmin() {
+		return apperrors.New(http.StatusForbidden, operation+" requires an admin", nil)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// flagNamePattern keeps flag names short identifiers such as sql_cost_aggregation.
+var flagNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+type FeatureFlagServiceInterface interface {
+	ListFlags(ctx context.Context) ([]domain.FeatureFlag, error)
+	SetFlag(ctx context.Context, flag domain.FeatureFlag) (domain.FeatureFlag, error)
+	DeleteFlag(ctx context.Context, name string) error
+	Enabled(ctx context.Context, name string) bool
+}
+
+// FeatureFlagService manages feature flags and answers whether one is on.
+// Answers come from an in-memory copy of every flag that is read again once
+// it is older than refresh, or as soon as this instance changes a flag.
+type FeatureFlagService struct {
+	repo        repository.FeatureFlagRepositoryInterface
+	environment string
+	refresh     time.Duration
+	clock       clock.Clock
+	logger      logger.Logger
+
+	mu       sync.RWMutex
+	flags    map[string]domain.FeatureFlag
+	loadedAt time.Time
+}
+
+func NewFeatureFlagService(repo repository.FeatureFlagRepositoryInterface, environment string, refresh time.Duration, clock clock.Clock, logger logger.Logger) *FeatureFlagService {
+	return &FeatureFlagService{
+		repo:        repo,
+		environment: environment,
+		refresh:     refresh,
+		clock:       clock,
+		logger:      logger,
+	}
+}
+
+func (s *FeatureFlagService) ListFlags(ctx context.Context) ([]domain.FeatureFlag, error) {
+	s.logger.DebugContext(ctx, "Entering ListFlags service")
+
+	rows, err := s.repo.ListFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flags := make([]domain.FeatureFlag, len(rows))
+	for i, row := range rows {
+		flags[i] = mapper.ToFeatureFlagFromDAO(row)
+	}
+	return flags, nil
+}
+
+// SetFlag creates or overwrites the flag named flag.Name.
+func (s *FeatureFlagService) SetFlag(ctx context.Context, flag domain.FeatureFlag) (domain.FeatureFlag, error) {
+	s.logger.DebugContext(ctx, "Entering SetFlag service", zap.String("flag", flag.Name))
+
+	if !flagNamePattern.MatchString(flag.Name) {
+		return domain.FeatureFlag{}, apperrors.NewBadRequest("flag name must be 1 to 64 lowercase letters, digits or underscores, starting with a letter", nil)
+	}
+	row, err := s.repo.SetFeatureFlag(ctx, mapper.ToFeatureFlagDAO(flag))
+	if err != nil {
+		return domain.FeatureFlag{}, err
+	}
+	s.invalidate()
+
+	saved := mapper.ToFeatureFlagFromDAO(row)
+	s.logger.InfoContext(ctx, "Feature flag set",
+		zap.String("flag", saved.Name),
+		zap.Bool("enabled", saved.Enabled),
+		zap.Strings("environments", saved.Environments),
+		zap.Int("rollout_percent", saved.RolloutPercent),
+	)
+	return saved, nil
+}
+
+// DeleteFlag removes the flag, which turns it off everywhere.
+func (s *FeatureFlagService) DeleteFlag(ctx context.Context, name string) error {
+	s.logger.DebugContext(ctx, "Entering DeleteFlag service", zap.String("flag", name))
+
+	if err := s.repo.DeleteFeatureFlag(ctx, name); err != nil {
+		return err
+	}
+	s.invalidate()
+	s.logger.InfoContext(ctx, "Feature flag deleted", zap.String("flag", name))
+	return nil
+}
+
+// Enabled reports whether the flag is on in this environment for the caller
+// in ctx. Unknown flags are off, and so is every flag until the first read of
+// the flags succeeds.
+func (s *FeatureFlagService) Enabled(ctx context.Context, name string) bool {
+	flag, ok := s.cached(ctx)[name]
+	if !ok {
+		return false
+	}
+	var userID string
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		userID = principal.UserID.String()
+	}
+	return flag.Allows(s.environment, userID)
+}
+
+// cached returns the in-memory flags, reading them again once they are stale.
+// A failed read keeps serving the previous flags and is retried after refresh.
+func (s *FeatureFlagService) cached(ctx context.Context) map[string]domain.FeatureFlag {
+	s.mu.RLock()
+	flags, fresh := s.flags, s.fresh()
+	s.mu.RUnlock()
+	if fresh {
+		return flags
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fresh() {
+		return s.flags
+	}
+	s.loadedAt = s.clock.Now()
+
+	rows, err := s.repo.ListFeatureFlags(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to refresh feature flags, serving the previous ones", zap.Error(err))
+		return s.flags
+	}
+	s.flags = make(map[string]domain.FeatureFlag, len(rows))
+	for _, row := range rows {
+		s.flags[row.Name] = mapper.ToFeatureFlagFromDAO(row)
+	}
+	return s.flags
+}
+
+func (s *FeatureFlagService) fresh() bool {
+	return !s.loadedAt.IsZero() && s.clock.Now().Sub(s.loadedAt) < s.refresh
+}
+
+func (s *FeatureFlagService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
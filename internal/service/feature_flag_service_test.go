@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFeatureFlagService_Enabled(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	setup := func() (*FeatureFlagService, *mocks.FeatureFlagRepositoryInterface, *clock.Frozen) {
+		repo := new(mocks.FeatureFlagRepositoryInterface)
+		clk := clock.NewFrozen(now)
+		return NewFeatureFlagService(repo, "staging", 30*time.Second, clk, logger.NewNopLogger()), repo, clk
+	}
+	asUser := func(id uuid.UUID) context.Context {
+		return WithPrincipal(context.Background(), domain.Principal{UserID: id, Role: domain.RoleUser})
+	}
+
+	t.Run("Environments And Enabled Switch", func(t *testing.T) {
+		s, repo, _ := setup()
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{
+			{Name: "everywhere", Enabled: true, Environments: []string{}, RolloutPercent: 100},
+			{Name: "staging_only", Enabled: true, Environments: []string{"staging"}, RolloutPercent: 100},
+			{Name: "production_only", Enabled: true, Environments: []string{"production"}, RolloutPercent: 100},
+			{Name: "switched_off", Enabled: false, Environments: []string{}, RolloutPercent: 100},
+		}, nil).Once()
+
+		ctx := context.Background()
+		assert.True(t, s.Enabled(ctx, "everywhere"))
+		assert.True(t, s.Enabled(ctx, "staging_only"))
+		assert.False(t, s.Enabled(ctx, "production_only"))
+		assert.False(t, s.Enabled(ctx, "switched_off"))
+		assert.False(t, s.Enabled(ctx, "unknown"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Rollout Grows Without Dropping Users", func(t *testing.T) {
+		s, repo, clk := setup()
+		flag := dao.FeatureFlagRow{Name: "sql_cost_aggregation", Enabled: true, Environments: []string{}, RolloutPercent: 30}
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{flag}, nil).Once()
+
+		users := make([]uuid.UUID, 200)
+		before := make(map[uuid.UUID]bool)
+		for i := range users {
+			users[i] = uuid.New()
+			before[users[i]] = s.Enabled(asUser(users[i]), flag.Name)
+		}
+		assert.False(t, s.Enabled(context.Background(), flag.Name), "a partial rollout needs a user")
+
+		flag.RolloutPercent = 60
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{flag}, nil).Once()
+		clk.Advance(30 * time.Second)
+
+		var inBefore, inAfter int
+		for _, id := range users {
+			after := s.Enabled(asUser(id), flag.Name)
+			if before[id] {
+				inBefore++
+				assert.True(t, after, "user %s dropped out of the rollout", id)
+			}
+			if after {
+				inAfter++
+			}
+		}
+		assert.Greater(t, inBefore, 0)
+		assert.Greater(t, inAfter, inBefore)
+		assert.Less(t, inAfter, len(users))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Served From Memory Until Stale", func(t *testing.T) {
+		s, repo, clk := setup()
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{
+			{Name: "new_importer", Enabled: true, Environments: []string{}, RolloutPercent: 100},
+		}, nil).Once()
+
+		assert.True(t, s.Enabled(context.Background(), "new_importer"))
+		clk.Advance(29 * time.Second)
+		assert.True(t, s.Enabled(context.Background(), "new_importer"))
+		repo.AssertNumberOfCalls(t, "ListFeatureFlags", 1)
+
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{}, nil).Once()
+		clk.Advance(time.Second)
+		assert.False(t, s.Enabled(context.Background(), "new_importer"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Refresh Keeps Previous Flags", func(t *testing.T) {
+		s, repo, clk := setup()
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{
+			{Name: "new_importer", Enabled: true, Environments: []string{}, RolloutPercent: 100},
+		}, nil).Once()
+		assert.True(t, s.Enabled(context.Background(), "new_importer"))
+
+		repo.On("ListFeatureFlags", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+		clk.Advance(time.Minute)
+		assert.True(t, s.Enabled(context.Background(), "new_importer"))
+		assert.True(t, s.Enabled(context.Background(), "new_importer"), "the failed read is not retried before refresh")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestFeatureFlagService_SetFlag(t *testing.T) {
+	t.Run("Invalidates The Cache", func(t *testing.T) {
+		repo := new(mocks.FeatureFlagRepositoryInterface)
+		s := NewFeatureFlagService(repo, "production", time.Hour, clock.NewFrozen(time.Now()), logger.NewNopLogger())
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{}, nil).Once()
+		assert.False(t, s.Enabled(context.Background(), "new_importer"))
+
+		row := dao.FeatureFlagRow{Name: "new_importer", Enabled: true, Environments: []string{}, RolloutPercent: 100}
+		repo.On("SetFeatureFlag", mock.Anything, row).Return(row, nil).Once()
+		repo.On("ListFeatureFlags", mock.Anything).Return([]dao.FeatureFlagRow{row}, nil).Once()
+
+		flag, err := s.SetFlag(context.Background(), domain.FeatureFlag{Name: "new_importer", Enabled: true, RolloutPercent: 100})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new_importer", flag.Name)
+		assert.True(t, s.Enabled(context.Background(), "new_importer"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Name", func(t *testing.T) {
+		repo := new(mocks.FeatureFlagRepositoryInterface)
+		s := NewFeatureFlagService(repo, "production", time.Hour, clock.System(), logger.NewNopLogger())
+
+		_, err := s.SetFlag(context.Background(), domain.FeatureFlag{Name: "New-Importer", Enabled: true})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		repo.AssertNotCalled(t, "SetFeatureFlag", mock.Anything, mock.Anything)
+	})
+}
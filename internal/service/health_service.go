@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type HealthServiceInterface interface {
+	Readiness(ctx context.Context) []string
+	Status(ctx context.Context) domain.SystemStatus
+	TopQueries(ctx context.Context, limit int) ([]domain.QueryStat, error)
+}
+
+type HealthService struct {
+	repo            repository.HealthRepositoryInterface
+	expectedVersion uint
+	build           domain.BuildInfo
+	startedAt       time.Time
+	clock           clock.Clock
+	logger          logger.Logger
+}
+
+// NewHealthService reports uptime from the time it is created, which is
+// taken to be when the instance started.
+func NewHealthService(repo repository.HealthRepositoryInterface, expectedVersion uint, build domain.BuildInfo, clock clock.Clock, logger logger.Logger) *HealthService {
+	return &HealthService{
+		repo:            repo,
+		expectedVersion: expectedVersion,
+		build:           build,
+		startedAt:       clock.Now(),
+		clock:           clock,
+		logger:          logger,
+	}
+}
+
+// Readiness returns the failing readiness checks; an empty result means the
+// instance can take traffic.
+func (s *HealthService) Readiness(ctx context.Context) []string {
+	var failures []string
+	for _, dependency := range s.checkDependencies(ctx) {
+		if !dependency.Healthy() {
+			failures = append(failures, dependency.Name+": "+dependency.Problem)
+		}
+	}
+	return failures
+}
+
+// Status describes the running build, its uptime and the same dependency
+// checks readiness runs.
+func (s *HealthService) Status(ctx context.Context) domain.SystemStatus {
+	return domain.SystemStatus{
+		Build:        s.build,
+		StartedAt:    s.startedAt,
+		Uptime:       s.clock.Now().Sub(s.startedAt),
+		Dependencies: s.checkDependencies(ctx),
+	}
+}
+
+// checkDependencies checks the database and then its migrations; the
+// migrations are left out when the database cannot be reached.
+func (s *HealthService) checkDependencies(ctx context.Context) []domain.DependencyHealth {
+	if err := s.repo.Ping(ctx); err != nil {
+		s.logger.WarnContext(ctx, "Readiness: database ping failed", zap.Error(err))
+		return []domain.DependencyHealth{{Name: "database", Problem: "unreachable"}}
+	}
+
+	migrations := domain.DependencyHealth{Name: "migrations"}
+	version, dirty, err := s.repo.MigrationVersion(ctx)
+	switch {
+	case err != nil:
+		s.logger.WarnContext(ctx, "Readiness: failed to read migration version", zap.Error(err))
+		migrations.Problem = "version unknown"
+	case dirty:
+		migrations.Problem = fmt.Sprintf("version %d is dirty", version)
+	case version < s.expectedVersion:
+		migrations.Problem = fmt.Sprintf("at version %d, expected %d", version, s.expectedVersion)
+	}
+	return []domain.DependencyHealth{{Name: "database"}, migrations}
+}
+
+// TopQueries returns the limit queries with the highest mean execution time,
+// to show where the repository layer is worth tuning.
+func (s *HealthService) TopQueries(ctx context.Context, limit int) ([]domain.QueryStat, error) {
+	rows, err := s.repo.TopQueries(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]domain.QueryStat, len(rows))
+	for i, row := range rows {
+		stats[i] = mapper.ToQueryStatFromDAO(row)
+	}
+	return stats, nil
+}
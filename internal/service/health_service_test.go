@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthService_Readiness(t *testing.T) {
+	t.Run("Ready", func(t *testing.T) {
+		mockRepo := new(mocks.HealthRepositoryInterface)
+		service := NewHealthService(mockRepo, 2, domain.BuildInfo{}, clock.System(), logger.NewNopLogger())
+		mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+		mockRepo.On("MigrationVersion", mock.Anything).Return(uint(2), false, nil).Once()
+
+		assert.Empty(t, service.Readiness(context.Background()))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Database Unreachable", func(t *testing.T) {
+		mockRepo := new(mocks.HealthRepositoryInterface)
+		service := NewHealthService(mockRepo, 2, domain.BuildInfo{}, clock.System(), logger.NewNopLogger())
+		mockRepo.On("Ping", mock.Anything).Return(errors.New("connection refused")).Once()
+
+		assert.Equal(t, []string{"database: unreachable"}, service.Readiness(context.Background()))
+		mockRepo.AssertNotCalled(t, "MigrationVersion", mock.Anything)
+	})
+
+	t.Run("Pending Migrations", func(t *testing.T) {
+		mockRepo := new(mocks.HealthRepositoryInterface)
+		service := NewHealthService(mockRepo, 2, domain.BuildInfo{}, clock.System(), logger.NewNopLogger())
+		mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+		mockRepo.On("MigrationVersion", mock.Anything).Return(uint(1), false, nil).Once()
+
+		assert.Equal(t, []string{"migrations: at version 1, expected 2"}, service.Readiness(context.Background()))
+	})
+
+	t.Run("Dirty Migration", func(t *testing.T) {
+		mockRepo := new(mocks.HealthRepositoryInterface)
+		service := NewHealthService(mockRepo, 2, domain.BuildInfo{}, clock.System(), logger.NewNopLogger())
+		mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+		mockRepo.On("MigrationVersion", mock.Anything).Return(uint(2), true, nil).Once()
+
+		assert.Equal(t, []string{"migrations: version 2 is dirty"}, service.Readiness(context.Background()))
+	})
+}
+
+func TestHealthService_Status(t *testing.T) {
+	started := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	build := domain.BuildInfo{Version: "v1.4.0", Commit: "9bcb46f"}
+
+	t.Run("Healthy", func(t *testing.T) {
+		mockRepo := new(mocks.HealthRepositoryInterface)
+		clk := clock.NewFrozen(started)
+		service := NewHealthService(mockRepo, 2, build, clk, logger.NewNopLogger())
+		mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+		mockRepo.On("MigrationVersion", mock.Anything).Return(uint(2), false, nil).Once()
+		clk.Advance(90 * time.Minute)
+
+		status := service.Status(context.Background())
+
+		assert.Equal(t, domain.SystemStatus{
+			Build:        build,
+			StartedAt:    started,
+			Uptime:       90 * time.Minute,
+			Dependencies: []domain.DependencyHealth{{Name: "database"}, {Name: "migrations"}},
+		}, status)
+		assert.True(t, status.Healthy())
+	})
+
+	t.Run("Pending Migrations", func(t *testing.T) {
+		mockRepo := new(mocks.HealthRepositoryInterface)
+		service := NewHealthService(mockRepo, 2, build, clock.NewFrozen(started), logger.NewNopLogger())
+		mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+		mockRepo.On("MigrationVersion", mock.Anything).Return(uint(1), false, nil).Once()
+
+		status := service.Status(context.Background())
+
+		assert.Equal(t, []domain.DependencyHealth{{Name: "database"}, {Name: "migrations", Problem: "at version 1, expected 2"}}, status.Dependencies)
+		assert.False(t, status.Healthy())
+	})
+}
+
+func TestHealthService_TopQueries(t *testing.T) {
+	mockRepo := new(mocks.HealthRepositoryInterface)
+	service := NewHealthService(mockRepo, 2, domain.BuildInfo{}, clock.System(), logger.NewNopLogger())
+	mockRepo.On("TopQueries", mock.Anything, 5).Return([]dao.QueryStatRow{
+		{QueryID: 11, Query: "SELECT SUM(price) FROM subscriptions", Calls: 4, Rows: 4, TotalExecTime: 200, MeanExecTime: 50.5},
+	}, nil).Once()
+
+	stats, err := service.TopQueries(context.Background(), 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, 50500*time.Microsecond, stats[0].MeanTime)
+	assert.Equal(t, 200*time.Millisecond, stats[0].TotalTime)
+	mockRepo.AssertExpectations(t)
+}
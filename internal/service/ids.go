@@ -0,0 +1,22 @@
+package service
+
+import "github.com/google/uuid"
+
+// IDGenerator creates the IDs of new records.
+type IDGenerator interface {
+	NewID() uuid.UUID
+}
+
+// TimeOrderedIDs generates UUIDv7s. They start with their creation time, so
+// records inserted together land next to each other in the primary key index
+// instead of on random pages. They are ordinary UUIDs and mix freely with the
+// v4 IDs generated before.
+func TimeOrderedIDs() IDGenerator {
+	return uuidV7{}
+}
+
+type uuidV7 struct{}
+
+func (uuidV7) NewID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
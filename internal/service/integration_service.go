@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type IntegrationServiceInterface interface {
+	AckMessage(ctx context.Context, ack domain.ConsumerAck) (domain.ConsumerAck, error)
+}
+
+// IntegrationService helps downstream consumers of our events. Webhook
+// deliveries are retried until acknowledged, so a consumer can see one more
+// than once; acking each before acting on it lets the consumer handle it
+// exactly once.
+type IntegrationService struct {
+	repo   repository.IntegrationRepositoryInterface
+	clock  clock.Clock
+	logger logger.Logger
+}
+
+func NewIntegrationService(repo repository.IntegrationRepositoryInterface, clock clock.Clock, logger logger.Logger) *IntegrationService {
+	return &IntegrationService{
+		repo:   repo,
+		clock:  clock,
+		logger: logger,
+	}
+}
+
+// AckMessage records that ack.Consumer handled ack.MessageID. Only the first
+// ack of a message is stored; later ones come back marked Duplicate, with the
+// first ack's time.
+func (s *IntegrationService) AckMessage(ctx context.Context, ack domain.ConsumerAck) (domain.ConsumerAck, error) {
+	if _, err := ScopeUserID(ctx, ack.UserID.String()); err != nil {
+		return domain.ConsumerAck{}, err
+	}
+	ack.AckedAt = s.clock.Now()
+
+	row, created, err := s.repo.AckMessage(ctx, mapper.ToConsumerAckDAO(ack))
+	if err != nil {
+		return domain.ConsumerAck{}, err
+	}
+	stored := mapper.ToConsumerAckFromDAO(row)
+	stored.Duplicate = !created
+	if stored.Duplicate {
+		s.logger.InfoContext(ctx, "Duplicate message ack",
+			zap.String("consumer", ack.Consumer),
+			zap.String("message_id", ack.MessageID),
+		)
+	}
+	return stored, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIntegrationService_AckMessage(t *testing.T) {
+	now := time.Date(2025, 7, 1, 10, 2, 31, 0, time.UTC)
+	userID := uuid.New()
+	ack := domain.ConsumerAck{UserID: userID, Consumer: "billing-sync", MessageID: "m1"}
+	row := dao.ConsumerAckRow{UserID: userID, Consumer: "billing-sync", MessageID: "m1", AckedAt: now}
+	setup := func() (*IntegrationService, *mocks.IntegrationRepositoryInterface) {
+		repo := new(mocks.IntegrationRepositoryInterface)
+		return NewIntegrationService(repo, clock.NewFrozen(now), logger.NewNopLogger()), repo
+	}
+
+	t.Run("First Ack", func(t *testing.T) {
+		s, repo := setup()
+		repo.On("AckMessage", mock.Anything, row).Return(row, true, nil).Once()
+
+		got, err := s.AckMessage(context.Background(), ack)
+
+		assert.NoError(t, err)
+		assert.False(t, got.Duplicate)
+		assert.Equal(t, now, got.AckedAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Second Ack Is Duplicate", func(t *testing.T) {
+		s, repo := setup()
+		first := row
+		first.AckedAt = now.Add(-time.Hour)
+		repo.On("AckMessage", mock.Anything, row).Return(first, false, nil).Once()
+
+		got, err := s.AckMessage(context.Background(), ack)
+
+		assert.NoError(t, err)
+		assert.True(t, got.Duplicate)
+		assert.Equal(t, first.AckedAt, got.AckedAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Other User Is Forbidden", func(t *testing.T) {
+		s, repo := setup()
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		_, err := s.AckMessage(ctx, ack)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		repo.AssertNotCalled(t, "AckMessage")
+	})
+}
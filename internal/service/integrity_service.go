@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/metrics"
+	"subtracker/internal/repository"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type IntegrityServiceInterface interface {
+	Check(ctx context.Context) (domain.IntegrityReport, error)
+}
+
+type IntegrityService struct {
+	repo   repository.ReportingRepositoryInterface
+	logger logger.Logger
+	clock  clock.Clock
+}
+
+func NewIntegrityService(repo repository.ReportingRepositoryInterface, clock clock.Clock, logger logger.Logger) *IntegrityService {
+	return &IntegrityService{
+		repo:   repo,
+		logger: logger,
+		clock:  clock,
+	}
+}
+
+// Check counts invariant violations and publishes them as metrics.
+func (s *IntegrityService) Check(ctx context.Context) (domain.IntegrityReport, error) {
+	counts, err := s.repo.IntegrityViolations(ctx)
+	if err != nil {
+		return domain.IntegrityReport{}, err
+	}
+
+	report := domain.IntegrityReport{
+		CheckedAt: s.clock.Now(),
+		Violations: map[string]int{
+			domain.InvariantNegativePrice:  counts.NegativePrice,
+			domain.InvariantEndBeforeStart: counts.EndBeforeStart,
+		},
+	}
+	for invariant, count := range report.Violations {
+		metrics.IntegrityViolations.WithLabelValues(invariant).Set(float64(count))
+	}
+	if !report.Healthy() {
+		s.logger.WarnContext(ctx, "Data integrity violations found", zap.Any("violations", report.Violations))
+	}
+	return report, nil
+}
+
+// Run checks integrity every interval until ctx is done.
+func (s *IntegrityService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Check(ctx); err != nil {
+			s.logger.WarnContext(ctx, "Integrity check failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIntegrityService_Check(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Reports Violations", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewIntegrityService(mockRepo, clock.NewFrozen(now), logger.NewNopLogger())
+		mockRepo.On("IntegrityViolations", mock.Anything).Return(dao.IntegrityCounts{NegativePrice: 3}, nil).Once()
+
+		report, err := service.Check(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, now, report.CheckedAt)
+		assert.False(t, report.Healthy())
+		assert.Equal(t, 3, report.Violations[domain.InvariantNegativePrice])
+		assert.Equal(t, 0, report.Violations[domain.InvariantEndBeforeStart])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewIntegrityService(mockRepo, clock.System(), logger.NewNopLogger())
+		dbErr := errors.New("db down")
+		mockRepo.On("IntegrityViolations", mock.Anything).Return(dao.IntegrityCounts{}, dbErr).Once()
+
+		_, err := service.Check(context.Background())
+
+		assert.ErrorIs(t, err, dbErr)
+		mockRepo.AssertExpectations(t)
+	})
+}
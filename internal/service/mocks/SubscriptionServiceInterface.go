@@ -8,6 +8,8 @@ import (
 	dto "subtracker/internal/domain/dto"
 
 	mock "github.com/stretchr/testify/mock"
+
+	service "subtracker/internal/service"
 )
 
 // SubscriptionServiceInterface is an autogenerated mock type for the SubscriptionServiceInterface type
@@ -43,35 +45,183 @@ func (_m *SubscriptionServiceInterface) CalculateCost(ctx context.Context, filte
 	return r0, r1
 }
 
+// CalculateCostBatch provides a mock function with given fields: ctx, filters
+func (_m *SubscriptionServiceInterface) CalculateCostBatch(ctx context.Context, filters []dto.CostFilter) []service.CostResult {
+	ret := _m.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CalculateCostBatch")
+	}
+
+	var r0 []service.CostResult
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.CostFilter) []service.CostResult); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.CostResult)
+		}
+	}
+
+	return r0
+}
+
+// CostByCostCenter provides a mock function with given fields: ctx, filter
+func (_m *SubscriptionServiceInterface) CostByCostCenter(ctx context.Context, filter dto.CostFilter) ([]domain.CostCenterCost, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CostByCostCenter")
+	}
+
+	var r0 []domain.CostCenterCost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CostFilter) ([]domain.CostCenterCost, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CostFilter) []domain.CostCenterCost); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CostCenterCost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.CostFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountSubscriptions provides a mock function with given fields: ctx, filter
+func (_m *SubscriptionServiceInterface) CountSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSubscriptions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.SubscriptionFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateSubscription provides a mock function with given fields: ctx, subDomain
-func (_m *SubscriptionServiceInterface) CreateSubscription(ctx context.Context, subDomain domain.Subscription) error {
+func (_m *SubscriptionServiceInterface) CreateSubscription(ctx context.Context, subDomain domain.Subscription) ([]domain.Warning, error) {
 	ret := _m.Called(ctx, subDomain)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSubscription")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Subscription) error); ok {
+	var r0 []domain.Warning
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Subscription) ([]domain.Warning, error)); ok {
+		return rf(ctx, subDomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Subscription) []domain.Warning); ok {
 		r0 = rf(ctx, subDomain)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Warning)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Subscription) error); ok {
+		r1 = rf(ctx, subDomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSubscriptions provides a mock function with given fields: ctx, subs
+func (_m *SubscriptionServiceInterface) CreateSubscriptions(ctx context.Context, subs []domain.Subscription) ([]service.CreateResult, error) {
+	ret := _m.Called(ctx, subs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscriptions")
+	}
+
+	var r0 []service.CreateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Subscription) ([]service.CreateResult, error)); ok {
+		return rf(ctx, subs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Subscription) []service.CreateResult); ok {
+		r0 = rf(ctx, subs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.CreateResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Subscription) error); ok {
+		r1 = rf(ctx, subs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DeleteSubscription provides a mock function with given fields: ctx, id
-func (_m *SubscriptionServiceInterface) DeleteSubscription(ctx context.Context, id string) error {
+func (_m *SubscriptionServiceInterface) DeleteSubscription(ctx context.Context, id string) (domain.UndoToken, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteSubscription")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 domain.UndoToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.UndoToken, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.UndoToken); ok {
 		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.UndoToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportSubscriptions provides a mock function with given fields: ctx, filter, fn
+func (_m *SubscriptionServiceInterface) ExportSubscriptions(ctx context.Context, filter dto.SubscriptionFilter, fn func(domain.Subscription) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportSubscriptions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter, func(domain.Subscription) error) error); ok {
+		r0 = rf(ctx, filter, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -137,22 +287,236 @@ func (_m *SubscriptionServiceInterface) ListSubscriptions(ctx context.Context, f
 	return r0, r1
 }
 
+// ListTrash provides a mock function with given fields: ctx, filter
+func (_m *SubscriptionServiceInterface) ListTrash(ctx context.Context, filter dto.TrashFilter) ([]domain.TrashedSubscription, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrash")
+	}
+
+	var r0 []domain.TrashedSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TrashFilter) ([]domain.TrashedSubscription, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TrashFilter) []domain.TrashedSubscription); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrashedSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TrashFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupSubscriptions provides a mock function with given fields: ctx, ids
+func (_m *SubscriptionServiceInterface) LookupSubscriptions(ctx context.Context, ids []string) (domain.LookupResult, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupSubscriptions")
+	}
+
+	var r0 domain.LookupResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (domain.LookupResult, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) domain.LookupResult); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Get(0).(domain.LookupResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchSubscription provides a mock function with given fields: ctx, id, patch
+func (_m *SubscriptionServiceInterface) PatchSubscription(ctx context.Context, id string, patch domain.SubscriptionPatch) ([]domain.Warning, error) {
+	ret := _m.Called(ctx, id, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchSubscription")
+	}
+
+	var r0 []domain.Warning
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionPatch) ([]domain.Warning, error)); ok {
+		return rf(ctx, id, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionPatch) []domain.Warning); ok {
+		r0 = rf(ctx, id, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Warning)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.SubscriptionPatch) error); ok {
+		r1 = rf(ctx, id, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuotaStatus provides a mock function with given fields: ctx, userID
+func (_m *SubscriptionServiceInterface) QuotaStatus(ctx context.Context, userID string) (domain.QuotaStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuotaStatus")
+	}
+
+	var r0 domain.QuotaStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.QuotaStatus, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.QuotaStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.QuotaStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreSubscriptions provides a mock function with given fields: ctx, userID, ids
+func (_m *SubscriptionServiceInterface) RestoreSubscriptions(ctx context.Context, userID string, ids []string) (domain.RestoreResult, error) {
+	ret := _m.Called(ctx, userID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSubscriptions")
+	}
+
+	var r0 domain.RestoreResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (domain.RestoreResult, error)); ok {
+		return rf(ctx, userID, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) domain.RestoreResult); ok {
+		r0 = rf(ctx, userID, ids)
+	} else {
+		r0 = ret.Get(0).(domain.RestoreResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscriptionHistory provides a mock function with given fields: ctx, id, limit
+func (_m *SubscriptionServiceInterface) SubscriptionHistory(ctx context.Context, id string, limit int) ([]domain.AuditEntry, error) {
+	ret := _m.Called(ctx, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscriptionHistory")
+	}
+
+	var r0 []domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.AuditEntry, error)); ok {
+		return rf(ctx, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.AuditEntry); ok {
+		r0 = rf(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UndoDelete provides a mock function with given fields: ctx, token
+func (_m *SubscriptionServiceInterface) UndoDelete(ctx context.Context, token string) (domain.Subscription, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoDelete")
+	}
+
+	var r0 domain.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Subscription, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Subscription); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(domain.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateSubscription provides a mock function with given fields: ctx, subDomain
-func (_m *SubscriptionServiceInterface) UpdateSubscription(ctx context.Context, subDomain domain.Subscription) error {
+func (_m *SubscriptionServiceInterface) UpdateSubscription(ctx context.Context, subDomain domain.Subscription) ([]domain.Warning, error) {
 	ret := _m.Called(ctx, subDomain)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateSubscription")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Subscription) error); ok {
+	var r0 []domain.Warning
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Subscription) ([]domain.Warning, error)); ok {
+		return rf(ctx, subDomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Subscription) []domain.Warning); ok {
 		r0 = rf(ctx, subDomain)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Warning)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Subscription) error); ok {
+		r1 = rf(ctx, subDomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewSubscriptionServiceInterface creates a new instance of SubscriptionServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+
+	"subtracker/pkg/apperrors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash is compared against when a login names an unknown email,
+// so the response time does not reveal which emails are registered.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("subtracker-dummy-password"), bcrypt.DefaultCost)
+
+// hashPassword rejects passwords bcrypt would truncate: the 72 character
+// limit on requests counts runes, not bytes.
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", apperrors.NewBadRequest("password must be at most 72 bytes", err)
+	}
+	if err != nil {
+		return "", apperrors.NewInternalServerError("failed to hash password", err)
+	}
+	return string(hash), nil
+}
+
+// checkPassword reports whether password matches hash. An empty hash, as held
+// by accounts created for pre-existing user IDs, never matches.
+func checkPassword(hash, password string) bool {
+	if hash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type PaymentServiceInterface interface {
+	GetPaymentHistory(ctx context.Context, subscriptionID string) (domain.PaymentHistory, error)
+	RecordFailure(ctx context.Context, failure domain.PaymentFailure) (domain.PaymentFailure, error)
+	RecordRetry(ctx context.Context, subscriptionID string, period time.Time, succeeded bool) (domain.PaymentFailure, error)
+}
+
+// PaymentService tracks failed charges, which make a subscription past due
+// until a retry pays them. Ownership is checked through the subscription
+// service.
+type PaymentService struct {
+	repo          repository.PaymentRepositoryInterface
+	subscriptions SubscriptionServiceInterface
+	clock         clock.Clock
+	logger        logger.Logger
+}
+
+func NewPaymentService(repo repository.PaymentRepositoryInterface, subscriptions SubscriptionServiceInterface, clock clock.Clock, logger logger.Logger) *PaymentService {
+	return &PaymentService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		clock:         clock,
+		logger:        logger,
+	}
+}
+
+func (s *PaymentService) GetPaymentHistory(ctx context.Context, subscriptionID string) (domain.PaymentHistory, error) {
+	sub, err := s.subscriptions.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return domain.PaymentHistory{}, err
+	}
+	rows, err := s.repo.ListPaymentFailures(ctx, subscriptionID)
+	if err != nil {
+		return domain.PaymentHistory{}, err
+	}
+	history := domain.PaymentHistory{SubscriptionID: sub.ID, Failures: make([]domain.PaymentFailure, len(rows))}
+	for i, row := range rows {
+		history.Failures[i] = mapper.ToPaymentFailureFromDAO(row)
+	}
+	return history, nil
+}
+
+// RecordFailure records that the charge for the month of failure.Period
+// failed. The month may not be a future one and must fall within the
+// subscription's term.
+func (s *PaymentService) RecordFailure(ctx context.Context, failure domain.PaymentFailure) (domain.PaymentFailure, error) {
+	sub, err := s.subscriptions.GetSubscription(ctx, failure.SubscriptionID.String())
+	if err != nil {
+		return domain.PaymentFailure{}, err
+	}
+	now := s.clock.Now()
+	if failure.Period.After(now) {
+		return domain.PaymentFailure{}, apperrors.NewBadRequest("period must not be a future month", nil).
+			WithErrorCode(ErrCodePaymentPeriodFuture)
+	}
+	if failure.Period.Before(sub.StartDate) || (sub.EndDate != nil && failure.Period.After(*sub.EndDate)) {
+		return domain.PaymentFailure{}, apperrors.NewBadRequest("period must fall between the subscription's start and end dates", nil).
+			WithErrorCode(ErrCodePaymentOutsideTerm)
+	}
+
+	failure.UserID = sub.UserID
+	failure.FailedAt = now
+	row, err := s.repo.RecordPaymentFailure(ctx, mapper.ToPaymentFailureDAO(failure))
+	if err != nil {
+		return domain.PaymentFailure{}, err
+	}
+	s.logger.InfoContext(ctx, "Payment failure recorded",
+		zap.String("subscription_id", failure.SubscriptionID.String()),
+		zap.Time("period", failure.Period),
+	)
+	return mapper.ToPaymentFailureFromDAO(row), nil
+}
+
+// RecordRetry records a retry of the month's unpaid charge; a successful one
+// pays it.
+func (s *PaymentService) RecordRetry(ctx context.Context, subscriptionID string, period time.Time, succeeded bool) (domain.PaymentFailure, error) {
+	if _, err := s.subscriptions.GetSubscription(ctx, subscriptionID); err != nil {
+		return domain.PaymentFailure{}, err
+	}
+	row, err := s.repo.RecordPaymentRetry(ctx, subscriptionID, period, succeeded, s.clock.Now())
+	if err != nil {
+		return domain.PaymentFailure{}, err
+	}
+	s.logger.InfoContext(ctx, "Payment retry recorded",
+		zap.String("subscription_id", subscriptionID),
+		zap.Time("period", period),
+		zap.Bool("succeeded", succeeded),
+	)
+	return mapper.ToPaymentFailureFromDAO(row), nil
+}
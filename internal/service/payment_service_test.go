@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPaymentService(t *testing.T) {
+	now := time.Date(2026, 1, 6, 8, 0, 0, 0, time.UTC)
+	sub := dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Figma", Price: 4500, StartDate: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)}
+	setup := func() (*PaymentService, *mocks.PaymentRepositoryInterface) {
+		repo := new(mocks.PaymentRepositoryInterface)
+		subRepo := new(mocks.SubscriptionRepositoryInterface)
+		subRepo.On("GetSubscription", mock.Anything, sub.ID.String()).Return(sub, nil)
+		subs := NewSubscriptionService(subRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		return NewPaymentService(repo, subs, clock.NewFrozen(now), logger.NewNopLogger()), repo
+	}
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Failure Makes Subscription Past Due", func(t *testing.T) {
+		s, repo := setup()
+		row := dao.PaymentFailureRow{SubscriptionID: sub.ID, UserID: sub.UserID, Period: january, FailedAt: now}
+		repo.On("RecordPaymentFailure", mock.Anything, row).Return(row, nil).Once()
+		repo.On("ListPaymentFailures", mock.Anything, sub.ID.String()).Return([]dao.PaymentFailureRow{row}, nil).Once()
+
+		failure, err := s.RecordFailure(context.Background(), domain.PaymentFailure{SubscriptionID: sub.ID, Period: january})
+		assert.NoError(t, err)
+		assert.True(t, failure.Unpaid())
+
+		history, err := s.GetPaymentHistory(context.Background(), sub.ID.String())
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PaymentPastDue, history.Status())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Future Month Is Rejected", func(t *testing.T) {
+		s, repo := setup()
+
+		_, err := s.RecordFailure(context.Background(), domain.PaymentFailure{SubscriptionID: sub.ID, Period: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Equal(t, ErrCodePaymentPeriodFuture, appErr.ErrorCode)
+		repo.AssertNotCalled(t, "RecordPaymentFailure")
+	})
+
+	t.Run("Month Before Start Is Rejected", func(t *testing.T) {
+		s, repo := setup()
+
+		_, err := s.RecordFailure(context.Background(), domain.PaymentFailure{SubscriptionID: sub.ID, Period: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodePaymentOutsideTerm, appErr.ErrorCode)
+		repo.AssertNotCalled(t, "RecordPaymentFailure")
+	})
+
+	t.Run("Successful Retry Pays The Charge", func(t *testing.T) {
+		s, repo := setup()
+		repo.On("RecordPaymentRetry", mock.Anything, sub.ID.String(), january, true, now).
+			Return(dao.PaymentFailureRow{SubscriptionID: sub.ID, Period: january, Retries: 1, LastRetryAt: &now, PaidAt: &now}, nil).Once()
+
+		failure, err := s.RecordRetry(context.Background(), sub.ID.String(), january, true)
+
+		assert.NoError(t, err)
+		assert.False(t, failure.Unpaid())
+		assert.Equal(t, domain.PaymentCurrent, domain.PaymentHistory{Failures: []domain.PaymentFailure{failure}}.Status())
+		repo.AssertExpectations(t)
+	})
+}
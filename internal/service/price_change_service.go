@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type PriceChangeServiceInterface interface {
+	SchedulePriceChange(ctx context.Context, change domain.PriceChange) (domain.PriceChange, error)
+	ListPriceChanges(ctx context.Context, subscriptionID string) ([]domain.PriceChange, error)
+}
+
+// PriceChangeService schedules future price changes and, run periodically,
+// writes the due ones to their subscriptions. Ownership is checked through the
+// subscription service.
+type PriceChangeService struct {
+	repo          repository.PriceChangeRepositoryInterface
+	subscriptions SubscriptionServiceInterface
+	audit         auditTrail
+	ids           IDGenerator
+	clock         clock.Clock
+	logger        logger.Logger
+}
+
+func NewPriceChangeService(repo repository.PriceChangeRepositoryInterface, subscriptions SubscriptionServiceInterface, audit repository.AuditRepositoryInterface, ids IDGenerator, clock clock.Clock, logger logger.Logger) *PriceChangeService {
+	return &PriceChangeService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		audit:         auditTrail{repo: audit, clock: clock, logger: logger},
+		ids:           ids,
+		clock:         clock,
+		logger:        logger,
+	}
+}
+
+// SchedulePriceChange schedules the subscription to switch to change.Price
+// from the month of change.EffectiveDate on. The month must be in the future
+// and within the subscription's term; scheduling a month twice replaces the
+// earlier price.
+func (s *PriceChangeService) SchedulePriceChange(ctx context.Context, change domain.PriceChange) (domain.PriceChange, error) {
+	s.logger.DebugContext(ctx, "Entering SchedulePriceChange service",
+		zap.String("subscription_id", change.SubscriptionID.String()),
+		zap.Time("effective_date", change.EffectiveDate),
+	)
+
+	sub, err := s.subscriptions.GetSubscription(ctx, change.SubscriptionID.String())
+	if err != nil {
+		return domain.PriceChange{}, err
+	}
+	if !change.EffectiveDate.After(s.clock.Now()) {
+		return domain.PriceChange{}, apperrors.NewBadRequest("effective date must be a future month", nil).
+			WithErrorCode(ErrCodeEffectiveNotFuture)
+	}
+	if change.EffectiveDate.Before(sub.StartDate) || (sub.EndDate != nil && change.EffectiveDate.After(*sub.EndDate)) {
+		return domain.PriceChange{}, apperrors.NewBadRequest("effective date must fall between the subscription's start and end dates", nil).
+			WithErrorCode(ErrCodeEffectiveOutsideTerm)
+	}
+
+	change.ID = s.ids.NewID()
+	change.UserID = sub.UserID
+	row, err := s.repo.SchedulePriceChange(ctx, mapper.ToPriceChangeDAO(change))
+	if err != nil {
+		return domain.PriceChange{}, err
+	}
+	return mapper.ToPriceChangeFromDAO(row), nil
+}
+
+// ListPriceChanges returns the subscription's applied and pending price
+// changes by effective date.
+func (s *PriceChangeService) ListPriceChanges(ctx context.Context, subscriptionID string) ([]domain.PriceChange, error) {
+	s.logger.DebugContext(ctx, "Entering ListPriceChanges service", zap.String("subscription_id", subscriptionID))
+
+	if _, err := s.subscriptions.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListPriceChanges(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]domain.PriceChange, len(rows))
+	for i, row := range rows {
+		changes[i] = mapper.ToPriceChangeFromDAO(row)
+	}
+	return changes, nil
+}
+
+// ApplyDue writes every price change that has taken effect to its
+// subscription and returns how many subscriptions changed price.
+func (s *PriceChangeService) ApplyDue(ctx context.Context) (int, error) {
+	rows, err := s.repo.ApplyDuePriceChanges(ctx, s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		after := mapper.ToDomainFromDAO(row.SubscriptionRow)
+		before := after
+		before.Price = row.PreviousPrice
+		s.audit.subscription(ctx, domain.AuditUpdate, &before, &after)
+	}
+	return len(rows), nil
+}
+
+// Run applies due price changes every interval until ctx is done.
+func (s *PriceChangeService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		applied, err := s.ApplyDue(ctx)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Price change run failed", zap.Error(err))
+		} else if applied > 0 {
+			s.logger.InfoContext(ctx, "Scheduled price changes applied", zap.Int("subscriptions", applied))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
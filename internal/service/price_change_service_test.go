@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPriceChangeService_SchedulePriceChange(t *testing.T) {
+	now := time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+	ended := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	sub := dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Netflix", Price: 999, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: &ended}
+	setup := func() (*PriceChangeService, *mocks.PriceChangeRepositoryInterface) {
+		repo := new(mocks.PriceChangeRepositoryInterface)
+		subRepo := new(mocks.SubscriptionRepositoryInterface)
+		subRepo.On("GetSubscription", mock.Anything, sub.ID.String()).Return(sub, nil)
+		subs := NewSubscriptionService(subRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		return NewPriceChangeService(repo, subs, nil, TimeOrderedIDs(), clock.NewFrozen(now), logger.NewNopLogger()), repo
+	}
+
+	t.Run("Schedules Future Month", func(t *testing.T) {
+		s, repo := setup()
+		effective := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo.On("SchedulePriceChange", mock.Anything, mock.MatchedBy(func(row dao.PriceChangeRow) bool {
+			return row.ID != uuid.Nil && row.SubscriptionID == sub.ID && row.UserID == sub.UserID && row.Price == 1299 && row.EffectiveDate.Equal(effective)
+		})).Return(func(_ context.Context, row dao.PriceChangeRow) (dao.PriceChangeRow, error) {
+			row.CreatedAt = now
+			return row, nil
+		}).Once()
+
+		change, err := s.SchedulePriceChange(context.Background(), domain.PriceChange{SubscriptionID: sub.ID, Price: 1299, EffectiveDate: effective})
+
+		assert.NoError(t, err)
+		assert.Equal(t, sub.UserID, change.UserID)
+		assert.True(t, change.Pending())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Current Month Is Rejected", func(t *testing.T) {
+		s, repo := setup()
+
+		_, err := s.SchedulePriceChange(context.Background(), domain.PriceChange{SubscriptionID: sub.ID, Price: 1299, EffectiveDate: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Equal(t, ErrCodeEffectiveNotFuture, appErr.ErrorCode)
+		repo.AssertNotCalled(t, "SchedulePriceChange")
+	})
+
+	t.Run("After End Date Is Rejected", func(t *testing.T) {
+		s, repo := setup()
+
+		_, err := s.SchedulePriceChange(context.Background(), domain.PriceChange{SubscriptionID: sub.ID, Price: 1299, EffectiveDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodeEffectiveOutsideTerm, appErr.ErrorCode)
+		repo.AssertNotCalled(t, "SchedulePriceChange")
+	})
+}
+
+func TestPriceChangeService_ApplyDue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC)
+	repo := new(mocks.PriceChangeRepositoryInterface)
+	audit := new(mocks.AuditRepositoryInterface)
+	s := NewPriceChangeService(repo, nil, audit, TimeOrderedIDs(), clock.NewFrozen(now), logger.NewNopLogger())
+	sub := dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Netflix", Price: 1299, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: "monthly"}
+	repo.On("ApplyDuePriceChanges", mock.Anything, now).Return([]dao.AppliedPriceChangeRow{{SubscriptionRow: sub, PreviousPrice: 999}}, nil).Once()
+	audit.On("CreateEntry", mock.Anything, mock.MatchedBy(func(row dao.AuditRow) bool {
+		return row.EntityID == sub.ID && row.Action == string(domain.AuditUpdate) && row.ActorID == nil
+	})).Return(nil).Once()
+
+	applied, err := s.ApplyDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	repo.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"subtracker/internal/config"
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/mailer"
+	"subtracker/internal/mapper"
+	"subtracker/internal/metrics"
+	"subtracker/internal/repository"
+	"subtracker/internal/telegram"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ReminderServiceInterface interface {
+	GetReminder(ctx context.Context, subscriptionID string) (domain.ReminderSetting, error)
+	SetReminder(ctx context.Context, setting domain.ReminderSetting) (domain.ReminderSetting, error)
+}
+
+// ReminderService manages renewal reminder settings and sends the reminders
+// that are due, by Telegram to owners who linked a chat and by email otherwise.
+type ReminderService struct {
+	repo          repository.ReminderRepositoryInterface
+	subscriptions SubscriptionServiceInterface
+	// mailer and telegram are nil while their channel is not configured;
+	// settings can still be managed, but nothing goes out that way.
+	mailer            mailer.Mailer
+	telegram          telegram.Sender
+	defaultDaysBefore int
+	digest            DigestDays
+	logger            logger.Logger
+	clock             clock.Clock
+}
+
+// DigestDays is, per channel, how many days early a reminder may go out to
+// join the digest of another one due for the same owner.
+type DigestDays struct {
+	Email    int
+	Telegram int
+}
+
+func NewDigestDays(cfg config.AppConfig) DigestDays {
+	return DigestDays{Email: cfg.ReminderDigestDaysEmail, Telegram: cfg.ReminderDigestDaysTelegram}
+}
+
+func NewReminderService(repo repository.ReminderRepositoryInterface, subscriptions SubscriptionServiceInterface, mailer mailer.Mailer, telegram telegram.Sender, defaultDaysBefore int, digest DigestDays, clock clock.Clock, logger logger.Logger) *ReminderService {
+	return &ReminderService{
+		repo:              repo,
+		subscriptions:     subscriptions,
+		mailer:            mailer,
+		telegram:          telegram,
+		defaultDaysBefore: defaultDaysBefore,
+		digest:            digest,
+		logger:            logger,
+		clock:             clock,
+	}
+}
+
+// GetReminder returns the subscription's reminder setting; a subscription that
+// never set one is reminded at the default offset.
+func (s *ReminderService) GetReminder(ctx context.Context, subscriptionID string) (domain.ReminderSetting, error) {
+	sub, err := s.subscriptions.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return domain.ReminderSetting{}, err
+	}
+	setting := domain.ReminderSetting{SubscriptionID: sub.ID, Enabled: true}
+	row, err := s.repo.GetReminderSetting(ctx, subscriptionID)
+	switch {
+	case err == nil:
+		setting = mapper.ToReminderSettingFromDAO(row)
+	case !isNotFound(err):
+		return domain.ReminderSetting{}, err
+	}
+	return s.resolve(setting), nil
+}
+
+func (s *ReminderService) SetReminder(ctx context.Context, setting domain.ReminderSetting) (domain.ReminderSetting, error) {
+	if _, err := s.subscriptions.GetSubscription(ctx, setting.SubscriptionID.String()); err != nil {
+		return domain.ReminderSetting{}, err
+	}
+	if err := s.repo.SetReminderSetting(ctx, mapper.ToReminderSettingDAO(setting)); err != nil {
+		return domain.ReminderSetting{}, err
+	}
+	s.logger.InfoContext(ctx, "Reminder setting saved",
+		zap.String("subscription_id", setting.SubscriptionID.String()),
+		zap.Bool("enabled", setting.Enabled),
+	)
+	return s.resolve(setting), nil
+}
+
+func (s *ReminderService) resolve(setting domain.ReminderSetting) domain.ReminderSetting {
+	setting.EffectiveDaysBefore = s.defaultDaysBefore
+	if setting.DaysBefore != nil {
+		setting.EffectiveDaysBefore = *setting.DaysBefore
+	}
+	return setting
+}
+
+// reminderChannel is how an owner is reached.
+type reminderChannel int
+
+const (
+	channelEmail reminderChannel = iota
+	channelTelegram
+)
+
+// String names the channel for metric labels.
+func (c reminderChannel) String() string {
+	if c == channelTelegram {
+		return "telegram"
+	}
+	return "email"
+}
+
+// dueReminder is a renewal to remind its owner of, today or early.
+type dueReminder struct {
+	candidate domain.ReminderCandidate
+	renewal   time.Time
+	due       bool
+}
+
+// reminderDigest is one message to an owner on one channel. It goes out only
+// when at least one of its reminders is due; the others are early.
+type reminderDigest struct {
+	channel   reminderChannel
+	reminders []dueReminder
+	due       bool
+}
+
+// SendDue sends every reminder due today and returns how many were sent. An
+// owner's reminders on the same channel go out as one digest, joined by those
+// within the channel's DigestDays of being due. Each renewal is claimed before
+// its reminder goes out, so concurrent runs never send it twice; a failed send
+// releases the claims for the next run to retry.
+func (s *ReminderService) SendDue(ctx context.Context) (int, error) {
+	now := s.clock.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	rows, err := s.repo.ListReminderCandidates(ctx, s.defaultDaysBefore)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, digest := range s.digests(rows, today) {
+		n, err := s.sendDigest(ctx, digest)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// digests groups the candidates' due and early reminders by owner and channel,
+// keeping only the digests with a reminder that is due.
+func (s *ReminderService) digests(rows []dao.ReminderCandidateRow, today time.Time) []*reminderDigest {
+	type key struct {
+		userID  uuid.UUID
+		channel reminderChannel
+	}
+	byOwner := make(map[key]*reminderDigest)
+	var digests []*reminderDigest
+	for _, row := range rows {
+		candidate := mapper.ToReminderCandidateFromDAO(row)
+		channel, ok := s.channel(candidate)
+		if !ok {
+			continue
+		}
+		renewal, due := candidate.DueRenewal(today)
+		if !due {
+			var early bool
+			if renewal, early = candidate.DueRenewalWithin(today, s.digestDays(channel)); !early {
+				continue
+			}
+		}
+		k := key{userID: candidate.UserID, channel: channel}
+		digest, ok := byOwner[k]
+		if !ok {
+			digest = &reminderDigest{channel: channel}
+			byOwner[k] = digest
+			digests = append(digests, digest)
+		}
+		digest.reminders = append(digest.reminders, dueReminder{candidate: candidate, renewal: renewal, due: due})
+		digest.due = digest.due || due
+	}
+
+	result := digests[:0]
+	for _, digest := range digests {
+		if digest.due {
+			sort.SliceStable(digest.reminders, func(i, j int) bool {
+				return digest.reminders[i].renewal.Before(digest.reminders[j].renewal)
+			})
+			result = append(result, digest)
+		}
+	}
+	return result
+}
+
+// sendDigest claims the digest's renewals and sends the ones it won in one
+// message, returning how many reminders went out. Early reminders are not sent
+// on their own when another run already took the due ones.
+func (s *ReminderService) sendDigest(ctx context.Context, digest *reminderDigest) (int, error) {
+	var claimed []dueReminder
+	anyDue := false
+	for _, r := range digest.reminders {
+		ok, err := s.repo.ClaimReminder(ctx, r.candidate.ID, r.renewal)
+		if err != nil {
+			return 0, s.releaseAll(claimed, err)
+		}
+		if ok {
+			claimed = append(claimed, r)
+			anyDue = anyDue || r.due
+		}
+	}
+	if !anyDue {
+		return 0, s.releaseAll(claimed, nil)
+	}
+	if err := s.send(ctx, digest.channel, claimed); err != nil {
+		s.logger.WarnContext(ctx, "Failed to send renewal reminder", zap.Error(err),
+			zap.String("subscription_id", claimed[0].candidate.ID.String()),
+			zap.Int("reminders", len(claimed)),
+		)
+		metrics.RemindersSent.WithLabelValues(digest.channel.String(), "failed").Add(float64(len(claimed)))
+		return 0, s.releaseAll(claimed, nil)
+	}
+	metrics.RemindersSent.WithLabelValues(digest.channel.String(), "sent").Add(float64(len(claimed)))
+	return len(claimed), nil
+}
+
+// releaseAll drops the claims on reminders and returns cause, or the first
+// release failure when there is no cause.
+func (s *ReminderService) releaseAll(reminders []dueReminder, cause error) error {
+	for _, r := range reminders {
+		if err := s.release(r.candidate.ID, r.renewal); err != nil && cause == nil {
+			cause = err
+		}
+	}
+	return cause
+}
+
+// channel picks how to reach the candidate's owner: their linked Telegram
+// chat while the bot runs, their email otherwise. It reports false when
+// neither is possible.
+func (s *ReminderService) channel(c domain.ReminderCandidate) (reminderChannel, bool) {
+	switch {
+	case s.telegram != nil && c.TelegramChatID != nil:
+		return channelTelegram, true
+	case s.mailer != nil && c.Email != "":
+		return channelEmail, true
+	default:
+		return 0, false
+	}
+}
+
+func (s *ReminderService) digestDays(channel reminderChannel) int {
+	if channel == channelTelegram {
+		return s.digest.Telegram
+	}
+	return s.digest.Email
+}
+
+// send delivers reminders, which share an owner, as one message on channel.
+func (s *ReminderService) send(ctx context.Context, channel reminderChannel, reminders []dueReminder) error {
+	owner := reminders[0].candidate
+	if channel == channelTelegram {
+		return s.telegram.SendMessage(ctx, *owner.TelegramChatID, "Reminder: "+renewalNotices(reminders)+"\n\nSend /list to see all your subscriptions.")
+	}
+	if len(reminders) == 1 {
+		return s.mailer.Send(ctx, renewalReminder(owner, reminders[0].renewal))
+	}
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      owner.Email,
+		Subject: fmt.Sprintf("%d subscriptions renew soon", len(reminders)),
+		Body:    renewalNotices(reminders) + "\n\n" + reminderFooter,
+	})
+}
+
+// release uses its own context so a claim is dropped even when the run was
+// cancelled while sending.
+func (s *ReminderService) release(subscriptionID uuid.UUID, renewal time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.repo.ReleaseReminder(ctx, subscriptionID, renewal)
+}
+
+// Run sends due reminders every interval until ctx is done.
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		sent, err := s.SendDue(ctx)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Renewal reminder run failed", zap.Error(err), zap.Int("sent", sent))
+		} else if sent > 0 {
+			s.logger.InfoContext(ctx, "Renewal reminders sent", zap.Int("sent", sent))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func renewalReminder(c domain.ReminderCandidate, renewal time.Time) mailer.Message {
+	return mailer.Message{
+		To:      c.Email,
+		Subject: fmt.Sprintf("%s renews on %s", c.ServiceName, renewal.Format("2 January 2006")),
+		Body:    renewalNotice(c, renewal) + "\n\n" + reminderFooter,
+	}
+}
+
+const reminderFooter = "You can change when you are reminded, or turn reminders off, in the subscription's reminder settings.\n"
+
+// renewalNotices is the notice of a single reminder, or a list of them.
+func renewalNotices(reminders []dueReminder) string {
+	if len(reminders) == 1 {
+		return renewalNotice(reminders[0].candidate, reminders[0].renewal)
+	}
+	lines := make([]string, len(reminders))
+	for i, r := range reminders {
+		lines[i] = "- " + renewalNotice(r.candidate, r.renewal)
+	}
+	return fmt.Sprintf("%d subscriptions renew soon.\n", len(reminders)) + strings.Join(lines, "\n")
+}
+
+func renewalNotice(c domain.ReminderCandidate, renewal time.Time) string {
+	return fmt.Sprintf("Your %s subscription renews on %s for %d per %s.",
+		c.ServiceName, renewal.Format("2 January 2006"), c.Price, c.BillingPeriod.Unit())
+}
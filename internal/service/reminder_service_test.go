@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/mailer"
+	mailermocks "subtracker/internal/mailer/mocks"
+	"subtracker/internal/repository/mocks"
+	telegrammocks "subtracker/internal/telegram/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReminderService_SendDue(t *testing.T) {
+	today := time.Date(2025, 6, 28, 0, 0, 0, 0, time.UTC)
+	candidate := func(service, period string, start time.Time, end *time.Time, daysBefore int) dao.ReminderCandidateRow {
+		return dao.ReminderCandidateRow{
+			SubscriptionRow: dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New(), ServiceName: service, Price: 999, StartDate: start, EndDate: end, BillingPeriod: period},
+			Email:           "user@example.com",
+			DaysBefore:      daysBefore,
+		}
+	}
+	ended := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	monthly := candidate("Netflix", "monthly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil, 3)
+	weekly := candidate("Gym", "weekly", time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), nil, 2)
+	notYet := candidate("Spotify", "monthly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil, 1)
+	endedBefore := candidate("Hulu", "monthly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), &ended, 3)
+	alreadySent := candidate("iCloud", "yearly", time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), nil, 5)
+	setup := func() (*ReminderService, *mocks.ReminderRepositoryInterface, *mailermocks.Mailer) {
+		repo := new(mocks.ReminderRepositoryInterface)
+		mail := new(mailermocks.Mailer)
+		s := NewReminderService(repo, nil, mail, nil, 3, DigestDays{}, clock.NewFrozen(today.Add(9*time.Hour)), logger.NewNopLogger())
+		return s, repo, mail
+	}
+
+	t.Run("Sends Each Due Renewal Once", func(t *testing.T) {
+		s, repo, mail := setup()
+		repo.On("ListReminderCandidates", mock.Anything, 3).Return([]dao.ReminderCandidateRow{monthly, weekly, notYet, endedBefore, alreadySent}, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, monthly.ID, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, weekly.ID, time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, alreadySent.ID, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)).Return(false, nil).Once()
+		mail.On("Send", mock.Anything, mailer.Message{
+			To:      "user@example.com",
+			Subject: "Netflix renews on 1 July 2025",
+			Body:    "Your Netflix subscription renews on 1 July 2025 for 999 per month.\n\nYou can change when you are reminded, or turn reminders off, in the subscription's reminder settings.\n",
+		}).Return(nil).Once()
+		mail.On("Send", mock.Anything, mock.MatchedBy(func(msg mailer.Message) bool { return msg.Subject == "Gym renews on 30 June 2025" })).Return(nil).Once()
+
+		sent, err := s.SendDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		repo.AssertExpectations(t)
+		mail.AssertExpectations(t)
+	})
+
+	t.Run("Failed Email Is Released For Retry", func(t *testing.T) {
+		s, repo, mail := setup()
+		renewal := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+		repo.On("ListReminderCandidates", mock.Anything, 3).Return([]dao.ReminderCandidateRow{monthly}, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, monthly.ID, renewal).Return(true, nil).Once()
+		repo.On("ReleaseReminder", mock.Anything, monthly.ID, renewal).Return(nil).Once()
+		mail.On("Send", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		sent, err := s.SendDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Linked Chats Are Reminded Through Telegram", func(t *testing.T) {
+		s, repo, mail := setup()
+		bot := new(telegrammocks.Sender)
+		s.telegram = bot
+		chatID := int64(42)
+		linked := monthly
+		linked.TelegramChatID = &chatID
+		unreachable := weekly
+		unreachable.Email = ""
+		renewal := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+		repo.On("ListReminderCandidates", mock.Anything, 3).Return([]dao.ReminderCandidateRow{linked, unreachable}, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, linked.ID, renewal).Return(true, nil).Once()
+		bot.On("SendMessage", mock.Anything, chatID,
+			"Reminder: Your Netflix subscription renews on 1 July 2025 for 999 per month.\n\nSend /list to see all your subscriptions.").
+			Return(nil).Once()
+
+		sent, err := s.SendDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		repo.AssertExpectations(t)
+		bot.AssertExpectations(t)
+		mail.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Same Owner Gets One Digest", func(t *testing.T) {
+		s, repo, mail := setup()
+		gym := weekly
+		gym.UserID = monthly.UserID
+		repo.On("ListReminderCandidates", mock.Anything, 3).Return([]dao.ReminderCandidateRow{monthly, gym}, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, monthly.ID, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, gym.ID, time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+		mail.On("Send", mock.Anything, mailer.Message{
+			To:      "user@example.com",
+			Subject: "2 subscriptions renew soon",
+			Body: "2 subscriptions renew soon.\n" +
+				"- Your Gym subscription renews on 30 June 2025 for 999 per week.\n" +
+				"- Your Netflix subscription renews on 1 July 2025 for 999 per month.\n\n" +
+				"You can change when you are reminded, or turn reminders off, in the subscription's reminder settings.\n",
+		}).Return(nil).Once()
+
+		sent, err := s.SendDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		repo.AssertExpectations(t)
+		mail.AssertExpectations(t)
+	})
+
+	t.Run("Reminders Within Digest Days Join A Due One", func(t *testing.T) {
+		s, repo, mail := setup()
+		s.digest = DigestDays{Email: 2}
+		spotify := notYet
+		spotify.UserID = monthly.UserID
+		earlyOnly := notYet
+		earlyOnly.ID = uuid.New()
+		repo.On("ListReminderCandidates", mock.Anything, 3).Return([]dao.ReminderCandidateRow{monthly, spotify, earlyOnly}, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, monthly.ID, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, spotify.ID, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+		mail.On("Send", mock.Anything, mock.MatchedBy(func(msg mailer.Message) bool { return msg.Subject == "2 subscriptions renew soon" })).Return(nil).Once()
+
+		sent, err := s.SendDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		repo.AssertExpectations(t)
+		mail.AssertExpectations(t)
+	})
+
+	t.Run("Early Reminders Wait When The Due One Was Taken", func(t *testing.T) {
+		s, repo, mail := setup()
+		s.digest = DigestDays{Email: 2}
+		spotify := notYet
+		spotify.UserID = monthly.UserID
+		renewal := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+		repo.On("ListReminderCandidates", mock.Anything, 3).Return([]dao.ReminderCandidateRow{monthly, spotify}, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, monthly.ID, renewal).Return(false, nil).Once()
+		repo.On("ClaimReminder", mock.Anything, spotify.ID, renewal).Return(true, nil).Once()
+		repo.On("ReleaseReminder", mock.Anything, spotify.ID, renewal).Return(nil).Once()
+
+		sent, err := s.SendDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+		repo.AssertExpectations(t)
+		mail.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReminderService_GetReminder(t *testing.T) {
+	sub := dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Netflix", Price: 999}
+	setup := func() (*ReminderService, *mocks.ReminderRepositoryInterface) {
+		repo := new(mocks.ReminderRepositoryInterface)
+		subRepo := new(mocks.SubscriptionRepositoryInterface)
+		subRepo.On("GetSubscription", mock.Anything, sub.ID.String()).Return(sub, nil)
+		subs := NewSubscriptionService(subRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		return NewReminderService(repo, subs, nil, nil, 3, DigestDays{}, clock.System(), logger.NewNopLogger()), repo
+	}
+
+	t.Run("Defaults When Never Set", func(t *testing.T) {
+		s, repo := setup()
+		repo.On("GetReminderSetting", mock.Anything, sub.ID.String()).Return(dao.ReminderSettingRow{}, apperrors.NewNotFound("reminder setting not found", nil)).Once()
+
+		setting, err := s.GetReminder(context.Background(), sub.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, sub.ID, setting.SubscriptionID)
+		assert.True(t, setting.Enabled)
+		assert.Nil(t, setting.DaysBefore)
+		assert.Equal(t, 3, setting.EffectiveDaysBefore)
+	})
+
+	t.Run("Own Offset", func(t *testing.T) {
+		s, repo := setup()
+		days := 10
+		repo.On("GetReminderSetting", mock.Anything, sub.ID.String()).Return(dao.ReminderSettingRow{SubscriptionID: sub.ID, Enabled: true, DaysBefore: &days}, nil).Once()
+
+		setting, err := s.GetReminder(context.Background(), sub.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, setting.EffectiveDaysBefore)
+	})
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type ReportServiceInterface interface {
+	Savings(ctx context.Context, userID string, periodStart, periodEnd time.Time) (domain.SavingsReport, error)
+	ServiceBenchmark(ctx context.Context, serviceName string) (domain.ServiceBenchmark, bool, error)
+}
+
+type ReportService struct {
+	repo              repository.ReportingRepositoryInterface
+	benchmarkMinUsers int
+	logger            logger.Logger
+	clock             clock.Clock
+}
+
+func NewReportService(repo repository.ReportingRepositoryInterface, benchmarkMinUsers int, clock clock.Clock, logger logger.Logger) *ReportService {
+	return &ReportService{
+		repo:              repo,
+		benchmarkMinUsers: benchmarkMinUsers,
+		logger:            logger,
+		clock:             clock,
+	}
+}
+
+// Savings reports the subscriptions cancelled between the first month of the
+// period and the last, both inclusive.
+func (s *ReportService) Savings(ctx context.Context, userID string, periodStart, periodEnd time.Time) (domain.SavingsReport, error) {
+	s.logger.DebugContext(ctx, "Entering Savings service",
+		zap.String("user_id", userID),
+		zap.Time("period_start", periodStart),
+		zap.Time("period_end", periodEnd),
+	)
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return domain.SavingsReport{}, err
+	}
+
+	rows, err := s.repo.ListCancelled(ctx, userID, periodStart, periodEnd)
+	if err != nil {
+		return domain.SavingsReport{}, err
+	}
+
+	report := domain.SavingsReport{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Cancelled:   make([]domain.Subscription, len(rows)),
+	}
+	for i, row := range rows {
+		report.Cancelled[i] = mapper.ToDomainFromDAO(row)
+		period := report.Cancelled[i].BillingPeriod
+		report.MonthlySavings += period.MonthlyPrice(row.Price)
+		report.ProjectedAnnualSavings += period.Cost(row.Price, 12)
+	}
+
+	s.logger.InfoContext(ctx, "Savings report calculated successfully",
+		zap.Int("cancelled", len(rows)),
+		zap.Int("monthly_savings", report.MonthlySavings),
+	)
+	return report, nil
+}
+
+// ServiceBenchmark returns the average price of a service. ok is false when
+// fewer than benchmarkMinUsers users currently hold it, as the average would
+// then say too much about the individual users behind it.
+func (s *ReportService) ServiceBenchmark(ctx context.Context, serviceName string) (domain.ServiceBenchmark, bool, error) {
+	row, err := s.repo.ServiceBenchmark(ctx, serviceName, s.clock.Now())
+	if err != nil {
+		return domain.ServiceBenchmark{}, false, err
+	}
+	if row.Users < s.benchmarkMinUsers {
+		s.logger.DebugContext(ctx, "Too few users for a benchmark",
+			zap.String("service_name", serviceName),
+			zap.Int("users", row.Users),
+		)
+		return domain.ServiceBenchmark{}, false, nil
+	}
+	return domain.ServiceBenchmark{ServiceName: serviceName, AveragePrice: row.AveragePrice}, true, nil
+}
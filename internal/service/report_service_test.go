@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReportService_Savings(t *testing.T) {
+	userID := uuid.New().String()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Sums Cancelled Subscriptions", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewReportService(mockRepo, 3, clock.System(), logger.NewNopLogger())
+		ended := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("ListCancelled", mock.Anything, userID, from, to).Return([]dao.SubscriptionRow{
+			{ID: uuid.New(), ServiceName: "Netflix", Price: 999, EndDate: &ended},
+			{ID: uuid.New(), ServiceName: "Spotify", Price: 299, EndDate: &ended},
+		}, nil).Once()
+
+		report, err := service.Savings(context.Background(), userID, from, to)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Cancelled, 2)
+		assert.Equal(t, 1298, report.MonthlySavings)
+		assert.Equal(t, 15576, report.ProjectedAnnualSavings)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Normalizes Billing Periods", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewReportService(mockRepo, 3, clock.System(), logger.NewNopLogger())
+		ended := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("ListCancelled", mock.Anything, userID, from, to).Return([]dao.SubscriptionRow{
+			{ID: uuid.New(), ServiceName: "Adobe", Price: 1199, EndDate: &ended, BillingPeriod: "yearly"},
+			{ID: uuid.New(), ServiceName: "Gym", Price: 30, EndDate: &ended, BillingPeriod: "weekly"},
+		}, nil).Once()
+
+		report, err := service.Savings(context.Background(), userID, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100+130, report.MonthlySavings)
+		assert.Equal(t, 1199+1560, report.ProjectedAnnualSavings)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewReportService(mockRepo, 3, clock.System(), logger.NewNopLogger())
+		dbErr := errors.New("db down")
+		mockRepo.On("ListCancelled", mock.Anything, userID, from, to).Return(nil, dbErr).Once()
+
+		_, err := service.Savings(context.Background(), userID, from, to)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("Other User Forbidden", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewReportService(mockRepo, 3, clock.System(), logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		_, err := service.Savings(ctx, userID, from, to)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRepo.AssertNotCalled(t, "ListCancelled", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReportService_ServiceBenchmark(t *testing.T) {
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Enough Users", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewReportService(mockRepo, 3, clock.NewFrozen(now), logger.NewNopLogger())
+		mockRepo.On("ServiceBenchmark", mock.Anything, "Netflix", now).Return(dao.ServiceBenchmarkRow{Users: 3, AveragePrice: 520}, nil).Once()
+
+		benchmark, ok, err := service.ServiceBenchmark(context.Background(), "Netflix")
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 520, benchmark.AveragePrice)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Too Few Users", func(t *testing.T) {
+		mockRepo := new(mocks.ReportingRepositoryInterface)
+		service := NewReportService(mockRepo, 3, clock.NewFrozen(now), logger.NewNopLogger())
+		mockRepo.On("ServiceBenchmark", mock.Anything, "Niche", now).Return(dao.ServiceBenchmarkRow{Users: 2, AveragePrice: 100}, nil).Once()
+
+		_, ok, err := service.ServiceBenchmark(context.Background(), "Niche")
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type RuleServiceInterface interface {
+	CreateRule(ctx context.Context, rule domain.CategoryRule) (domain.CategoryRule, error)
+	ListRules(ctx context.Context, userID string) ([]domain.CategoryRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	ApplyRules(ctx context.Context, userID string, overwrite bool) (domain.RuleApplyResult, error)
+}
+
+type RuleService struct {
+	repo          repository.RuleRepositoryInterface
+	subscriptions repository.SubscriptionRepositoryInterface
+	audit         auditTrail
+	logger        logger.Logger
+}
+
+func NewRuleService(repo repository.RuleRepositoryInterface, subscriptions repository.SubscriptionRepositoryInterface, audit repository.AuditRepositoryInterface, clock clock.Clock, logger logger.Logger) *RuleService {
+	return &RuleService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		audit:         auditTrail{repo: audit, clock: clock, logger: logger},
+		logger:        logger,
+	}
+}
+
+// CreateRule adds a rule for its user, or a global rule when it has none.
+// Global rules categorize every user's subscriptions, so only admins may
+// create them.
+func (s *RuleService) CreateRule(ctx context.Context, rule domain.CategoryRule) (domain.CategoryRule, error) {
+	s.logger.DebugContext(ctx, "Entering CreateRule service",
+		zap.String("pattern", rule.Pattern),
+		zap.String("category", rule.Category),
+	)
+	if err := s.authorizeRule(ctx, rule.UserID); err != nil {
+		return domain.CategoryRule{}, err
+	}
+	if rule.ID == uuid.Nil {
+		rule.ID = uuid.New()
+		s.logger.DebugContext(ctx, "Generated new rule ID", zap.String("rule_id", rule.ID.String()))
+	}
+	if err := s.repo.CreateRule(ctx, mapper.ToRuleDAOFromDomain(rule)); err != nil {
+		return domain.CategoryRule{}, err
+	}
+	return rule, nil
+}
+
+// ListRules returns the rules that apply to userID in the order they are
+// tried, or only the global rules when userID is empty.
+func (s *RuleService) ListRules(ctx context.Context, userID string) ([]domain.CategoryRule, error) {
+	s.logger.DebugContext(ctx, "Entering ListRules service", zap.String("user_id", userID))
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]domain.CategoryRule, len(rows))
+	for i, row := range rows {
+		rules[i] = mapper.ToRuleDomainFromDAO(row)
+	}
+	return rules, nil
+}
+
+func (s *RuleService) DeleteRule(ctx context.Context, id string) error {
+	s.logger.DebugContext(ctx, "Entering DeleteRule service", zap.String("id", id))
+
+	existing, err := s.repo.GetRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.UserID != nil {
+		if err := authorizeOwner(ctx, *existing.UserID, "rule"); err != nil {
+			return err
+		}
+	} else if err := authorizeAdmin(ctx, "deleting a global rule"); err != nil {
+		return err
+	}
+	return s.repo.DeleteRule(ctx, id)
+}
+
+// authorizeRule checks that the caller may create rules for owner, where a
+// nil owner means a global rule.
+func (s *RuleService) authorizeRule(ctx context.Context, owner *uuid.UUID) error {
+	if owner == nil {
+		return authorizeAdmin(ctx, "a global rule")
+	}
+	_, err := ScopeUserID(ctx, owner.String())
+	return err
+}
+
+// ApplyRules categorizes the user's existing subscriptions. Subscriptions that
+// already have a category are only recategorized with overwrite, and none
+// loses its category because no rule matches it. The user is scoped like
+// ListRules, so only an admin can apply rules to every user at once.
+func (s *RuleService) ApplyRules(ctx context.Context, userID string, overwrite bool) (domain.RuleApplyResult, error) {
+	s.logger.DebugContext(ctx, "Entering ApplyRules service", zap.String("user_id", userID), zap.Bool("overwrite", overwrite))
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return domain.RuleApplyResult{}, err
+	}
+
+	rules, err := s.ListRules(ctx, userID)
+	if err != nil {
+		return domain.RuleApplyResult{}, err
+	}
+	subscriptions, err := s.subscriptions.ListSubscriptions(ctx, dto.SubscriptionFilter{UserID: userID})
+	if err != nil {
+		return domain.RuleApplyResult{}, err
+	}
+
+	result := domain.RuleApplyResult{Checked: len(subscriptions)}
+	for _, sub := range subscriptions {
+		if sub.Category != "" && !overwrite {
+			continue
+		}
+		category, ok := domain.MatchCategory(rules, sub.ServiceName)
+		if !ok || category == sub.Category {
+			continue
+		}
+		if err := s.subscriptions.UpdateCategory(ctx, sub.ID.String(), category); err != nil {
+			return result, err
+		}
+		before := mapper.ToDomainFromDAO(sub)
+		after := before
+		after.Category = category
+		s.audit.subscription(ctx, domain.AuditUpdate, &before, &after)
+		result.Updated++
+	}
+
+	s.logger.InfoContext(ctx, "Category rules applied",
+		zap.String("user_id", userID),
+		zap.Int("checked", result.Checked),
+		zap.Int("updated", result.Updated),
+	)
+	return result, nil
+}
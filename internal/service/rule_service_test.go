@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRuleService_ApplyRules(t *testing.T) {
+	userID := uuid.New()
+	rules := []dao.CategoryRuleRow{
+		{ID: uuid.New(), Pattern: "netflix", Category: "Entertainment"},
+		{ID: uuid.New(), Pattern: "notion", Category: "Work"},
+	}
+	uncategorized := dao.SubscriptionRow{ID: uuid.New(), UserID: userID, ServiceName: "Netflix"}
+	categorized := dao.SubscriptionRow{ID: uuid.New(), UserID: userID, ServiceName: "Notion", Category: "Productivity"}
+	unmatched := dao.SubscriptionRow{ID: uuid.New(), UserID: userID, ServiceName: "Yandex Plus", Category: "Bundles"}
+	subscriptions := []dao.SubscriptionRow{uncategorized, categorized, unmatched}
+
+	t.Run("Fills Only Empty Categories", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepositoryInterface)
+		mockSubs := new(mocks.SubscriptionRepositoryInterface)
+		service := NewRuleService(mockRules, mockSubs, nil, clock.System(), logger.NewNopLogger())
+
+		mockRules.On("ListRules", mock.Anything, userID.String()).Return(rules, nil).Once()
+		mockSubs.On("ListSubscriptions", mock.Anything, dto.SubscriptionFilter{UserID: userID.String()}).Return(subscriptions, nil).Once()
+		mockSubs.On("UpdateCategory", mock.Anything, uncategorized.ID.String(), "Entertainment").Return(nil).Once()
+
+		result, err := service.ApplyRules(context.Background(), userID.String(), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RuleApplyResult{Checked: 3, Updated: 1}, result)
+		mockSubs.AssertExpectations(t)
+	})
+
+	t.Run("Overwrite Recategorizes Matches Only", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepositoryInterface)
+		mockSubs := new(mocks.SubscriptionRepositoryInterface)
+		service := NewRuleService(mockRules, mockSubs, nil, clock.System(), logger.NewNopLogger())
+
+		mockRules.On("ListRules", mock.Anything, userID.String()).Return(rules, nil).Once()
+		mockSubs.On("ListSubscriptions", mock.Anything, mock.Anything).Return(subscriptions, nil).Once()
+		mockSubs.On("UpdateCategory", mock.Anything, uncategorized.ID.String(), "Entertainment").Return(nil).Once()
+		mockSubs.On("UpdateCategory", mock.Anything, categorized.ID.String(), "Work").Return(nil).Once()
+
+		result, err := service.ApplyRules(context.Background(), userID.String(), true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Updated)
+		mockSubs.AssertExpectations(t)
+	})
+
+	t.Run("Stops on Update Error", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepositoryInterface)
+		mockSubs := new(mocks.SubscriptionRepositoryInterface)
+		service := NewRuleService(mockRules, mockSubs, nil, clock.System(), logger.NewNopLogger())
+
+		dbErr := errors.New("db down")
+		mockRules.On("ListRules", mock.Anything, userID.String()).Return(rules, nil).Once()
+		mockSubs.On("ListSubscriptions", mock.Anything, mock.Anything).Return(subscriptions, nil).Once()
+		mockSubs.On("UpdateCategory", mock.Anything, mock.Anything, mock.Anything).Return(dbErr).Once()
+
+		_, err := service.ApplyRules(context.Background(), userID.String(), true)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("Unscoped Apply Stays With The Caller", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepositoryInterface)
+		mockSubs := new(mocks.SubscriptionRepositoryInterface)
+		service := NewRuleService(mockRules, mockSubs, nil, clock.System(), logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: userID, Role: domain.RoleUser})
+
+		mockRules.On("ListRules", mock.Anything, userID.String()).Return(rules, nil).Once()
+		mockSubs.On("ListSubscriptions", mock.Anything, dto.SubscriptionFilter{UserID: userID.String()}).Return([]dao.SubscriptionRow{}, nil).Once()
+
+		_, err := service.ApplyRules(ctx, "", true)
+
+		assert.NoError(t, err)
+		mockSubs.AssertExpectations(t)
+	})
+
+	t.Run("Other User Forbidden", func(t *testing.T) {
+		mockRules := new(mocks.RuleRepositoryInterface)
+		mockSubs := new(mocks.SubscriptionRepositoryInterface)
+		service := NewRuleService(mockRules, mockSubs, nil, clock.System(), logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		_, err := service.ApplyRules(ctx, userID.String(), true)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockSubs.AssertNotCalled(t, "UpdateCategory", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRuleService_Authorization(t *testing.T) {
+	owner := uuid.New()
+	asUser := WithPrincipal(context.Background(), domain.Principal{UserID: owner, Role: domain.RoleUser})
+	asAdmin := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleAdmin})
+	setup := func() (*RuleService, *mocks.RuleRepositoryInterface) {
+		mockRules := new(mocks.RuleRepositoryInterface)
+		return NewRuleService(mockRules, nil, nil, clock.System(), logger.NewNopLogger()), mockRules
+	}
+
+	t.Run("User Cannot Create A Global Rule", func(t *testing.T) {
+		service, mockRules := setup()
+
+		_, err := service.CreateRule(asUser, domain.CategoryRule{Pattern: "netflix", Category: "Entertainment"})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRules.AssertNotCalled(t, "CreateRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("User Cannot Create Another User's Rule", func(t *testing.T) {
+		service, mockRules := setup()
+		other := uuid.New()
+
+		_, err := service.CreateRule(asUser, domain.CategoryRule{UserID: &other, Pattern: "netflix", Category: "Entertainment"})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRules.AssertNotCalled(t, "CreateRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin Creates A Global Rule", func(t *testing.T) {
+		service, mockRules := setup()
+		mockRules.On("CreateRule", mock.Anything, mock.MatchedBy(func(row dao.CategoryRuleRow) bool { return row.UserID == nil })).Return(nil).Once()
+
+		_, err := service.CreateRule(asAdmin, domain.CategoryRule{Pattern: "netflix", Category: "Entertainment"})
+
+		assert.NoError(t, err)
+		mockRules.AssertExpectations(t)
+	})
+
+	t.Run("User Cannot Delete Another User's Rule", func(t *testing.T) {
+		service, mockRules := setup()
+		other := uuid.New()
+		id := uuid.NewString()
+		mockRules.On("GetRule", mock.Anything, id).Return(dao.CategoryRuleRow{UserID: &other}, nil).Once()
+
+		err := service.DeleteRule(asUser, id)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		mockRules.AssertNotCalled(t, "DeleteRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("User Cannot Delete A Global Rule", func(t *testing.T) {
+		service, mockRules := setup()
+		id := uuid.NewString()
+		mockRules.On("GetRule", mock.Anything, id).Return(dao.CategoryRuleRow{}, nil).Once()
+
+		err := service.DeleteRule(asUser, id)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRules.AssertNotCalled(t, "DeleteRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Owner Deletes Their Rule", func(t *testing.T) {
+		service, mockRules := setup()
+		id := uuid.NewString()
+		mockRules.On("GetRule", mock.Anything, id).Return(dao.CategoryRuleRow{UserID: &owner}, nil).Once()
+		mockRules.On("DeleteRule", mock.Anything, id).Return(nil).Once()
+
+		assert.NoError(t, service.DeleteRule(asUser, id))
+		mockRules.AssertExpectations(t)
+	})
+}
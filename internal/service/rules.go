@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"subtracker/internal/config"
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/domain/validate"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	WarningUnusualPrice       = "unusual_price"
+	WarningFarFutureStart     = "far_future_start"
+	WarningProbableDuplicate  = "probable_duplicate"
+	WarningQuotaNearlyReached = "quota_nearly_reached"
+
+	unusualPriceThreshold = 100000
+	farFutureStartYears   = 1
+	duplicateLookupLimit  = 20
+	// quotaWarningRatio is the share of the quota in use from which writes warn.
+	quotaWarningRatio = 0.9
+)
+
+// warningRules computes soft warnings for a subscription about to be written.
+// None of the rules block the write; a rule that cannot be evaluated is skipped.
+type warningRules struct {
+	repo   repository.SubscriptionRepositoryInterface
+	logger logger.Logger
+	clock  clock.Clock
+}
+
+func (w warningRules) check(ctx context.Context, sub domain.Subscription) []domain.Warning {
+	var warnings []domain.Warning
+	if warning, ok := w.unusualPrice(sub); ok {
+		warnings = append(warnings, warning)
+	}
+	if warning, ok := w.farFutureStart(sub); ok {
+		warnings = append(warnings, warning)
+	}
+	if warning, ok := w.probableDuplicate(ctx, sub); ok {
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+func (w warningRules) unusualPrice(sub domain.Subscription) (domain.Warning, bool) {
+	// Compare the monthly equivalent so a yearly plan is not flagged for its cycle length.
+	monthly := sub.BillingPeriod.MonthlyPrice(sub.Price)
+	switch {
+	case sub.Price == 0:
+		return domain.Warning{Code: WarningUnusualPrice, Message: "price is zero"}, true
+	case monthly > unusualPriceThreshold:
+		return domain.Warning{
+			Code:    WarningUnusualPrice,
+			Message: fmt.Sprintf("price %d is unusually high", sub.Price),
+		}, true
+	}
+	return domain.Warning{}, false
+}
+
+func (w warningRules) farFutureStart(sub domain.Subscription) (domain.Warning, bool) {
+	if sub.StartDate.After(w.clock.Now().AddDate(farFutureStartYears, 0, 0)) {
+		return domain.Warning{
+			Code:    WarningFarFutureStart,
+			Message: fmt.Sprintf("start date %s is more than %d year(s) ahead", sub.StartDate.Format("01-2006"), farFutureStartYears),
+		}, true
+	}
+	return domain.Warning{}, false
+}
+
+func (w warningRules) probableDuplicate(ctx context.Context, sub domain.Subscription) (domain.Warning, bool) {
+	existing, err := w.repo.ListSubscriptions(ctx, dto.SubscriptionFilter{
+		UserID:      sub.UserID.String(),
+		ServiceName: sub.ServiceName,
+		Limit:       duplicateLookupLimit,
+	})
+	if err != nil {
+		w.logger.WarnContext(ctx, "Skipping duplicate check", zap.Error(err))
+		return domain.Warning{}, false
+	}
+
+	for _, other := range existing {
+		if other.ID == sub.ID {
+			continue
+		}
+		if overlaps(sub.StartDate, sub.EndDate, other.StartDate, other.EndDate) {
+			return domain.Warning{
+				Code:    WarningProbableDuplicate,
+				Message: fmt.Sprintf("an overlapping %s subscription already exists (%s)", other.ServiceName, other.ID),
+			}, true
+		}
+	}
+	return domain.Warning{}, false
+}
+
+// overlaps reports whether two date ranges intersect; a nil end means open-ended.
+func overlaps(startA time.Time, endA *time.Time, startB time.Time, endB *time.Time) bool {
+	if endA != nil && endA.Before(startB) {
+		return false
+	}
+	if endB != nil && endB.Before(startA) {
+		return false
+	}
+	return true
+}
+
+const (
+	ErrCodeServiceName          = validate.CodeServiceName
+	ErrCodePriceOutOfRange      = validate.CodePriceOutOfRange
+	ErrCodeBillingPeriod        = validate.CodeBillingPeriod
+	ErrCodeEndBeforeStart       = validate.CodeEndBeforeStart
+	ErrCodeStartTooFarInPast    = validate.CodeStartTooFarInPast
+	ErrCodeStartTooFarInFuture  = validate.CodeStartTooFarInFuture
+	ErrCodeQuotaExceeded        = "quota_exceeded"
+	ErrCodeEffectiveNotFuture   = "effective_date_not_in_future"
+	ErrCodeEffectiveOutsideTerm = "effective_date_outside_term"
+	ErrCodeTrialEndInPast       = "trial_end_in_past"
+	ErrCodePaymentPeriodFuture  = "payment_period_in_future"
+	ErrCodePaymentOutsideTerm   = "payment_period_outside_term"
+	ErrCodeExpenseBillingPeriod = validate.CodeExpenseBillingPeriod
+	ErrCodeExpensePriceRequired = validate.CodeExpensePriceRequired
+)
+
+// DateLimits bounds how far a start date may lie from today, in years.
+// A zero bound disables that side of the check.
+type DateLimits struct {
+	YearsPast   int
+	YearsFuture int
+}
+
+func NewDateLimits(cfg config.AppConfig) DateLimits {
+	return DateLimits{YearsPast: cfg.StartDateMaxYearsPast, YearsFuture: cfg.StartDateMaxYearsFuture}
+}
+
+// validateDates rejects date ranges that cannot describe a real subscription.
+// Unlike warnings these block the write.
+func (l DateLimits) validateDates(sub domain.Subscription, now time.Time) error {
+	return ruleError(validate.All(
+		validate.DateRange(sub.StartDate, sub.EndDate),
+		l.startDate(sub.StartDate, now),
+	))
+}
+
+func (l DateLimits) validateStartDate(start, now time.Time) error {
+	return ruleError(l.startDate(start, now)())
+}
+
+func (l DateLimits) startDate(start, now time.Time) validate.Rule {
+	return validate.StartDate(start, now, l.YearsPast, l.YearsFuture)
+}
+
+// validateSubscription applies the rules every stored subscription satisfies,
+// whichever path writes it. The start date limits are checked separately,
+// since edits that leave an old start date alone are exempt from them.
+func validateSubscription(sub domain.Subscription) error {
+	return ruleError(validate.All(
+		validate.ServiceName(sub.ServiceName),
+		validate.Price(sub.Price),
+		validate.BillingPeriod(sub.BillingPeriod),
+		validate.DateRange(sub.StartDate, sub.EndDate),
+		validate.Expense(sub.ExpenseType, sub.BillingPeriod, sub.Price),
+	))
+}
+
+// ruleError turns a broken rule into a 400 carrying the rule's code.
+func ruleError(err error) error {
+	var ruleErr *validate.Error
+	if errors.As(err, &ruleErr) {
+		return apperrors.NewBadRequest(ruleErr.Message, nil).WithErrorCode(ruleErr.Code)
+	}
+	return err
+}
+
+// quotaWarning warns once usage reaches quotaWarningRatio of the quota, so
+// clients can prompt for an upgrade before creates start failing.
+func quotaWarning(quota domain.QuotaStatus) (domain.Warning, bool) {
+	if !quota.Enabled() || float64(quota.Used) < quotaWarningRatio*float64(quota.Limit) {
+		return domain.Warning{}, false
+	}
+	return domain.Warning{
+		Code:    WarningQuotaNearlyReached,
+		Message: fmt.Sprintf("%d of %d subscriptions used", quota.Used, quota.Limit),
+	}, true
+}
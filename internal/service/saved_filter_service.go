@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// savedFilterParams lists the ListSubscriptions query params a saved filter may store.
+// user_id is deliberately absent: a saved filter always applies to its owner.
+var savedFilterParams = map[string]struct{}{
+	"service_name": {},
+	"min_price":    {},
+	"max_price":    {},
+	"start_date":   {},
+	"end_date":     {},
+	"has_end_date": {},
+	"cost_center":  {},
+	"category":     {},
+	"past_due":     {},
+	"expense_type": {},
+}
+
+type SavedFilterServiceInterface interface {
+	CreateSavedFilter(ctx context.Context, filter domain.SavedFilter) (domain.SavedFilter, error)
+	ListSavedFilters(ctx context.Context, userID string) ([]domain.SavedFilter, error)
+	GetSavedFilter(ctx context.Context, id string) (domain.SavedFilter, error)
+	UpdateSavedFilter(ctx context.Context, filter domain.SavedFilter) error
+	DeleteSavedFilter(ctx context.Context, id string) error
+}
+
+type SavedFilterService struct {
+	repo   repository.SavedFilterRepositoryInterface
+	logger logger.Logger
+}
+
+func NewSavedFilterService(repo repository.SavedFilterRepositoryInterface, logger logger.Logger) *SavedFilterService {
+	return &SavedFilterService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func validateSavedFilterParams(params map[string]string) error {
+	for key := range params {
+		if _, ok := savedFilterParams[key]; !ok {
+			return apperrors.NewBadRequest(fmt.Sprintf("unsupported saved filter param '%s'", key), nil)
+		}
+	}
+	return nil
+}
+
+func (s *SavedFilterService) CreateSavedFilter(ctx context.Context, filter domain.SavedFilter) (domain.SavedFilter, error) {
+	s.logger.DebugContext(ctx, "Entering CreateSavedFilter service",
+		zap.String("user_id", filter.UserID.String()),
+		zap.String("name", filter.Name),
+	)
+	if _, err := ScopeUserID(ctx, filter.UserID.String()); err != nil {
+		return domain.SavedFilter{}, err
+	}
+	if err := validateSavedFilterParams(filter.Params); err != nil {
+		return domain.SavedFilter{}, err
+	}
+	if filter.ID == uuid.Nil {
+		filter.ID = uuid.New()
+		s.logger.DebugContext(ctx, "Generated new saved filter ID", zap.String("saved_filter_id", filter.ID.String()))
+	}
+
+	row, err := mapper.ToSavedFilterDAOFromDomain(filter)
+	if err != nil {
+		return domain.SavedFilter{}, apperrors.NewInternalServerError("failed to encode saved filter params", err)
+	}
+	if err := s.repo.CreateSavedFilter(ctx, row); err != nil {
+		return domain.SavedFilter{}, err
+	}
+	return filter, nil
+}
+
+func (s *SavedFilterService) ListSavedFilters(ctx context.Context, userID string) ([]domain.SavedFilter, error) {
+	s.logger.DebugContext(ctx, "Entering ListSavedFilters service", zap.String("user_id", userID))
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListSavedFilters(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	filters := make([]domain.SavedFilter, len(rows))
+	for i, row := range rows {
+		f, err := mapper.ToSavedFilterDomainFromDAO(row)
+		if err != nil {
+			return nil, apperrors.NewInternalServerError("failed to decode saved filter params", err)
+		}
+		filters[i] = f
+	}
+	return filters, nil
+}
+
+func (s *SavedFilterService) GetSavedFilter(ctx context.Context, id string) (domain.SavedFilter, error) {
+	s.logger.DebugContext(ctx, "Entering GetSavedFilter service", zap.String("id", id))
+
+	row, err := s.getOwned(ctx, id)
+	if err != nil {
+		return domain.SavedFilter{}, err
+	}
+	f, err := mapper.ToSavedFilterDomainFromDAO(row)
+	if err != nil {
+		return domain.SavedFilter{}, apperrors.NewInternalServerError("failed to decode saved filter params", err)
+	}
+	return f, nil
+}
+
+func (s *SavedFilterService) UpdateSavedFilter(ctx context.Context, filter domain.SavedFilter) error {
+	s.logger.DebugContext(ctx, "Entering UpdateSavedFilter service", zap.String("saved_filter_id", filter.ID.String()))
+
+	if err := validateSavedFilterParams(filter.Params); err != nil {
+		return err
+	}
+	existing, err := s.getOwned(ctx, filter.ID.String())
+	if err != nil {
+		return err
+	}
+	filter.UserID = existing.UserID
+
+	row, err := mapper.ToSavedFilterDAOFromDomain(filter)
+	if err != nil {
+		return apperrors.NewInternalServerError("failed to encode saved filter params", err)
+	}
+	return s.repo.UpdateSavedFilter(ctx, row)
+}
+
+func (s *SavedFilterService) DeleteSavedFilter(ctx context.Context, id string) error {
+	s.logger.DebugContext(ctx, "Entering DeleteSavedFilter service", zap.String("id", id))
+	if _, err := s.getOwned(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteSavedFilter(ctx, id)
+}
+
+// getOwned reads a saved filter, reporting other users' filters as not found.
+func (s *SavedFilterService) getOwned(ctx context.Context, id string) (dao.SavedFilterRow, error) {
+	row, err := s.repo.GetSavedFilter(ctx, id)
+	if err != nil {
+		return dao.SavedFilterRow{}, err
+	}
+	if err := authorizeOwner(ctx, row.UserID, "saved filter"); err != nil {
+		return dao.SavedFilterRow{}, err
+	}
+	return row, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSavedFilterService_CreateSavedFilter(t *testing.T) {
+	t.Run("Success - Generates ID", func(t *testing.T) {
+		mockRepo := new(mocks.SavedFilterRepositoryInterface)
+		service := NewSavedFilterService(mockRepo, logger.NewNopLogger())
+
+		filter := domain.SavedFilter{UserID: uuid.New(), Name: "Work tools", Params: map[string]string{"min_price": "1000"}}
+		mockRepo.On("CreateSavedFilter", mock.Anything, mock.MatchedBy(func(row dao.SavedFilterRow) bool {
+			return row.ID != uuid.Nil && string(row.Params) == `{"min_price":"1000"}`
+		})).Return(nil).Once()
+
+		created, err := service.CreateSavedFilter(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, created.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unsupported Param", func(t *testing.T) {
+		mockRepo := new(mocks.SavedFilterRepositoryInterface)
+		service := NewSavedFilterService(mockRepo, logger.NewNopLogger())
+
+		filter := domain.SavedFilter{UserID: uuid.New(), Name: "Sneaky", Params: map[string]string{"user_id": uuid.NewString()}}
+		_, err := service.CreateSavedFilter(context.Background(), filter)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		mockRepo.AssertNotCalled(t, "CreateSavedFilter", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSavedFilterService_GetSavedFilter(t *testing.T) {
+	mockRepo := new(mocks.SavedFilterRepositoryInterface)
+	service := NewSavedFilterService(mockRepo, logger.NewNopLogger())
+
+	id := uuid.New()
+	row := dao.SavedFilterRow{ID: id, UserID: uuid.New(), Name: "A", Params: []byte(`{"service_name":"Netflix"}`)}
+	mockRepo.On("GetSavedFilter", mock.Anything, id.String()).Return(row, nil).Once()
+
+	result, err := service.GetSavedFilter(context.Background(), id.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"service_name": "Netflix"}, result.Params)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSavedFilterService_UpdateSavedFilter(t *testing.T) {
+	mockRepo := new(mocks.SavedFilterRepositoryInterface)
+	service := NewSavedFilterService(mockRepo, logger.NewNopLogger())
+
+	id := uuid.New()
+	ownerID := uuid.New()
+	mockRepo.On("GetSavedFilter", mock.Anything, id.String()).
+		Return(dao.SavedFilterRow{ID: id, UserID: ownerID}, nil).Once()
+	mockRepo.On("UpdateSavedFilter", mock.Anything, mock.MatchedBy(func(row dao.SavedFilterRow) bool {
+		return row.ID == id && row.UserID == ownerID && row.Name == "Renamed"
+	})).Return(nil).Once()
+
+	err := service.UpdateSavedFilter(context.Background(), domain.SavedFilter{ID: id, Name: "Renamed"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSavedFilterService_Authorization(t *testing.T) {
+	caller := uuid.New()
+	ctx := WithPrincipal(context.Background(), domain.Principal{UserID: caller, Role: domain.RoleUser})
+	other := dao.SavedFilterRow{ID: uuid.New(), UserID: uuid.New(), Name: "Theirs", Params: []byte(`{}`)}
+
+	t.Run("Create For Another User Forbidden", func(t *testing.T) {
+		mockRepo := new(mocks.SavedFilterRepositoryInterface)
+		service := NewSavedFilterService(mockRepo, logger.NewNopLogger())
+
+		_, err := service.CreateSavedFilter(ctx, domain.SavedFilter{UserID: uuid.New(), Name: "Sneaky"})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRepo.AssertNotCalled(t, "CreateSavedFilter", mock.Anything, mock.Anything)
+	})
+
+	t.Run("List For Another User Forbidden", func(t *testing.T) {
+		mockRepo := new(mocks.SavedFilterRepositoryInterface)
+		service := NewSavedFilterService(mockRepo, logger.NewNopLogger())
+
+		_, err := service.ListSavedFilters(ctx, uuid.NewString())
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRepo.AssertNotCalled(t, "ListSavedFilters", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Another User's Filter Is Hidden", func(t *testing.T) {
+		mockRepo := new(mocks.SavedFilterRepositoryInterface)
+		service := NewSavedFilterService(mockRepo, logger.NewNopLogger())
+		mockRepo.On("GetSavedFilter", mock.Anything, other.ID.String()).Return(other, nil)
+
+		_, getErr := service.GetSavedFilter(ctx, other.ID.String())
+		updateErr := service.UpdateSavedFilter(ctx, domain.SavedFilter{ID: other.ID, Name: "Mine now"})
+		deleteErr := service.DeleteSavedFilter(ctx, other.ID.String())
+
+		for _, err := range []error{getErr, updateErr, deleteErr} {
+			var appErr *apperrors.AppError
+			assert.True(t, errors.As(err, &appErr))
+			assert.Equal(t, http.StatusNotFound, appErr.Code)
+		}
+		mockRepo.AssertNotCalled(t, "UpdateSavedFilter", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "DeleteSavedFilter", mock.Anything, mock.Anything)
+	})
+}
@@ -1,16 +1,69 @@
 package service
 
 import (
+	"subtracker/internal/buildinfo"
+	"subtracker/internal/config"
+	"subtracker/internal/domain"
+	"subtracker/internal/mailer"
 	"subtracker/internal/repository"
+	"subtracker/internal/telegram"
+	"subtracker/internal/webhook"
+	"subtracker/migrations"
+	"subtracker/pkg/clock"
 	"subtracker/pkg/logger"
 )
 
 type Service struct {
 	SubscriptionService *SubscriptionService
+	SavedFilterService  *SavedFilterService
+	HealthService       *HealthService
+	IntegrityService    *IntegrityService
+	SyncService         *SyncService
+	ReportService       *ReportService
+	RuleService         *RuleService
+	UserService         *UserService
+	SuggestionService   *SuggestionService
+	ReminderService     *ReminderService
+	WebhookService      *WebhookService
+	PriceChangeService  *PriceChangeService
+	TrialService        *TrialService
+	PaymentService      *PaymentService
+	IntegrationService  *IntegrationService
+	FeatureFlagService  *FeatureFlagService
+	// AuthService is nil unless authentication is enabled.
+	AuthService *AuthService
+	// TelegramService is nil unless the Telegram bot is enabled.
+	TelegramService *TelegramService
 }
 
-func NewService(repo *repository.Repository, logger logger.Logger) *Service {
-	return &Service{
-		SubscriptionService: NewSubscriptionService(repo.SubscriptionRepository, logger),
+// NewService wires every service. mailer and bot are nil while email and the
+// Telegram bot are not configured; hooks sends webhook deliveries. Every
+// service reads the current time from clock.
+func NewService(repo *repository.Repository, mailer mailer.Mailer, bot telegram.Sender, hooks webhook.Sender, cfg config.AppConfig, telegramCfg config.TelegramConfig, webhookCfg config.WebhookConfig, clock clock.Clock, logger logger.Logger) *Service {
+	subscriptions := NewSubscriptionService(repo.SubscriptionRepository, repo.ReportingRepository, repo.RuleRepository, repo.AuditRepository, NewDateLimits(cfg), cfg.SubscriptionQuota, cfg.UndoWindow, TimeOrderedIDs(), clock, logger)
+	service := &Service{
+		SubscriptionService: subscriptions,
+		SavedFilterService:  NewSavedFilterService(repo.SavedFilterRepository, logger),
+		HealthService:       NewHealthService(repo.HealthRepository, migrations.LatestVersion(), domain.BuildInfo{Version: buildinfo.Version, Commit: buildinfo.Revision()}, clock, logger),
+		IntegrityService:    NewIntegrityService(repo.ReportingRepository, clock, logger),
+		SyncService:         NewSyncService(repo.SyncRepository, subscriptions, logger),
+		ReportService:       NewReportService(repo.ReportingRepository, cfg.BenchmarkMinUsers, clock, logger),
+		RuleService:         NewRuleService(repo.RuleRepository, repo.SubscriptionRepository, repo.AuditRepository, clock, logger),
+		UserService:         NewUserService(repo.UserRepository, logger),
+		SuggestionService:   NewSuggestionService(repo.SuggestionRepository, subscriptions, logger),
+		ReminderService:     NewReminderService(repo.ReminderRepository, subscriptions, mailer, bot, cfg.ReminderDaysBefore, NewDigestDays(cfg), clock, logger),
+		WebhookService:      NewWebhookService(repo.WebhookRepository, hooks, webhookCfg.MaxAttempts, webhookCfg.Timeout, clock, logger),
+		PriceChangeService:  NewPriceChangeService(repo.PriceChangeRepository, subscriptions, repo.AuditRepository, TimeOrderedIDs(), clock, logger),
+		TrialService:        NewTrialService(repo.TrialRepository, subscriptions, mailer, bot, repo.AuditRepository, clock, logger),
+		PaymentService:      NewPaymentService(repo.PaymentRepository, subscriptions, clock, logger),
+		IntegrationService:  NewIntegrationService(repo.IntegrationRepository, clock, logger),
+		FeatureFlagService:  NewFeatureFlagService(repo.FeatureFlagRepository, cfg.Environment, cfg.FeatureFlagRefresh, clock, logger),
 	}
+	if cfg.AuthEnabled() {
+		service.AuthService = NewAuthService(repo.UserRepository, string(cfg.JWTSecret), cfg.TokenTTL, clock, logger)
+	}
+	if bot != nil {
+		service.TelegramService = NewTelegramService(repo.TelegramRepository, subscriptions, bot, telegramCfg.LinkCodeTTL, clock, logger)
+	}
+	return service
 }
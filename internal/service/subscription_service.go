@@ -2,61 +2,282 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
 	"time"
 
 	"subtracker/internal/domain"
 	"subtracker/internal/domain/dao"
 	"subtracker/internal/domain/dto"
 	"subtracker/internal/mapper"
+	"subtracker/internal/metrics"
 	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
 	"subtracker/pkg/logger"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 type SubscriptionServiceInterface interface {
-	CreateSubscription(ctx context.Context, subDomain domain.Subscription) error
+	CreateSubscription(ctx context.Context, subDomain domain.Subscription) ([]domain.Warning, error)
+	CreateSubscriptions(ctx context.Context, subs []domain.Subscription) ([]CreateResult, error)
 	ListSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) ([]domain.Subscription, error)
+	CountSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) (int, error)
+	ExportSubscriptions(ctx context.Context, filter dto.SubscriptionFilter, fn func(domain.Subscription) error) error
 	GetSubscription(ctx context.Context, id string) (domain.Subscription, error)
-	UpdateSubscription(ctx context.Context, subDomain domain.Subscription) error
-	DeleteSubscription(ctx context.Context, id string) error
+	LookupSubscriptions(ctx context.Context, ids []string) (domain.LookupResult, error)
+	UpdateSubscription(ctx context.Context, subDomain domain.Subscription) ([]domain.Warning, error)
+	PatchSubscription(ctx context.Context, id string, patch domain.SubscriptionPatch) ([]domain.Warning, error)
+	DeleteSubscription(ctx context.Context, id string) (domain.UndoToken, error)
+	UndoDelete(ctx context.Context, token string) (domain.Subscription, error)
+	ListTrash(ctx context.Context, filter dto.TrashFilter) ([]domain.TrashedSubscription, error)
+	RestoreSubscriptions(ctx context.Context, userID string, ids []string) (domain.RestoreResult, error)
+	SubscriptionHistory(ctx context.Context, id string, limit int) ([]domain.AuditEntry, error)
 	CalculateCost(ctx context.Context, filter dto.CostFilter) (int, error)
+	CalculateCostBatch(ctx context.Context, filters []dto.CostFilter) []CostResult
+	CostByCostCenter(ctx context.Context, filter dto.CostFilter) ([]domain.CostCenterCost, error)
+	QuotaStatus(ctx context.Context, userID string) (domain.QuotaStatus, error)
+}
+
+// costBatchWorkers bounds how many cost calculations of a batch hit the database at once.
+const costBatchWorkers = 8
+
+// CostResult is the outcome of one calculation in a batch.
+type CostResult struct {
+	TotalCost int
+	Err       error
+}
+
+// CreateResult is the outcome of one subscription in a batch create. Err is
+// set when the subscription was not created.
+type CreateResult struct {
+	ID       uuid.UUID
+	Warnings []domain.Warning
+	Err      error
 }
 
 type SubscriptionService struct {
-	repo   repository.SubscriptionRepositoryInterface
+	repo    repository.SubscriptionRepositoryInterface
+	reports repository.ReportingRepositoryInterface
+	// categories supplies the rules that categorize new subscriptions; nil
+	// disables auto-categorization.
+	categories repository.RuleRepositoryInterface
+	audit      auditTrail
+	rules      warningRules
+	dates      DateLimits
+	quota      int
+	// undoWindow is how long the token returned by a delete stays valid.
+	undoWindow time.Duration
+	// ids generates the IDs of subscriptions created without one.
+	ids IDGenerator
+	// costs coalesces concurrent identical cost calculations into one, keyed
+	// by costFilterKey.
+	costs  singleflight.Group
+	clock  clock.Clock
 	logger logger.Logger
 }
 
-func NewSubscriptionService(repo repository.SubscriptionRepositoryInterface, logger logger.Logger) *SubscriptionService {
+func NewSubscriptionService(repo repository.SubscriptionRepositoryInterface, reports repository.ReportingRepositoryInterface, categories repository.RuleRepositoryInterface, audit repository.AuditRepositoryInterface, dates DateLimits, quota int, undoWindow time.Duration, ids IDGenerator, clock clock.Clock, logger logger.Logger) *SubscriptionService {
 	return &SubscriptionService{
-		repo:   repo,
-		logger: logger,
+		repo:       repo,
+		reports:    reports,
+		categories: categories,
+		audit:      auditTrail{repo: audit, clock: clock, logger: logger},
+		rules:      warningRules{repo: repo, logger: logger, clock: clock},
+		dates:      dates,
+		quota:      quota,
+		undoWindow: undoWindow,
+		ids:        ids,
+		clock:      clock,
+		logger:     logger,
 	}
 }
 
-func (s *SubscriptionService) CreateSubscription(ctx context.Context, subDomain domain.Subscription) error {
-	s.logger.Debug("Entering CreateSubscription service",
+func (s *SubscriptionService) CreateSubscription(ctx context.Context, subDomain domain.Subscription) ([]domain.Warning, error) {
+	s.logger.DebugContext(ctx, "Entering CreateSubscription service",
 		zap.String("service_name", subDomain.ServiceName),
 		zap.String("user_id", subDomain.UserID.String()),
 	)
+	if _, err := ScopeUserID(ctx, subDomain.UserID.String()); err != nil {
+		return nil, err
+	}
 	if subDomain.ID == uuid.Nil {
-		subDomain.ID = uuid.New()
-		s.logger.Debug("Generated new subscription ID", zap.String("subscription_id", subDomain.ID.String()))
+		subDomain.ID = s.ids.NewID()
+		s.logger.DebugContext(ctx, "Generated new subscription ID", zap.String("subscription_id", subDomain.ID.String()))
+	}
+	if subDomain.BillingPeriod == "" {
+		subDomain.BillingPeriod = domain.BillingMonthly
+	}
+	if subDomain.ExpenseType == "" {
+		subDomain.ExpenseType = domain.ExpenseSubscription
+	}
+	if err := validateSubscription(subDomain); err != nil {
+		return nil, err
+	}
+	if err := s.dates.validateStartDate(subDomain.StartDate, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	quota, err := s.QuotaStatus(ctx, subDomain.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+	if quota.Enabled() && quota.Remaining() == 0 {
+		return nil, apperrors.New(http.StatusForbidden, fmt.Sprintf("subscription quota of %d reached", quota.Limit), nil).
+			WithErrorCode(ErrCodeQuotaExceeded)
+	}
+
+	if subDomain.Category == "" {
+		subDomain.Category = s.autoCategory(ctx, subDomain)
 	}
+	warnings := s.rules.check(ctx, subDomain)
 	subDao := mapper.ToDAOFromDomain(subDomain)
-	return s.repo.CreateSubscription(ctx, subDao)
+	if err := s.repo.CreateSubscription(ctx, subDao); err != nil {
+		return nil, err
+	}
+	metrics.SubscriptionsCreated.Inc()
+	s.audit.subscription(ctx, domain.AuditCreate, nil, &subDomain)
+
+	quota.Used++
+	if warning, ok := quotaWarning(quota); ok {
+		warnings = append(warnings, warning)
+	}
+	return warnings, nil
+}
+
+// CreateSubscriptions creates subs in a single transaction and reports an
+// outcome for each, in order. A subscription that fails validation or would
+// exceed its user's quota is reported and left out while the rest are still
+// created; an error is only returned when the write itself fails, and then
+// nothing is created.
+func (s *SubscriptionService) CreateSubscriptions(ctx context.Context, subs []domain.Subscription) ([]CreateResult, error) {
+	s.logger.DebugContext(ctx, "Entering CreateSubscriptions service", zap.Int("subscriptions", len(subs)))
+
+	results := make([]CreateResult, len(subs))
+	quotas := make(map[uuid.UUID]domain.QuotaStatus)
+	created := make([]domain.Subscription, 0, len(subs))
+	for i, sub := range subs {
+		if _, err := ScopeUserID(ctx, sub.UserID.String()); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if sub.ID == uuid.Nil {
+			sub.ID = s.ids.NewID()
+		}
+		if sub.BillingPeriod == "" {
+			sub.BillingPeriod = domain.BillingMonthly
+		}
+		if sub.ExpenseType == "" {
+			sub.ExpenseType = domain.ExpenseSubscription
+		}
+		if err := validateSubscription(sub); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err := s.dates.validateStartDate(sub.StartDate, s.clock.Now()); err != nil {
+			results[i].Err = err
+			continue
+		}
+		quota, ok := quotas[sub.UserID]
+		if !ok {
+			var err error
+			if quota, err = s.QuotaStatus(ctx, sub.UserID.String()); err != nil {
+				return nil, err
+			}
+		}
+		if quota.Enabled() && quota.Remaining() == 0 {
+			results[i].Err = apperrors.New(http.StatusForbidden, fmt.Sprintf("subscription quota of %d reached", quota.Limit), nil).
+				WithErrorCode(ErrCodeQuotaExceeded)
+			quotas[sub.UserID] = quota
+			continue
+		}
+		quota.Used++
+		quotas[sub.UserID] = quota
+
+		if sub.Category == "" {
+			sub.Category = s.autoCategory(ctx, sub)
+		}
+		results[i].ID = sub.ID
+		results[i].Warnings = s.rules.check(ctx, sub)
+		if warning, ok := quotaWarning(quota); ok {
+			results[i].Warnings = append(results[i].Warnings, warning)
+		}
+		created = append(created, sub)
+	}
+	if len(created) == 0 {
+		return results, nil
+	}
+
+	rows := make([]dao.SubscriptionRow, len(created))
+	for i, sub := range created {
+		rows[i] = mapper.ToDAOFromDomain(sub)
+	}
+	if err := s.repo.CreateSubscriptions(ctx, rows); err != nil {
+		return nil, err
+	}
+	metrics.SubscriptionsCreated.Add(float64(len(created)))
+	for i := range created {
+		s.audit.subscription(ctx, domain.AuditCreate, nil, &created[i])
+	}
+	s.logger.DebugContext(ctx, "Exiting CreateSubscriptions service", zap.Int("created", len(created)))
+	return results, nil
+}
+
+// autoCategory returns the category of the first rule matching the
+// subscription. Categorization is best effort: a failed rule lookup is logged
+// and leaves the subscription uncategorized.
+func (s *SubscriptionService) autoCategory(ctx context.Context, sub domain.Subscription) string {
+	if s.categories == nil {
+		return ""
+	}
+	rows, err := s.categories.ListRules(ctx, sub.UserID.String())
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load category rules, leaving subscription uncategorized",
+			zap.String("user_id", sub.UserID.String()),
+			zap.Error(err),
+		)
+		return ""
+	}
+	rules := make([]domain.CategoryRule, len(rows))
+	for i, row := range rows {
+		rules[i] = mapper.ToRuleDomainFromDAO(row)
+	}
+	category, _ := domain.MatchCategory(rules, sub.ServiceName)
+	return category
+}
+
+// QuotaStatus reports the user's quota usage. It does not touch the database
+// when no quota is configured.
+func (s *SubscriptionService) QuotaStatus(ctx context.Context, userID string) (domain.QuotaStatus, error) {
+	if s.quota <= 0 {
+		return domain.QuotaStatus{}, nil
+	}
+	used, err := s.repo.CountUserSubscriptions(ctx, userID)
+	if err != nil {
+		return domain.QuotaStatus{}, err
+	}
+	return domain.QuotaStatus{Limit: s.quota, Used: used}, nil
 }
 
 func (s *SubscriptionService) ListSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) ([]domain.Subscription, error) {
-	s.logger.Debug("Filtering subscriptions", zap.String("user_id", filter.UserID),
+	s.logger.DebugContext(ctx, "Filtering subscriptions", zap.String("user_id", filter.UserID),
 		zap.String("service_name", filter.ServiceName),
 		zap.String("start_date", filter.StartDate),
 		zap.String("end_date", filter.EndDate),
 		zap.Int("limit", filter.Limit),
 		zap.Int("offset", filter.Offset),
 	)
+	userID, err := ScopeUserID(ctx, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = userID
 	subscriptions, err := s.repo.ListSubscriptions(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -65,111 +286,630 @@ func (s *SubscriptionService) ListSubscriptions(ctx context.Context, filter dto.
 	for i, sub := range subscriptions {
 		subDomainList[i] = mapper.ToDomainFromDAO(sub)
 	}
-	s.logger.Debug("Exiting ListSubscriptions service", zap.Int("count", len(subDomainList)))
+	s.logger.DebugContext(ctx, "Exiting ListSubscriptions service", zap.Int("count", len(subDomainList)))
 
 	return subDomainList, nil
 }
 
+// CountSubscriptions returns how many subscriptions match filter regardless
+// of its page. It is scoped like ListSubscriptions.
+func (s *SubscriptionService) CountSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) (int, error) {
+	userID, err := ScopeUserID(ctx, filter.UserID)
+	if err != nil {
+		return 0, err
+	}
+	filter.UserID = userID
+	return s.repo.CountSubscriptions(ctx, filter)
+}
+
+// ExportSubscriptions passes every subscription matching filter to fn as it
+// is read, so exports of any size run in constant memory. It is scoped like
+// ListSubscriptions; an error from fn aborts the export.
+func (s *SubscriptionService) ExportSubscriptions(ctx context.Context, filter dto.SubscriptionFilter, fn func(domain.Subscription) error) error {
+	userID, err := ScopeUserID(ctx, filter.UserID)
+	if err != nil {
+		return err
+	}
+	filter.UserID = userID
+	count := 0
+	err = s.repo.StreamSubscriptions(ctx, filter, func(row dao.SubscriptionRow) error {
+		count++
+		return fn(mapper.ToDomainFromDAO(row))
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.DebugContext(ctx, "Exiting ExportSubscriptions service", zap.Int("count", count))
+	return nil
+}
+
 func (s *SubscriptionService) GetSubscription(ctx context.Context, id string) (domain.Subscription, error) {
-	s.logger.Debug("Entering GetSubscription service", zap.String("id", id))
+	s.logger.DebugContext(ctx, "Entering GetSubscription service", zap.String("id", id))
 	subDao, err := s.repo.GetSubscription(ctx, id)
 	if err != nil {
 		return domain.Subscription{}, err
 	}
+	if err := authorizeOwner(ctx, subDao.UserID, "subscription"); err != nil {
+		return domain.Subscription{}, err
+	}
 	return mapper.ToDomainFromDAO(subDao), nil
 }
 
-func (s *SubscriptionService) UpdateSubscription(ctx context.Context, subToUpdate domain.Subscription) error {
-	s.logger.Debug("Entering UpdateSubscription service",
+// LookupSubscriptions resolves many IDs in one query. IDs that do not exist or
+// belong to another user are reported rather than failing the request.
+func (s *SubscriptionService) LookupSubscriptions(ctx context.Context, ids []string) (domain.LookupResult, error) {
+	s.logger.DebugContext(ctx, "Entering LookupSubscriptions service", zap.Int("ids", len(ids)))
+
+	userID, err := ScopeUserID(ctx, "")
+	if err != nil {
+		return domain.LookupResult{}, err
+	}
+	requested, err := parseSubscriptionIDs(ids)
+	if err != nil {
+		return domain.LookupResult{}, err
+	}
+	rows, err := s.repo.GetSubscriptions(ctx, uuidStrings(requested), userID)
+	if err != nil {
+		return domain.LookupResult{}, err
+	}
+
+	found := make(map[uuid.UUID]dao.SubscriptionRow, len(rows))
+	for _, row := range rows {
+		found[row.ID] = row
+	}
+	var result domain.LookupResult
+	for _, id := range requested {
+		if row, ok := found[id]; ok {
+			result.Found = append(result.Found, mapper.ToDomainFromDAO(row))
+		} else {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	return result, nil
+}
+
+// parseSubscriptionIDs parses the IDs of a bulk request, dropping repeats.
+func parseSubscriptionIDs(ids []string) ([]uuid.UUID, error) {
+	parsed := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, raw := range ids {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, apperrors.NewBadRequest("invalid subscription ID format", err)
+		}
+		if !seen[id] {
+			seen[id] = true
+			parsed = append(parsed, id)
+		}
+	}
+	return parsed, nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
+
+func (s *SubscriptionService) UpdateSubscription(ctx context.Context, subToUpdate domain.Subscription) ([]domain.Warning, error) {
+	s.logger.DebugContext(ctx, "Entering UpdateSubscription service",
 		zap.String("subscription_id", subToUpdate.ID.String()),
 		zap.Any("updates", subToUpdate),
 	)
 
+	if err := s.dates.validateDates(subToUpdate, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
 	existingSubDAO, err := s.repo.GetSubscription(ctx, subToUpdate.ID.String())
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := authorizeOwner(ctx, existingSubDAO.UserID, "subscription"); err != nil {
+		return nil, err
+	}
+	if err := matchVersion(existingSubDAO, subToUpdate.Version); err != nil {
+		return nil, err
 	}
 
-	s.logger.Debug("Found existing subscription to update", zap.Any("existing_dao", existingSubDAO))
+	s.logger.DebugContext(ctx, "Found existing subscription to update", zap.Any("existing_dao", existingSubDAO))
+
+	// Clients that predate billing periods and expense types omit the fields;
+	// keep the stored values rather than silently turning a yearly
+	// subscription monthly or rent into a subscription.
+	billingPeriod := string(subToUpdate.BillingPeriod)
+	if billingPeriod == "" {
+		billingPeriod = existingSubDAO.BillingPeriod
+	}
+	expenseType := string(subToUpdate.ExpenseType)
+	if expenseType == "" {
+		expenseType = existingSubDAO.ExpenseType
+	}
 
 	finalSubDAO := dao.SubscriptionRow{
-		ID:          existingSubDAO.ID,
-		UserID:      existingSubDAO.UserID,
-		ServiceName: subToUpdate.ServiceName,
-		Price:       subToUpdate.Price,
-		StartDate:   subToUpdate.StartDate,
-		EndDate:     subToUpdate.EndDate,
+		ID:            existingSubDAO.ID,
+		UserID:        existingSubDAO.UserID,
+		ServiceName:   subToUpdate.ServiceName,
+		Price:         subToUpdate.Price,
+		StartDate:     subToUpdate.StartDate,
+		EndDate:       subToUpdate.EndDate,
+		CostCenter:    subToUpdate.CostCenter,
+		Category:      subToUpdate.Category,
+		BillingPeriod: billingPeriod,
+		ExpenseType:   expenseType,
+		Version:       existingSubDAO.Version,
 	}
 
-	s.logger.Debug("Proceeding to update with final DAO object", zap.Any("final_dao", finalSubDAO))
+	s.logger.DebugContext(ctx, "Proceeding to update with final DAO object", zap.Any("final_dao", finalSubDAO))
 
-	return s.repo.UpdateSubscription(ctx, finalSubDAO)
+	return s.update(ctx, existingSubDAO, finalSubDAO)
 }
 
-func (s *SubscriptionService) DeleteSubscription(ctx context.Context, id string) error {
-	s.logger.Debug("Entering DeleteSubscription service", zap.String("id", id))
+// PatchSubscription changes only the fields set in patch. A patch that leaves
+// start_date alone is not held to the start date limits, so an old
+// subscription can still be edited.
+func (s *SubscriptionService) PatchSubscription(ctx context.Context, id string, patch domain.SubscriptionPatch) ([]domain.Warning, error) {
+	s.logger.DebugContext(ctx, "Entering PatchSubscription service", zap.String("subscription_id", id), zap.Any("patch", patch))
 
-	err := s.repo.DeleteSubscription(ctx, id)
+	existingSubDAO, err := s.repo.GetSubscription(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := authorizeOwner(ctx, existingSubDAO.UserID, "subscription"); err != nil {
+		return nil, err
+	}
+	if err := matchVersion(existingSubDAO, patch.Version); err != nil {
+		return nil, err
 	}
 
-	s.logger.Debug("Exiting DeleteSubscription service", zap.String("id", id))
+	patched := patch.Apply(mapper.ToDomainFromDAO(existingSubDAO))
+	if patch.StartDate != nil {
+		if err := s.dates.validateStartDate(patched.StartDate, s.clock.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return s.update(ctx, existingSubDAO, mapper.ToDAOFromDomain(patched))
+}
+
+// matchVersion rejects an update based on a version other than the stored one;
+// zero matches any. The write itself is made conditional on the stored version
+// too, which catches a change that lands between the read and the write.
+func matchVersion(existing dao.SubscriptionRow, version int64) error {
+	if version != 0 && version != existing.Version {
+		return apperrors.New(http.StatusPreconditionFailed, "subscription was changed since it was read", nil)
+	}
 	return nil
 }
 
+// update writes final over existing and records the change.
+func (s *SubscriptionService) update(ctx context.Context, existing, final dao.SubscriptionRow) ([]domain.Warning, error) {
+	updated := mapper.ToDomainFromDAO(final)
+	if err := validateSubscription(updated); err != nil {
+		return nil, err
+	}
+	warnings := s.rules.check(ctx, updated)
+	if err := s.repo.UpdateSubscription(ctx, final); err != nil {
+		return nil, err
+	}
+	before := mapper.ToDomainFromDAO(existing)
+	s.audit.subscription(ctx, domain.AuditUpdate, &before, &updated)
+	return warnings, nil
+}
+
+// DeleteSubscription moves the subscription to the trash and returns a token
+// that reverses the delete within the undo window.
+func (s *SubscriptionService) DeleteSubscription(ctx context.Context, id string) (domain.UndoToken, error) {
+	s.logger.DebugContext(ctx, "Entering DeleteSubscription service", zap.String("id", id))
+
+	// The subscription is loaded to check its owner and to record what was
+	// deleted.
+	var deleted *domain.Subscription
+	if principal, ok := PrincipalFromContext(ctx); (ok && !principal.IsAdmin()) || s.audit.enabled() {
+		sub, err := s.GetSubscription(ctx, id)
+		if err != nil {
+			return domain.UndoToken{}, err
+		}
+		deleted = &sub
+	}
+	token, hash, err := newSecretToken("undo token")
+	if err != nil {
+		return domain.UndoToken{}, err
+	}
+	expiresAt := s.clock.Now().Add(s.undoWindow).UTC()
+	if err := s.repo.DeleteSubscription(ctx, id, hash, expiresAt); err != nil {
+		return domain.UndoToken{}, err
+	}
+	metrics.SubscriptionsDeleted.Inc()
+	s.audit.subscription(ctx, domain.AuditDelete, deleted, nil)
+
+	s.logger.DebugContext(ctx, "Exiting DeleteSubscription service", zap.String("id", id))
+	return domain.UndoToken{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// UndoDelete restores the subscription deleted with token. A regular user can
+// only undo their own deletes, and the restore counts against the quota like
+// any other.
+func (s *SubscriptionService) UndoDelete(ctx context.Context, token string) (domain.Subscription, error) {
+	s.logger.DebugContext(ctx, "Entering UndoDelete service")
+
+	userID, err := ScopeUserID(ctx, "")
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+	if userID != "" {
+		quota, err := s.QuotaStatus(ctx, userID)
+		if err != nil {
+			return domain.Subscription{}, err
+		}
+		if quota.Enabled() && quota.Remaining() == 0 {
+			return domain.Subscription{}, apperrors.New(http.StatusForbidden, fmt.Sprintf("subscription quota of %d reached", quota.Limit), nil).
+				WithErrorCode(ErrCodeQuotaExceeded)
+		}
+	}
+
+	row, err := s.repo.UndoDelete(ctx, hashSecretToken(token), userID)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+	s.logger.InfoContext(ctx, "Subscription delete undone", zap.String("subscription_id", row.ID.String()))
+	restored := mapper.ToDomainFromDAO(row)
+	s.audit.subscription(ctx, domain.AuditRestore, nil, &restored)
+	return restored, nil
+}
+
+func (s *SubscriptionService) ListTrash(ctx context.Context, filter dto.TrashFilter) ([]domain.TrashedSubscription, error) {
+	s.logger.DebugContext(ctx, "Entering ListTrash service", zap.String("user_id", filter.UserID), zap.Int("limit", filter.Limit), zap.Int("offset", filter.Offset))
+
+	userID, err := ScopeUserID(ctx, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = userID
+	rows, err := s.repo.ListTrash(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	trashed := make([]domain.TrashedSubscription, len(rows))
+	for i, row := range rows {
+		trashed[i] = mapper.ToTrashedDomainFromDAO(row)
+	}
+	return trashed, nil
+}
+
+// RestoreSubscriptions brings trashed subscriptions back. IDs that are not in
+// the user's trash are reported rather than failing the request. Restoring
+// counts against the quota like creating does, and is refused when every
+// requested subscription would not fit.
+func (s *SubscriptionService) RestoreSubscriptions(ctx context.Context, userID string, ids []string) (domain.RestoreResult, error) {
+	s.logger.DebugContext(ctx, "Entering RestoreSubscriptions service", zap.String("user_id", userID), zap.Strings("ids", ids))
+
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return domain.RestoreResult{}, err
+	}
+	requested, err := parseSubscriptionIDs(ids)
+	if err != nil {
+		return domain.RestoreResult{}, err
+	}
+
+	if userID != "" {
+		quota, err := s.QuotaStatus(ctx, userID)
+		if err != nil {
+			return domain.RestoreResult{}, err
+		}
+		if quota.Enabled() && quota.Remaining() < len(requested) {
+			return domain.RestoreResult{}, apperrors.New(http.StatusForbidden, fmt.Sprintf("restoring %d subscriptions would exceed the quota of %d", len(requested), quota.Limit), nil).
+				WithErrorCode(ErrCodeQuotaExceeded)
+		}
+	}
+
+	rows, err := s.repo.RestoreSubscriptions(ctx, userID, uuidStrings(requested))
+	if err != nil {
+		return domain.RestoreResult{}, err
+	}
+
+	restored := make(map[uuid.UUID]bool, len(rows))
+	for _, row := range rows {
+		restored[row.ID] = true
+		sub := mapper.ToDomainFromDAO(row)
+		s.audit.subscription(ctx, domain.AuditRestore, nil, &sub)
+	}
+	var result domain.RestoreResult
+	for _, id := range requested {
+		if restored[id] {
+			result.Restored = append(result.Restored, id)
+		} else {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	s.logger.InfoContext(ctx, "Subscriptions restored from trash",
+		zap.String("user_id", userID),
+		zap.Int("restored", len(result.Restored)),
+		zap.Int("not_found", len(result.NotFound)),
+	)
+	return result, nil
+}
+
+// SubscriptionHistory returns the subscription's most recent audit entries,
+// newest first. The history outlives the subscription, so it can still be
+// read after a delete.
+func (s *SubscriptionService) SubscriptionHistory(ctx context.Context, id string, limit int) ([]domain.AuditEntry, error) {
+	s.logger.DebugContext(ctx, "Entering SubscriptionHistory service", zap.String("id", id), zap.Int("limit", limit))
+
+	var rows []dao.AuditRow
+	if s.audit.enabled() {
+		var err error
+		if rows, err = s.audit.repo.ListEntries(ctx, domain.AuditEntitySubscription, id, limit); err != nil {
+			return nil, err
+		}
+	}
+	if len(rows) == 0 {
+		// Nothing recorded yet, e.g. for a subscription older than the audit
+		// log: the subscription itself decides between empty and not found.
+		if _, err := s.GetSubscription(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.AuditEntry{}, nil
+	}
+	if err := authorizeOwner(ctx, rows[0].OwnerID, "subscription"); err != nil {
+		return nil, err
+	}
+	entries := make([]domain.AuditEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = mapper.ToAuditEntryFromDAO(row)
+	}
+	return entries, nil
+}
+
 func (s *SubscriptionService) CalculateCost(ctx context.Context, filter dto.CostFilter) (int, error) {
-	s.logger.Debug("Entering CalculateCost service", zap.Any("filter", filter))
+	s.logger.DebugContext(ctx, "Entering CalculateCost service", zap.Any("filter", filter))
 
-	subscriptions, err := s.repo.ListForCostCalculation(ctx, filter)
+	userID, err := ScopeUserID(ctx, filter.UserID)
 	if err != nil {
 		return 0, err
 	}
+	filter.UserID = userID
 
-	s.logger.Debug("Found subscriptions for calculation", zap.Int("count", len(subscriptions)))
+	// The shared calculation outlives a caller that gives up, so the callers
+	// that joined it still get its result; each caller stops waiting on its own.
+	shared := context.WithoutCancel(ctx)
+	ch := s.costs.DoChan(costFilterKey(filter), func() (any, error) {
+		return s.calculateCost(shared, filter)
+	})
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case res := <-ch:
+		if res.Shared {
+			s.logger.DebugContext(ctx, "Cost calculation shared with concurrent identical requests")
+		}
+		if res.Err != nil {
+			return 0, res.Err
+		}
+		return res.Val.(int), nil
+	}
+}
 
-	totalCost := 0
-	periodEndEffective := filter.PeriodEnd.AddDate(0, 1, 0).Add(-1 * time.Nanosecond)
+// costFilterKey hashes a scoped cost filter, so identical cost queries map to
+// the same key.
+func costFilterKey(filter dto.CostFilter) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s\x00%s\x00%d\x00%d\x00%t",
+		filter.UserID, filter.ServiceName, filter.PeriodStart.UnixNano(), filter.PeriodEnd.UnixNano(), filter.ExcludeUnpaid))
+	return hex.EncodeToString(sum[:])
+}
 
+func (s *SubscriptionService) calculateCost(ctx context.Context, filter dto.CostFilter) (int, error) {
+	subscriptions, err := s.reports.ListForCostCalculation(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.DebugContext(ctx, "Found subscriptions for calculation", zap.Int("count", len(subscriptions)))
+
+	changes, err := s.pendingPriceChanges(ctx, subscriptions, filter)
+	if err != nil {
+		return 0, err
+	}
+	unpaid, err := s.unpaidMonths(ctx, subscriptions, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	totalCost := 0
 	for _, sub := range subscriptions {
-		s.logger.Debug("Processing subscription for cost calculation",
+		s.logger.DebugContext(ctx, "Processing subscription for cost calculation",
 			zap.String("subscription_id", sub.ID.String()),
 			zap.Time("sub_start_date", sub.StartDate),
 			zap.Any("sub_end_date", sub.EndDate),
 			zap.Int("sub_price", sub.Price),
 		)
 
-		subStart := sub.StartDate
-		subEnd := periodEndEffective
-		if sub.EndDate != nil && sub.EndDate.Before(periodEndEffective) {
-			subEnd = *sub.EndDate
-		}
-
-		overlapStart := filter.PeriodStart
-		if subStart.After(overlapStart) {
-			overlapStart = subStart
-		}
-
-		overlapEnd := subEnd
-
-		if overlapStart.After(overlapEnd) {
-			s.logger.Debug("Subscription is outside the calculation period, skipping.", zap.String("subscription_id", sub.ID.String()))
+		costForSub, months := billedCost(sub, changes[sub.ID], unpaid[sub.ID], filter)
+		if months == 0 {
+			s.logger.DebugContext(ctx, "Subscription is outside the calculation period, skipping.", zap.String("subscription_id", sub.ID.String()))
 			continue
 		}
-
-		months := (overlapEnd.Year()-overlapStart.Year())*12 + int(overlapEnd.Month()) - int(overlapStart.Month()) + 1
-		costForSub := sub.Price * months
 		totalCost += costForSub
 
-		s.logger.Debug("Calculated cost for one subscription",
+		s.logger.DebugContext(ctx, "Calculated cost for one subscription",
 			zap.String("subscription_id", sub.ID.String()),
-			zap.Time("overlap_start", overlapStart),
-			zap.Time("overlap_end", overlapEnd),
 			zap.Int("months_counted", months),
+			zap.String("billing_period", sub.BillingPeriod),
 			zap.Int("cost_for_this_sub", costForSub),
 		)
 	}
 
-	s.logger.Info("Total cost calculated successfully", zap.Int("total_cost", totalCost))
+	s.logger.InfoContext(ctx, "Total cost calculated successfully", zap.Int("total_cost", totalCost))
 	return totalCost, nil
 }
+
+// CostByCostCenter splits the cost of the filter's period by cost center,
+// ordered by cost center name; unassigned subscriptions are grouped under "".
+func (s *SubscriptionService) CostByCostCenter(ctx context.Context, filter dto.CostFilter) ([]domain.CostCenterCost, error) {
+	s.logger.DebugContext(ctx, "Entering CostByCostCenter service", zap.Any("filter", filter))
+
+	userID, err := ScopeUserID(ctx, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = userID
+
+	subscriptions, err := s.reports.ListForCostCalculation(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.pendingPriceChanges(ctx, subscriptions, filter)
+	if err != nil {
+		return nil, err
+	}
+	unpaid, err := s.unpaidMonths(ctx, subscriptions, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byCenter := make(map[string]*domain.CostCenterCost)
+	for _, sub := range subscriptions {
+		cost, months := billedCost(sub, changes[sub.ID], unpaid[sub.ID], filter)
+		if months == 0 {
+			continue
+		}
+		group, ok := byCenter[sub.CostCenter]
+		if !ok {
+			group = &domain.CostCenterCost{CostCenter: sub.CostCenter}
+			byCenter[sub.CostCenter] = group
+		}
+		group.Subscriptions++
+		group.TotalCost += cost
+	}
+
+	result := make([]domain.CostCenterCost, 0, len(byCenter))
+	for _, group := range byCenter {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CostCenter < result[j].CostCenter })
+
+	s.logger.InfoContext(ctx, "Cost by cost center calculated successfully", zap.Int("cost_centers", len(result)))
+	return result, nil
+}
+
+// pendingPriceChanges groups the scheduled price changes that take effect by
+// the end of the filter's period by subscription, in effective date order.
+func (s *SubscriptionService) pendingPriceChanges(ctx context.Context, subscriptions []dao.SubscriptionRow, filter dto.CostFilter) (map[uuid.UUID][]dao.PriceChangeRow, error) {
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+	rows, err := s.reports.ListPendingPriceChanges(ctx, filter.UserID, filter.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(map[uuid.UUID][]dao.PriceChangeRow)
+	for _, row := range rows {
+		changes[row.SubscriptionID] = append(changes[row.SubscriptionID], row)
+	}
+	return changes, nil
+}
+
+// unpaidMonths groups the months of the filter's period whose charge failed
+// and is still unpaid by subscription, when the filter excludes them.
+func (s *SubscriptionService) unpaidMonths(ctx context.Context, subscriptions []dao.SubscriptionRow, filter dto.CostFilter) (map[uuid.UUID][]time.Time, error) {
+	if !filter.ExcludeUnpaid || len(subscriptions) == 0 {
+		return nil, nil
+	}
+	rows, err := s.reports.ListUnpaidPeriods(ctx, filter.UserID, filter.PeriodStart, filter.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	months := make(map[uuid.UUID][]time.Time)
+	for _, row := range rows {
+		months[row.SubscriptionID] = append(months[row.SubscriptionID], row.Period)
+	}
+	return months, nil
+}
+
+// billedCost is what a subscription costs over the filter's period and how
+// many months of it are billed. Each pending price change splits the
+// subscription at its effective date, with the new price billed from then on.
+// Unpaid months are not billed.
+func billedCost(sub dao.SubscriptionRow, changes []dao.PriceChangeRow, unpaid []time.Time, filter dto.CostFilter) (cost, months int) {
+	period := domain.BillingPeriod(sub.BillingPeriod)
+	for _, change := range changes {
+		segment := sub
+		end := change.EffectiveDate.Add(-1 * time.Nanosecond)
+		if segment.EndDate == nil || end.Before(*segment.EndDate) {
+			segment.EndDate = &end
+		}
+		n := billedMonths(segment, unpaid, filter)
+		cost += period.Cost(segment.Price, n)
+		months += n
+
+		if change.EffectiveDate.After(sub.StartDate) {
+			sub.StartDate = change.EffectiveDate
+		}
+		sub.Price = change.Price
+	}
+	n := billedMonths(sub, unpaid, filter)
+	return cost + period.Cost(sub.Price, n), months + n
+}
+
+// billedMonths counts the calendar months of the filter's period a subscription
+// is billed for, counting partial months in full and skipping the unpaid ones;
+// zero when they do not overlap.
+func billedMonths(sub dao.SubscriptionRow, unpaid []time.Time, filter dto.CostFilter) int {
+	periodEndEffective := filter.PeriodEnd.AddDate(0, 1, 0).Add(-1 * time.Nanosecond)
+
+	overlapEnd := periodEndEffective
+	if sub.EndDate != nil && sub.EndDate.Before(periodEndEffective) {
+		overlapEnd = *sub.EndDate
+	}
+	overlapStart := filter.PeriodStart
+	if sub.StartDate.After(overlapStart) {
+		overlapStart = sub.StartDate
+	}
+	if overlapStart.After(overlapEnd) {
+		return 0
+	}
+	months := (overlapEnd.Year()-overlapStart.Year())*12 + int(overlapEnd.Month()) - int(overlapStart.Month()) + 1
+	firstMonth := time.Date(overlapStart.Year(), overlapStart.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, month := range unpaid {
+		if !month.Before(firstMonth) && !month.After(overlapEnd) {
+			months--
+		}
+	}
+	return months
+}
+
+func (s *SubscriptionService) CalculateCostBatch(ctx context.Context, filters []dto.CostFilter) []CostResult {
+	s.logger.DebugContext(ctx, "Entering CalculateCostBatch service", zap.Int("items", len(filters)))
+
+	results := make([]CostResult, len(filters))
+	jobs := make(chan int)
+
+	workers := costBatchWorkers
+	if len(filters) < workers {
+		workers = len(filters)
+	}
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				total, err := s.CalculateCost(ctx, filters[i])
+				results[i] = CostResult{TotalCost: total, Err: err}
+			}
+		}()
+	}
+
+	for i := range filters {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	s.logger.DebugContext(ctx, "Exiting CalculateCostBatch service", zap.Int("items", len(filters)))
+	return results
+}
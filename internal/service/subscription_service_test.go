@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	"subtracker/internal/repository/mocks"
 
 	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
 	"subtracker/pkg/logger"
 
 	"github.com/google/uuid"
@@ -24,38 +28,382 @@ import (
 func TestSubscriptionService_CreateSubscription(t *testing.T) {
 	t.Run("Success - Generates ID", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 
-		subDomain := domain.Subscription{UserID: uuid.New(), ServiceName: "Yandex Plus"}
+		subDomain := domain.Subscription{UserID: uuid.New(), ServiceName: "Yandex Plus", Price: 299}
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
+			Return([]dao.SubscriptionRow{}, nil).Once()
 		mockRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool {
-			return d.ID != uuid.Nil && d.UserID == subDomain.UserID
+			return d.ID.Version() == 7 && d.UserID == subDomain.UserID
 		})).Return(nil).Once()
 
-		err := service.CreateSubscription(context.Background(), subDomain)
+		warnings, err := service.CreateSubscription(context.Background(), subDomain)
 
 		assert.NoError(t, err)
+		assert.Empty(t, warnings)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		dbError := errors.New("repository error")
 
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
+			Return([]dao.SubscriptionRow{}, nil).Once()
 		mockRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).
 			Return(dbError).Once()
 
-		err := service.CreateSubscription(context.Background(), domain.Subscription{})
+		_, err := service.CreateSubscription(context.Background(), domain.Subscription{ServiceName: "Netflix"})
 
 		assert.Equal(t, dbError, err)
 		mockRepo.AssertExpectations(t)
 	})
 }
 
+func TestSubscriptionService_CreateSubscriptionExpenseTypes(t *testing.T) {
+	userID := uuid.New()
+	setup := func() (*SubscriptionService, *mocks.SubscriptionRepositoryInterface) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		return NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger()), mockRepo
+	}
+
+	t.Run("Defaults to Subscription", func(t *testing.T) {
+		service, mockRepo := setup()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil).Once()
+		mockRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool {
+			return d.ExpenseType == "subscription" && d.BillingPeriod == "monthly"
+		})).Return(nil).Once()
+
+		_, err := service.CreateSubscription(context.Background(), domain.Subscription{UserID: userID, ServiceName: "Netflix", Price: 999})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Weekly Rent", func(t *testing.T) {
+		service, mockRepo := setup()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil).Once()
+		mockRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool {
+			return d.ExpenseType == "rent" && d.BillingPeriod == "weekly"
+		})).Return(nil).Once()
+
+		_, err := service.CreateSubscription(context.Background(), domain.Subscription{
+			UserID: userID, ServiceName: "Flat", Price: 30000, ExpenseType: domain.ExpenseRent, BillingPeriod: domain.BillingWeekly,
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Billing Period Not Allowed", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		_, err := service.CreateSubscription(context.Background(), domain.Subscription{
+			UserID: userID, ServiceName: "Flat", Price: 360000, ExpenseType: domain.ExpenseRent, BillingPeriod: domain.BillingYearly,
+		})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Equal(t, ErrCodeExpenseBillingPeriod, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Free Insurance", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		_, err := service.CreateSubscription(context.Background(), domain.Subscription{
+			UserID: userID, ServiceName: "Home", ExpenseType: domain.ExpenseInsurance,
+		})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodeExpensePriceRequired, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubscriptionService_CreateSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	sub := func(name string) domain.Subscription {
+		return domain.Subscription{UserID: userID, ServiceName: name, Price: 999, StartDate: time.Now()}
+	}
+
+	t.Run("Skips Rejected Items", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 2, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		invalid := sub("Spotify")
+		invalid.EndDate = ptrTime(invalid.StartDate.AddDate(0, -1, 0))
+		mockRepo.On("CountUserSubscriptions", mock.Anything, userID.String()).Return(0, nil).Once()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil)
+		mockRepo.On("CreateSubscriptions", mock.Anything, mock.MatchedBy(func(rows []dao.SubscriptionRow) bool {
+			return len(rows) == 2 && rows[0].ServiceName == "Netflix" && rows[1].ServiceName == "HBO" && rows[1].BillingPeriod == "monthly"
+		})).Return(nil).Once()
+
+		results, err := service.CreateSubscriptions(context.Background(), []domain.Subscription{sub("Netflix"), invalid, sub("HBO"), sub("Kinopoisk")})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 4)
+		assert.NotEqual(t, uuid.Nil, results[0].ID)
+		assert.NoError(t, results[0].Err)
+		assert.Error(t, results[1].Err)
+		assert.NoError(t, results[2].Err)
+		assert.Equal(t, WarningQuotaNearlyReached, results[2].Warnings[0].Code)
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(results[3].Err, &appErr))
+		assert.Equal(t, ErrCodeQuotaExceeded, appErr.ErrorCode, "the quota counts earlier items of the batch")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Write Creates Nothing", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		dbError := apperrors.NewBadRequest("user does not exist", nil)
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil)
+		mockRepo.On("CreateSubscriptions", mock.Anything, mock.Anything).Return(dbError).Once()
+
+		_, err := service.CreateSubscriptions(context.Background(), []domain.Subscription{sub("Netflix"), sub("HBO")})
+
+		assert.Equal(t, dbError, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Other User Rejected", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		results, err := service.CreateSubscriptions(ctx, []domain.Subscription{sub("Netflix")})
+
+		assert.NoError(t, err)
+		assert.Error(t, results[0].Err)
+		mockRepo.AssertNotCalled(t, "CreateSubscriptions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubscriptionService_CreateSubscriptionCategorizes(t *testing.T) {
+	userID := uuid.New()
+	rules := []dao.CategoryRuleRow{
+		{ID: uuid.New(), UserID: &userID, Pattern: "netflix", Category: "Movies"},
+		{ID: uuid.New(), Pattern: "NETFLIX", Category: "Entertainment"},
+	}
+
+	cases := []struct {
+		name     string
+		category string
+		rulesErr error
+		want     string
+	}{
+		{name: "First Matching Rule", want: "Movies"},
+		{name: "Explicit Category Kept", category: "Family", want: "Family"},
+		{name: "Rule Lookup Failure Leaves Uncategorized", rulesErr: errors.New("db down"), want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.SubscriptionRepositoryInterface)
+			mockRules := new(mocks.RuleRepositoryInterface)
+			service := NewSubscriptionService(mockRepo, nil, mockRules, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+			mockRules.On("ListRules", mock.Anything, userID.String()).Return(rules, tc.rulesErr).Maybe()
+			mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
+				Return([]dao.SubscriptionRow{}, nil).Once()
+			mockRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool {
+				return d.Category == tc.want
+			})).Return(nil).Once()
+
+			_, err := service.CreateSubscription(context.Background(), domain.Subscription{
+				UserID: userID, ServiceName: "Netflix Premium", Price: 999, Category: tc.category,
+			})
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionService_CreateSubscriptionWarnings(t *testing.T) {
+	userID := uuid.New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Unusual Price and Far Future Start", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.NewFrozen(start), logger.NewNopLogger())
+
+		sub := domain.Subscription{UserID: userID, ServiceName: "Netflix", Price: 0, StartDate: start.AddDate(3, 0, 0)}
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
+			Return([]dao.SubscriptionRow{}, nil).Once()
+		mockRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).Return(nil).Once()
+
+		warnings, err := service.CreateSubscription(context.Background(), sub)
+
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 2)
+		assert.Equal(t, WarningUnusualPrice, warnings[0].Code)
+		assert.Equal(t, WarningFarFutureStart, warnings[1].Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Probable Duplicate", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.NewFrozen(start), logger.NewNopLogger())
+
+		existing := dao.SubscriptionRow{ID: uuid.New(), UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: start.AddDate(0, -6, 0)}
+		sub := domain.Subscription{UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: start}
+		mockRepo.On("ListSubscriptions", mock.Anything, dto.SubscriptionFilter{UserID: userID.String(), ServiceName: "Netflix", Limit: duplicateLookupLimit}).
+			Return([]dao.SubscriptionRow{existing}, nil).Once()
+		mockRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).Return(nil).Once()
+
+		warnings, err := service.CreateSubscription(context.Background(), sub)
+
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 1)
+		assert.Equal(t, WarningProbableDuplicate, warnings[0].Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate Check Failure Does Not Block Write", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.NewFrozen(start), logger.NewNopLogger())
+
+		sub := domain.Subscription{UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: start}
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
+			Return(nil, errors.New("db down")).Once()
+		mockRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).Return(nil).Once()
+
+		warnings, err := service.CreateSubscription(context.Background(), sub)
+
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSubscriptionService_Quota(t *testing.T) {
+	userID := uuid.New()
+	sub := domain.Subscription{UserID: userID, ServiceName: "Netflix", Price: 999, StartDate: time.Now()}
+
+	t.Run("Warns Near Quota", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 10, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("CountUserSubscriptions", mock.Anything, userID.String()).Return(8, nil).Once()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return([]dao.SubscriptionRow{}, nil).Once()
+		mockRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).Return(nil).Once()
+
+		warnings, err := service.CreateSubscription(context.Background(), sub)
+
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 1)
+		assert.Equal(t, WarningQuotaNearlyReached, warnings[0].Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects At Quota", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 10, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("CountUserSubscriptions", mock.Anything, userID.String()).Return(10, nil).Once()
+
+		_, err := service.CreateSubscription(context.Background(), sub)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		assert.Equal(t, ErrCodeQuotaExceeded, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Disabled Skips Count", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+		quota, err := service.QuotaStatus(context.Background(), userID.String())
+
+		assert.NoError(t, err)
+		assert.False(t, quota.Enabled())
+		mockRepo.AssertNotCalled(t, "CountUserSubscriptions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubscriptionService_FieldValidation(t *testing.T) {
+	start := time.Now()
+	cases := []struct {
+		name string
+		sub  domain.Subscription
+		code string
+	}{
+		{"Empty Name", domain.Subscription{Price: 999}, ErrCodeServiceName},
+		{"Name Too Long", domain.Subscription{ServiceName: strings.Repeat("я", 101), Price: 999}, ErrCodeServiceName},
+		{"Negative Price", domain.Subscription{ServiceName: "Netflix", Price: -1}, ErrCodePriceOutOfRange},
+		{"Unknown Billing Period", domain.Subscription{ServiceName: "Netflix", Price: 999, BillingPeriod: "daily"}, ErrCodeBillingPeriod},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.SubscriptionRepositoryInterface)
+			service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+			tc.sub.UserID = uuid.New()
+			tc.sub.StartDate = start
+			_, err := service.CreateSubscription(context.Background(), tc.sub)
+
+			var appErr *apperrors.AppError
+			assert.True(t, errors.As(err, &appErr))
+			assert.Equal(t, http.StatusBadRequest, appErr.Code)
+			assert.Equal(t, tc.code, appErr.ErrorCode)
+			mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSubscriptionService_DateValidation(t *testing.T) {
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	limits := DateLimits{YearsPast: 10, YearsFuture: 2}
+
+	cases := []struct {
+		name  string
+		start time.Time
+		end   *time.Time
+		code  string
+	}{
+		{"End Before Start", now, ptrTime(now.AddDate(0, -1, 0)), ErrCodeEndBeforeStart},
+		{"Start Too Far In Past", now.AddDate(-11, 0, 0), nil, ErrCodeStartTooFarInPast},
+		{"Start Too Far In Future", now.AddDate(3, 0, 0), nil, ErrCodeStartTooFarInFuture},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.SubscriptionRepositoryInterface)
+			service := NewSubscriptionService(mockRepo, nil, nil, nil, limits, 0, time.Minute, TimeOrderedIDs(), clock.NewFrozen(now), logger.NewNopLogger())
+
+			sub := domain.Subscription{UserID: uuid.New(), ServiceName: "Netflix", Price: 999, StartDate: tc.start, EndDate: tc.end}
+			_, err := service.CreateSubscription(context.Background(), sub)
+
+			var appErr *apperrors.AppError
+			assert.True(t, errors.As(err, &appErr))
+			assert.Equal(t, http.StatusBadRequest, appErr.Code)
+			assert.Equal(t, tc.code, appErr.ErrorCode)
+			mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Update Rejected Before Lookup", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, limits, 0, time.Minute, TimeOrderedIDs(), clock.NewFrozen(now), logger.NewNopLogger())
+
+		sub := domain.Subscription{ID: uuid.New(), StartDate: now, EndDate: ptrTime(now.AddDate(-1, 0, 0))}
+		_, err := service.UpdateSubscription(context.Background(), sub)
+
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "GetSubscription", mock.Anything, mock.Anything)
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
 func TestSubscriptionService_ListSubscriptions(t *testing.T) {
 	t.Run("Success - With Results", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 
 		filter := dto.SubscriptionFilter{Limit: 10, Offset: 0}
 		mockDAOList := []dao.SubscriptionRow{
@@ -78,7 +426,7 @@ func TestSubscriptionService_ListSubscriptions(t *testing.T) {
 
 	t.Run("Success - No Results", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		filter := dto.SubscriptionFilter{}
 
 		mockRepo.On("ListSubscriptions", mock.Anything, filter).Return([]dao.SubscriptionRow{}, nil).Once()
@@ -93,7 +441,7 @@ func TestSubscriptionService_ListSubscriptions(t *testing.T) {
 
 	t.Run("Repository Error", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		dbError := errors.New("db connection failed")
 
 		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
@@ -107,10 +455,54 @@ func TestSubscriptionService_ListSubscriptions(t *testing.T) {
 	})
 }
 
+func TestSubscriptionService_CountSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	ctx := WithPrincipal(context.Background(), domain.Principal{UserID: userID, Role: domain.RoleUser})
+	mockRepo := new(mocks.SubscriptionRepositoryInterface)
+	service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+	mockRepo.On("CountSubscriptions", mock.Anything, dto.SubscriptionFilter{UserID: userID.String(), Limit: 10}).Return(27, nil).Once()
+
+	count, err := service.CountSubscriptions(ctx, dto.SubscriptionFilter{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 27, count)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_ExportSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	ctx := WithPrincipal(context.Background(), domain.Principal{UserID: userID, Role: domain.RoleUser})
+	rows := []dao.SubscriptionRow{
+		{ID: uuid.New(), UserID: userID, ServiceName: "Netflix"},
+		{ID: uuid.New(), UserID: userID, ServiceName: "Spotify"},
+	}
+	mockRepo := new(mocks.SubscriptionRepositoryInterface)
+	service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+	mockRepo.On("StreamSubscriptions", mock.Anything, dto.SubscriptionFilter{UserID: userID.String(), Category: "video"}, mock.Anything).
+		Return(func(_ context.Context, _ dto.SubscriptionFilter, fn func(dao.SubscriptionRow) error) error {
+			for _, row := range rows {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+
+	var exported []domain.Subscription
+	err := service.ExportSubscriptions(ctx, dto.SubscriptionFilter{Category: "video"}, func(sub domain.Subscription) error {
+		exported = append(exported, sub)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Subscription{mapper.ToDomainFromDAO(rows[0]), mapper.ToDomainFromDAO(rows[1])}, exported)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestSubscriptionService_GetSubscription(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 
 		testID := uuid.New().String()
 		mockDAO := dao.SubscriptionRow{
@@ -130,7 +522,7 @@ func TestSubscriptionService_GetSubscription(t *testing.T) {
 
 	t.Run("Not Found in Repo", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		testID := uuid.New().String()
 
 		mockRepo.On("GetSubscription", mock.Anything, testID).
@@ -145,7 +537,7 @@ func TestSubscriptionService_GetSubscription(t *testing.T) {
 
 	t.Run("Other Repo Error", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		testID := uuid.New().String()
 		repoErr := errors.New("some other db error")
 
@@ -163,7 +555,7 @@ func TestSubscriptionService_GetSubscription(t *testing.T) {
 func TestSubscriptionService_UpdateSubscription(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 
 		subID := uuid.New()
 		userID := uuid.New()
@@ -184,22 +576,33 @@ func TestSubscriptionService_UpdateSubscription(t *testing.T) {
 			Price:       500,
 			StartDate:   now.AddDate(0, -1, 0),
 			EndDate:     &now,
+			// The handler's subscription has no billing period or expense
+			// type, so the stored ones are kept.
+			BillingPeriod: "yearly",
+			ExpenseType:   "insurance",
+			Version:       3,
 		}
 
+		// The write is conditional on the version that was read.
 		expectedDAOForUpdate := dao.SubscriptionRow{
-			ID:          subID,
-			UserID:      userID,
-			ServiceName: subFromHandler.ServiceName,
-			Price:       subFromHandler.Price,
-			StartDate:   subFromHandler.StartDate,
-			EndDate:     subFromHandler.EndDate,
+			ID:            subID,
+			UserID:        userID,
+			ServiceName:   subFromHandler.ServiceName,
+			Price:         subFromHandler.Price,
+			StartDate:     subFromHandler.StartDate,
+			EndDate:       subFromHandler.EndDate,
+			BillingPeriod: "yearly",
+			ExpenseType:   "insurance",
+			Version:       3,
 		}
 
 		mockRepo.On("GetSubscription", mock.Anything, subID.String()).Return(subFromDB, nil).Once()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).
+			Return([]dao.SubscriptionRow{subFromDB}, nil).Once()
 
 		mockRepo.On("UpdateSubscription", mock.Anything, expectedDAOForUpdate).Return(nil).Once()
 
-		err := service.UpdateSubscription(context.Background(), subFromHandler)
+		_, err := service.UpdateSubscription(context.Background(), subFromHandler)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -207,13 +610,13 @@ func TestSubscriptionService_UpdateSubscription(t *testing.T) {
 
 	t.Run("GetSubscription Fails (Not Found)", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		subID := uuid.New()
 
 		repoErr := apperrors.NewNotFound("not found", nil)
 		mockRepo.On("GetSubscription", mock.Anything, subID.String()).Return(dao.SubscriptionRow{}, repoErr).Once()
 
-		err := service.UpdateSubscription(context.Background(), domain.Subscription{ID: subID})
+		_, err := service.UpdateSubscription(context.Background(), domain.Subscription{ID: subID})
 
 		assert.Error(t, err)
 		assert.Equal(t, repoErr, err)
@@ -223,29 +626,132 @@ func TestSubscriptionService_UpdateSubscription(t *testing.T) {
 	})
 }
 
+func TestSubscriptionService_PatchSubscription(t *testing.T) {
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	limits := DateLimits{YearsPast: 10, YearsFuture: 2}
+	stored := dao.SubscriptionRow{
+		ID:            uuid.New(),
+		UserID:        uuid.New(),
+		ServiceName:   "Netflix",
+		Price:         999,
+		StartDate:     now.AddDate(-12, 0, 0),
+		EndDate:       ptrTime(now.AddDate(1, 0, 0)),
+		BillingPeriod: "monthly",
+		Version:       7,
+	}
+	setup := func() (*SubscriptionService, *mocks.SubscriptionRepositoryInterface) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, limits, 0, time.Minute, TimeOrderedIDs(), clock.NewFrozen(now), logger.NewNopLogger())
+		mockRepo.On("GetSubscription", mock.Anything, stored.ID.String()).Return(stored, nil).Once()
+		return service, mockRepo
+	}
+
+	t.Run("Changes Only Patched Fields", func(t *testing.T) {
+		service, mockRepo := setup()
+		price := 1299
+		want := stored
+		want.Price = price
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil).Once()
+		mockRepo.On("UpdateSubscription", mock.Anything, want).Return(nil).Once()
+
+		// The stored start date is older than the limit allows, but it is
+		// not being changed, so it is not checked again.
+		_, err := service.PatchSubscription(context.Background(), stored.ID.String(), domain.SubscriptionPatch{Price: &price, Version: 7})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Stale Version", func(t *testing.T) {
+		service, mockRepo := setup()
+		price := 1299
+
+		_, err := service.PatchSubscription(context.Background(), stored.ID.String(), domain.SubscriptionPatch{Price: &price, Version: 6})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusPreconditionFailed, appErr.Code)
+		mockRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Clears End Date", func(t *testing.T) {
+		service, mockRepo := setup()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil).Once()
+		mockRepo.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(row dao.SubscriptionRow) bool {
+			return row.EndDate == nil && row.Price == stored.Price
+		})).Return(nil).Once()
+
+		_, err := service.PatchSubscription(context.Background(), stored.ID.String(), domain.SubscriptionPatch{ClearEndDate: true})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("End Before Stored Start", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		_, err := service.PatchSubscription(context.Background(), stored.ID.String(), domain.SubscriptionPatch{EndDate: ptrTime(now.AddDate(-13, 0, 0))})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodeEndBeforeStart, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Patched Start Date Is Checked", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		_, err := service.PatchSubscription(context.Background(), stored.ID.String(), domain.SubscriptionPatch{StartDate: ptrTime(now.AddDate(-11, 0, 0))})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodeStartTooFarInPast, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Patched Expense Type Is Checked", func(t *testing.T) {
+		service, mockRepo := setup()
+		expenseType := domain.ExpenseInsurance
+		weekly := domain.BillingWeekly
+
+		_, err := service.PatchSubscription(context.Background(), stored.ID.String(), domain.SubscriptionPatch{ExpenseType: &expenseType, BillingPeriod: &weekly})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodeExpenseBillingPeriod, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+	})
+}
+
 func TestSubscriptionService_DeleteSubscription(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		testID := uuid.New().String()
 
-		mockRepo.On("DeleteSubscription", mock.Anything, testID).Return(nil).Once()
+		var storedHash string
+		mockRepo.On("DeleteSubscription", mock.Anything, testID, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { storedHash = args.String(2) }).Return(nil).Once()
 
-		err := service.DeleteSubscription(context.Background(), testID)
+		before := time.Now()
+		undo, err := service.DeleteSubscription(context.Background(), testID)
 
 		assert.NoError(t, err)
+		assert.NotEmpty(t, undo.Token)
+		assert.Equal(t, hashSecretToken(undo.Token), storedHash, "only the token's hash is stored")
+		assert.WithinDuration(t, before.Add(time.Minute), undo.ExpiresAt, time.Second)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Repository Returns Error", func(t *testing.T) {
 		mockRepo := new(mocks.SubscriptionRepositoryInterface)
-		service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 		testID := uuid.New().String()
 
 		repoErr := apperrors.NewNotFound("not found in repo", nil)
-		mockRepo.On("DeleteSubscription", mock.Anything, testID).Return(repoErr).Once()
+		mockRepo.On("DeleteSubscription", mock.Anything, testID, mock.Anything, mock.Anything).Return(repoErr).Once()
 
-		err := service.DeleteSubscription(context.Background(), testID)
+		_, err := service.DeleteSubscription(context.Background(), testID)
 
 		assert.Error(t, err)
 		assert.Equal(t, repoErr, err)
@@ -253,9 +759,272 @@ func TestSubscriptionService_DeleteSubscription(t *testing.T) {
 	})
 }
 
+func TestSubscriptionService_UndoDelete(t *testing.T) {
+	userID := uuid.New()
+	asUser := WithPrincipal(context.Background(), domain.Principal{UserID: userID, Role: domain.RoleUser})
+
+	t.Run("Restores By Token Hash For The Caller", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		row := dao.SubscriptionRow{ID: uuid.New(), UserID: userID, ServiceName: "Netflix", Price: 999, BillingPeriod: "monthly"}
+		mockRepo.On("UndoDelete", mock.Anything, hashSecretToken("token"), userID.String()).Return(row, nil).Once()
+
+		sub, err := service.UndoDelete(asUser, "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, row.ID, sub.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refused When Quota Is Full", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 10, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("CountUserSubscriptions", mock.Anything, userID.String()).Return(10, nil).Once()
+
+		_, err := service.UndoDelete(asUser, "token")
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodeQuotaExceeded, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "UndoDelete", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubscriptionService_LookupSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("Keeps Requested Order", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("GetSubscriptions", mock.Anything, []string{first.String(), missing.String(), second.String()}, "").
+			Return([]dao.SubscriptionRow{{ID: second, UserID: userID}, {ID: first, UserID: userID}}, nil).Once()
+
+		result, err := service.LookupSubscriptions(context.Background(), []string{first.String(), missing.String(), second.String(), first.String()})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Found, 2)
+		assert.Equal(t, first, result.Found[0].ID)
+		assert.Equal(t, second, result.Found[1].ID)
+		assert.Equal(t, []uuid.UUID{missing}, result.NotFound)
+	})
+
+	t.Run("Scoped To Authenticated User", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: userID, Role: domain.RoleUser})
+		mockRepo.On("GetSubscriptions", mock.Anything, []string{first.String()}, userID.String()).Return(nil, nil).Once()
+
+		result, err := service.LookupSubscriptions(ctx, []string{first.String()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{first}, result.NotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSubscriptionService_RestoreSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	restored, missing := uuid.New(), uuid.New()
+
+	t.Run("Reports Missing IDs", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("RestoreSubscriptions", mock.Anything, userID.String(), []string{restored.String(), missing.String()}).
+			Return([]dao.SubscriptionRow{{ID: restored, UserID: userID}}, nil).Once()
+
+		result, err := service.RestoreSubscriptions(context.Background(), userID.String(), []string{restored.String(), missing.String(), restored.String()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{restored}, result.Restored)
+		assert.Equal(t, []uuid.UUID{missing}, result.NotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refused Over Quota", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 10, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("CountUserSubscriptions", mock.Anything, userID.String()).Return(9, nil).Once()
+
+		_, err := service.RestoreSubscriptions(context.Background(), userID.String(), []string{restored.String(), missing.String()})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, ErrCodeQuotaExceeded, appErr.ErrorCode)
+		mockRepo.AssertNotCalled(t, "RestoreSubscriptions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Scoped To Authenticated User", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: userID, Role: domain.RoleUser})
+		mockRepo.On("RestoreSubscriptions", mock.Anything, userID.String(), []string{restored.String()}).Return(nil, nil).Once()
+
+		result, err := service.RestoreSubscriptions(ctx, "", []string{restored.String()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{restored}, result.NotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSubscriptionService_Audit(t *testing.T) {
+	owner := uuid.New()
+	now := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	stored := dao.SubscriptionRow{ID: uuid.New(), UserID: owner, ServiceName: "Netflix", Price: 999, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: "monthly"}
+	asOwner := WithPrincipal(context.Background(), domain.Principal{UserID: owner, Role: domain.RoleUser})
+	setup := func() (*SubscriptionService, *mocks.SubscriptionRepositoryInterface, *mocks.AuditRepositoryInterface) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		mockAudit := new(mocks.AuditRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, mockAudit, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.NewFrozen(now), logger.NewNopLogger())
+		return service, mockRepo, mockAudit
+	}
+
+	t.Run("Update Records Changed Fields And Actor", func(t *testing.T) {
+		service, mockRepo, mockAudit := setup()
+		update := mapper.ToDomainFromDAO(stored)
+		update.Price = 1099
+		mockRepo.On("GetSubscription", mock.Anything, stored.ID.String()).Return(stored, nil).Once()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil).Once()
+		mockRepo.On("UpdateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).Return(nil).Once()
+		mockAudit.On("CreateEntry", mock.Anything, dao.AuditRow{
+			EntityType: domain.AuditEntitySubscription,
+			EntityID:   stored.ID,
+			OwnerID:    owner,
+			ActorID:    &owner,
+			Action:     "update",
+			Changes:    map[string]dao.AuditChange{"price": {Before: 999, After: 1099}},
+			ChangedAt:  now,
+		}).Return(nil).Once()
+
+		_, err := service.UpdateSubscription(asOwner, update)
+
+		assert.NoError(t, err)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Unchanged Update Not Recorded", func(t *testing.T) {
+		service, mockRepo, mockAudit := setup()
+		mockRepo.On("GetSubscription", mock.Anything, stored.ID.String()).Return(stored, nil).Once()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil).Once()
+		mockRepo.On("UpdateSubscription", mock.Anything, stored).Return(nil).Once()
+
+		_, err := service.UpdateSubscription(asOwner, mapper.ToDomainFromDAO(stored))
+
+		assert.NoError(t, err)
+		mockAudit.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Delete Records The Deleted Subscription", func(t *testing.T) {
+		service, mockRepo, mockAudit := setup()
+		mockRepo.On("GetSubscription", mock.Anything, stored.ID.String()).Return(stored, nil).Once()
+		mockRepo.On("DeleteSubscription", mock.Anything, stored.ID.String(), mock.Anything, mock.Anything).Return(nil).Once()
+		mockAudit.On("CreateEntry", mock.Anything, mock.MatchedBy(func(row dao.AuditRow) bool {
+			return row.Action == "delete" && row.ActorID == nil &&
+				row.Changes["service_name"] == dao.AuditChange{Before: "Netflix", After: nil}
+		})).Return(nil).Once()
+
+		_, err := service.DeleteSubscription(context.Background(), stored.ID.String())
+
+		assert.NoError(t, err)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed Write Does Not Fail The Change", func(t *testing.T) {
+		service, mockRepo, mockAudit := setup()
+		mockRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil).Once()
+		mockRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).Return(nil).Once()
+		mockAudit.On("CreateEntry", mock.Anything, mock.AnythingOfType("dao.AuditRow")).
+			Return(apperrors.NewInternalServerError("db down", nil)).Once()
+
+		_, err := service.CreateSubscription(asOwner, domain.Subscription{UserID: owner, ServiceName: "Spotify", Price: 199, StartDate: now})
+
+		assert.NoError(t, err)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("History Of Other User Not Found", func(t *testing.T) {
+		service, _, mockAudit := setup()
+		mockAudit.On("ListEntries", mock.Anything, domain.AuditEntitySubscription, stored.ID.String(), 10).
+			Return([]dao.AuditRow{{ID: 1, EntityID: stored.ID, OwnerID: owner, Action: "create"}}, nil).Once()
+		asOther := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		_, err := service.SubscriptionHistory(asOther, stored.ID.String(), 10)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+
+	t.Run("Empty History Checks The Subscription", func(t *testing.T) {
+		service, mockRepo, mockAudit := setup()
+		mockAudit.On("ListEntries", mock.Anything, domain.AuditEntitySubscription, stored.ID.String(), 10).Return(nil, nil).Once()
+		mockRepo.On("GetSubscription", mock.Anything, stored.ID.String()).Return(stored, nil).Once()
+
+		entries, err := service.SubscriptionHistory(asOwner, stored.ID.String(), 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSubscriptionService_Authorization(t *testing.T) {
+	owner := uuid.New()
+	sub := dao.SubscriptionRow{ID: uuid.New(), UserID: owner, ServiceName: "Netflix", Price: 999}
+	asUser := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+	asAdmin := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleAdmin})
+
+	t.Run("Another User's Subscription Is Hidden", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("GetSubscription", mock.Anything, sub.ID.String()).Return(sub, nil).Twice()
+
+		_, err := service.GetSubscription(asUser, sub.ID.String())
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+
+		_, err = service.DeleteSubscription(asUser, sub.ID.String())
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		mockRepo.AssertNotCalled(t, "DeleteSubscription", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin Reaches Every User", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		mockRepo.On("GetSubscription", mock.Anything, sub.ID.String()).Return(sub, nil).Once()
+		mockRepo.On("DeleteSubscription", mock.Anything, sub.ID.String(), mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("ListSubscriptions", mock.Anything, dto.SubscriptionFilter{}).Return([]dao.SubscriptionRow{sub}, nil).Once()
+
+		got, err := service.GetSubscription(asAdmin, sub.ID.String())
+		assert.NoError(t, err)
+		assert.Equal(t, owner, got.UserID)
+		_, err = service.DeleteSubscription(asAdmin, sub.ID.String())
+		assert.NoError(t, err)
+		list, err := service.ListSubscriptions(asAdmin, dto.SubscriptionFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Regular User Lists Own Only", func(t *testing.T) {
+		mockRepo := new(mocks.SubscriptionRepositoryInterface)
+		service := NewSubscriptionService(mockRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+		_, err := service.ListSubscriptions(asUser, dto.SubscriptionFilter{UserID: owner.String()})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRepo.AssertNotCalled(t, "ListSubscriptions", mock.Anything, mock.Anything)
+	})
+}
+
 func TestSubscriptionService_CalculateCost(t *testing.T) {
-	mockRepo := new(mocks.SubscriptionRepositoryInterface)
-	service := NewSubscriptionService(mockRepo, logger.NewNopLogger())
+	mockReports := new(mocks.ReportingRepositoryInterface)
+	service := NewSubscriptionService(new(mocks.SubscriptionRepositoryInterface), mockReports, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
 
 	userID := uuid.New().String()
 	periodStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -281,11 +1050,207 @@ func TestSubscriptionService_CalculateCost(t *testing.T) {
 	}
 
 	mockSubscriptions := []dao.SubscriptionRow{sub1, sub2}
-	mockRepo.On("ListForCostCalculation", mock.Anything, filter).Return(mockSubscriptions, nil).Once()
+	mockReports.On("ListForCostCalculation", mock.Anything, filter).Return(mockSubscriptions, nil).Once()
+	mockReports.On("ListPendingPriceChanges", mock.Anything, userID, periodEnd).Return(nil, nil).Once()
 
 	totalCost, err := service.CalculateCost(context.Background(), filter)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 400, totalCost)
-	mockRepo.AssertExpectations(t)
+	mockReports.AssertExpectations(t)
+}
+
+func TestSubscriptionService_CalculateCostCoalescesIdenticalQueries(t *testing.T) {
+	mockReports := new(mocks.ReportingRepositoryInterface)
+	service := NewSubscriptionService(new(mocks.SubscriptionRepositoryInterface), mockReports, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+	periodStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter := dto.CostFilter{UserID: uuid.New().String(), PeriodStart: periodStart, PeriodEnd: periodEnd}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockReports.On("ListForCostCalculation", mock.Anything, filter).
+		Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return([]dao.SubscriptionRow{{Price: 100, StartDate: periodStart}}, nil).Once()
+	mockReports.On("ListPendingPriceChanges", mock.Anything, filter.UserID, periodEnd).Return(nil, nil).Once()
+
+	const callers = 5
+	totals := make([]int, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	call := func(i int) {
+		defer wg.Done()
+		totals[i], errs[i] = service.CalculateCost(context.Background(), filter)
+	}
+	wg.Add(callers)
+	go call(0)
+	<-started
+	for i := 1; i < callers; i++ {
+		go call(i)
+	}
+	// Give the duplicates time to join the calculation in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range callers {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, 300, totals[i])
+	}
+	mockReports.AssertExpectations(t)
+}
+
+func TestSubscriptionService_CalculateCostBillingPeriods(t *testing.T) {
+	mockReports := new(mocks.ReportingRepositoryInterface)
+	service := NewSubscriptionService(new(mocks.SubscriptionRepositoryInterface), mockReports, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+	filter := dto.CostFilter{
+		UserID:      uuid.New().String(),
+		PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockReports.On("ListForCostCalculation", mock.Anything, filter).Return([]dao.SubscriptionRow{
+		{Price: 1200, StartDate: started, BillingPeriod: "yearly"},
+		{Price: 100, StartDate: started, BillingPeriod: "weekly"},
+		{Price: 10, StartDate: started, BillingPeriod: "monthly"},
+	}, nil).Once()
+	mockReports.On("ListPendingPriceChanges", mock.Anything, filter.UserID, filter.PeriodEnd).Return(nil, nil).Once()
+
+	totalCost, err := service.CalculateCost(context.Background(), filter)
+
+	// 3 months of each: 1200/12*3 + 100*52/12*3 + 10*3.
+	assert.NoError(t, err)
+	assert.Equal(t, 300+1300+30, totalCost)
+}
+
+func TestSubscriptionService_CalculateCostWithPriceChanges(t *testing.T) {
+	mockReports := new(mocks.ReportingRepositoryInterface)
+	service := NewSubscriptionService(new(mocks.SubscriptionRepositoryInterface), mockReports, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+	filter := dto.CostFilter{
+		UserID:      uuid.New().String(),
+		PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ended := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	monthly, yearly, cancelled := uuid.New(), uuid.New(), uuid.New()
+	mockReports.On("ListForCostCalculation", mock.Anything, filter).Return([]dao.SubscriptionRow{
+		{ID: monthly, Price: 100, StartDate: started, BillingPeriod: "monthly"},
+		{ID: yearly, Price: 1200, StartDate: started, BillingPeriod: "yearly"},
+		{ID: cancelled, Price: 10, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: &ended, BillingPeriod: "monthly"},
+	}, nil).Once()
+	mockReports.On("ListPendingPriceChanges", mock.Anything, filter.UserID, filter.PeriodEnd).Return([]dao.PriceChangeRow{
+		{SubscriptionID: monthly, Price: 150, EffectiveDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{SubscriptionID: yearly, Price: 2400, EffectiveDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{SubscriptionID: cancelled, Price: 500, EffectiveDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil).Once()
+
+	totalCost, err := service.CalculateCost(context.Background(), filter)
+
+	// 100*3 + 150*3; 1200/12 + 2400/12*5; the cancelled one ends before its change.
+	assert.NoError(t, err)
+	assert.Equal(t, 750+1100+30, totalCost)
+	mockReports.AssertExpectations(t)
+}
+
+func TestSubscriptionService_CalculateCostExcludingUnpaid(t *testing.T) {
+	mockReports := new(mocks.ReportingRepositoryInterface)
+	service := NewSubscriptionService(new(mocks.SubscriptionRepositoryInterface), mockReports, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+	filter := dto.CostFilter{
+		UserID:        uuid.New().String(),
+		PeriodStart:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:     time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		ExcludeUnpaid: true,
+	}
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	monthly, yearly := uuid.New(), uuid.New()
+	mockReports.On("ListForCostCalculation", mock.Anything, filter).Return([]dao.SubscriptionRow{
+		{ID: monthly, Price: 100, StartDate: started, BillingPeriod: "monthly"},
+		{ID: yearly, Price: 1200, StartDate: started, BillingPeriod: "yearly"},
+	}, nil).Once()
+	mockReports.On("ListPendingPriceChanges", mock.Anything, filter.UserID, filter.PeriodEnd).Return([]dao.PriceChangeRow{
+		{SubscriptionID: monthly, Price: 150, EffectiveDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil).Once()
+	mockReports.On("ListUnpaidPeriods", mock.Anything, filter.UserID, filter.PeriodStart, filter.PeriodEnd).Return([]dao.PaymentFailureRow{
+		{SubscriptionID: monthly, Period: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{SubscriptionID: monthly, Period: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{SubscriptionID: yearly, Period: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil).Once()
+
+	totalCost, err := service.CalculateCost(context.Background(), filter)
+
+	// 100*2 + 150*2 without February and May; 1200/12*5 without March.
+	assert.NoError(t, err)
+	assert.Equal(t, 500+500, totalCost)
+	mockReports.AssertExpectations(t)
+}
+
+func TestSubscriptionService_CostByCostCenter(t *testing.T) {
+	mockReports := new(mocks.ReportingRepositoryInterface)
+	service := NewSubscriptionService(new(mocks.SubscriptionRepositoryInterface), mockReports, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+	filter := dto.CostFilter{
+		UserID:      uuid.New().String(),
+		PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	ended := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockReports.On("ListForCostCalculation", mock.Anything, filter).Return([]dao.SubscriptionRow{
+		{Price: 100, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), CostCenter: "Sales"},
+		{Price: 50, StartDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), CostCenter: "Marketing"},
+		{Price: 20, StartDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), CostCenter: "Marketing"},
+		{Price: 10, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Price: 999, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: &ended, CostCenter: "Legacy"},
+	}, nil).Once()
+	mockReports.On("ListPendingPriceChanges", mock.Anything, filter.UserID, filter.PeriodEnd).Return(nil, nil).Once()
+
+	groups, err := service.CostByCostCenter(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CostCenterCost{
+		{CostCenter: "", Subscriptions: 1, TotalCost: 30},
+		{CostCenter: "Marketing", Subscriptions: 2, TotalCost: 120},
+		{CostCenter: "Sales", Subscriptions: 1, TotalCost: 300},
+	}, groups)
+	mockReports.AssertExpectations(t)
+}
+
+func TestSubscriptionService_CalculateCostBatch(t *testing.T) {
+	mockReports := new(mocks.ReportingRepositoryInterface)
+	service := NewSubscriptionService(new(mocks.SubscriptionRepositoryInterface), mockReports, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+
+	periodStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var filters []dto.CostFilter
+	for i := 0; i < 20; i++ {
+		filters = append(filters, dto.CostFilter{UserID: uuid.New().String(), PeriodStart: periodStart, PeriodEnd: periodEnd})
+	}
+	failing := filters[7]
+	dbErr := apperrors.NewInternalServerError("database error on cost calculation", nil)
+
+	mockReports.On("ListForCostCalculation", mock.Anything, failing).Return(nil, dbErr).Once()
+	mockReports.On("ListForCostCalculation", mock.Anything, mock.AnythingOfType("dto.CostFilter")).
+		Return([]dao.SubscriptionRow{{Price: 100, StartDate: periodStart}}, nil).Times(len(filters) - 1)
+	mockReports.On("ListPendingPriceChanges", mock.Anything, mock.Anything, periodEnd).Return(nil, nil).Times(len(filters) - 1)
+
+	results := service.CalculateCostBatch(context.Background(), filters)
+
+	assert.Len(t, results, len(filters))
+	for i, result := range results {
+		if i == 7 {
+			assert.Equal(t, dbErr, result.Err)
+			continue
+		}
+		assert.NoError(t, result.Err)
+		assert.Equal(t, 300, result.TotalCost)
+	}
+	mockReports.AssertExpectations(t)
 }
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/validate"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SuggestionServiceInterface interface {
+	SubmitSuggestion(ctx context.Context, suggestion domain.Suggestion) (domain.Suggestion, error)
+	ListSuggestions(ctx context.Context, userID string, status domain.SuggestionStatus) ([]domain.Suggestion, error)
+	AcceptSuggestion(ctx context.Context, id string) (domain.Suggestion, []domain.Warning, error)
+	RejectSuggestion(ctx context.Context, id string) (domain.Suggestion, error)
+	MergeSuggestion(ctx context.Context, id, subscriptionID string) (domain.Suggestion, []domain.Warning, error)
+}
+
+// SuggestionService is the review queue for detected subscriptions. Only
+// accepting or merging a suggestion writes to subscriptions, and it does so
+// through the subscription service so the usual validation, quota and
+// ownership checks apply.
+type SuggestionService struct {
+	repo          repository.SuggestionRepositoryInterface
+	subscriptions SubscriptionServiceInterface
+	logger        logger.Logger
+}
+
+func NewSuggestionService(repo repository.SuggestionRepositoryInterface, subscriptions SubscriptionServiceInterface, logger logger.Logger) *SuggestionService {
+	return &SuggestionService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		logger:        logger,
+	}
+}
+
+func (s *SuggestionService) SubmitSuggestion(ctx context.Context, suggestion domain.Suggestion) (domain.Suggestion, error) {
+	s.logger.DebugContext(ctx, "Entering SubmitSuggestion service",
+		zap.String("source", suggestion.Source),
+		zap.String("user_id", suggestion.UserID.String()),
+	)
+	if _, err := ScopeUserID(ctx, suggestion.UserID.String()); err != nil {
+		return domain.Suggestion{}, err
+	}
+	if suggestion.ID == uuid.Nil {
+		suggestion.ID = uuid.New()
+		s.logger.DebugContext(ctx, "Generated new suggestion ID", zap.String("suggestion_id", suggestion.ID.String()))
+	}
+	err := ruleError(validate.All(
+		validate.ServiceName(suggestion.ServiceName),
+		validate.Price(suggestion.Price),
+		validate.DateRange(suggestion.StartDate, suggestion.EndDate),
+	))
+	if err != nil {
+		return domain.Suggestion{}, err
+	}
+
+	row, err := s.repo.CreateSuggestion(ctx, mapper.ToSuggestionDAOFromDomain(suggestion))
+	if err != nil {
+		return domain.Suggestion{}, err
+	}
+	return mapper.ToSuggestionDomainFromDAO(row), nil
+}
+
+// ListSuggestions returns the user's suggestions with the given status, the
+// pending review queue when status is empty.
+func (s *SuggestionService) ListSuggestions(ctx context.Context, userID string, status domain.SuggestionStatus) ([]domain.Suggestion, error) {
+	s.logger.DebugContext(ctx, "Entering ListSuggestions service", zap.String("user_id", userID), zap.String("status", string(status)))
+
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if status == "" {
+		status = domain.SuggestionPending
+	}
+	rows, err := s.repo.ListSuggestions(ctx, userID, string(status))
+	if err != nil {
+		return nil, err
+	}
+	suggestions := make([]domain.Suggestion, len(rows))
+	for i, row := range rows {
+		suggestions[i] = mapper.ToSuggestionDomainFromDAO(row)
+	}
+	return suggestions, nil
+}
+
+// AcceptSuggestion creates the suggested subscription. The subscription takes
+// the suggestion's ID, so retrying after a failure between the two writes
+// reports a conflict instead of creating a duplicate.
+func (s *SuggestionService) AcceptSuggestion(ctx context.Context, id string) (domain.Suggestion, []domain.Warning, error) {
+	s.logger.DebugContext(ctx, "Entering AcceptSuggestion service", zap.String("id", id))
+
+	suggestion, err := s.pending(ctx, id)
+	if err != nil {
+		return domain.Suggestion{}, nil, err
+	}
+	warnings, err := s.subscriptions.CreateSubscription(ctx, suggestion.Subscription())
+	if err != nil {
+		return domain.Suggestion{}, nil, err
+	}
+	resolved, err := s.resolve(ctx, id, domain.SuggestionAccepted, &suggestion.ID)
+	if err != nil {
+		return domain.Suggestion{}, nil, err
+	}
+	return resolved, warnings, nil
+}
+
+func (s *SuggestionService) RejectSuggestion(ctx context.Context, id string) (domain.Suggestion, error) {
+	s.logger.DebugContext(ctx, "Entering RejectSuggestion service", zap.String("id", id))
+
+	if _, err := s.pending(ctx, id); err != nil {
+		return domain.Suggestion{}, err
+	}
+	return s.resolve(ctx, id, domain.SuggestionRejected, nil)
+}
+
+// MergeSuggestion folds the suggestion into a subscription the user already
+// tracks: the subscription takes the suggested price, and the suggested end
+// date when there is one. Everything else about the subscription is kept.
+func (s *SuggestionService) MergeSuggestion(ctx context.Context, id, subscriptionID string) (domain.Suggestion, []domain.Warning, error) {
+	s.logger.DebugContext(ctx, "Entering MergeSuggestion service", zap.String("id", id), zap.String("subscription_id", subscriptionID))
+
+	suggestion, err := s.pending(ctx, id)
+	if err != nil {
+		return domain.Suggestion{}, nil, err
+	}
+	target, err := s.subscriptions.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return domain.Suggestion{}, nil, err
+	}
+	if target.UserID != suggestion.UserID {
+		return domain.Suggestion{}, nil, apperrors.NewBadRequest("subscription belongs to another user", nil)
+	}
+
+	target.Price = suggestion.Price
+	if suggestion.EndDate != nil {
+		target.EndDate = suggestion.EndDate
+	}
+	warnings, err := s.subscriptions.UpdateSubscription(ctx, target)
+	if err != nil {
+		return domain.Suggestion{}, nil, err
+	}
+	resolved, err := s.resolve(ctx, id, domain.SuggestionMerged, &target.ID)
+	if err != nil {
+		return domain.Suggestion{}, nil, err
+	}
+	return resolved, warnings, nil
+}
+
+// pending loads a suggestion the caller may review and that is still awaiting
+// review.
+func (s *SuggestionService) pending(ctx context.Context, id string) (domain.Suggestion, error) {
+	row, err := s.repo.GetSuggestion(ctx, id)
+	if err != nil {
+		return domain.Suggestion{}, err
+	}
+	if err := authorizeOwner(ctx, row.UserID, "suggestion"); err != nil {
+		return domain.Suggestion{}, err
+	}
+	suggestion := mapper.ToSuggestionDomainFromDAO(row)
+	if suggestion.Status != domain.SuggestionPending {
+		return domain.Suggestion{}, apperrors.New(http.StatusConflict, "suggestion is already "+string(suggestion.Status), nil)
+	}
+	return suggestion, nil
+}
+
+func (s *SuggestionService) resolve(ctx context.Context, id string, status domain.SuggestionStatus, subscriptionID *uuid.UUID) (domain.Suggestion, error) {
+	row, err := s.repo.ResolveSuggestion(ctx, id, string(status), subscriptionID)
+	if err != nil {
+		return domain.Suggestion{}, err
+	}
+	s.logger.InfoContext(ctx, "Suggestion resolved", zap.String("suggestion_id", id), zap.String("status", string(status)))
+	return mapper.ToSuggestionDomainFromDAO(row), nil
+}
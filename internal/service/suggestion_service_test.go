@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSuggestionService_Review(t *testing.T) {
+	userID := uuid.New()
+	end := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	pending := dao.SuggestionRow{
+		ID: uuid.New(), UserID: userID, Source: "bank", ServiceName: "Netflix", Price: 1099,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), EndDate: &end, Status: "pending",
+	}
+	existing := dao.SubscriptionRow{ID: uuid.New(), UserID: userID, ServiceName: "Netflix Premium", Price: 999, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Category: "Entertainment", BillingPeriod: "monthly"}
+	setup := func() (*SuggestionService, *mocks.SuggestionRepositoryInterface, *mocks.SubscriptionRepositoryInterface) {
+		suggestionRepo := new(mocks.SuggestionRepositoryInterface)
+		subRepo := new(mocks.SubscriptionRepositoryInterface)
+		subs := NewSubscriptionService(subRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		return NewSuggestionService(suggestionRepo, subs, logger.NewNopLogger()), suggestionRepo, subRepo
+	}
+	resolved := func(status string, subscriptionID uuid.UUID) dao.SuggestionRow {
+		row := pending
+		row.Status = status
+		row.SubscriptionID = &subscriptionID
+		return row
+	}
+
+	t.Run("Accept Creates Subscription With Suggestion ID", func(t *testing.T) {
+		service, suggestionRepo, subRepo := setup()
+		suggestionRepo.On("GetSuggestion", mock.Anything, pending.ID.String()).Return(pending, nil).Once()
+		subRepo.On("ListSubscriptions", mock.Anything, mock.Anything).Return([]dao.SubscriptionRow{}, nil).Once()
+		subRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool {
+			return d.ID == pending.ID && d.UserID == userID && d.Price == 1099 && d.EndDate.Equal(end)
+		})).Return(nil).Once()
+		suggestionRepo.On("ResolveSuggestion", mock.Anything, pending.ID.String(), "accepted", &pending.ID).
+			Return(resolved("accepted", pending.ID), nil).Once()
+
+		suggestion, _, err := service.AcceptSuggestion(context.Background(), pending.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SuggestionAccepted, suggestion.Status)
+		assert.Equal(t, pending.ID, *suggestion.SubscriptionID)
+		suggestionRepo.AssertExpectations(t)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("Merge Takes Price And End Date", func(t *testing.T) {
+		service, suggestionRepo, subRepo := setup()
+		suggestionRepo.On("GetSuggestion", mock.Anything, pending.ID.String()).Return(pending, nil).Once()
+		subRepo.On("GetSubscription", mock.Anything, existing.ID.String()).Return(existing, nil).Twice()
+		subRepo.On("ListSubscriptions", mock.Anything, mock.Anything).Return([]dao.SubscriptionRow{}, nil).Once()
+		subRepo.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool {
+			return d.ID == existing.ID && d.Price == 1099 && d.EndDate.Equal(end) &&
+				d.ServiceName == existing.ServiceName && d.Category == existing.Category
+		})).Return(nil).Once()
+		suggestionRepo.On("ResolveSuggestion", mock.Anything, pending.ID.String(), "merged", &existing.ID).
+			Return(resolved("merged", existing.ID), nil).Once()
+
+		suggestion, _, err := service.MergeSuggestion(context.Background(), pending.ID.String(), existing.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SuggestionMerged, suggestion.Status)
+		suggestionRepo.AssertExpectations(t)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("Merge Into Another Users Subscription", func(t *testing.T) {
+		service, suggestionRepo, subRepo := setup()
+		other := existing
+		other.UserID = uuid.New()
+		suggestionRepo.On("GetSuggestion", mock.Anything, pending.ID.String()).Return(pending, nil).Once()
+		subRepo.On("GetSubscription", mock.Anything, other.ID.String()).Return(other, nil).Once()
+
+		_, _, err := service.MergeSuggestion(context.Background(), pending.ID.String(), other.ID.String())
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		subRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+		suggestionRepo.AssertNotCalled(t, "ResolveSuggestion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Resolved Suggestion Conflicts", func(t *testing.T) {
+		service, suggestionRepo, _ := setup()
+		suggestionRepo.On("GetSuggestion", mock.Anything, pending.ID.String()).Return(resolved("rejected", uuid.Nil), nil).Once()
+
+		_, err := service.RejectSuggestion(context.Background(), pending.ID.String())
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+
+	t.Run("Other Users Suggestion Is Hidden", func(t *testing.T) {
+		service, suggestionRepo, _ := setup()
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+		suggestionRepo.On("GetSuggestion", mock.Anything, pending.ID.String()).Return(pending, nil).Once()
+
+		_, err := service.RejectSuggestion(ctx, pending.ID.String())
+
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+
+	t.Run("List Defaults To Pending", func(t *testing.T) {
+		service, suggestionRepo, _ := setup()
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: userID, Role: domain.RoleUser})
+		suggestionRepo.On("ListSuggestions", mock.Anything, userID.String(), "pending").Return([]dao.SuggestionRow{pending}, nil).Once()
+
+		suggestions, err := service.ListSuggestions(ctx, "", "")
+
+		assert.NoError(t, err)
+		assert.Len(t, suggestions, 1)
+		suggestionRepo.AssertExpectations(t)
+	})
+}
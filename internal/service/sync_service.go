@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SyncServiceInterface interface {
+	Changes(ctx context.Context, userID string, since int64, limit int) (domain.ChangeSet, error)
+	Push(ctx context.Context, userID string, strategy string, changes []domain.ClientChange) ([]domain.PushResult, error)
+}
+
+// SyncService reads the changelog directly and applies client writes through
+// the subscription service, so pushed changes get the same validation, quota
+// and metrics as regular API writes.
+type SyncService struct {
+	repo          repository.SyncRepositoryInterface
+	subscriptions SubscriptionServiceInterface
+	logger        logger.Logger
+}
+
+func NewSyncService(repo repository.SyncRepositoryInterface, subscriptions SubscriptionServiceInterface, logger logger.Logger) *SyncService {
+	return &SyncService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		logger:        logger,
+	}
+}
+
+// Changes returns up to limit subscription changes after the since cursor. One
+// extra row is fetched to tell whether another page follows.
+func (s *SyncService) Changes(ctx context.Context, userID string, since int64, limit int) (domain.ChangeSet, error) {
+	s.logger.DebugContext(ctx, "Entering Changes service",
+		zap.String("user_id", userID),
+		zap.Int64("since", since),
+		zap.Int("limit", limit),
+	)
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return domain.ChangeSet{}, err
+	}
+
+	rows, err := s.repo.ListChanges(ctx, userID, since, limit+1)
+	if err != nil {
+		return domain.ChangeSet{}, err
+	}
+
+	set := domain.ChangeSet{Cursor: since}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		set.HasMore = true
+	}
+	set.Changes = make([]domain.SubscriptionChange, len(rows))
+	for i, row := range rows {
+		set.Changes[i] = mapper.ToSubscriptionChangeFromDAO(row)
+	}
+	if len(rows) > 0 {
+		set.Cursor = rows[len(rows)-1].Seq
+	}
+	return set, nil
+}
+
+// Push applies offline client changes in order. A change conflicts when the
+// server has written the subscription after the client's base version; under
+// ConflictLastWriterWins it is still applied if its client timestamp is newer
+// than that write, otherwise the server state is returned for the client to
+// resolve. A failing change does not stop the rest of the batch; only a
+// userID the caller may not act on fails it as a whole.
+func (s *SyncService) Push(ctx context.Context, userID string, strategy string, changes []domain.ClientChange) ([]domain.PushResult, error) {
+	s.logger.DebugContext(ctx, "Entering Push service",
+		zap.String("user_id", userID),
+		zap.String("strategy", strategy),
+		zap.Int("changes", len(changes)),
+	)
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.PushResult, len(changes))
+	for i, change := range changes {
+		results[i] = s.push(ctx, userID, strategy, change)
+	}
+	return results, nil
+}
+
+func (s *SyncService) push(ctx context.Context, userID string, strategy string, change domain.ClientChange) domain.PushResult {
+	result := domain.PushResult{SubscriptionID: change.SubscriptionID, Status: domain.PushRejected}
+	id := change.SubscriptionID.String()
+
+	latest, found, err := s.repo.LatestVersion(ctx, id)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if found && latest.UserID.String() != userID {
+		result.Err = apperrors.NewNotFound("subscription not found", nil)
+		return result
+	}
+
+	if found && latest.Seq > change.BaseVersion {
+		lastWriterWins := strategy == domain.ConflictLastWriterWins && change.ClientUpdatedAt.After(latest.ChangedAt)
+		if !lastWriterWins {
+			server, err := s.serverState(ctx, latest, change.SubscriptionID)
+			if err != nil {
+				result.Err = err
+				return result
+			}
+			s.logger.InfoContext(ctx, "Sync conflict",
+				zap.String("subscription_id", id),
+				zap.Int64("base_version", change.BaseVersion),
+				zap.Int64("server_version", latest.Seq),
+			)
+			result.Status = domain.PushConflict
+			result.Server = &server
+			return result
+		}
+	}
+
+	exists := found && latest.Op == dao.ChangeOpUpsert
+	if err := s.apply(ctx, change, exists); err != nil {
+		result.Err = err
+		return result
+	}
+	result.Status = domain.PushApplied
+	return result
+}
+
+func (s *SyncService) apply(ctx context.Context, change domain.ClientChange, exists bool) error {
+	switch {
+	case change.Deleted && !exists:
+		return nil
+	case change.Deleted:
+		_, err := s.subscriptions.DeleteSubscription(ctx, change.SubscriptionID.String())
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	case exists:
+		_, err := s.subscriptions.UpdateSubscription(ctx, change.Subscription)
+		return err
+	default:
+		_, err := s.subscriptions.CreateSubscription(ctx, change.Subscription)
+		return err
+	}
+}
+
+// serverState is the server's side of a conflict, shaped like a pulled change.
+func (s *SyncService) serverState(ctx context.Context, latest dao.ChangeVersion, id uuid.UUID) (domain.SubscriptionChange, error) {
+	change := domain.SubscriptionChange{Version: latest.Seq, SubscriptionID: id, Deleted: true}
+	if latest.Op == dao.ChangeOpDelete {
+		return change, nil
+	}
+	sub, err := s.subscriptions.GetSubscription(ctx, id.String())
+	if isNotFound(err) {
+		return change, nil
+	}
+	if err != nil {
+		return domain.SubscriptionChange{}, err
+	}
+	change.Deleted = false
+	change.Subscription = &sub
+	return change, nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *apperrors.AppError
+	return errors.As(err, &appErr) && appErr.Code == http.StatusNotFound
+}
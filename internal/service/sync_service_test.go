@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncService_Changes(t *testing.T) {
+	userID := uuid.New()
+	name, price, start := "Netflix", 999, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	changeRows := []dao.SubscriptionChangeRow{
+		{Seq: 5, SubscriptionID: uuid.New(), UserID: userID, Op: dao.ChangeOpUpsert, ServiceName: &name, Price: &price, StartDate: &start},
+		{Seq: 8, SubscriptionID: uuid.New(), UserID: userID, Op: dao.ChangeOpDelete},
+		{Seq: 9, SubscriptionID: uuid.New(), UserID: userID, Op: dao.ChangeOpDelete},
+	}
+
+	t.Run("Has More", func(t *testing.T) {
+		mockRepo := new(mocks.SyncRepositoryInterface)
+		service := NewSyncService(mockRepo, nil, logger.NewNopLogger())
+		mockRepo.On("ListChanges", context.Background(), userID.String(), int64(4), 3).Return(changeRows, nil).Once()
+
+		set, err := service.Changes(context.Background(), userID.String(), 4, 2)
+
+		assert.NoError(t, err)
+		assert.True(t, set.HasMore)
+		assert.Equal(t, int64(8), set.Cursor)
+		assert.Len(t, set.Changes, 2)
+		assert.Equal(t, "Netflix", set.Changes[0].Subscription.ServiceName)
+		assert.True(t, set.Changes[1].Deleted)
+		assert.Nil(t, set.Changes[1].Subscription)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Empty Keeps Cursor", func(t *testing.T) {
+		mockRepo := new(mocks.SyncRepositoryInterface)
+		service := NewSyncService(mockRepo, nil, logger.NewNopLogger())
+		mockRepo.On("ListChanges", context.Background(), userID.String(), int64(42), 11).Return(nil, nil).Once()
+
+		set, err := service.Changes(context.Background(), userID.String(), 42, 10)
+
+		assert.NoError(t, err)
+		assert.False(t, set.HasMore)
+		assert.Equal(t, int64(42), set.Cursor)
+		assert.Empty(t, set.Changes)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Other User Forbidden", func(t *testing.T) {
+		mockRepo := new(mocks.SyncRepositoryInterface)
+		service := NewSyncService(mockRepo, nil, logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		_, err := service.Changes(ctx, userID.String(), 0, 10)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRepo.AssertNotCalled(t, "ListChanges", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSyncService_Push(t *testing.T) {
+	userID := uuid.New()
+	subID := uuid.New()
+	serverWrite := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	latest := dao.ChangeVersion{Seq: 20, UserID: userID, Op: dao.ChangeOpUpsert, ChangedAt: serverWrite}
+	serverRow := dao.SubscriptionRow{ID: subID, UserID: userID, ServiceName: "Netflix", Price: 999, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: "monthly"}
+	upsert := func(base int64, at time.Time) domain.ClientChange {
+		return domain.ClientChange{
+			SubscriptionID:  subID,
+			BaseVersion:     base,
+			ClientUpdatedAt: at,
+			Subscription:    domain.Subscription{ID: subID, UserID: userID, ServiceName: "Netflix", Price: 1099, StartDate: serverRow.StartDate},
+		}
+	}
+	setup := func() (*SyncService, *mocks.SyncRepositoryInterface, *mocks.SubscriptionRepositoryInterface) {
+		syncRepo := new(mocks.SyncRepositoryInterface)
+		subRepo := new(mocks.SubscriptionRepositoryInterface)
+		subs := NewSubscriptionService(subRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		return NewSyncService(syncRepo, subs, logger.NewNopLogger()), syncRepo, subRepo
+	}
+
+	t.Run("Up To Date Change Is Applied", func(t *testing.T) {
+		service, syncRepo, subRepo := setup()
+		syncRepo.On("LatestVersion", mock.Anything, subID.String()).Return(latest, true, nil).Once()
+		subRepo.On("GetSubscription", mock.Anything, subID.String()).Return(serverRow, nil).Once()
+		subRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return([]dao.SubscriptionRow{}, nil).Once()
+		subRepo.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool { return d.Price == 1099 })).Return(nil).Once()
+
+		results, _ := service.Push(context.Background(), userID.String(), domain.ConflictManual, []domain.ClientChange{upsert(20, serverWrite.Add(-time.Hour))})
+
+		assert.Equal(t, domain.PushApplied, results[0].Status)
+		assert.NoError(t, results[0].Err)
+		syncRepo.AssertExpectations(t)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("Stale Change Conflicts Under Manual", func(t *testing.T) {
+		service, syncRepo, subRepo := setup()
+		syncRepo.On("LatestVersion", mock.Anything, subID.String()).Return(latest, true, nil).Once()
+		subRepo.On("GetSubscription", mock.Anything, subID.String()).Return(serverRow, nil).Once()
+
+		results, _ := service.Push(context.Background(), userID.String(), domain.ConflictManual, []domain.ClientChange{upsert(12, serverWrite.Add(time.Hour))})
+
+		assert.Equal(t, domain.PushConflict, results[0].Status)
+		assert.Equal(t, int64(20), results[0].Server.Version)
+		assert.Equal(t, 999, results[0].Server.Subscription.Price)
+		subRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Newer Client Wins Under Last Writer Wins", func(t *testing.T) {
+		service, syncRepo, subRepo := setup()
+		syncRepo.On("LatestVersion", mock.Anything, subID.String()).Return(latest, true, nil).Once()
+		subRepo.On("GetSubscription", mock.Anything, subID.String()).Return(serverRow, nil).Once()
+		subRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return([]dao.SubscriptionRow{}, nil).Once()
+		subRepo.On("UpdateSubscription", mock.Anything, mock.AnythingOfType("dao.SubscriptionRow")).Return(nil).Once()
+
+		results, _ := service.Push(context.Background(), userID.String(), domain.ConflictLastWriterWins, []domain.ClientChange{upsert(12, serverWrite.Add(time.Hour))})
+
+		assert.Equal(t, domain.PushApplied, results[0].Status)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("Older Client Loses Under Last Writer Wins", func(t *testing.T) {
+		service, syncRepo, subRepo := setup()
+		deleted := latest
+		deleted.Op = dao.ChangeOpDelete
+		syncRepo.On("LatestVersion", mock.Anything, subID.String()).Return(deleted, true, nil).Once()
+
+		results, _ := service.Push(context.Background(), userID.String(), domain.ConflictLastWriterWins, []domain.ClientChange{upsert(12, serverWrite.Add(-time.Hour))})
+
+		assert.Equal(t, domain.PushConflict, results[0].Status)
+		assert.True(t, results[0].Server.Deleted)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Subscription Is Created", func(t *testing.T) {
+		service, syncRepo, subRepo := setup()
+		syncRepo.On("LatestVersion", mock.Anything, subID.String()).Return(dao.ChangeVersion{}, false, nil).Once()
+		subRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return([]dao.SubscriptionRow{}, nil).Once()
+		subRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(d dao.SubscriptionRow) bool { return d.ID == subID })).Return(nil).Once()
+
+		results, _ := service.Push(context.Background(), userID.String(), domain.ConflictManual, []domain.ClientChange{upsert(0, serverWrite)})
+
+		assert.Equal(t, domain.PushApplied, results[0].Status)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("Deleting A Deleted Subscription Is Applied", func(t *testing.T) {
+		service, syncRepo, subRepo := setup()
+		deleted := latest
+		deleted.Op = dao.ChangeOpDelete
+		syncRepo.On("LatestVersion", mock.Anything, subID.String()).Return(deleted, true, nil).Once()
+
+		results, _ := service.Push(context.Background(), userID.String(), domain.ConflictManual, []domain.ClientChange{{SubscriptionID: subID, Deleted: true, BaseVersion: 20}})
+
+		assert.Equal(t, domain.PushApplied, results[0].Status)
+		subRepo.AssertNotCalled(t, "DeleteSubscription", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Other User's Subscription Is Rejected", func(t *testing.T) {
+		service, syncRepo, _ := setup()
+		syncRepo.On("LatestVersion", mock.Anything, subID.String()).Return(latest, true, nil).Once()
+
+		results, _ := service.Push(context.Background(), uuid.New().String(), domain.ConflictManual, []domain.ClientChange{upsert(20, serverWrite)})
+
+		assert.Equal(t, domain.PushRejected, results[0].Status)
+		assert.Error(t, results[0].Err)
+	})
+
+	t.Run("Pushing For Another User Is Forbidden", func(t *testing.T) {
+		service, syncRepo, subRepo := setup()
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		results, err := service.Push(ctx, userID.String(), domain.ConflictManual, []domain.ClientChange{upsert(20, serverWrite)})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		assert.Nil(t, results)
+		syncRepo.AssertNotCalled(t, "LatestVersion", mock.Anything, mock.Anything)
+		subRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+	})
+}
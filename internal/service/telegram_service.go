@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dto"
+	"subtracker/internal/domain/validate"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/internal/telegram"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// telegramListLimit keeps /list within one Telegram message.
+const telegramListLimit = 50
+
+const telegramHelp = "Commands:\n" +
+	"/list - show your subscriptions\n" +
+	"/add - add a subscription\n" +
+	"/cancel - stop adding a subscription\n" +
+	"/unlink - stop receiving messages here\n\n" +
+	"Renewal reminders are sent to this chat instead of by email."
+
+const telegramNotLinked = "This chat is not linked to an account yet. Create a link code with " +
+	"POST /users/{id}/telegram/link-code and send the command it returns here."
+
+const telegramFailed = "Something went wrong, please try again later."
+
+type TelegramServiceInterface interface {
+	CreateLinkCode(ctx context.Context, userID string) (domain.TelegramLinkCode, error)
+	GetLink(ctx context.Context, userID string) (domain.TelegramLink, error)
+	Unlink(ctx context.Context, userID string) error
+}
+
+// TelegramService links users' Telegram chats and answers what they send the
+// bot.
+type TelegramService struct {
+	repo          repository.TelegramRepositoryInterface
+	subscriptions SubscriptionServiceInterface
+	sender        telegram.Sender
+	linkCodeTTL   time.Duration
+	logger        logger.Logger
+	clock         clock.Clock
+
+	mu sync.Mutex
+	// dialogs holds the /add dialog of every chat that is in one. They live in
+	// memory: only one process polls the bot, and a dialog lost to a restart
+	// is just started again.
+	dialogs map[int64]*addDialog
+}
+
+func NewTelegramService(repo repository.TelegramRepositoryInterface, subscriptions SubscriptionServiceInterface, sender telegram.Sender, linkCodeTTL time.Duration, clock clock.Clock, logger logger.Logger) *TelegramService {
+	return &TelegramService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		sender:        sender,
+		linkCodeTTL:   linkCodeTTL,
+		logger:        logger,
+		clock:         clock,
+		dialogs:       make(map[int64]*addDialog),
+	}
+}
+
+// CreateLinkCode issues a one-time code the user sends the bot to link that
+// chat. Issuing a code invalidates the previous one.
+func (s *TelegramService) CreateLinkCode(ctx context.Context, userID string) (domain.TelegramLinkCode, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.TelegramLinkCode{}, apperrors.NewBadRequest("invalid user ID format", err)
+	}
+	code, hash, err := newSecretToken("link code")
+	if err != nil {
+		return domain.TelegramLinkCode{}, err
+	}
+	expiresAt := s.clock.Now().Add(s.linkCodeTTL)
+	if err := s.repo.CreateLinkCode(ctx, id, hash, expiresAt); err != nil {
+		return domain.TelegramLinkCode{}, err
+	}
+	s.logger.InfoContext(ctx, "Telegram link code issued", zap.String("user_id", userID))
+	return domain.TelegramLinkCode{Code: code, ExpiresAt: expiresAt}, nil
+}
+
+func (s *TelegramService) GetLink(ctx context.Context, userID string) (domain.TelegramLink, error) {
+	row, err := s.repo.GetLinkByUser(ctx, userID)
+	if err != nil {
+		return domain.TelegramLink{}, err
+	}
+	return mapper.ToTelegramLinkFromDAO(row), nil
+}
+
+func (s *TelegramService) Unlink(ctx context.Context, userID string) error {
+	link, err := s.repo.GetLinkByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteLink(ctx, userID); err != nil {
+		return err
+	}
+	s.endDialog(link.ChatID)
+	s.logger.InfoContext(ctx, "Telegram chat unlinked", zap.String("user_id", userID))
+	return nil
+}
+
+// HandleMessage answers one message sent to the bot. Failures are reported to
+// the chat and logged; there is no caller to return them to.
+func (s *TelegramService) HandleMessage(ctx context.Context, msg telegram.Message) {
+	reply := s.reply(ctx, msg)
+	if err := s.sender.SendMessage(ctx, msg.ChatID, reply); err != nil {
+		s.logger.WarnContext(ctx, "Failed to answer Telegram message", zap.Error(err), zap.Int64("chat_id", msg.ChatID))
+	}
+}
+
+func (s *TelegramService) reply(ctx context.Context, msg telegram.Message) string {
+	command, arg := parseCommand(msg.Text)
+	switch {
+	case (command == "/start" || command == "/link") && arg != "":
+		return s.link(ctx, msg.ChatID, arg)
+	case command == "/start" || command == "/help":
+		return telegramHelp
+	}
+
+	link, err := s.repo.GetLinkByChat(ctx, msg.ChatID)
+	if isNotFound(err) {
+		return telegramNotLinked
+	}
+	if err != nil {
+		return telegramFailed
+	}
+	// The bot acts for the linked user only, even when they are an admin.
+	ctx = WithPrincipal(ctx, domain.Principal{UserID: link.UserID, Role: domain.RoleUser})
+
+	switch command {
+	case "/list":
+		return s.list(ctx, link.UserID)
+	case "/add":
+		s.mu.Lock()
+		s.dialogs[msg.ChatID] = &addDialog{}
+		s.mu.Unlock()
+		return "What is the service called?"
+	case "/cancel":
+		if !s.endDialog(msg.ChatID) {
+			return "There is nothing to cancel."
+		}
+		return "Cancelled."
+	case "/unlink":
+		if err := s.repo.DeleteLink(ctx, link.UserID.String()); err != nil {
+			return telegramFailed
+		}
+		s.endDialog(msg.ChatID)
+		s.logger.InfoContext(ctx, "Telegram chat unlinked from the bot", zap.String("user_id", link.UserID.String()))
+		return "This chat is unlinked. Reminders go back to email."
+	case "":
+		s.mu.Lock()
+		dialog, ok := s.dialogs[msg.ChatID]
+		s.mu.Unlock()
+		if ok {
+			return s.continueDialog(ctx, msg.ChatID, link.UserID, dialog, strings.TrimSpace(msg.Text))
+		}
+	}
+	return telegramHelp
+}
+
+func (s *TelegramService) link(ctx context.Context, chatID int64, code string) string {
+	row, err := s.repo.LinkChat(ctx, hashSecretToken(code), chatID)
+	if isNotFound(err) {
+		return "That link code is unknown or has expired. Create a new one and try again."
+	}
+	if err != nil {
+		return telegramFailed
+	}
+	s.endDialog(chatID)
+	s.logger.InfoContext(ctx, "Telegram chat linked", zap.String("user_id", row.UserID.String()))
+	return "This chat is now linked to your account.\n\n" + telegramHelp
+}
+
+func (s *TelegramService) list(ctx context.Context, userID uuid.UUID) string {
+	subs, err := s.subscriptions.ListSubscriptions(ctx, dto.SubscriptionFilter{
+		UserID: userID.String(),
+		Limit:  telegramListLimit,
+		Sort:   "service_name",
+	})
+	if err != nil {
+		return telegramFailed
+	}
+	if len(subs) == 0 {
+		return "You have no subscriptions yet. Send /add to add one."
+	}
+	var b strings.Builder
+	b.WriteString("Your subscriptions:\n")
+	for _, sub := range subs {
+		fmt.Fprintf(&b, "\n%s - %d per %s", sub.ServiceName, sub.Price, sub.BillingPeriod.Unit())
+		if sub.EndDate != nil {
+			fmt.Fprintf(&b, ", until %s", sub.EndDate.Format("01-2006"))
+		}
+	}
+	if len(subs) == telegramListLimit {
+		fmt.Fprintf(&b, "\n\nShowing the first %d.", telegramListLimit)
+	}
+	return b.String()
+}
+
+// endDialog drops the chat's /add dialog and reports whether there was one.
+func (s *TelegramService) endDialog(chatID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.dialogs[chatID]
+	delete(s.dialogs, chatID)
+	return ok
+}
+
+type addStep int
+
+const (
+	stepServiceName addStep = iota
+	stepPrice
+	stepBillingPeriod
+	stepStartDate
+)
+
+// addDialog collects a new subscription one answer at a time.
+type addDialog struct {
+	step addStep
+	sub  domain.Subscription
+}
+
+// continueDialog takes the answer to the dialog's current question and asks
+// the next one; an invalid answer repeats the question. The last answer
+// creates the subscription.
+func (s *TelegramService) continueDialog(ctx context.Context, chatID int64, userID uuid.UUID, dialog *addDialog, answer string) string {
+	switch dialog.step {
+	case stepServiceName:
+		if validate.ServiceName(answer)() != nil {
+			return fmt.Sprintf("Send a name of at most %d characters.", validate.MaxServiceNameLength)
+		}
+		dialog.sub.ServiceName = answer
+		dialog.step = stepPrice
+		return "How much is it per billing period? Send a whole number, e.g. 299."
+	case stepPrice:
+		price, err := strconv.Atoi(answer)
+		if err != nil || validate.Price(price)() != nil {
+			return "Send the price as a whole number, e.g. 299."
+		}
+		dialog.sub.Price = price
+		dialog.step = stepBillingPeriod
+		return "How often is it billed: monthly, yearly or weekly?"
+	case stepBillingPeriod:
+		period := domain.BillingPeriod(strings.ToLower(answer))
+		if validate.BillingPeriod(period)() != nil {
+			return "Send monthly, yearly or weekly."
+		}
+		dialog.sub.BillingPeriod = period
+		dialog.step = stepStartDate
+		return "When did it start? Send the month as MM-YYYY, e.g. 07-2025."
+	}
+
+	start, err := time.Parse("01-2006", answer)
+	if err != nil {
+		return "Send the month as MM-YYYY, e.g. 07-2025."
+	}
+	s.endDialog(chatID)
+	sub := dialog.sub
+	sub.UserID = userID
+	sub.StartDate = start
+	warnings, err := s.subscriptions.CreateSubscription(ctx, sub)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) && appErr.Code < 500 {
+			return fmt.Sprintf("Could not add %s: %s. Send /add to start over.", sub.ServiceName, appErr.Message)
+		}
+		return telegramFailed
+	}
+
+	reply := fmt.Sprintf("Added %s: %d per %s since %s.", sub.ServiceName, sub.Price, sub.BillingPeriod.Unit(), answer)
+	for _, w := range warnings {
+		reply += "\nNote: " + w.Message
+	}
+	return reply
+}
+
+// parseCommand splits "/command@bot argument" into the lowercased command and
+// its argument. Text that is not a command has an empty command.
+func parseCommand(text string) (command, arg string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", ""
+	}
+	command, arg, _ = strings.Cut(text, " ")
+	command, _, _ = strings.Cut(command, "@")
+	return strings.ToLower(command), strings.TrimSpace(arg)
+}
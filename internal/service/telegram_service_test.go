@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/internal/telegram"
+	telegrammocks "subtracker/internal/telegram/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTelegramService_CreateLinkCode(t *testing.T) {
+	repo := new(mocks.TelegramRepositoryInterface)
+	now := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	s := NewTelegramService(repo, nil, nil, 15*time.Minute, clock.NewFrozen(now), logger.NewNopLogger())
+	userID := uuid.New()
+	var storedHash string
+	repo.On("CreateLinkCode", mock.Anything, userID, mock.AnythingOfType("string"), now.Add(15*time.Minute)).
+		Run(func(args mock.Arguments) { storedHash = args.String(2) }).Return(nil).Once()
+
+	code, err := s.CreateLinkCode(context.Background(), userID.String())
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, code.Code)
+	assert.Equal(t, now.Add(15*time.Minute), code.ExpiresAt)
+	assert.Equal(t, hashSecretToken(code.Code), storedHash, "only the code's hash is stored")
+	repo.AssertExpectations(t)
+}
+
+func TestTelegramService_HandleMessage(t *testing.T) {
+	const chatID = int64(42)
+	userID := uuid.New()
+	link := dao.TelegramLinkRow{UserID: userID, ChatID: chatID, LinkedAt: time.Now()}
+	setup := func() (*TelegramService, *mocks.TelegramRepositoryInterface, *mocks.SubscriptionRepositoryInterface, *telegrammocks.Sender) {
+		repo := new(mocks.TelegramRepositoryInterface)
+		subRepo := new(mocks.SubscriptionRepositoryInterface)
+		bot := new(telegrammocks.Sender)
+		subs := NewSubscriptionService(subRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		return NewTelegramService(repo, subs, bot, 15*time.Minute, clock.System(), logger.NewNopLogger()), repo, subRepo, bot
+	}
+	send := func(s *TelegramService, text string) {
+		s.HandleMessage(context.Background(), telegram.Message{ChatID: chatID, Text: text})
+	}
+
+	t.Run("Links With Code", func(t *testing.T) {
+		s, repo, _, bot := setup()
+		repo.On("LinkChat", mock.Anything, hashSecretToken("code"), chatID).Return(link, nil).Once()
+		bot.On("SendMessage", mock.Anything, chatID, mock.MatchedBy(func(text string) bool {
+			return assert.Contains(t, text, "now linked")
+		})).Return(nil).Once()
+
+		send(s, "/start code")
+
+		repo.AssertExpectations(t)
+		bot.AssertExpectations(t)
+	})
+
+	t.Run("Expired Code", func(t *testing.T) {
+		s, repo, _, bot := setup()
+		repo.On("LinkChat", mock.Anything, hashSecretToken("old"), chatID).
+			Return(dao.TelegramLinkRow{}, apperrors.NewNotFound("link code not found or expired", nil)).Once()
+		bot.On("SendMessage", mock.Anything, chatID, mock.MatchedBy(func(text string) bool {
+			return assert.Contains(t, text, "unknown or has expired")
+		})).Return(nil).Once()
+
+		send(s, "/link@SubtrackerBot old")
+
+		bot.AssertExpectations(t)
+	})
+
+	t.Run("Unlinked Chat", func(t *testing.T) {
+		s, repo, _, bot := setup()
+		repo.On("GetLinkByChat", mock.Anything, chatID).
+			Return(dao.TelegramLinkRow{}, apperrors.NewNotFound("telegram chat not linked", nil)).Once()
+		bot.On("SendMessage", mock.Anything, chatID, telegramNotLinked).Return(nil).Once()
+
+		send(s, "/list")
+
+		bot.AssertExpectations(t)
+	})
+
+	t.Run("Lists Own Subscriptions", func(t *testing.T) {
+		s, repo, subRepo, bot := setup()
+		repo.On("GetLinkByChat", mock.Anything, chatID).Return(link, nil).Once()
+		actsAsOwner := mock.MatchedBy(func(ctx context.Context) bool {
+			principal, ok := PrincipalFromContext(ctx)
+			return ok && principal.UserID == userID && !principal.IsAdmin()
+		})
+		subRepo.On("ListSubscriptions", actsAsOwner, mock.AnythingOfType("dto.SubscriptionFilter")).
+			Return([]dao.SubscriptionRow{{ID: uuid.New(), UserID: userID, ServiceName: "Netflix", Price: 999, BillingPeriod: "monthly"}}, nil).Once()
+		bot.On("SendMessage", mock.Anything, chatID, "Your subscriptions:\n\nNetflix - 999 per month").Return(nil).Once()
+
+		send(s, "/list")
+
+		subRepo.AssertExpectations(t)
+		bot.AssertExpectations(t)
+	})
+
+	t.Run("Adds Through Dialog", func(t *testing.T) {
+		s, repo, subRepo, bot := setup()
+		repo.On("GetLinkByChat", mock.Anything, chatID).Return(link, nil)
+		subRepo.On("ListSubscriptions", mock.Anything, mock.AnythingOfType("dto.SubscriptionFilter")).Return(nil, nil)
+		subRepo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(row dao.SubscriptionRow) bool {
+			return row.UserID == userID && row.ServiceName == "Spotify" && row.Price == 199 &&
+				row.BillingPeriod == "yearly" && row.StartDate.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+		})).Return(nil).Once()
+		var replies []string
+		bot.On("SendMessage", mock.Anything, chatID, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { replies = append(replies, args.String(2)) }).Return(nil)
+
+		for _, text := range []string{"/add", "Spotify", "cheap", "199", "Yearly", "07-2025"} {
+			send(s, text)
+		}
+
+		assert.Equal(t, []string{
+			"What is the service called?",
+			"How much is it per billing period? Send a whole number, e.g. 299.",
+			"Send the price as a whole number, e.g. 299.",
+			"How often is it billed: monthly, yearly or weekly?",
+			"When did it start? Send the month as MM-YYYY, e.g. 07-2025.",
+			"Added Spotify: 199 per year since 07-2025.",
+		}, replies)
+		subRepo.AssertExpectations(t)
+		assert.Empty(t, s.dialogs, "a finished dialog is dropped")
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		s, repo, _, bot := setup()
+		repo.On("GetLinkByChat", mock.Anything, chatID).Return(link, nil)
+		bot.On("SendMessage", mock.Anything, chatID, mock.AnythingOfType("string")).Return(nil)
+
+		send(s, "/add")
+		send(s, "/cancel")
+
+		assert.Empty(t, s.dialogs)
+		bot.AssertCalled(t, "SendMessage", mock.Anything, chatID, "Cancelled.")
+	})
+}
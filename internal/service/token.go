@@ -0,0 +1,32 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"subtracker/pkg/apperrors"
+)
+
+// secretTokenBytes makes undo tokens and link codes as hard to guess as a
+// random UUID is unique.
+const secretTokenBytes = 16
+
+// newSecretToken returns a URL-safe token and the hash under which it is
+// stored. kind names the token in the error.
+func newSecretToken(kind string) (token, hash string, err error) {
+	raw := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", apperrors.NewInternalServerError("failed to generate "+kind, err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashSecretToken(token), nil
+}
+
+// hashSecretToken is deterministic so a presented token can be looked up by
+// its hash; the token itself is random, so no salt is needed.
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/mailer"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/internal/telegram"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type TrialServiceInterface interface {
+	GetTrial(ctx context.Context, subscriptionID string) (domain.Trial, error)
+	SetTrial(ctx context.Context, trial domain.Trial) (domain.Trial, error)
+}
+
+// TrialService manages subscription trials and, run periodically, converts
+// the ones that ended to their regular price and tells the owner, by Telegram
+// when they linked a chat and by email otherwise.
+type TrialService struct {
+	repo          repository.TrialRepositoryInterface
+	subscriptions SubscriptionServiceInterface
+	// mailer and telegram are nil while their channel is not configured;
+	// trials still convert, but nobody is told that way.
+	mailer   mailer.Mailer
+	telegram telegram.Sender
+	audit    auditTrail
+	clock    clock.Clock
+	logger   logger.Logger
+}
+
+func NewTrialService(repo repository.TrialRepositoryInterface, subscriptions SubscriptionServiceInterface, mailer mailer.Mailer, telegram telegram.Sender, audit repository.AuditRepositoryInterface, clock clock.Clock, logger logger.Logger) *TrialService {
+	return &TrialService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		mailer:        mailer,
+		telegram:      telegram,
+		audit:         auditTrail{repo: audit, clock: clock, logger: logger},
+		clock:         clock,
+		logger:        logger,
+	}
+}
+
+func (s *TrialService) GetTrial(ctx context.Context, subscriptionID string) (domain.Trial, error) {
+	if _, err := s.subscriptions.GetSubscription(ctx, subscriptionID); err != nil {
+		return domain.Trial{}, err
+	}
+	row, err := s.repo.GetTrial(ctx, subscriptionID)
+	if err != nil {
+		return domain.Trial{}, err
+	}
+	return mapper.ToTrialFromDAO(row), nil
+}
+
+// SetTrial starts or replaces the subscription's trial. The last day may not
+// have passed; the regular billing period defaults to the subscription's.
+func (s *TrialService) SetTrial(ctx context.Context, trial domain.Trial) (domain.Trial, error) {
+	sub, err := s.subscriptions.GetSubscription(ctx, trial.SubscriptionID.String())
+	if err != nil {
+		return domain.Trial{}, err
+	}
+	if trial.EndsOn.Before(s.today()) {
+		return domain.Trial{}, apperrors.NewBadRequest("trial end must not be in the past", nil).
+			WithErrorCode(ErrCodeTrialEndInPast)
+	}
+	if trial.RegularBillingPeriod == "" {
+		trial.RegularBillingPeriod = sub.BillingPeriod
+	}
+	trial.UserID = sub.UserID
+
+	row, err := s.repo.SetTrial(ctx, mapper.ToTrialDAO(trial))
+	if err != nil {
+		return domain.Trial{}, err
+	}
+	s.logger.InfoContext(ctx, "Trial saved",
+		zap.String("subscription_id", trial.SubscriptionID.String()),
+		zap.Time("ends_on", trial.EndsOn),
+	)
+	return mapper.ToTrialFromDAO(row), nil
+}
+
+// ConvertDue converts every trial that ended before today and returns how
+// many subscriptions converted. Owners are told on a best-effort basis: the
+// conversion is committed first, so a failed notice is logged, not retried.
+func (s *TrialService) ConvertDue(ctx context.Context) (int, error) {
+	rows, err := s.repo.ConvertDueTrials(ctx, s.today(), s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		trial := mapper.ToConvertedTrialFromDAO(row)
+		after := trial.Subscription
+		before := after
+		before.Price = trial.TrialPrice
+		before.BillingPeriod = trial.TrialBillingPeriod
+		s.audit.subscription(ctx, domain.AuditUpdate, &before, &after)
+
+		if err := s.notify(ctx, trial); err != nil {
+			s.logger.WarnContext(ctx, "Failed to send trial conversion notice", zap.Error(err), zap.String("subscription_id", trial.ID.String()))
+		}
+	}
+	return len(rows), nil
+}
+
+// notify tells the owner through their linked Telegram chat while the bot
+// runs and by email otherwise; owners reachable neither way are skipped.
+func (s *TrialService) notify(ctx context.Context, trial domain.ConvertedTrial) error {
+	switch {
+	case s.telegram != nil && trial.TelegramChatID != nil:
+		return s.telegram.SendMessage(ctx, *trial.TelegramChatID, "Trial converted: "+trialConversionNotice(trial)+"\n\nSend /list to see all your subscriptions.")
+	case s.mailer != nil && trial.Email != "":
+		return s.mailer.Send(ctx, mailer.Message{
+			To:      trial.Email,
+			Subject: fmt.Sprintf("Your %s trial just converted", trial.ServiceName),
+			Body: trialConversionNotice(trial) + "\n\n" +
+				"If you did not mean to keep it, cancel it with the provider and set an end date on the subscription.\n",
+		})
+	default:
+		return nil
+	}
+}
+
+// Run converts ended trials every interval until ctx is done.
+func (s *TrialService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		converted, err := s.ConvertDue(ctx)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Trial conversion run failed", zap.Error(err))
+		} else if converted > 0 {
+			s.logger.InfoContext(ctx, "Trials converted", zap.Int("converted", converted))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func trialConversionNotice(trial domain.ConvertedTrial) string {
+	return fmt.Sprintf("Your %s trial has ended and the subscription now costs %d per %s.",
+		trial.ServiceName, trial.Price, trial.BillingPeriod.Unit())
+}
+
+// today is the current UTC date at midnight.
+func (s *TrialService) today() time.Time {
+	now := s.clock.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/mailer"
+	mailermocks "subtracker/internal/mailer/mocks"
+	"subtracker/internal/repository/mocks"
+	telegrammocks "subtracker/internal/telegram/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrialService_SetTrial(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	sub := dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Netflix", Price: 0, StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: "yearly"}
+	setup := func() (*TrialService, *mocks.TrialRepositoryInterface) {
+		repo := new(mocks.TrialRepositoryInterface)
+		subRepo := new(mocks.SubscriptionRepositoryInterface)
+		subRepo.On("GetSubscription", mock.Anything, sub.ID.String()).Return(sub, nil)
+		subs := NewSubscriptionService(subRepo, nil, nil, nil, DateLimits{}, 0, time.Minute, TimeOrderedIDs(), clock.System(), logger.NewNopLogger())
+		return NewTrialService(repo, subs, nil, nil, nil, clock.NewFrozen(now), logger.NewNopLogger()), repo
+	}
+
+	t.Run("Defaults To Current Billing Period", func(t *testing.T) {
+		s, repo := setup()
+		endsOn := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+		row := dao.TrialRow{SubscriptionID: sub.ID, UserID: sub.UserID, EndsOn: endsOn, RegularPrice: 9999, RegularBillingPeriod: "yearly"}
+		repo.On("SetTrial", mock.Anything, row).Return(row, nil).Once()
+
+		trial, err := s.SetTrial(context.Background(), domain.Trial{SubscriptionID: sub.ID, EndsOn: endsOn, RegularPrice: 9999})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.BillingYearly, trial.RegularBillingPeriod)
+		assert.Equal(t, domain.TrialActive, trial.Status())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Ending Today Is Allowed", func(t *testing.T) {
+		s, repo := setup()
+		repo.On("SetTrial", mock.Anything, mock.AnythingOfType("dao.TrialRow")).Return(dao.TrialRow{}, nil).Once()
+
+		_, err := s.SetTrial(context.Background(), domain.Trial{SubscriptionID: sub.ID, EndsOn: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), RegularPrice: 9999})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Past End Is Rejected", func(t *testing.T) {
+		s, repo := setup()
+
+		_, err := s.SetTrial(context.Background(), domain.Trial{SubscriptionID: sub.ID, EndsOn: time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC), RegularPrice: 9999})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Equal(t, ErrCodeTrialEndInPast, appErr.ErrorCode)
+		repo.AssertNotCalled(t, "SetTrial")
+	})
+}
+
+func TestTrialService_ConvertDue(t *testing.T) {
+	now := time.Date(2026, 1, 15, 0, 5, 0, 0, time.UTC)
+	today := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	converted := func(service string, email string, chatID *int64) dao.ConvertedTrialRow {
+		return dao.ConvertedTrialRow{
+			SubscriptionRow:    dao.SubscriptionRow{ID: uuid.New(), UserID: uuid.New(), ServiceName: service, Price: 999, StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: "monthly"},
+			TrialPrice:         0,
+			TrialBillingPeriod: "monthly",
+			Email:              email,
+			TelegramChatID:     chatID,
+		}
+	}
+	chatID := int64(42)
+	byEmail := converted("Netflix", "user@example.com", nil)
+	byChat := converted("Spotify", "user@example.com", &chatID)
+	unreachable := converted("Hulu", "", nil)
+
+	repo := new(mocks.TrialRepositoryInterface)
+	audit := new(mocks.AuditRepositoryInterface)
+	mail := new(mailermocks.Mailer)
+	bot := new(telegrammocks.Sender)
+	s := NewTrialService(repo, nil, mail, bot, audit, clock.NewFrozen(now), logger.NewNopLogger())
+	repo.On("ConvertDueTrials", mock.Anything, today, now).Return([]dao.ConvertedTrialRow{byEmail, byChat, unreachable}, nil).Once()
+	audit.On("CreateEntry", mock.Anything, mock.MatchedBy(func(row dao.AuditRow) bool {
+		return row.Action == string(domain.AuditUpdate) && row.ActorID == nil && row.Changes["price"].Before == 0
+	})).Return(nil).Times(3)
+	mail.On("Send", mock.Anything, mailer.Message{
+		To:      "user@example.com",
+		Subject: "Your Netflix trial just converted",
+		Body:    "Your Netflix trial has ended and the subscription now costs 999 per month.\n\nIf you did not mean to keep it, cancel it with the provider and set an end date on the subscription.\n",
+	}).Return(errors.New("smtp down")).Once()
+	bot.On("SendMessage", mock.Anything, chatID,
+		"Trial converted: Your Spotify trial has ended and the subscription now costs 999 per month.\n\nSend /list to see all your subscriptions.").
+		Return(nil).Once()
+
+	count, err := s.ConvertDue(context.Background())
+
+	assert.NoError(t, err, "a failed notice does not fail the run")
+	assert.Equal(t, 3, count)
+	repo.AssertExpectations(t)
+	audit.AssertExpectations(t)
+	mail.AssertExpectations(t)
+	bot.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/mapper"
+	"subtracker/internal/repository"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type UserServiceInterface interface {
+	Register(ctx context.Context, email, password string) (domain.User, error)
+	GetUser(ctx context.Context, id string) (domain.User, error)
+	ChangePassword(ctx context.Context, id, currentPassword, newPassword string) error
+}
+
+type UserService struct {
+	repo   repository.UserRepositoryInterface
+	logger logger.Logger
+}
+
+func NewUserService(repo repository.UserRepositoryInterface, logger logger.Logger) *UserService {
+	return &UserService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// normalizeEmail makes email lookups case-insensitive.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s *UserService) Register(ctx context.Context, email, password string) (domain.User, error) {
+	s.logger.DebugContext(ctx, "Entering Register service")
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return domain.User{}, err
+	}
+	row, err := s.repo.CreateUser(ctx, dao.UserRow{
+		ID:           uuid.New(),
+		Email:        normalizeEmail(email),
+		PasswordHash: hash,
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+	s.logger.InfoContext(ctx, "User registered", zap.String("user_id", row.ID.String()))
+	return mapper.ToUserDomainFromDAO(row), nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, id string) (domain.User, error) {
+	s.logger.DebugContext(ctx, "Entering GetUser service", zap.String("user_id", id))
+
+	row, err := s.repo.GetUser(ctx, id)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return mapper.ToUserDomainFromDAO(row), nil
+}
+
+func (s *UserService) ChangePassword(ctx context.Context, id, currentPassword, newPassword string) error {
+	s.logger.DebugContext(ctx, "Entering ChangePassword service", zap.String("user_id", id))
+
+	row, err := s.repo.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !checkPassword(row.PasswordHash, currentPassword) {
+		s.logger.WarnContext(ctx, "Password change rejected: wrong current password", zap.String("user_id", id))
+		return apperrors.New(http.StatusForbidden, "current password is incorrect", nil).
+			WithErrorCode(ErrCodeInvalidCredentials)
+	}
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdatePasswordHash(ctx, id, hash); err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "Password changed", zap.String("user_id", id))
+	return nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserService_Register(t *testing.T) {
+	t.Run("Hashes Password and Normalizes Email", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepositoryInterface)
+		service := NewUserService(mockRepo, logger.NewNopLogger())
+
+		mockRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(row dao.UserRow) bool {
+			return row.ID != uuid.Nil && row.Email == "alice@example.com" &&
+				row.PasswordHash != "correct horse battery" && checkPassword(row.PasswordHash, "correct horse battery")
+		})).Return(func(_ context.Context, row dao.UserRow) (dao.UserRow, error) { return row, nil }).Once()
+
+		user, err := service.Register(context.Background(), " Alice@Example.COM ", "correct horse battery")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "alice@example.com", user.Email)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Password Longer Than Bcrypt Accepts", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepositoryInterface)
+		service := NewUserService(mockRepo, logger.NewNopLogger())
+
+		_, err := service.Register(context.Background(), "alice@example.com", strings.Repeat("é", 40))
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	id := uuid.New()
+	hash, err := hashPassword("correct horse battery")
+	assert.NoError(t, err)
+	row := dao.UserRow{ID: id, Email: "alice@example.com", PasswordHash: hash}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepositoryInterface)
+		service := NewUserService(mockRepo, logger.NewNopLogger())
+
+		mockRepo.On("GetUser", mock.Anything, id.String()).Return(row, nil).Once()
+		mockRepo.On("UpdatePasswordHash", mock.Anything, id.String(), mock.MatchedBy(func(newHash string) bool {
+			return checkPassword(newHash, "staple battery horse")
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ChangePassword(context.Background(), id.String(), "correct horse battery", "staple battery horse"))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Wrong Current Password", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepositoryInterface)
+		service := NewUserService(mockRepo, logger.NewNopLogger())
+
+		mockRepo.On("GetUser", mock.Anything, id.String()).Return(row, nil).Once()
+
+		err := service.ChangePassword(context.Background(), id.String(), "guess", "staple battery horse")
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		mockRepo.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Legacy Account Has No Password", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepositoryInterface)
+		service := NewUserService(mockRepo, logger.NewNopLogger())
+
+		mockRepo.On("GetUser", mock.Anything, id.String()).Return(dao.UserRow{ID: id}, nil).Once()
+
+		assert.Error(t, service.ChangePassword(context.Background(), id.String(), "", "staple battery horse"))
+	})
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/mapper"
+	"subtracker/internal/metrics"
+	"subtracker/internal/repository"
+	"subtracker/internal/webhook"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// webhookDispatchBatch is how many deliveries one dispatch claims at a time.
+const webhookDispatchBatch = 50
+
+// webhookRetryBase and webhookRetryMax bound the backoff between attempts:
+// 30s after the first failure, doubling up to an hour.
+const (
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = time.Hour
+)
+
+type WebhookServiceInterface interface {
+	CreateWebhook(ctx context.Context, hook domain.Webhook) (domain.Webhook, error)
+	ListWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error)
+	GetWebhook(ctx context.Context, id string) (domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, id string, patch domain.WebhookPatch) (domain.Webhook, error)
+	RotateSecret(ctx context.Context, id string) (domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error)
+	DeliveryStats(ctx context.Context, webhookID string) (domain.WebhookStats, error)
+}
+
+// WebhookService manages users' webhooks and sends the deliveries queued for
+// them. Deliveries are queued by the database whenever a subscription changes.
+type WebhookService struct {
+	repo        repository.WebhookRepositoryInterface
+	sender      webhook.Sender
+	maxAttempts int
+	// lease is how long a claimed delivery is hidden from other dispatchers;
+	// it must outlast a send.
+	lease  time.Duration
+	logger logger.Logger
+	clock  clock.Clock
+}
+
+// NewWebhookService sends through sender, whose attempts take at most
+// sendTimeout.
+func NewWebhookService(repo repository.WebhookRepositoryInterface, sender webhook.Sender, maxAttempts int, sendTimeout time.Duration, clock clock.Clock, logger logger.Logger) *WebhookService {
+	return &WebhookService{
+		repo:        repo,
+		sender:      sender,
+		maxAttempts: maxAttempts,
+		// A minute on top leaves room for recording the outcome.
+		lease:  sendTimeout + time.Minute,
+		logger: logger,
+		clock:  clock,
+	}
+}
+
+// CreateWebhook registers the webhook under a newly generated secret, which
+// the returned webhook carries.
+func (s *WebhookService) CreateWebhook(ctx context.Context, hook domain.Webhook) (domain.Webhook, error) {
+	s.logger.DebugContext(ctx, "Entering CreateWebhook service", zap.String("user_id", hook.UserID.String()))
+
+	if _, err := ScopeUserID(ctx, hook.UserID.String()); err != nil {
+		return domain.Webhook{}, err
+	}
+	if err := validateWebhookURL(hook.URL); err != nil {
+		return domain.Webhook{}, err
+	}
+	secret, _, err := newSecretToken("webhook secret")
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	hook.ID = uuid.New()
+	hook.Secret = secret
+
+	row, err := s.repo.CreateWebhook(ctx, mapper.ToWebhookDAO(hook))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	s.logger.InfoContext(ctx, "Webhook created", zap.String("webhook_id", hook.ID.String()), zap.String("user_id", hook.UserID.String()))
+	return mapper.ToWebhookFromDAO(row), nil
+}
+
+func validateWebhookURL(raw string) error {
+	if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apperrors.NewBadRequest("url must be an http(s) URL", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	userID, err := ScopeUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userID == "" {
+		return nil, apperrors.NewBadRequest("user_id is required", nil)
+	}
+	rows, err := s.repo.ListWebhooks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	hooks := make([]domain.Webhook, len(rows))
+	for i, row := range rows {
+		hooks[i] = mapper.ToWebhookFromDAO(row)
+	}
+	return hooks, nil
+}
+
+// GetWebhook returns a webhook the caller owns.
+func (s *WebhookService) GetWebhook(ctx context.Context, id string) (domain.Webhook, error) {
+	row, err := s.repo.GetWebhook(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	if err := authorizeOwner(ctx, row.UserID, "webhook"); err != nil {
+		return domain.Webhook{}, err
+	}
+	return mapper.ToWebhookFromDAO(row), nil
+}
+
+// UpdateWebhook changes the webhook's URL or events. Deliveries already
+// queued are still sent, to the new URL.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id string, patch domain.WebhookPatch) (domain.Webhook, error) {
+	hook, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	hook = patch.Apply(hook)
+	if err := validateWebhookURL(hook.URL); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	row, err := s.repo.UpdateWebhook(ctx, mapper.ToWebhookDAO(hook))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	s.logger.InfoContext(ctx, "Webhook updated", zap.String("webhook_id", id), zap.Strings("events", row.Events))
+	return mapper.ToWebhookFromDAO(row), nil
+}
+
+// RotateSecret replaces the webhook's secret with a newly generated one, which
+// the returned webhook carries. Every delivery sent from now on, including
+// retries of earlier ones, is signed with it.
+func (s *WebhookService) RotateSecret(ctx context.Context, id string) (domain.Webhook, error) {
+	hook, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	if hook.Secret, _, err = newSecretToken("webhook secret"); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	row, err := s.repo.UpdateWebhook(ctx, mapper.ToWebhookDAO(hook))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	s.logger.InfoContext(ctx, "Webhook secret rotated", zap.String("webhook_id", id))
+	return mapper.ToWebhookFromDAO(row), nil
+}
+
+// DeleteWebhook removes the webhook; deliveries still pending are dropped.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	if _, err := s.GetWebhook(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "Webhook deleted", zap.String("webhook_id", id))
+	return nil
+}
+
+// ListDeliveries returns the webhook's most recent deliveries, newest first.
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = mapper.ToWebhookDeliveryFromDAO(row)
+	}
+	return deliveries, nil
+}
+
+// DeliveryStats counts the webhook's deliveries by outcome, overall and for
+// each event. Events the webhook is registered for are listed even before
+// their first delivery.
+func (s *WebhookService) DeliveryStats(ctx context.Context, webhookID string) (domain.WebhookStats, error) {
+	hook, err := s.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return domain.WebhookStats{}, err
+	}
+	rows, err := s.repo.CountDeliveries(ctx, webhookID)
+	if err != nil {
+		return domain.WebhookStats{}, err
+	}
+
+	stats := domain.WebhookStats{WebhookID: hook.ID, Events: make([]domain.EventDeliveryCounts, len(hook.Events))}
+	index := make(map[string]int, len(hook.Events))
+	for i, event := range hook.Events {
+		stats.Events[i].Event = event
+		index[event] = i
+	}
+	for _, row := range rows {
+		i, ok := index[row.Event]
+		if !ok {
+			i = len(stats.Events)
+			index[row.Event] = i
+			stats.Events = append(stats.Events, domain.EventDeliveryCounts{Event: row.Event})
+		}
+		status := domain.DeliveryStatus(row.Status)
+		stats.Events[i].Add(status, row.Count)
+		stats.Add(status, row.Count)
+		if row.LastDeliveredAt != nil && (stats.LastDeliveredAt == nil || row.LastDeliveredAt.After(*stats.LastDeliveredAt)) {
+			stats.LastDeliveredAt = row.LastDeliveredAt
+		}
+	}
+	return stats, nil
+}
+
+// DispatchDue sends every delivery that is due and returns how many were
+// delivered. A failed delivery is retried with exponential backoff until
+// maxAttempts is reached, after which it is marked failed for good.
+func (s *WebhookService) DispatchDue(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		rows, err := s.repo.ClaimDueDeliveries(ctx, webhookDispatchBatch, s.lease)
+		if err != nil {
+			return delivered, err
+		}
+		for _, row := range rows {
+			ok, err := s.dispatch(ctx, row)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+		if len(rows) < webhookDispatchBatch || ctx.Err() != nil {
+			return delivered, nil
+		}
+	}
+}
+
+// dispatch sends one claimed delivery and records the outcome. It reports
+// whether the receiver accepted it; the error is only about recording.
+func (s *WebhookService) dispatch(ctx context.Context, row dao.DueDeliveryRow) (bool, error) {
+	status, sendErr := s.sender.Send(ctx, webhook.Delivery{
+		ID:       row.ID.String(),
+		Event:    row.Event,
+		Sequence: row.Sequence,
+		URL:      row.URL,
+		Secret:   row.Secret,
+		Payload:  row.Payload,
+	})
+	attempt := dao.WebhookAttemptRow{ID: row.ID, Status: string(domain.DeliveryDelivered), NextAttemptAt: s.clock.Now()}
+	if status != 0 {
+		attempt.ResponseStatus = &status
+	}
+	result := "delivered"
+	switch {
+	case sendErr == nil:
+	case row.Attempts >= s.maxAttempts:
+		attempt.Status = string(domain.DeliveryFailed)
+		attempt.LastError = sendErr.Error()
+		result = "failed"
+	default:
+		attempt.Status = string(domain.DeliveryPending)
+		attempt.LastError = sendErr.Error()
+		attempt.NextAttemptAt = attempt.NextAttemptAt.Add(webhookBackoff(row.Attempts))
+		result = "retried"
+	}
+	if sendErr != nil {
+		s.logger.WarnContext(ctx, "Webhook delivery failed",
+			zap.Error(sendErr),
+			zap.String("delivery_id", row.ID.String()),
+			zap.Int("attempt", row.Attempts),
+			zap.String("status", attempt.Status),
+		)
+	}
+	metrics.WebhookDeliveries.WithLabelValues(result).Inc()
+
+	// The outcome is recorded even when the run was cancelled mid-send, so a
+	// delivered payload is not sent again once the lease runs out.
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.repo.RecordAttempt(recordCtx, attempt); err != nil {
+		return false, err
+	}
+	return sendErr == nil, nil
+}
+
+// webhookBackoff is the wait after the given failed attempt.
+func webhookBackoff(attempt int) time.Duration {
+	wait := webhookRetryBase
+	for i := 1; i < attempt && wait < webhookRetryMax; i++ {
+		wait *= 2
+	}
+	return min(wait, webhookRetryMax)
+}
+
+// Run dispatches due deliveries every interval until ctx is done.
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		delivered, err := s.DispatchDue(ctx)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Webhook dispatch run failed", zap.Error(err), zap.Int("delivered", delivered))
+		} else if delivered > 0 {
+			s.logger.InfoContext(ctx, "Webhook deliveries sent", zap.Int("delivered", delivered))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
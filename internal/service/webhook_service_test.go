@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"subtracker/internal/domain"
+	"subtracker/internal/domain/dao"
+	"subtracker/internal/repository/mocks"
+	"subtracker/internal/webhook"
+	webhookmocks "subtracker/internal/webhook/mocks"
+	"subtracker/pkg/apperrors"
+	"subtracker/pkg/clock"
+	"subtracker/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	userID := uuid.New()
+	hook := domain.Webhook{UserID: userID, URL: "https://example.com/hook", Events: []string{domain.EventSubscriptionCreated}}
+
+	t.Run("Generates ID And Secret", func(t *testing.T) {
+		repo := new(mocks.WebhookRepositoryInterface)
+		s := NewWebhookService(repo, nil, 8, 10*time.Second, clock.System(), logger.NewNopLogger())
+		repo.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(row dao.WebhookRow) bool {
+			return row.ID != uuid.Nil && row.Secret != "" && row.UserID == userID
+		})).Return(func(_ context.Context, row dao.WebhookRow) (dao.WebhookRow, error) { return row, nil }).Once()
+
+		created, err := s.CreateWebhook(context.Background(), hook)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.Secret)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects Other Schemes", func(t *testing.T) {
+		s := NewWebhookService(new(mocks.WebhookRepositoryInterface), nil, 8, 10*time.Second, clock.System(), logger.NewNopLogger())
+		ftp := hook
+		ftp.URL = "ftp://example.com/hook"
+
+		_, err := s.CreateWebhook(context.Background(), ftp)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	})
+
+	t.Run("Other User Forbidden", func(t *testing.T) {
+		s := NewWebhookService(new(mocks.WebhookRepositoryInterface), nil, 8, 10*time.Second, clock.System(), logger.NewNopLogger())
+		ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+		_, err := s.CreateWebhook(ctx, hook)
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+	})
+}
+
+func TestWebhookService_GetWebhookHidesOtherUsers(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryInterface)
+	s := NewWebhookService(repo, nil, 8, 10*time.Second, clock.System(), logger.NewNopLogger())
+	row := dao.WebhookRow{ID: uuid.New(), UserID: uuid.New()}
+	repo.On("GetWebhook", mock.Anything, row.ID.String()).Return(row, nil)
+	ctx := WithPrincipal(context.Background(), domain.Principal{UserID: uuid.New(), Role: domain.RoleUser})
+
+	err := s.DeleteWebhook(ctx, row.ID.String())
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+	repo.AssertNotCalled(t, "DeleteWebhook", mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_UpdateWebhook(t *testing.T) {
+	row := dao.WebhookRow{ID: uuid.New(), UserID: uuid.New(), URL: "https://example.com/hook", Secret: "s3cret", Events: []string{domain.EventSubscriptionCreated}}
+	setup := func() (*WebhookService, *mocks.WebhookRepositoryInterface) {
+		repo := new(mocks.WebhookRepositoryInterface)
+		repo.On("GetWebhook", mock.Anything, row.ID.String()).Return(row, nil).Once()
+		return NewWebhookService(repo, nil, 8, 10*time.Second, clock.System(), logger.NewNopLogger()), repo
+	}
+
+	t.Run("Changes Events Only", func(t *testing.T) {
+		s, repo := setup()
+		want := row
+		want.Events = []string{domain.EventSubscriptionDeleted}
+		repo.On("UpdateWebhook", mock.Anything, want).Return(want, nil).Once()
+
+		updated, err := s.UpdateWebhook(context.Background(), row.ID.String(), domain.WebhookPatch{Events: want.Events})
+
+		assert.NoError(t, err)
+		assert.Equal(t, want.Events, updated.Events)
+		assert.Equal(t, row.URL, updated.URL)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects Other Schemes", func(t *testing.T) {
+		s, repo := setup()
+		ftp := "ftp://example.com/hook"
+
+		_, err := s.UpdateWebhook(context.Background(), row.ID.String(), domain.WebhookPatch{URL: &ftp})
+
+		var appErr *apperrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		repo.AssertNotCalled(t, "UpdateWebhook", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rotates Secret", func(t *testing.T) {
+		s, repo := setup()
+		repo.On("UpdateWebhook", mock.Anything, mock.MatchedBy(func(updated dao.WebhookRow) bool {
+			return updated.Secret != "" && updated.Secret != row.Secret && updated.URL == row.URL
+		})).Return(func(_ context.Context, updated dao.WebhookRow) (dao.WebhookRow, error) { return updated, nil }).Once()
+
+		rotated, err := s.RotateSecret(context.Background(), row.ID.String())
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, row.Secret, rotated.Secret)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_DeliveryStats(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryInterface)
+	s := NewWebhookService(repo, nil, 8, 10*time.Second, clock.System(), logger.NewNopLogger())
+	row := dao.WebhookRow{ID: uuid.New(), UserID: uuid.New(), Events: []string{domain.EventSubscriptionCreated, domain.EventSubscriptionDeleted}}
+	earlier := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	later := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	repo.On("GetWebhook", mock.Anything, row.ID.String()).Return(row, nil).Once()
+	repo.On("CountDeliveries", mock.Anything, row.ID.String()).Return([]dao.WebhookDeliveryCountRow{
+		{Event: domain.EventSubscriptionCreated, Status: "delivered", Count: 4, LastDeliveredAt: &earlier},
+		{Event: domain.EventSubscriptionCreated, Status: "failed", Count: 1},
+		// No longer in the webhook's events.
+		{Event: domain.EventSubscriptionUpdated, Status: "delivered", Count: 2, LastDeliveredAt: &later},
+		{Event: domain.EventSubscriptionUpdated, Status: "pending", Count: 1},
+	}, nil).Once()
+
+	stats, err := s.DeliveryStats(context.Background(), row.ID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryCounts{Pending: 1, Delivered: 6, Failed: 1}, stats.DeliveryCounts)
+	assert.Equal(t, []domain.EventDeliveryCounts{
+		{Event: domain.EventSubscriptionCreated, DeliveryCounts: domain.DeliveryCounts{Delivered: 4, Failed: 1}},
+		{Event: domain.EventSubscriptionDeleted},
+		{Event: domain.EventSubscriptionUpdated, DeliveryCounts: domain.DeliveryCounts{Pending: 1, Delivered: 2}},
+	}, stats.Events)
+	assert.Equal(t, later, *stats.LastDeliveredAt)
+	repo.AssertExpectations(t)
+}
+
+func TestWebhookService_DispatchDue(t *testing.T) {
+	now := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	due := func(attempts int) dao.DueDeliveryRow {
+		return dao.DueDeliveryRow{ID: uuid.New(), Event: domain.EventSubscriptionUpdated, Sequence: int64(attempts), Payload: []byte(`{}`), Attempts: attempts, URL: "https://example.com/hook", Secret: "s3cret"}
+	}
+	setup := func() (*WebhookService, *mocks.WebhookRepositoryInterface, *webhookmocks.Sender) {
+		repo := new(mocks.WebhookRepositoryInterface)
+		sender := new(webhookmocks.Sender)
+		s := NewWebhookService(repo, sender, 3, 10*time.Second, clock.NewFrozen(now), logger.NewNopLogger())
+		return s, repo, sender
+	}
+	status := func(code int) *int { return &code }
+
+	t.Run("Records Each Outcome", func(t *testing.T) {
+		s, repo, sender := setup()
+		ok, retry, last := due(1), due(2), due(3)
+		repo.On("ClaimDueDeliveries", mock.Anything, webhookDispatchBatch, 70*time.Second).Return([]dao.DueDeliveryRow{ok, retry, last}, nil).Once()
+		sender.On("Send", mock.Anything, webhook.Delivery{ID: ok.ID.String(), Event: ok.Event, Sequence: ok.Sequence, URL: ok.URL, Secret: ok.Secret, Payload: ok.Payload}).Return(http.StatusOK, nil).Once()
+		sender.On("Send", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool { return d.ID == retry.ID.String() })).Return(http.StatusServiceUnavailable, errors.New("receiver answered 503")).Once()
+		sender.On("Send", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool { return d.ID == last.ID.String() })).Return(0, errors.New("connection refused")).Once()
+		repo.On("RecordAttempt", mock.Anything, dao.WebhookAttemptRow{ID: ok.ID, Status: "delivered", ResponseStatus: status(200), NextAttemptAt: now}).Return(nil).Once()
+		repo.On("RecordAttempt", mock.Anything, dao.WebhookAttemptRow{ID: retry.ID, Status: "pending", ResponseStatus: status(503), LastError: "receiver answered 503", NextAttemptAt: now.Add(time.Minute)}).Return(nil).Once()
+		repo.On("RecordAttempt", mock.Anything, dao.WebhookAttemptRow{ID: last.ID, Status: "failed", LastError: "connection refused", NextAttemptAt: now}).Return(nil).Once()
+
+		delivered, err := s.DispatchDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		repo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("Claim Fails", func(t *testing.T) {
+		s, repo, _ := setup()
+		repo.On("ClaimDueDeliveries", mock.Anything, webhookDispatchBatch, 70*time.Second).Return(nil, apperrors.NewInternalServerError("db down", nil)).Once()
+
+		_, err := s.DispatchDue(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhookBackoff(1))
+	assert.Equal(t, 2*time.Minute, webhookBackoff(3))
+	assert.Equal(t, time.Hour, webhookBackoff(20))
+}
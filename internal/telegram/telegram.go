@@ -0,0 +1,145 @@
+// Package telegram talks to the Telegram Bot API: it sends plain-text messages
+// and long-polls for the ones users send the bot.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"subtracker/internal/config"
+	"subtracker/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// pollTimeout is how long one getUpdates call waits for a message.
+	pollTimeout = 30 * time.Second
+	// retryDelay spaces out getUpdates calls after a failure.
+	retryDelay = 5 * time.Second
+)
+
+// Message is a text message a user sent the bot.
+type Message struct {
+	ChatID int64
+	Text   string
+}
+
+type Sender interface {
+	SendMessage(ctx context.Context, chatID int64, text string) error
+}
+
+type Client struct {
+	// endpoint embeds the bot token, so it must never end up in errors or logs.
+	endpoint string
+	http     *http.Client
+	logger   logger.Logger
+}
+
+func NewClient(cfg config.TelegramConfig, logger logger.Logger) *Client {
+	return &Client{
+		endpoint: strings.TrimRight(cfg.APIURL, "/") + "/bot" + string(cfg.BotToken),
+		http:     &http.Client{Timeout: pollTimeout + 10*time.Second},
+		logger:   logger,
+	}
+}
+
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Description string          `json:"description"`
+	Result      json.RawMessage `json:"result"`
+}
+
+type update struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string) error {
+	if err := c.call(ctx, "sendMessage", map[string]any{"chat_id": chatID, "text": text}, nil); err != nil {
+		return fmt.Errorf("send telegram message to chat %d: %w", chatID, err)
+	}
+	return nil
+}
+
+// Poll long-polls for messages and hands each text message to handle, one at
+// a time, until ctx is done. Only one process may poll a bot at once; Telegram
+// rejects concurrent getUpdates calls.
+func (c *Client) Poll(ctx context.Context, handle func(context.Context, Message)) {
+	var offset int64
+	for ctx.Err() == nil {
+		var updates []update
+		params := map[string]any{
+			"offset":          offset,
+			"timeout":         int(pollTimeout.Seconds()),
+			"allowed_updates": []string{"message"},
+		}
+		if err := c.call(ctx, "getUpdates", params, &updates); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn("Failed to poll Telegram updates", zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if u.Message == nil || u.Message.Text == "" {
+				continue
+			}
+			handle(ctx, Message{ChatID: u.Message.Chat.ID, Text: u.Message.Text})
+		}
+	}
+}
+
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		// Parse errors quote the URL, token included.
+		return fmt.Errorf("%s: invalid API URL", method)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// A *url.Error quotes the URL, token included.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var apiResp apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("%s: decode response with status %d: %w", method, resp.StatusCode, err)
+	}
+	if !apiResp.OK {
+		return fmt.Errorf("%s: %s", method, apiResp.Description)
+	}
+	if result != nil {
+		return json.Unmarshal(apiResp.Result, result)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"subtracker/internal/config"
+	"subtracker/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendMessage(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bot123:secret/sendMessage", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if got["chat_id"] == float64(42) {
+			w.Write([]byte(`{"ok":true,"result":{}}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+	}))
+	defer server.Close()
+	client := NewClient(config.TelegramConfig{BotToken: "123:secret", APIURL: server.URL + "/"}, logger.NewNopLogger())
+
+	t.Run("Sent", func(t *testing.T) {
+		assert.NoError(t, client.SendMessage(context.Background(), 42, "hello"))
+		assert.Equal(t, "hello", got["text"])
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		err := client.SendMessage(context.Background(), 7, "hello")
+		assert.ErrorContains(t, err, "chat not found")
+	})
+
+	t.Run("Unreachable Without Leaking Token", func(t *testing.T) {
+		offline := NewClient(config.TelegramConfig{BotToken: "123:secret", APIURL: "http://127.0.0.1:1"}, logger.NewNopLogger())
+		err := offline.SendMessage(context.Background(), 42, "hello")
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret")
+	})
+}
+
+func TestPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var offsets []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		offsets = append(offsets, params["offset"].(float64))
+		if len(offsets) > 1 {
+			cancel()
+			w.Write([]byte(`{"ok":true,"result":[]}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":[
+			{"update_id":10,"message":{"chat":{"id":42},"text":"/list"}},
+			{"update_id":11,"message":{"chat":{"id":42}}},
+			{"update_id":12,"edited_message":{"chat":{"id":42},"text":"x"}}
+		]}`))
+	}))
+	defer server.Close()
+	client := NewClient(config.TelegramConfig{BotToken: "123:secret", APIURL: server.URL}, logger.NewNopLogger())
+
+	var received []Message
+	client.Poll(ctx, func(_ context.Context, msg Message) {
+		received = append(received, msg)
+	})
+
+	assert.Equal(t, []Message{{ChatID: 42, Text: "/list"}}, received)
+	assert.Equal(t, []float64{0, 13}, offsets, "the next poll acknowledges every update seen")
+}
@@ -0,0 +1,130 @@
+// Package webhook POSTs signed event payloads to user-registered URLs.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" under the webhook's secret, prefixed with "sha256=";
+// receivers should also reject stale timestamps to stop replays.
+const (
+	HeaderEvent     = "X-Subtracker-Event"
+	HeaderDelivery  = "X-Subtracker-Delivery"
+	HeaderSequence  = "X-Subtracker-Sequence"
+	HeaderTimestamp = "X-Subtracker-Timestamp"
+	HeaderSignature = "X-Subtracker-Signature"
+)
+
+// ErrPrivateAddress rejects URLs that resolve to loopback, private or
+// link-local addresses, so webhooks cannot probe the internal network.
+var ErrPrivateAddress = errors.New("webhook URL resolves to a non-public address")
+
+// Delivery is one payload to send.
+type Delivery struct {
+	ID    string
+	Event string
+	// Sequence counts the webhook's deliveries in event order; receivers can
+	// use it to reorder events and detect missing ones.
+	Sequence int64
+	URL      string
+	Secret   string
+	Payload  []byte
+}
+
+type Sender interface {
+	// Send posts the delivery and returns the response status, zero when no
+	// response arrived. Any status other than 2xx is an error.
+	Send(ctx context.Context, d Delivery) (int, error)
+}
+
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPSender sends with the given per-request timeout. Redirects are not
+// followed, and unless allowPrivate is set only public addresses are dialed.
+func NewHTTPSender(timeout time.Duration, allowPrivate bool) *HTTPSender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = rejectPrivate
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, d Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Subtracker-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, d.Event)
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderSequence, strconv.FormatInt(d.Sequence, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(d.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little so the connection can be reused; the body is ignored.
+	_, _ = io.CopyN(io.Discard, resp.Body, 4096)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for a payload sent at timestamp.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// rejectPrivate runs after DNS resolution, so hostnames that point inside the
+// network are caught too.
+func rejectPrivate(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return ErrPrivateAddress
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	payload := []byte(`{"event":"subscription.created"}`)
+	var got *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+	sender := NewHTTPSender(time.Second, true)
+	sender.now = func() time.Time { return time.Unix(1751364000, 0) }
+
+	t.Run("Signed", func(t *testing.T) {
+		status, err := sender.Send(context.Background(), Delivery{ID: "d1", Event: "subscription.created", Sequence: 3, URL: server.URL, Secret: "s3cret", Payload: payload})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, payload, body)
+		assert.Equal(t, "subscription.created", got.Header.Get(HeaderEvent))
+		assert.Equal(t, "d1", got.Header.Get(HeaderDelivery))
+		assert.Equal(t, "3", got.Header.Get(HeaderSequence))
+		assert.Equal(t, "1751364000", got.Header.Get(HeaderTimestamp))
+		assert.Equal(t, Sign("s3cret", 1751364000, payload), got.Header.Get(HeaderSignature))
+		assert.NotEqual(t, Sign("other", 1751364000, payload), got.Header.Get(HeaderSignature))
+	})
+
+	t.Run("Rejected By Receiver", func(t *testing.T) {
+		status, err := sender.Send(context.Background(), Delivery{URL: server.URL + "/gone", Payload: payload})
+
+		assert.Equal(t, http.StatusGone, status)
+		assert.ErrorContains(t, err, "410")
+	})
+
+	t.Run("Private Addresses Blocked", func(t *testing.T) {
+		status, err := NewHTTPSender(time.Second, false).Send(context.Background(), Delivery{URL: server.URL, Payload: payload})
+
+		assert.Zero(t, status)
+		assert.ErrorIs(t, err, ErrPrivateAddress)
+	})
+}
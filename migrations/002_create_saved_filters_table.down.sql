@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_saved_filters_user_id;
+
+DROP TABLE IF EXISTS saved_filters;
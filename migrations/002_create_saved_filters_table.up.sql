@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS saved_filters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}'::jsonb,
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_filters_user_id ON saved_filters(user_id);
@@ -0,0 +1,23 @@
+ALTER TABLE subscriptions RENAME TO subscriptions_partitioned;
+ALTER TABLE subscriptions_partitioned RENAME CONSTRAINT subscriptions_pkey TO subscriptions_partitioned_pkey;
+
+CREATE TABLE subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date)
+SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions_partitioned;
+
+-- Dropping the parent drops every partition and their indexes with it.
+DROP TABLE subscriptions_partitioned;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_service_name ON subscriptions(service_name);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_start_date ON subscriptions(start_date);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_end_date ON subscriptions(end_date);
@@ -0,0 +1,37 @@
+-- Hash-partition subscriptions by user_id so per-user queries touch a single
+-- partition. The primary key must contain the partition key, so it becomes
+-- (id, user_id); ids are still generated as random UUIDs.
+ALTER TABLE subscriptions RENAME TO subscriptions_unpartitioned;
+ALTER TABLE subscriptions_unpartitioned RENAME CONSTRAINT subscriptions_pkey TO subscriptions_unpartitioned_pkey;
+
+CREATE TABLE subscriptions (
+    id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    CHECK (end_date IS NULL OR end_date >= start_date),
+    PRIMARY KEY (id, user_id)
+) PARTITION BY HASH (user_id);
+
+DO $$
+BEGIN
+    FOR i IN 0..15 LOOP
+        EXECUTE format(
+            'CREATE TABLE subscriptions_p%s PARTITION OF subscriptions FOR VALUES WITH (MODULUS 16, REMAINDER %s)',
+            i, i
+        );
+    END LOOP;
+END $$;
+
+INSERT INTO subscriptions (id, user_id, service_name, price, start_date, end_date)
+SELECT id, user_id, service_name, price, start_date, end_date FROM subscriptions_unpartitioned;
+
+DROP TABLE subscriptions_unpartitioned;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_id ON subscriptions(id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_service_name ON subscriptions(service_name);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_start_date ON subscriptions(start_date);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_end_date ON subscriptions(end_date);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS subscriptions_changelog ON subscriptions;
+
+DROP FUNCTION IF EXISTS record_subscription_change();
+
+DROP INDEX IF EXISTS idx_subscription_changes_user_seq;
+
+DROP TABLE IF EXISTS subscription_changes;
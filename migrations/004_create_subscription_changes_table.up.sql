@@ -0,0 +1,29 @@
+-- Append-only changelog of subscription writes, filled by a trigger so every
+-- writer (API, migrations, manual edits) is captured. seq is the sync cursor.
+CREATE TABLE IF NOT EXISTS subscription_changes (
+    seq BIGSERIAL PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    op TEXT NOT NULL CHECK (op IN ('upsert', 'delete')),
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_changes_user_seq ON subscription_changes(user_id, seq);
+
+CREATE OR REPLACE FUNCTION record_subscription_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO subscription_changes (subscription_id, user_id, op) VALUES (OLD.id, OLD.user_id, 'delete');
+        RETURN OLD;
+    END IF;
+    IF TG_OP = 'UPDATE' AND OLD.user_id <> NEW.user_id THEN
+        INSERT INTO subscription_changes (subscription_id, user_id, op) VALUES (OLD.id, OLD.user_id, 'delete');
+    END IF;
+    INSERT INTO subscription_changes (subscription_id, user_id, op) VALUES (NEW.id, NEW.user_id, 'upsert');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscriptions_changelog
+    AFTER INSERT OR UPDATE OR DELETE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION record_subscription_change();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_subscriptions_user_cost_center;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS cost_center;
@@ -0,0 +1,4 @@
+-- Free-form department/project tag for recharging spend; '' means unassigned.
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS cost_center TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_cost_center ON subscriptions(user_id, cost_center);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS category_rules;
+
+DROP INDEX IF EXISTS idx_subscriptions_user_category;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS category;
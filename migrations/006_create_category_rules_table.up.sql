@@ -0,0 +1,17 @@
+-- Category of a subscription; '' means uncategorized.
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_category ON subscriptions(user_id, category);
+
+-- Rules that categorize subscriptions by a case-insensitive substring of the
+-- service name. A NULL user_id makes the rule global.
+CREATE TABLE IF NOT EXISTS category_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID,
+    pattern TEXT NOT NULL,
+    category TEXT NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_rules_user_id ON category_rules(user_id);
@@ -0,0 +1,5 @@
+ALTER TABLE category_rules DROP CONSTRAINT IF EXISTS category_rules_user_id_fkey;
+ALTER TABLE saved_filters DROP CONSTRAINT IF EXISTS saved_filters_user_id_fkey;
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_user_id_fkey;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- Stored lowercased so uniqueness is case-insensitive.
+    email TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Give every user ID already in use an account so the foreign keys below hold.
+-- These accounts have a reserved placeholder email and no password, so nobody
+-- can log in as them.
+INSERT INTO users (id, email, password_hash)
+SELECT user_id, user_id::text || '@legacy.invalid', ''
+FROM (
+    SELECT user_id FROM subscriptions
+    UNION SELECT user_id FROM saved_filters
+    UNION SELECT user_id FROM category_rules WHERE user_id IS NOT NULL
+) AS existing
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE saved_filters
+    ADD CONSTRAINT saved_filters_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE category_rules
+    ADD CONSTRAINT category_rules_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
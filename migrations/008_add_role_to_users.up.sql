@@ -0,0 +1,3 @@
+-- Admins can act on every user's data. Promote accounts directly in the
+-- database: UPDATE users SET role = 'admin' WHERE email = '...';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
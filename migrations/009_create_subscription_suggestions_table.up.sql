@@ -0,0 +1,19 @@
+-- Subscriptions detected automatically (bank feeds, email, receipts) wait here
+-- until the user accepts, rejects or merges them, so unconfirmed guesses never
+-- reach the subscriptions table. subscription_id is the subscription an
+-- accepted or merged suggestion resolved to.
+CREATE TABLE IF NOT EXISTS subscription_suggestions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    service_name TEXT NOT NULL,
+    price INT NOT NULL CHECK (price >= 0),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected', 'merged')),
+    subscription_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_suggestions_user_status ON subscription_suggestions(user_id, status);
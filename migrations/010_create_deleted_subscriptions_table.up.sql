@@ -0,0 +1,17 @@
+-- Trash bin. Deleting a subscription moves its row here instead of dropping
+-- it, so it can be restored later. Keeping trashed rows out of subscriptions
+-- means no existing query, report or constraint has to learn to skip them;
+-- the changelog still records the delete and, on restore, the re-insert.
+CREATE TABLE IF NOT EXISTS deleted_subscriptions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    cost_center TEXT NOT NULL DEFAULT '',
+    category TEXT NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_deleted_subscriptions_user_deleted_at ON deleted_subscriptions(user_id, deleted_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE deleted_subscriptions DROP COLUMN IF EXISTS billing_period;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS billing_period;
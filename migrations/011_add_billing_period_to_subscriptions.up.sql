@@ -0,0 +1,6 @@
+-- How often price is charged. Existing subscriptions were all modeled as
+-- monthly.
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS billing_period TEXT NOT NULL DEFAULT 'monthly'
+    CHECK (billing_period IN ('monthly', 'yearly', 'weekly'));
+
+ALTER TABLE deleted_subscriptions ADD COLUMN IF NOT EXISTS billing_period TEXT NOT NULL DEFAULT 'monthly';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_deleted_subscriptions_undo_token_hash;
+
+ALTER TABLE deleted_subscriptions DROP COLUMN IF EXISTS undo_expires_at;
+ALTER TABLE deleted_subscriptions DROP COLUMN IF EXISTS undo_token_hash;
@@ -0,0 +1,7 @@
+-- Undo window. A delete stores the SHA-256 of a short-lived token on the
+-- trashed row; presenting the token before undo_expires_at restores exactly
+-- that row. Only the hash is kept so the table cannot be used to undo.
+ALTER TABLE deleted_subscriptions ADD COLUMN IF NOT EXISTS undo_token_hash TEXT;
+ALTER TABLE deleted_subscriptions ADD COLUMN IF NOT EXISTS undo_expires_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_deleted_subscriptions_undo_token_hash ON deleted_subscriptions(undo_token_hash);
@@ -0,0 +1,18 @@
+-- Renewal reminder settings. A subscription without a row is reminded the
+-- server's default number of days ahead; days_before NULL also means the
+-- default. subscriptions is partitioned with (id, user_id) as its key, so
+-- neither table can reference it; rows of deleted subscriptions are ignored.
+CREATE TABLE IF NOT EXISTS subscription_reminders (
+    subscription_id UUID PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    days_before INTEGER CHECK (days_before BETWEEN 0 AND 60)
+);
+
+-- One row per reminder sent, so no renewal is reminded twice even when several
+-- instances run the scheduler.
+CREATE TABLE IF NOT EXISTS sent_reminders (
+    subscription_id UUID NOT NULL,
+    renewal_date DATE NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (subscription_id, renewal_date)
+);
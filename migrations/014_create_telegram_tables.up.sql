@@ -0,0 +1,15 @@
+-- A user's linked Telegram chat. A chat belongs to at most one user; linking
+-- it again moves it to the new user.
+CREATE TABLE IF NOT EXISTS telegram_links (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL UNIQUE,
+    linked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One-time codes a user sends to the bot to link a chat. Only a hash of the
+-- code is stored, like undo tokens.
+CREATE TABLE IF NOT EXISTS telegram_link_codes (
+    code_hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS subscriptions_webhooks ON subscriptions;
+DROP FUNCTION IF EXISTS enqueue_subscription_webhooks();
+DROP FUNCTION IF EXISTS enqueue_subscription_webhook(TEXT, subscriptions);
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,77 @@
+-- Callback URLs users registered for subscription events. secret signs every
+-- payload; it is kept in plain text because signing needs it.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON webhooks(user_id);
+
+-- One row per event and webhook, written in the same transaction as the
+-- subscription change so no event is lost. A pending delivery is attempted
+-- once next_attempt_at has passed.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at);
+
+-- Queues deliveries from a trigger, like the changelog, so every writer is
+-- covered. Moving a subscription to another user is a deletion for the old
+-- owner and a creation for the new one.
+CREATE OR REPLACE FUNCTION enqueue_subscription_webhooks() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM enqueue_subscription_webhook('subscription.deleted', OLD);
+    ELSIF TG_OP = 'INSERT' THEN
+        PERFORM enqueue_subscription_webhook('subscription.created', NEW);
+    ELSIF OLD.user_id <> NEW.user_id THEN
+        PERFORM enqueue_subscription_webhook('subscription.deleted', OLD);
+        PERFORM enqueue_subscription_webhook('subscription.created', NEW);
+    ELSE
+        PERFORM enqueue_subscription_webhook('subscription.updated', NEW);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The payload mirrors the API's subscription response.
+CREATE OR REPLACE FUNCTION enqueue_subscription_webhook(event_name TEXT, sub subscriptions) RETURNS void AS $$
+    INSERT INTO webhook_deliveries (webhook_id, event, payload)
+    SELECT w.id, event_name, jsonb_build_object(
+        'event', event_name,
+        'occurred_at', now(),
+        'subscription', jsonb_strip_nulls(jsonb_build_object(
+            'id', sub.id,
+            'user_id', sub.user_id,
+            'service_name', sub.service_name,
+            'price', sub.price,
+            'start_date', to_char(sub.start_date, 'MM-YYYY'),
+            'end_date', to_char(sub.end_date, 'MM-YYYY'),
+            'cost_center', NULLIF(sub.cost_center, ''),
+            'category', NULLIF(sub.category, ''),
+            'billing_period', sub.billing_period
+        ))
+    )
+    FROM webhooks w
+    WHERE w.user_id = sub.user_id AND event_name = ANY(w.events);
+$$ LANGUAGE sql;
+
+CREATE TRIGGER subscriptions_webhooks
+    AFTER INSERT OR UPDATE OR DELETE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION enqueue_subscription_webhooks();
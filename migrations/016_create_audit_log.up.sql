@@ -0,0 +1,16 @@
+-- Who changed what and when, written by the service layer after each change.
+-- owner_id is the user the entity belongs to, so history stays readable after
+-- the entity itself is gone; actor_id is NULL when no user was authenticated.
+-- changes maps each changed field to its value before and after.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    actor_id UUID,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete', 'restore')),
+    changes JSONB NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id, id);
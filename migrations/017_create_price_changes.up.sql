@@ -0,0 +1,18 @@
+-- Price changes scheduled for a future month, e.g. an announced price hike.
+-- The scheduler writes price to the subscription once effective_date has come
+-- and stamps applied_at; cost forecasts use the pending ones before that.
+-- subscriptions is partitioned with (id, user_id) as its key, so it cannot be
+-- referenced; rows of deleted subscriptions are skipped.
+CREATE TABLE IF NOT EXISTS price_changes (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    price INTEGER NOT NULL CHECK (price >= 0),
+    effective_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    applied_at TIMESTAMPTZ,
+    UNIQUE (subscription_id, effective_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_changes_due ON price_changes(effective_date) WHERE applied_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_price_changes_user_pending ON price_changes(user_id, effective_date) WHERE applied_at IS NULL;
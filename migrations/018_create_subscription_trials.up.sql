@@ -0,0 +1,16 @@
+-- Trials of subscriptions. While a trial runs the subscription carries its
+-- trial price; the day after ends_on the scheduler switches it to the regular
+-- price and billing period, stamps ended_at and sets converted. A subscription
+-- that ended during its trial, or was deleted, lapses instead. subscriptions
+-- is partitioned with (id, user_id) as its key, so it cannot be referenced.
+CREATE TABLE IF NOT EXISTS subscription_trials (
+    subscription_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    ends_on DATE NOT NULL,
+    regular_price INTEGER NOT NULL CHECK (regular_price >= 0),
+    regular_billing_period TEXT NOT NULL CHECK (regular_billing_period IN ('monthly', 'yearly', 'weekly')),
+    ended_at TIMESTAMPTZ,
+    converted BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_trials_due ON subscription_trials(ends_on) WHERE ended_at IS NULL;
@@ -0,0 +1,17 @@
+-- Charges of a subscription that failed, one row per billing month. A
+-- subscription with a row not yet paid is past due. Retries are counted until
+-- one succeeds and stamps paid_at; marking a paid month failed again reopens
+-- it. subscriptions is partitioned with (id, user_id) as its key, so it cannot
+-- be referenced; rows of deleted subscriptions are ignored.
+CREATE TABLE IF NOT EXISTS payment_failures (
+    subscription_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    period DATE NOT NULL,
+    failed_at TIMESTAMPTZ NOT NULL,
+    retries INTEGER NOT NULL DEFAULT 0,
+    last_retry_at TIMESTAMPTZ,
+    paid_at TIMESTAMPTZ,
+    PRIMARY KEY (subscription_id, period)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_failures_unpaid ON payment_failures(user_id, period) WHERE paid_at IS NULL;
@@ -0,0 +1,24 @@
+CREATE OR REPLACE FUNCTION enqueue_subscription_webhook(event_name TEXT, sub subscriptions) RETURNS void AS $$
+    INSERT INTO webhook_deliveries (webhook_id, event, payload)
+    SELECT w.id, event_name, jsonb_build_object(
+        'event', event_name,
+        'occurred_at', now(),
+        'subscription', jsonb_strip_nulls(jsonb_build_object(
+            'id', sub.id,
+            'user_id', sub.user_id,
+            'service_name', sub.service_name,
+            'price', sub.price,
+            'start_date', to_char(sub.start_date, 'MM-YYYY'),
+            'end_date', to_char(sub.end_date, 'MM-YYYY'),
+            'cost_center', NULLIF(sub.cost_center, ''),
+            'category', NULLIF(sub.category, ''),
+            'billing_period', sub.billing_period
+        ))
+    )
+    FROM webhooks w
+    WHERE w.user_id = sub.user_id AND event_name = ANY(w.events);
+$$ LANGUAGE sql;
+
+ALTER TABLE deleted_subscriptions DROP COLUMN IF EXISTS expense_type;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS expense_type;
@@ -0,0 +1,30 @@
+-- What kind of recurring expense a row records. Everything tracked so far was
+-- a subscription.
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS expense_type TEXT NOT NULL DEFAULT 'subscription'
+    CHECK (expense_type IN ('subscription', 'rent', 'utilities', 'insurance', 'other'));
+
+ALTER TABLE deleted_subscriptions ADD COLUMN IF NOT EXISTS expense_type TEXT NOT NULL DEFAULT 'subscription';
+
+-- Webhook payloads mirror the API's subscription response, which now carries
+-- the type.
+CREATE OR REPLACE FUNCTION enqueue_subscription_webhook(event_name TEXT, sub subscriptions) RETURNS void AS $$
+    INSERT INTO webhook_deliveries (webhook_id, event, payload)
+    SELECT w.id, event_name, jsonb_build_object(
+        'event', event_name,
+        'occurred_at', now(),
+        'subscription', jsonb_strip_nulls(jsonb_build_object(
+            'id', sub.id,
+            'user_id', sub.user_id,
+            'service_name', sub.service_name,
+            'price', sub.price,
+            'start_date', to_char(sub.start_date, 'MM-YYYY'),
+            'end_date', to_char(sub.end_date, 'MM-YYYY'),
+            'cost_center', NULLIF(sub.cost_center, ''),
+            'category', NULLIF(sub.category, ''),
+            'billing_period', sub.billing_period,
+            'expense_type', sub.expense_type
+        ))
+    )
+    FROM webhooks w
+    WHERE w.user_id = sub.user_id AND event_name = ANY(w.events);
+$$ LANGUAGE sql;
@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS consumer_acks;
+
+CREATE OR REPLACE FUNCTION enqueue_subscription_webhook(event_name TEXT, sub subscriptions) RETURNS void AS $$
+    INSERT INTO webhook_deliveries (webhook_id, event, payload)
+    SELECT w.id, event_name, jsonb_build_object(
+        'event', event_name,
+        'occurred_at', now(),
+        'subscription', jsonb_strip_nulls(jsonb_build_object(
+            'id', sub.id,
+            'user_id', sub.user_id,
+            'service_name', sub.service_name,
+            'price', sub.price,
+            'start_date', to_char(sub.start_date, 'MM-YYYY'),
+            'end_date', to_char(sub.end_date, 'MM-YYYY'),
+            'cost_center', NULLIF(sub.cost_center, ''),
+            'category', NULLIF(sub.category, ''),
+            'billing_period', sub.billing_period,
+            'expense_type', sub.expense_type
+        ))
+    )
+    FROM webhooks w
+    WHERE w.user_id = sub.user_id AND event_name = ANY(w.events);
+$$ LANGUAGE sql;
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS sequence;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS last_sequence;
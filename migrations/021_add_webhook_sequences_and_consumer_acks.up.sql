@@ -0,0 +1,56 @@
+-- Every delivery carries a sequence number that counts up per webhook, so a
+-- receiver can order events and spot gaps. last_sequence is bumped under the
+-- webhook's row lock, which keeps the numbers gapless across concurrent
+-- writers.
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS last_sequence BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS sequence BIGINT NOT NULL DEFAULT 0;
+
+UPDATE webhook_deliveries d SET sequence = numbered.sequence
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY webhook_id ORDER BY created_at, id) AS sequence
+    FROM webhook_deliveries
+) numbered
+WHERE d.id = numbered.id;
+
+UPDATE webhooks w SET last_sequence = counted.last_sequence
+FROM (SELECT webhook_id, MAX(sequence) AS last_sequence FROM webhook_deliveries GROUP BY webhook_id) counted
+WHERE w.id = counted.webhook_id;
+
+CREATE OR REPLACE FUNCTION enqueue_subscription_webhook(event_name TEXT, sub subscriptions) RETURNS void AS $$
+    WITH hooks AS (
+        UPDATE webhooks SET last_sequence = last_sequence + 1
+        WHERE user_id = sub.user_id AND event_name = ANY(events)
+        RETURNING id, last_sequence
+    )
+    INSERT INTO webhook_deliveries (webhook_id, event, sequence, payload)
+    SELECT h.id, event_name, h.last_sequence, jsonb_build_object(
+        'event', event_name,
+        'sequence', h.last_sequence,
+        'occurred_at', now(),
+        'subscription', jsonb_strip_nulls(jsonb_build_object(
+            'id', sub.id,
+            'user_id', sub.user_id,
+            'service_name', sub.service_name,
+            'price', sub.price,
+            'start_date', to_char(sub.start_date, 'MM-YYYY'),
+            'end_date', to_char(sub.end_date, 'MM-YYYY'),
+            'cost_center', NULLIF(sub.cost_center, ''),
+            'category', NULLIF(sub.category, ''),
+            'billing_period', sub.billing_period,
+            'expense_type', sub.expense_type
+        ))
+    )
+    FROM hooks h;
+$$ LANGUAGE sql;
+
+-- Messages consumers have handled, recorded through POST /integrations/ack.
+-- The first ack of a message wins; a consumer that is told it acked before
+-- skips the message, which turns at-least-once deliveries into exactly-once
+-- handling.
+CREATE TABLE IF NOT EXISTS consumer_acks (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    consumer TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    acked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, consumer, message_id)
+);
@@ -0,0 +1,11 @@
+-- Flags gating features that are rolled out gradually. A flag is on only while
+-- enabled, in one of its environments (none means every environment), and for
+-- the share of users given by rollout_percent. Unknown flags are off.
+CREATE TABLE IF NOT EXISTS feature_flags (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    environments TEXT[] NOT NULL DEFAULT '{}',
+    rollout_percent SMALLINT NOT NULL DEFAULT 100 CHECK (rollout_percent BETWEEN 0 AND 100),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS subscriptions_version ON subscriptions;
+DROP FUNCTION IF EXISTS next_subscription_version();
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS version;
+DROP SEQUENCE IF EXISTS subscription_versions;
@@ -0,0 +1,18 @@
+-- version identifies the stored state of a subscription and is served as its
+-- ETag. Every insert and update draws a new value from one sequence, so an old
+-- ETag never matches again, whichever writer changed the row and even after
+-- the subscription is deleted and restored.
+CREATE SEQUENCE IF NOT EXISTS subscription_versions;
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT nextval('subscription_versions');
+
+CREATE OR REPLACE FUNCTION next_subscription_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version := nextval('subscription_versions');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscriptions_version
+    BEFORE UPDATE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION next_subscription_version();
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS subscriptions_track_id ON subscriptions;
+DROP FUNCTION IF EXISTS track_subscription_id();
+DROP TABLE IF EXISTS subscription_ids;
@@ -0,0 +1,29 @@
+-- subscriptions is partitioned by user_id, so its primary key is (id, user_id)
+-- and no longer keeps ids unique across users. subscription_ids restores that:
+-- every stored subscription has exactly one row here, so inserting an id that
+-- another user already holds fails with a unique violation, and a lookup by id
+-- finds the owning user, letting queries by id touch a single partition.
+CREATE TABLE IF NOT EXISTS subscription_ids (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL
+);
+
+INSERT INTO subscription_ids (id, user_id)
+SELECT id, user_id FROM subscriptions
+ON CONFLICT (id) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION track_subscription_id() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        DELETE FROM subscription_ids WHERE id = OLD.id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        INSERT INTO subscription_ids (id, user_id) VALUES (NEW.id, NEW.user_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscriptions_track_id
+    AFTER INSERT OR DELETE OR UPDATE OF id, user_id ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION track_subscription_id();
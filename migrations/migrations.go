@@ -0,0 +1,33 @@
+// Package migrations embeds the SQL migrations so the binary knows which schema
+// version it expects without the files being shipped alongside it.
+package migrations
+
+import (
+	"embed"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// LatestVersion returns the highest migration version in this directory.
+func LatestVersion() uint {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		return 0
+	}
+
+	var latest uint
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err == nil && uint(v) > latest {
+			latest = uint(v)
+		}
+	}
+	return latest
+}
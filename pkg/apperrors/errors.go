@@ -9,7 +9,9 @@ import (
 type AppError struct {
 	Code    int
 	Message string
-	Err     error
+	// ErrorCode is an optional machine-readable reason clients can branch on.
+	ErrorCode string
+	Err       error
 }
 
 func (e *AppError) Error() string {
@@ -23,6 +25,12 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// WithErrorCode attaches a machine-readable reason to the error.
+func (e *AppError) WithErrorCode(errorCode string) *AppError {
+	e.ErrorCode = errorCode
+	return e
+}
+
 func New(code int, message string, err error) *AppError {
 	return &AppError{
 		Code:    code,
@@ -0,0 +1,75 @@
+package binder
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"subtracker/pkg/validator"
+)
+
+// BindQuery fills the fields of the struct pointed to by dst from query
+// parameters named by their `form` tags, falling back to the `default` tag
+// when a parameter is absent, and then runs the struct's validate tags.
+// Supported field types are string, int, bool and *bool.
+func BindQuery(query url.Values, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binder: destination must be a pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		raw := query.Get(name)
+		if raw == "" {
+			raw = field.Tag.Get("default")
+		}
+		if raw == "" {
+			continue
+		}
+		if err := setField(v.Field(i), raw); err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", raw, name, err)
+		}
+	}
+
+	return validator.ValidateStruct(dst)
+}
+
+func setField(f reflect.Value, raw string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+		f.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("expected a boolean")
+		}
+		f.SetBool(b)
+	case reflect.Pointer:
+		if f.Type().Elem().Kind() != reflect.Bool {
+			return fmt.Errorf("unsupported field type %s", f.Type())
+		}
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("expected a boolean")
+		}
+		f.Set(reflect.ValueOf(&b))
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package binder
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type listQuery struct {
+	UserID   string `form:"user_id"`
+	Limit    int    `form:"limit" default:"10" validate:"min=1,max=100"`
+	Offset   int    `form:"offset"`
+	Active   bool   `form:"active"`
+	Trial    *bool  `form:"trial"`
+	Internal string `form:"-"`
+	Ignored  string
+}
+
+func TestBindQuery(t *testing.T) {
+	t.Run("Binds Every Supported Type", func(t *testing.T) {
+		var q listQuery
+		err := BindQuery(url.Values{
+			"user_id": {"60601fee-2bf1-4721-ae6f-7636e79a0cba"},
+			"limit":   {"25"},
+			"offset":  {"50"},
+			"active":  {"true"},
+			"trial":   {"false"},
+		}, &q)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "60601fee-2bf1-4721-ae6f-7636e79a0cba", q.UserID)
+		assert.Equal(t, 25, q.Limit)
+		assert.Equal(t, 50, q.Offset)
+		assert.True(t, q.Active)
+		if assert.NotNil(t, q.Trial) {
+			assert.False(t, *q.Trial)
+		}
+	})
+
+	t.Run("Default Tag Fills Missing Parameters", func(t *testing.T) {
+		var q listQuery
+		err := BindQuery(url.Values{}, &q)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, q.Limit)
+		assert.Zero(t, q.Offset, "fields without a default keep their zero value")
+	})
+
+	t.Run("Empty Parameter Uses The Default", func(t *testing.T) {
+		var q listQuery
+		err := BindQuery(url.Values{"limit": {""}}, &q)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, q.Limit)
+	})
+
+	t.Run("Missing Pointer Bool Stays Nil", func(t *testing.T) {
+		var q listQuery
+		err := BindQuery(url.Values{}, &q)
+
+		assert.NoError(t, err)
+		assert.Nil(t, q.Trial, "nil tells an absent filter apart from false")
+	})
+
+	t.Run("Untagged And Skipped Fields Are Left Alone", func(t *testing.T) {
+		q := listQuery{Internal: "kept", Ignored: "kept"}
+		err := BindQuery(url.Values{"Internal": {"x"}, "Ignored": {"x"}, "-": {"x"}}, &q)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "kept", q.Internal)
+		assert.Equal(t, "kept", q.Ignored)
+	})
+
+	t.Run("Malformed Int", func(t *testing.T) {
+		var q listQuery
+		err := BindQuery(url.Values{"offset": {"ten"}}, &q)
+
+		assert.EqualError(t, err, `invalid value "ten" for offset: expected an integer`)
+	})
+
+	t.Run("Malformed Bool", func(t *testing.T) {
+		var q listQuery
+		err := BindQuery(url.Values{"trial": {"maybe"}}, &q)
+
+		assert.EqualError(t, err, `invalid value "maybe" for trial: expected a boolean`)
+	})
+
+	t.Run("Unsupported Field Type", func(t *testing.T) {
+		var q struct {
+			Ratio float64 `form:"ratio"`
+		}
+		err := BindQuery(url.Values{"ratio": {"0.5"}}, &q)
+
+		assert.EqualError(t, err, `invalid value "0.5" for ratio: unsupported field type float64`)
+	})
+
+	t.Run("Unsupported Pointer Type", func(t *testing.T) {
+		var q struct {
+			Limit *int `form:"limit"`
+		}
+		err := BindQuery(url.Values{"limit": {"5"}}, &q)
+
+		assert.EqualError(t, err, `invalid value "5" for limit: unsupported field type *int`)
+	})
+
+	t.Run("Non-Pointer Destination", func(t *testing.T) {
+		err := BindQuery(url.Values{}, listQuery{})
+
+		assert.EqualError(t, err, "binder: destination must be a pointer to a struct, got binder.listQuery")
+	})
+
+	t.Run("Pointer To Non-Struct Destination", func(t *testing.T) {
+		n := 0
+		err := BindQuery(url.Values{}, &n)
+
+		assert.EqualError(t, err, "binder: destination must be a pointer to a struct, got *int")
+	})
+
+	t.Run("Validate Tags Run After Binding", func(t *testing.T) {
+		var q listQuery
+		err := BindQuery(url.Values{"limit": {"500"}}, &q)
+
+		assert.Error(t, err)
+		assert.Equal(t, 500, q.Limit, "the value is bound before it is validated")
+		assert.Contains(t, err.Error(), "Limit")
+	})
+}
@@ -0,0 +1,53 @@
+// Package clock is the source of the current time for services and
+// schedulers, so it can be frozen in tests or pinned to another date.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+// System returns the wall clock.
+func System() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Frozen stands still at the time it was last set. It is safe for
+// concurrent use.
+type Frozen struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFrozen(now time.Time) *Frozen {
+	return &Frozen{now: now}
+}
+
+func (c *Frozen) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Frozen) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d.
+func (c *Frozen) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,259 @@
+// Package jsonschema derives JSON Schemas from request structs, reading the
+// same json/form and validate tags the server decodes and validates with, so
+// the schema cannot drift from what the server accepts.
+package jsonschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// datetimePatterns translates the time layouts used in datetime validations.
+var datetimePatterns = map[string]string{
+	"01-2006": `^(0[1-9]|1[0-2])-[0-9]{4}$`,
+}
+
+type Schema struct {
+	Schema           string             `json:"$schema,omitempty"`
+	Title            string             `json:"title,omitempty"`
+	Type             string             `json:"type,omitempty"`
+	Format           string             `json:"format,omitempty"`
+	Pattern          string             `json:"pattern,omitempty"`
+	Enum             []any              `json:"enum,omitempty"`
+	Not              *Schema            `json:"not,omitempty"`
+	Const            any                `json:"const,omitempty"`
+	Minimum          *float64           `json:"minimum,omitempty"`
+	Maximum          *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int               `json:"minLength,omitempty"`
+	MaxLength        *int               `json:"maxLength,omitempty"`
+	MinItems         *int               `json:"minItems,omitempty"`
+	MaxItems         *int               `json:"maxItems,omitempty"`
+	Items            *Schema            `json:"items,omitempty"`
+	Properties       map[string]*Schema `json:"properties,omitempty"`
+	Required         []string           `json:"required,omitempty"`
+	Examples         []any              `json:"examples,omitempty"`
+}
+
+// Generate returns the schema of v, a struct or pointer to one. Query
+// structs, whose fields carry form tags instead of json tags, are described as
+// objects of their parameters.
+func Generate(v any) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	s := schemaOf(t)
+	s.Schema = draft
+	s.Title = t.Name()
+	return s
+}
+
+func schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addFields(s, t)
+		return s
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaOf(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+func addFields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := schemaOf(f.Type)
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := schemaOf(f.Type)
+		if applyRules(prop, f.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		if enums := f.Tag.Get("enums"); enums != "" && prop.Enum == nil {
+			prop.Enum = values(prop, strings.Split(enums, ","))
+		}
+		if example, ok := f.Tag.Lookup("example"); ok {
+			prop.Examples = values(prop, []string{example})
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// fieldName is the name a field is decoded from, or "" for an embedded struct
+// whose fields are promoted. It reports false for fields that are skipped.
+func fieldName(f reflect.StructField) (string, bool) {
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		tag, ok = f.Tag.Lookup("form")
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", false
+	}
+	if !ok && f.Anonymous {
+		return "", true
+	}
+	return name, true
+}
+
+// applyRules translates validate rules into schema keywords and reports
+// whether the field is required. Rules after dive apply to the elements of a
+// slice. Rules with no JSON Schema equivalent, such as cross-field
+// comparisons, are left to the server.
+func applyRules(s *Schema, tag string) bool {
+	required := false
+	target := s
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if s.Items != nil {
+				target = s.Items
+			}
+		case "required":
+			if target != s {
+				continue
+			}
+			required = true
+			// required rejects zero values, not just missing ones.
+			switch s.Type {
+			case "string":
+				if s.MinLength == nil {
+					s.MinLength = intPtr(1)
+				}
+			case "integer", "number":
+				s.Not = &Schema{Const: 0}
+			}
+		case "min", "max", "len":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			setLength(target, name, n)
+		case "gte", "lte", "gt", "lt":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			switch name {
+			case "gte":
+				target.Minimum = &n
+			case "lte":
+				target.Maximum = &n
+			case "gt":
+				target.ExclusiveMinimum = &n
+			case "lt":
+				target.ExclusiveMaximum = &n
+			}
+		case "oneof":
+			target.Enum = values(target, strings.Fields(param))
+		case "uuid", "uuid4":
+			target.Format = "uuid"
+		case "email":
+			target.Format = "email"
+		case "url":
+			target.Format = "uri"
+		case "datetime":
+			if pattern, ok := datetimePatterns[param]; ok {
+				target.Pattern = pattern
+			}
+		}
+	}
+	return required
+}
+
+// setLength applies min, max or len to a string's length, an array's size or
+// a number's value, matching how the validator interprets them.
+func setLength(s *Schema, rule string, n int) {
+	lo, hi := rule == "min" || rule == "len", rule == "max" || rule == "len"
+	switch s.Type {
+	case "string":
+		if lo {
+			s.MinLength = intPtr(n)
+		}
+		if hi {
+			s.MaxLength = intPtr(n)
+		}
+	case "array":
+		if lo {
+			s.MinItems = intPtr(n)
+		}
+		if hi {
+			s.MaxItems = intPtr(n)
+		}
+	case "integer", "number":
+		f := float64(n)
+		if lo {
+			s.Minimum = &f
+		}
+		if hi {
+			s.Maximum = &f
+		}
+	}
+}
+
+// values converts tag values to the JSON type of s.
+func values(s *Schema, raw []string) []any {
+	out := make([]any, len(raw))
+	for i, v := range raw {
+		out[i] = v
+		switch s.Type {
+		case "integer":
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				out[i] = n
+			}
+		case "number":
+			if n, err := strconv.ParseFloat(v, 64); err == nil {
+				out[i] = n
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(v); err == nil {
+				out[i] = b
+			}
+		}
+	}
+	return out
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 )
 
@@ -15,13 +17,38 @@ type Logger interface {
 	Error(msg string, fields ...zap.Field)
 	Fatal(msg string, fields ...zap.Field)
 	Warn(msg string, fields ...zap.Field)
+	// The *Context variants also log the fields stored in ctx by ContextWith.
+	DebugContext(ctx context.Context, msg string, fields ...zap.Field)
+	InfoContext(ctx context.Context, msg string, fields ...zap.Field)
+	ErrorContext(ctx context.Context, msg string, fields ...zap.Field)
+	WarnContext(ctx context.Context, msg string, fields ...zap.Field)
 	Sync() error
 }
 
+type contextFieldsKey struct{}
+
+// ContextWith returns a copy of ctx that carries fields in addition to those it
+// already carries, so every line logged with it can be tied to the request.
+func ContextWith(ctx context.Context, fields ...zap.Field) context.Context {
+	existing := FieldsFromContext(ctx)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(append(merged, existing...), fields...)
+	return context.WithValue(ctx, contextFieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the fields stored in ctx by ContextWith.
+func FieldsFromContext(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(contextFieldsKey{}).([]zap.Field)
+	return fields
+}
+
 type zapLogger struct {
-	logger *zap.Logger
+	logger    *zap.Logger
+	sanitizer *Sanitizer
 }
 
+// New builds the application logger. Production loggers scrub fields through
+// DefaultSanitizer so credentials and personal data never reach the output.
 func New(env string) Logger {
 	var cfg zap.Config
 
@@ -41,27 +68,80 @@ func New(env string) Logger {
 		panic("cannot initialize zap logger: " + err.Error())
 	}
 
-	return &zapLogger{logger: logger}
+	var sanitizer *Sanitizer
+	if env == EnvProd {
+		sanitizer = DefaultSanitizer()
+	}
+
+	return &zapLogger{logger: logger, sanitizer: sanitizer}
+}
+
+func (l *zapLogger) fields(fields []zap.Field) []zap.Field {
+	if l.sanitizer == nil {
+		return fields
+	}
+	return l.sanitizer.fields(fields)
 }
 
 func (l *zapLogger) Debug(msg string, fields ...zap.Field) {
-	l.logger.Debug(msg, fields...)
+	l.logger.Debug(msg, l.fields(fields)...)
 }
 
 func (l *zapLogger) Info(msg string, fields ...zap.Field) {
-	l.logger.Info(msg, fields...)
+	l.logger.Info(msg, l.fields(fields)...)
 }
 
 func (l *zapLogger) Error(msg string, fields ...zap.Field) {
-	l.logger.Error(msg, fields...)
+	l.logger.Error(msg, l.fields(fields)...)
 }
 
 func (l *zapLogger) Fatal(msg string, fields ...zap.Field) {
-	l.logger.Fatal(msg, fields...)
+	l.logger.Fatal(msg, l.fields(fields)...)
 }
 
 func (l *zapLogger) Warn(msg string, fields ...zap.Field) {
-	l.logger.Warn(msg, fields...)
+	l.logger.Warn(msg, l.fields(fields)...)
+}
+
+// withContext puts the fields carried by ctx ahead of the call's own fields. A
+// carried field is left out when the call logs the same key itself.
+func withContext(ctx context.Context, fields []zap.Field) []zap.Field {
+	carried := FieldsFromContext(ctx)
+	if len(carried) == 0 {
+		return fields
+	}
+	out := make([]zap.Field, 0, len(carried)+len(fields))
+	for _, c := range carried {
+		if !hasKey(fields, c.Key) {
+			out = append(out, c)
+		}
+	}
+	return append(out, fields...)
+}
+
+func hasKey(fields []zap.Field, key string) bool {
+	for _, f := range fields {
+		if f.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *zapLogger) DebugContext(ctx context.Context, msg string, fields ...zap.Field) {
+	l.logger.Debug(msg, l.fields(withContext(ctx, fields))...)
+}
+
+func (l *zapLogger) InfoContext(ctx context.Context, msg string, fields ...zap.Field) {
+	l.logger.Info(msg, l.fields(withContext(ctx, fields))...)
+}
+
+func (l *zapLogger) ErrorContext(ctx context.Context, msg string, fields ...zap.Field) {
+	l.logger.Error(msg, l.fields(withContext(ctx, fields))...)
+}
+
+func (l *zapLogger) WarnContext(ctx context.Context, msg string, fields ...zap.Field) {
+	l.logger.Warn(msg, l.fields(withContext(ctx, fields))...)
 }
 
 func (l *zapLogger) Sync() error {
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const redactedValue = "[REDACTED]"
+
+var (
+	// The password runs to the last @ of the URL, so one containing @ is
+	// masked whole. A key=value password may be single-quoted to hold spaces.
+	urlPasswordPattern = regexp.MustCompile(`(\w+://[^:/@\s]+:)\S+(@[^@\s]*)`)
+	kvPasswordPattern  = regexp.MustCompile(`(?i)(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+)
+
+// Sanitizer rewrites log fields before they reach the encoder: listed keys are
+// dropped or hashed, and credentials embedded in any string value are masked.
+type Sanitizer struct {
+	redact map[string]struct{}
+	hash   map[string]struct{}
+}
+
+// NewSanitizer redacts the values of redactKeys entirely and replaces string
+// values of hashKeys with a short stable hash, so records stay correlatable.
+func NewSanitizer(redactKeys, hashKeys []string) *Sanitizer {
+	s := &Sanitizer{
+		redact: make(map[string]struct{}, len(redactKeys)),
+		hash:   make(map[string]struct{}, len(hashKeys)),
+	}
+	for _, k := range redactKeys {
+		s.redact[k] = struct{}{}
+	}
+	for _, k := range hashKeys {
+		s.hash[k] = struct{}{}
+	}
+	return s
+}
+
+// DefaultSanitizer covers the fields this service logs that carry credentials or
+// personal data: user IDs, SQL arguments and decoded request/filter payloads.
+func DefaultSanitizer() *Sanitizer {
+	return NewSanitizer(
+		[]string{"password", "args", "config", "filter", "request_dto", "updates", "patch", "existing_dao", "final_dao"},
+		[]string{"user_id"},
+	)
+}
+
+func (s *Sanitizer) fields(fields []zap.Field) []zap.Field {
+	out := make([]zap.Field, len(fields))
+	for i, f := range fields {
+		out[i] = s.field(f)
+	}
+	return out
+}
+
+func (s *Sanitizer) field(f zap.Field) zap.Field {
+	if _, ok := s.redact[f.Key]; ok {
+		return zap.String(f.Key, redactedValue)
+	}
+	if f.Type != zapcore.StringType {
+		return f
+	}
+	if _, ok := s.hash[f.Key]; ok {
+		return zap.String(f.Key, hashValue(f.String))
+	}
+	return zap.String(f.Key, MaskSecrets(f.String))
+}
+
+// MaskSecrets hides passwords in URL-style and key=value connection strings.
+func MaskSecrets(s string) string {
+	s = urlPasswordPattern.ReplaceAllString(s, "${1}***${2}")
+	return kvPasswordPattern.ReplaceAllString(s, "${1}***")
+}
+
+func hashValue(s string) string {
+	if s == "" {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	return "h:" + hex.EncodeToString(sum[:6])
+}
@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMaskSecrets(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "URL DSN",
+			in:   "postgres://postgres:supersecret@db:5432/subtracker?sslmode=disable",
+			want: "postgres://postgres:***@db:5432/subtracker?sslmode=disable",
+		},
+		{
+			name: "URL DSN With @ In Password",
+			in:   "postgres://postgres:p@ss@word@db:5432/subtracker",
+			want: "postgres://postgres:***@db:5432/subtracker",
+		},
+		{
+			name: "URL DSN Inside A Message",
+			in:   "dial postgres://app:s3cret@db:5432/subtracker failed: timeout",
+			want: "dial postgres://app:***@db:5432/subtracker failed: timeout",
+		},
+		{
+			name: "URL Without Password",
+			in:   "https://api.telegram.org/bot/getUpdates",
+			want: "https://api.telegram.org/bot/getUpdates",
+		},
+		{
+			name: "Key Value DSN",
+			in:   "host=db port=5432 user=postgres password=supersecret dbname=subtracker sslmode=disable",
+			want: "host=db port=5432 user=postgres password=*** dbname=subtracker sslmode=disable",
+		},
+		{
+			name: "Key Value DSN With @ In Password",
+			in:   "host=db password=p@ss@word dbname=subtracker",
+			want: "host=db password=*** dbname=subtracker",
+		},
+		{
+			name: "Key Value DSN With Quoted Spaces",
+			in:   "host=db password='my secret \\' phrase' dbname=subtracker",
+			want: "host=db password=*** dbname=subtracker",
+		},
+		{
+			name: "Key Value DSN With Spaces Around Equals",
+			in:   "host=db PASSWORD = supersecret dbname=subtracker",
+			want: "host=db PASSWORD = *** dbname=subtracker",
+		},
+		{
+			name: "No Secrets",
+			in:   "subscription created",
+			want: "subscription created",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskSecrets(tt.in))
+		})
+	}
+}
+
+func TestSanitizer_Field(t *testing.T) {
+	s := DefaultSanitizer()
+
+	tests := []struct {
+		name  string
+		field zap.Field
+		want  zap.Field
+	}{
+		{
+			name:  "Redacted String",
+			field: zap.String("password", "supersecret"),
+			want:  zap.String("password", redactedValue),
+		},
+		{
+			name:  "Redacted Non-String",
+			field: zap.Any("args", []any{"a@example.com", 42}),
+			want:  zap.String("args", redactedValue),
+		},
+		{
+			name:  "Redacted Patch",
+			field: zap.Any("patch", map[string]any{"service_name": "Therapy sessions"}),
+			want:  zap.String("patch", redactedValue),
+		},
+		{
+			name:  "Hashed User ID",
+			field: zap.String("user_id", "60601fee-2bf1-4721-ae6f-7636e79a0cba"),
+			want:  zap.String("user_id", hashValue("60601fee-2bf1-4721-ae6f-7636e79a0cba")),
+		},
+		{
+			name:  "Empty User ID Kept Empty",
+			field: zap.String("user_id", ""),
+			want:  zap.String("user_id", ""),
+		},
+		{
+			name:  "Secrets Masked In Other Strings",
+			field: zap.String("dsn", "postgres://postgres:supersecret@db:5432/subtracker"),
+			want:  zap.String("dsn", "postgres://postgres:***@db:5432/subtracker"),
+		},
+		{
+			name:  "Int Unchanged",
+			field: zap.Int("attempt", 3),
+			want:  zap.Int("attempt", 3),
+		},
+		{
+			name:  "Duration Unchanged",
+			field: zap.Duration("retry_in", time.Second),
+			want:  zap.Duration("retry_in", time.Second),
+		},
+		{
+			name:  "Bool Unchanged",
+			field: zap.Bool("ready", true),
+			want:  zap.Bool("ready", true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.field(tt.field))
+		})
+	}
+}
+
+func TestSanitizer_HashIsStable(t *testing.T) {
+	s := NewSanitizer(nil, []string{"user_id"})
+	const userID = "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	first := s.field(zap.String("user_id", userID))
+	second := s.field(zap.String("user_id", userID))
+	other := s.field(zap.String("user_id", "7a1f3b0e-0c55-4a7e-9d3c-1f2e3d4c5b6a"))
+
+	assert.Equal(t, zapcore.StringType, first.Type)
+	assert.Equal(t, first.String, second.String, "the same user hashes the same way every time")
+	assert.NotEqual(t, first.String, other.String)
+	assert.NotContains(t, first.String, userID)
+	assert.Equal(t, "h:", first.String[:2])
+	assert.Len(t, first.String, len("h:")+12)
+}
+
+func TestSanitizer_Fields(t *testing.T) {
+	s := DefaultSanitizer()
+	in := []zap.Field{zap.String("password", "supersecret"), zap.Int("status", 200)}
+
+	out := s.fields(in)
+
+	assert.Equal(t, []zap.Field{zap.String("password", redactedValue), zap.Int("status", 200)}, out)
+	assert.Equal(t, "supersecret", in[0].String, "the caller's fields are left untouched")
+}
@@ -1,40 +1,79 @@
 package response
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
 type APIResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code     int       `json:"code"`
+	Message  string    `json:"message"`
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// Warning describes a non-fatal issue with an accepted request.
+type Warning struct {
+	Code    string `json:"code" example:"unusual_price"`
+	Message string `json:"message" example:"price is zero"`
 }
 
 type APIError struct {
-	Code     int    `json:"code"`
-	Message  string `json:"message"`
-	Resource string `json:"resource"`
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Resource  string `json:"resource"`
 }
 
 func (e APIError) Send(w http.ResponseWriter) {
-	j, err := json.MarshalIndent(e, "", "\t")
+	JSON(w, e.Code, e)
+}
+
+func (r APIResponse) Send(w http.ResponseWriter) {
+	JSON(w, r.Code, r)
+}
+
+// JSON writes v as the response body with the given status. Statuses that must
+// not carry a body (1xx, 204 and 304) are sent bare whatever v is, so handlers
+// cannot produce a response that violates HTTP semantics.
+func JSON(w http.ResponseWriter, status int, v any) {
+	if !bodyAllowed(status) {
+		w.WriteHeader(status)
+		return
+	}
+	j, err := json.MarshalIndent(v, "", "\t")
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
-
 	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(e.Code)
+	w.WriteHeader(status)
 	w.Write(j)
 }
 
-func (r APIResponse) Send(w http.ResponseWriter) {
-	j, err := json.MarshalIndent(r, "", "\t")
-	if err != nil {
+// CSV writes records as a downloadable CSV attachment named filename. The
+// records are encoded before any header is sent so an encoding failure can
+// still be reported as a 500.
+func CSV(w http.ResponseWriter, filename string, records [][]string) {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if err := cw.WriteAll(records); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(r.Code)
-	w.Write(j)
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// NoContent acknowledges a successful request that has nothing to return.
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
 }